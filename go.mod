@@ -5,10 +5,13 @@ go 1.26.0
 require (
 	github.com/gen2brain/malgo v0.11.24
 	github.com/spf13/cobra v1.10.1
+	golang.org/x/crypto v0.54.0
+	golang.org/x/term v0.45.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.10 // indirect
+	golang.org/x/sys v0.47.0 // indirect
 )
@@ -0,0 +1,90 @@
+package audio
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/gen2brain/malgo"
+)
+
+// BackendAuto lets miniaudio pick the audio backend (the default)
+const BackendAuto = "auto"
+
+// backendsByName maps audio_backend config names to malgo backends
+var backendsByName = map[string]malgo.Backend{
+	"coreaudio":  malgo.BackendCoreaudio,
+	"wasapi":     malgo.BackendWasapi,
+	"dsound":     malgo.BackendDsound,
+	"winmm":      malgo.BackendWinmm,
+	"pulseaudio": malgo.BackendPulseaudio,
+	"alsa":       malgo.BackendAlsa,
+	"jack":       malgo.BackendJack,
+	"oss":        malgo.BackendOss,
+	"sndio":      malgo.BackendSndio,
+	"audio4":     malgo.BackendAudio4,
+}
+
+// platformBackends lists the capture backends for each OS in miniaudio's order of preference
+var platformBackends = map[string][]string{
+	"darwin":  {"coreaudio"},
+	"windows": {"wasapi", "dsound", "winmm"},
+	"linux":   {"pulseaudio", "alsa", "jack"},
+	"freebsd": {"oss", "sndio"},
+	"openbsd": {"sndio", "audio4"},
+	"netbsd":  {"audio4"},
+}
+
+// ListBackends returns the audio backends that can be initialized on this machine,
+// in the order automatic selection tries them
+func ListBackends() []string {
+	var available []string
+	for _, name := range platformBackends[runtime.GOOS] {
+		ctx, err := malgo.InitContext([]malgo.Backend{backendsByName[name]}, malgo.ContextConfig{}, nil)
+		if err != nil {
+			continue
+		}
+		_ = ctx.Uninit()
+		ctx.Free()
+		available = append(available, name)
+	}
+	return available
+}
+
+// ValidateBackend checks that name is "auto" (or empty) or one of the available backends
+func ValidateBackend(name string, available []string) error {
+	if name == "" || name == BackendAuto {
+		return nil
+	}
+	for _, b := range available {
+		if b == name {
+			return nil
+		}
+	}
+	if len(available) == 0 {
+		return fmt.Errorf("audio backend %s is not available (no audio backends found)", name)
+	}
+	return fmt.Errorf("audio backend %s is not available (must be auto or one of: %s)", name, strings.Join(available, ", "))
+}
+
+// contextBackends returns the malgo backends to initialize a context with for an
+// audio_backend name ("auto" or empty lets miniaudio choose, returning nil)
+func contextBackends(name string) ([]malgo.Backend, error) {
+	if name == "" || name == BackendAuto {
+		return nil, nil
+	}
+	b, ok := backendsByName[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown audio backend: %s", name)
+	}
+	return []malgo.Backend{b}, nil
+}
+
+// initContext initializes a malgo context with the named audio backend
+func initContext(backend string) (*malgo.AllocatedContext, error) {
+	backends, err := contextBackends(backend)
+	if err != nil {
+		return nil, err
+	}
+	return malgo.InitContext(backends, malgo.ContextConfig{}, nil)
+}
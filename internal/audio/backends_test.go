@@ -0,0 +1,70 @@
+package audio
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateBackend(t *testing.T) {
+	available := []string{"pulseaudio", "alsa"}
+
+	tests := []struct {
+		name      string
+		backend   string
+		available []string
+		wantErr   string
+	}{
+		{"auto", "auto", available, ""},
+		{"empty means auto", "", available, ""},
+		{"available", "alsa", available, ""},
+		{"known but unavailable", "jack", available, "must be auto or one of: pulseaudio, alsa"},
+		{"unknown", "directsound", available, "not available"},
+		{"nothing available", "alsa", nil, "no audio backends found"},
+		{"auto with nothing available", "auto", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBackend(tt.backend, tt.available)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateBackend(%q) error = %v, want nil", tt.backend, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateBackend(%q) error = %v, want it to contain %q", tt.backend, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContextBackends(t *testing.T) {
+	backends, err := contextBackends("coreaudio")
+	if err != nil {
+		t.Fatalf("contextBackends(coreaudio) error = %v", err)
+	}
+	if len(backends) != 1 || backends[0] != backendsByName["coreaudio"] {
+		t.Errorf("contextBackends(coreaudio) = %v, want coreaudio", backends)
+	}
+
+	if _, err := contextBackends("nope"); err == nil {
+		t.Error("contextBackends(nope) error = nil, want an error")
+	}
+
+	for _, name := range []string{"", BackendAuto} {
+		if backends, err := contextBackends(name); err != nil || backends != nil {
+			t.Errorf("contextBackends(%q) = %v, %v; want auto (nil)", name, backends, err)
+		}
+	}
+}
+
+func TestPlatformBackendsAreKnown(t *testing.T) {
+	for goos, names := range platformBackends {
+		for _, name := range names {
+			if _, ok := backendsByName[name]; !ok {
+				t.Errorf("platformBackends[%s] lists unknown backend %s", goos, name)
+			}
+		}
+	}
+}
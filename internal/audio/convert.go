@@ -0,0 +1,69 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// DownmixToMono averages interleaved 16-bit little-endian PCM channels into a single channel.
+// Data with one channel (or fewer) is returned unchanged. A trailing partial frame
+// (e.g. an odd byte, or a left sample without its right pair) is dropped.
+func DownmixToMono(data []byte, channels uint32) []byte {
+	if channels <= 1 {
+		return data
+	}
+
+	frameSize := int(channels) * 2
+	numFrames := len(data) / frameSize
+	mono := make([]byte, numFrames*2)
+
+	for frame := 0; frame < numFrames; frame++ {
+		var sum int32
+		for ch := 0; ch < int(channels); ch++ {
+			offset := frame*frameSize + ch*2
+			sum += int32(int16(binary.LittleEndian.Uint16(data[offset:])))
+		}
+		binary.LittleEndian.PutUint16(mono[frame*2:], uint16(int16(sum/int32(channels))))
+	}
+
+	return mono
+}
+
+// Resample converts interleaved 16-bit little-endian PCM from fromRate to toRate
+// using linear interpolation between neighbouring frames. No anti-aliasing filter
+// is applied, which is acceptable for speech downsampled to 16kHz.
+// Data is returned unchanged when the rates match or either rate is 0.
+func Resample(data []byte, fromRate, toRate, channels uint32) []byte {
+	if fromRate == toRate || fromRate == 0 || toRate == 0 || channels == 0 {
+		return data
+	}
+
+	frameSize := int(channels) * 2
+	inFrames := len(data) / frameSize
+	if inFrames == 0 {
+		return []byte{}
+	}
+
+	outFrames := int(uint64(inFrames) * uint64(toRate) / uint64(fromRate))
+	out := make([]byte, outFrames*frameSize)
+	step := float64(fromRate) / float64(toRate)
+
+	sampleAt := func(frame, ch int) float64 {
+		offset := frame*frameSize + ch*2
+		return float64(int16(binary.LittleEndian.Uint16(data[offset:])))
+	}
+
+	for i := 0; i < outFrames; i++ {
+		pos := float64(i) * step
+		idx := int(pos)
+		frac := pos - float64(idx)
+		next := min(idx+1, inFrames-1)
+
+		for ch := 0; ch < int(channels); ch++ {
+			value := sampleAt(idx, ch)*(1-frac) + sampleAt(next, ch)*frac
+			binary.LittleEndian.PutUint16(out[i*frameSize+ch*2:], uint16(int16(math.Round(value))))
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,126 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// pcm encodes 16-bit samples as little-endian bytes
+func pcm(samples ...int16) []byte {
+	data := make([]byte, len(samples)*2)
+	for i, s := range samples {
+		binary.LittleEndian.PutUint16(data[i*2:], uint16(s))
+	}
+	return data
+}
+
+// samplesOf decodes little-endian 16-bit PCM
+func samplesOf(data []byte) []int16 {
+	samples := make([]int16, len(data)/2)
+	for i := range samples {
+		samples[i] = int16(binary.LittleEndian.Uint16(data[i*2:]))
+	}
+	return samples
+}
+
+func TestDownmixToMono(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		channels uint32
+		want     []int16
+	}{
+		{"Stereo pair averaged", pcm(1000, 3000), 2, []int16{2000}},
+		{"Opposite phase cancels", pcm(12000, -12000, -500, 1500), 2, []int16{0, 500}},
+		{"Full scale does not overflow", pcm(32767, 32767, -32768, -32768), 2, []int16{32767, -32768}},
+		{"Odd trailing byte dropped", append(pcm(100, 300), 0x7f), 2, []int16{200}},
+		{"Unpaired left sample dropped", pcm(100, 300, 500), 2, []int16{200}},
+		{"Mono unchanged", pcm(1, 2, 3), 1, []int16{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := samplesOf(DownmixToMono(tt.data, tt.channels))
+			if len(got) != len(tt.want) {
+				t.Fatalf("DownmixToMono() returned %d samples, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("sample %d = %d, want %d", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// sineWave generates one channel of 16-bit PCM at the given frequency
+func sineWave(freq float64, sampleRate uint32, duration float64) []byte {
+	numSamples := int(float64(sampleRate) * duration)
+	samples := make([]int16, numSamples)
+	for i := range samples {
+		samples[i] = int16(10000 * math.Sin(2*math.Pi*freq*float64(i)/float64(sampleRate)))
+	}
+	return pcm(samples...)
+}
+
+// zeroCrossings counts sign changes between consecutive samples
+func zeroCrossings(samples []int16) int {
+	count := 0
+	for i := 1; i < len(samples); i++ {
+		if (samples[i-1] < 0) != (samples[i] < 0) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestResample_48kTo16k(t *testing.T) {
+	input := sineWave(440, 48000, 1.0)
+	output := Resample(input, 48000, 16000, 1)
+
+	// Length should shrink by exactly the rate ratio
+	if len(output)*3 != len(input) {
+		t.Errorf("Resample() output length = %d, want %d", len(output), len(input)/3)
+	}
+
+	// A 440Hz sine crosses zero ~880 times per second at any sample rate
+	crossings := zeroCrossings(samplesOf(output))
+	if crossings < 870 || crossings > 890 {
+		t.Errorf("Resample() output has %d zero crossings, want ~880 (440Hz preserved)", crossings)
+	}
+}
+
+func TestResample_Upsample(t *testing.T) {
+	output := samplesOf(Resample(pcm(0, 1000), 8000, 16000, 1))
+	want := []int16{0, 500, 1000, 1000}
+	if len(output) != len(want) {
+		t.Fatalf("Resample() returned %d samples, want %d", len(output), len(want))
+	}
+	for i := range want {
+		if output[i] != want[i] {
+			t.Errorf("sample %d = %d, want %d", i, output[i], want[i])
+		}
+	}
+}
+
+func TestResample_Stereo(t *testing.T) {
+	// Channels must be interpolated independently
+	output := samplesOf(Resample(pcm(100, -100, 300, -300), 16000, 32000, 2))
+	want := []int16{100, -100, 200, -200, 300, -300, 300, -300}
+	if len(output) != len(want) {
+		t.Fatalf("Resample() returned %d samples, want %d", len(output), len(want))
+	}
+	for i := range want {
+		if output[i] != want[i] {
+			t.Errorf("sample %d = %d, want %d", i, output[i], want[i])
+		}
+	}
+}
+
+func TestResample_SameRate(t *testing.T) {
+	input := pcm(1, 2, 3)
+	if got := Resample(input, 16000, 16000, 1); len(got) != len(input) {
+		t.Errorf("Resample() with equal rates changed length to %d", len(got))
+	}
+}
@@ -16,13 +16,87 @@ type Device struct {
 	ID         string
 	Name       string
 	IsDefault  bool
-	SampleRate uint32
-	Channels   uint32
+	SampleRate uint32 // Preferred native sample rate (0 = unknown)
+	Channels   uint32 // Preferred native channel count (0 = unknown)
+
+	// Supported ranges from the native formats; 0 bounds mean unknown or any
+	MinSampleRate, MaxSampleRate uint32
+	MinChannels, MaxChannels     uint32
+}
+
+// CanCapture reports whether the device natively supports sampleRate and channels.
+// Devices that report no formats are assumed to support them.
+func (d Device) CanCapture(sampleRate, channels uint32) bool {
+	if d.MaxSampleRate != 0 && (sampleRate < d.MinSampleRate || sampleRate > d.MaxSampleRate) {
+		return false
+	}
+	if d.MaxChannels != 0 && (channels < d.MinChannels || channels > d.MaxChannels) {
+		return false
+	}
+	return true
 }
 
-// ListMicrophones returns a list of all available audio input devices
-func ListMicrophones() ([]Device, error) {
-	ctx, err := NewMalgoContext()
+// Capabilities describes the device's native formats, e.g. "16000-48000 Hz, 1-2 ch"
+// ("" when unknown)
+func (d Device) Capabilities() string {
+	var parts []string
+	switch {
+	case d.MaxSampleRate == 0:
+	case d.MinSampleRate == d.MaxSampleRate:
+		parts = append(parts, fmt.Sprintf("%d Hz", d.MaxSampleRate))
+	default:
+		parts = append(parts, fmt.Sprintf("%d-%d Hz", d.MinSampleRate, d.MaxSampleRate))
+	}
+	switch {
+	case d.MaxChannels == 0:
+	case d.MinChannels == d.MaxChannels:
+		parts = append(parts, fmt.Sprintf("%d ch", d.MaxChannels))
+	default:
+		parts = append(parts, fmt.Sprintf("%d-%d ch", d.MinChannels, d.MaxChannels))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// setCapabilities fills in the device's sample rate and channel fields from its native formats
+func (d *Device) setCapabilities(formats []malgo.DataFormat) {
+	if len(formats) == 0 {
+		return
+	}
+	d.SampleRate, d.Channels = formats[0].SampleRate, formats[0].Channels
+
+	anyRate, anyChannels := false, false
+	for _, f := range formats {
+		if f.SampleRate == 0 {
+			anyRate = true
+		} else {
+			d.MinSampleRate, d.MaxSampleRate = widen(d.MinSampleRate, d.MaxSampleRate, f.SampleRate)
+		}
+		if f.Channels == 0 {
+			anyChannels = true
+		} else {
+			d.MinChannels, d.MaxChannels = widen(d.MinChannels, d.MaxChannels, f.Channels)
+		}
+	}
+	if anyRate {
+		d.MinSampleRate, d.MaxSampleRate = 0, 0
+	}
+	if anyChannels {
+		d.MinChannels, d.MaxChannels = 0, 0
+	}
+}
+
+// widen extends the [lo, hi] range (empty when hi is 0) to include v
+func widen(lo, hi, v uint32) (uint32, uint32) {
+	if hi == 0 {
+		return v, v
+	}
+	return min(lo, v), max(hi, v)
+}
+
+// ListMicrophones returns a list of all available audio input devices on the named
+// audio backend ("auto" or empty lets miniaudio choose)
+func ListMicrophones(backend string) ([]Device, error) {
+	ctx, err := NewMalgoContext(backend)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize audio context: %w", err)
 	}
@@ -54,15 +128,16 @@ func listMicrophonesWithEnumerator(enumerator DeviceEnumerator) ([]Device, error
 			Name:      info.Name(),
 			IsDefault: info.IsDefault() == 1,
 		}
+		device.setCapabilities(info.NativeFormats())
 		devices = append(devices, device)
 	}
 
 	return devices, nil
 }
 
-// GetDefaultMicrophone returns the system's default audio input device
-func GetDefaultMicrophone() (*Device, error) {
-	devices, err := ListMicrophones()
+// GetDefaultMicrophone returns the system's default audio input device on the named audio backend
+func GetDefaultMicrophone(backend string) (*Device, error) {
+	devices, err := ListMicrophones(backend)
 	if err != nil {
 		return nil, err
 	}
@@ -86,9 +161,9 @@ func getDefaultMicrophoneFromList(devices []Device) (*Device, error) {
 	return nil, fmt.Errorf("no default microphone found")
 }
 
-// FindMicrophoneByName searches for a microphone by name
-func FindMicrophoneByName(name string) (*Device, error) {
-	devices, err := ListMicrophones()
+// FindMicrophoneByName searches for a microphone by name on the named audio backend
+func FindMicrophoneByName(name, backend string) (*Device, error) {
+	devices, err := ListMicrophones(backend)
 	if err != nil {
 		return nil, err
 	}
@@ -108,11 +183,11 @@ func findMicrophoneByNameInList(devices []Device, name string) (*Device, error)
 }
 
 // FindMicrophoneByNameOrIndex searches for a microphone by name or by index number (1-based)
-// If the input is a valid number, it will try to find the device by index.
-// Otherwise, it will search by name.
+// on the named audio backend. If the input is a valid number, it will try to find the device
+// by index. Otherwise, it will search by name.
 // Examples: "1", "2", "MacBook Pro Microphone"
-func FindMicrophoneByNameOrIndex(nameOrIndex string) (*Device, error) {
-	devices, err := ListMicrophones()
+func FindMicrophoneByNameOrIndex(nameOrIndex, backend string) (*Device, error) {
+	devices, err := ListMicrophones(backend)
 	if err != nil {
 		return nil, err
 	}
@@ -146,14 +221,18 @@ func findMicrophoneByNameOrIndexInList(devices []Device, nameOrIndex string) (*D
 
 // SelectMicrophone selects the best available microphone based on user preferences.
 // It tries preferred microphones in order, then falls back to legacy Microphone field,
-// and finally to the system default.
+// and finally to the system default. Devices are listed on the configured audio_backend.
 func SelectMicrophone(cfg *config.Config) (*Device, error) {
-	devices, err := ListMicrophones()
+	devices, err := ListMicrophones(cfg.AudioBackend)
 	if err != nil {
 		return nil, fmt.Errorf("failed to enumerate devices: %w\n\nPlease check:\n  1. Microphone is connected\n  2. System Preferences > Sound > Input\n  3. Microphone permissions granted", err)
 	}
 
-	return selectMicrophoneFromList(devices, cfg)
+	device, err := selectMicrophoneFromList(devices, cfg)
+	if err == nil && !device.CanCapture(16000, 1) {
+		log.Printf("[AUDIO] ⚠ %s doesn't capture 16kHz mono natively (%s); audio will be resampled", device.Name, device.Capabilities())
+	}
+	return device, err
 }
 
 // selectMicrophoneFromList is an internal helper for testing
@@ -15,7 +15,7 @@ func TestListMicrophones_Integration(t *testing.T) {
 		t.Skip("Skipping integration test in CI environment")
 	}
 
-	devices, err := ListMicrophones()
+	devices, err := ListMicrophones(BackendAuto)
 	if err != nil {
 		t.Fatalf("Failed to list microphones: %v", err)
 	}
@@ -52,7 +52,7 @@ func TestGetDefaultMicrophone_Integration(t *testing.T) {
 		t.Skip("Skipping integration test in CI environment")
 	}
 
-	device, err := GetDefaultMicrophone()
+	device, err := GetDefaultMicrophone(BackendAuto)
 	if err != nil {
 		t.Fatalf("Failed to get default microphone: %v", err)
 	}
@@ -72,7 +72,7 @@ func TestFindMicrophoneByName_Integration(t *testing.T) {
 	}
 
 	// First get a list of available devices
-	devices, err := ListMicrophones()
+	devices, err := ListMicrophones(BackendAuto)
 	if err != nil {
 		t.Fatalf("Failed to list microphones: %v", err)
 	}
@@ -83,7 +83,7 @@ func TestFindMicrophoneByName_Integration(t *testing.T) {
 
 	// Try to find the first device by name
 	testDevice := devices[0]
-	found, err := FindMicrophoneByName(testDevice.Name)
+	found, err := FindMicrophoneByName(testDevice.Name, BackendAuto)
 	if err != nil {
 		t.Fatalf("Failed to find microphone by name '%s': %v", testDevice.Name, err)
 	}
@@ -93,7 +93,7 @@ func TestFindMicrophoneByName_Integration(t *testing.T) {
 	}
 
 	// Try to find a non-existent device
-	_, err = FindMicrophoneByName("NonExistentMicrophone12345")
+	_, err = FindMicrophoneByName("NonExistentMicrophone12345", BackendAuto)
 	if err == nil {
 		t.Error("Expected error when searching for non-existent microphone, got nil")
 	}
@@ -105,7 +105,7 @@ func TestFindMicrophoneByNameOrIndex_Integration(t *testing.T) {
 	}
 
 	// First get a list of available devices
-	devices, err := ListMicrophones()
+	devices, err := ListMicrophones(BackendAuto)
 	if err != nil {
 		t.Fatalf("Failed to list microphones: %v", err)
 	}
@@ -118,7 +118,7 @@ func TestFindMicrophoneByNameOrIndex_Integration(t *testing.T) {
 		// Test finding by valid index (1-based)
 		for i := 0; i < len(devices); i++ {
 			indexStr := fmt.Sprintf("%d", i+1)
-			found, err := FindMicrophoneByNameOrIndex(indexStr)
+			found, err := FindMicrophoneByNameOrIndex(indexStr, BackendAuto)
 			if err != nil {
 				t.Fatalf("Failed to find microphone by index '%s': %v", indexStr, err)
 			}
@@ -132,7 +132,7 @@ func TestFindMicrophoneByNameOrIndex_Integration(t *testing.T) {
 	t.Run("FindByName", func(t *testing.T) {
 		// Test finding by name
 		testDevice := devices[0]
-		found, err := FindMicrophoneByNameOrIndex(testDevice.Name)
+		found, err := FindMicrophoneByNameOrIndex(testDevice.Name, BackendAuto)
 		if err != nil {
 			t.Fatalf("Failed to find microphone by name '%s': %v", testDevice.Name, err)
 		}
@@ -144,7 +144,7 @@ func TestFindMicrophoneByNameOrIndex_Integration(t *testing.T) {
 
 	t.Run("InvalidIndex_Zero", func(t *testing.T) {
 		// Test with index 0 (should be out of range since we use 1-based indexing)
-		_, err := FindMicrophoneByNameOrIndex("0")
+		_, err := FindMicrophoneByNameOrIndex("0", BackendAuto)
 		if err == nil {
 			t.Error("Expected error when using index 0, got nil")
 		}
@@ -152,7 +152,7 @@ func TestFindMicrophoneByNameOrIndex_Integration(t *testing.T) {
 
 	t.Run("InvalidIndex_Negative", func(t *testing.T) {
 		// Test with negative index
-		_, err := FindMicrophoneByNameOrIndex("-1")
+		_, err := FindMicrophoneByNameOrIndex("-1", BackendAuto)
 		if err == nil {
 			t.Error("Expected error when using negative index, got nil")
 		}
@@ -161,7 +161,7 @@ func TestFindMicrophoneByNameOrIndex_Integration(t *testing.T) {
 	t.Run("InvalidIndex_TooLarge", func(t *testing.T) {
 		// Test with index beyond available devices
 		tooLargeIndex := fmt.Sprintf("%d", len(devices)+10)
-		_, err := FindMicrophoneByNameOrIndex(tooLargeIndex)
+		_, err := FindMicrophoneByNameOrIndex(tooLargeIndex, BackendAuto)
 		if err == nil {
 			t.Error("Expected error when using too large index, got nil")
 		}
@@ -169,7 +169,7 @@ func TestFindMicrophoneByNameOrIndex_Integration(t *testing.T) {
 
 	t.Run("InvalidName", func(t *testing.T) {
 		// Test with non-existent name
-		_, err := FindMicrophoneByNameOrIndex("NonExistentMicrophone12345")
+		_, err := FindMicrophoneByNameOrIndex("NonExistentMicrophone12345", BackendAuto)
 		if err == nil {
 			t.Error("Expected error when searching for non-existent microphone, got nil")
 		}
@@ -178,7 +178,7 @@ func TestFindMicrophoneByNameOrIndex_Integration(t *testing.T) {
 	t.Run("NameThatLooksLikeNumber", func(t *testing.T) {
 		// If a device name is literally a number that's out of range,
 		// it should fail on index check first
-		_, err := FindMicrophoneByNameOrIndex("999")
+		_, err := FindMicrophoneByNameOrIndex("999", BackendAuto)
 		if err == nil {
 			t.Error("Expected error when using out of range number, got nil")
 		}
@@ -488,3 +488,93 @@ func TestSelectMicrophoneFromList_NoDevices(t *testing.T) {
 		t.Error("Expected error when no devices available, got nil")
 	}
 }
+
+func TestSelectMicrophoneFromList_FollowsPreferenceOrderNotDeviceOrder(t *testing.T) {
+	// Devices are enumerated in a different order than the user's priority list,
+	// and the legacy field names another connected mic (as in an upgraded config)
+	devices := []Device{
+		{ID: "0", Name: "MacBook Pro Microphone", IsDefault: true},
+		{ID: "1", Name: "AirPods Pro", IsDefault: false},
+		{ID: "2", Name: "Blue Yeti USB Microphone", IsDefault: false},
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Microphone = "MacBook Pro Microphone"
+	cfg.PreferredMicrophones = []string{"Studio Condenser", "Blue Yeti USB Microphone", "AirPods Pro"}
+
+	device, err := selectMicrophoneFromList(devices, cfg)
+	if err != nil {
+		t.Fatalf("SelectMicrophone failed: %v", err)
+	}
+
+	// The first connected preference wins, not the first enumerated device or the legacy field
+	if device.Name != "Blue Yeti USB Microphone" {
+		t.Errorf("Expected 'Blue Yeti USB Microphone', got '%s'", device.Name)
+	}
+}
+
+func TestListMicrophonesWithEnumerator_Capabilities(t *testing.T) {
+	mockEnum := CreateMockEnumerator([]DeviceInfo{
+		NewMockDeviceInfoWithFormats("Studio Mic", true, [2]uint32{48000, 2}, [2]uint32{16000, 1}),
+		NewMockDeviceInfoWithFormats("Any Rate Mic", false, [2]uint32{0, 1}),
+		NewMockDeviceInfo("Unknown Mic", false),
+	}, nil)
+
+	devices, err := listMicrophonesWithEnumerator(mockEnum)
+	if err != nil {
+		t.Fatalf("Failed to list microphones: %v", err)
+	}
+
+	tests := []struct {
+		name                     string
+		sampleRate, channels     uint32
+		minRate, maxRate         uint32
+		minChannels, maxChannels uint32
+		capabilities             string
+		canCapture16kMono        bool
+	}{
+		{"Studio Mic", 48000, 2, 16000, 48000, 1, 2, "16000-48000 Hz, 1-2 ch", true},
+		{"Any Rate Mic", 0, 1, 0, 0, 1, 1, "1 ch", true},
+		{"Unknown Mic", 0, 0, 0, 0, 0, 0, "", true},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := devices[i]
+			if d.SampleRate != tt.sampleRate || d.Channels != tt.channels {
+				t.Errorf("got %d Hz / %d ch, want %d Hz / %d ch", d.SampleRate, d.Channels, tt.sampleRate, tt.channels)
+			}
+			if d.MinSampleRate != tt.minRate || d.MaxSampleRate != tt.maxRate {
+				t.Errorf("sample rate range = %d-%d, want %d-%d", d.MinSampleRate, d.MaxSampleRate, tt.minRate, tt.maxRate)
+			}
+			if d.MinChannels != tt.minChannels || d.MaxChannels != tt.maxChannels {
+				t.Errorf("channel range = %d-%d, want %d-%d", d.MinChannels, d.MaxChannels, tt.minChannels, tt.maxChannels)
+			}
+			if got := d.Capabilities(); got != tt.capabilities {
+				t.Errorf("Capabilities() = %q, want %q", got, tt.capabilities)
+			}
+			if got := d.CanCapture(16000, 1); got != tt.canCapture16kMono {
+				t.Errorf("CanCapture(16000, 1) = %v, want %v", got, tt.canCapture16kMono)
+			}
+		})
+	}
+}
+
+func TestDeviceCanCapture(t *testing.T) {
+	tests := []struct {
+		name   string
+		device Device
+		want   bool
+	}{
+		{"only 48kHz", Device{MinSampleRate: 48000, MaxSampleRate: 48000, MinChannels: 1, MaxChannels: 2}, false},
+		{"stereo only", Device{MinSampleRate: 8000, MaxSampleRate: 48000, MinChannels: 2, MaxChannels: 2}, false},
+		{"range covers 16kHz mono", Device{MinSampleRate: 8000, MaxSampleRate: 48000, MinChannels: 1, MaxChannels: 2}, true},
+		{"unknown", Device{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.device.CanCapture(16000, 1); got != tt.want {
+				t.Errorf("CanCapture(16000, 1) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
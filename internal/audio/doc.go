@@ -4,6 +4,7 @@
 //   - Audio input device enumeration and selection
 //   - Audio recording with configurable parameters (sample rate, channels, format)
 //   - WAV file generation from recorded audio data
+//   - Cleanup of captured audio: silence trimming, noise gating and gain
 //   - Audio feedback (system sounds) for user notifications
 //
 // The audio recording is implemented using the malgo library which provides
@@ -13,13 +14,13 @@
 // Example usage:
 //
 //	// List available microphones
-//	devices, err := audio.ListDevices()
+//	devices, err := audio.ListMicrophones(audio.BackendAuto)
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
 //
 //	// Create recorder with default settings
-//	recorder, err := audio.NewRecorder(devices[0].ID)
+//	recorder, err := audio.NewRecorder(devices[0].Name, audio.BackendAuto)
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
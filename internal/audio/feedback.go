@@ -1,5 +1,76 @@
 package audio
 
+import (
+	"path/filepath"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+// Default system sounds used for audio feedback
+const (
+	DefaultStartSound    = "Tink"  // Short ascending beep
+	DefaultStopSound     = "Pop"   // Short neutral beep
+	DefaultCompleteSound = "Glass" // Pleasant "ding"
+)
+
+// FeedbackOptions selects the sounds played for each event.
+// Empty names fall back to the defaults. When a sound file is set it is played
+// instead of the system sound, which remains the fallback if the file is missing.
+type FeedbackOptions struct {
+	StartSound    string
+	StopSound     string
+	CompleteSound string
+
+	StartSoundFile    string
+	StopSoundFile     string
+	CompleteSoundFile string
+
+	// Volume is the playback volume from 0.0 to 1.0 (0 = unset, plays at full volume)
+	Volume float64
+}
+
+// FeedbackOptionsFromConfig builds feedback options from the user's configuration
+func FeedbackOptionsFromConfig(cfg *config.Config) FeedbackOptions {
+	return FeedbackOptions{
+		StartSound:    cfg.StartSound,
+		StopSound:     cfg.StopSound,
+		CompleteSound: cfg.CompleteSound,
+		Volume:        cfg.FeedbackVolume,
+
+		StartSoundFile:    absSoundPath(cfg.StartSoundFile),
+		StopSoundFile:     absSoundPath(cfg.StopSoundFile),
+		CompleteSoundFile: absSoundPath(cfg.CompleteSoundFile),
+	}
+}
+
+// absSoundPath resolves a configured sound file to an absolute path
+func absSoundPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// withDefaults fills in any unset sound names
+func (o FeedbackOptions) withDefaults() FeedbackOptions {
+	if o.StartSound == "" {
+		o.StartSound = DefaultStartSound
+	}
+	if o.StopSound == "" {
+		o.StopSound = DefaultStopSound
+	}
+	if o.CompleteSound == "" {
+		o.CompleteSound = DefaultCompleteSound
+	}
+	if o.Volume <= 0 || o.Volume > 1 {
+		o.Volume = 1.0
+	}
+	return o
+}
+
 // Feedback provides audio feedback for state changes during recording and transcription.
 // This is a placeholder interface that will be implemented per-platform.
 type Feedback interface {
@@ -17,6 +88,6 @@ type Feedback interface {
 }
 
 // NewFeedback creates a new platform-specific audio feedback instance
-func NewFeedback() (Feedback, error) {
-	return newPlatformFeedback()
+func NewFeedback(opts FeedbackOptions) (Feedback, error) {
+	return newPlatformFeedback(opts.withDefaults())
 }
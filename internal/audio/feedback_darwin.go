@@ -19,8 +19,8 @@ static void initSoundCache() {
     }
 }
 
-// Play a system sound by name
-static void playSystemSound(const char* soundName) {
+// Play a system sound by name at the given volume (0.0-1.0)
+static void playSystemSound(const char* soundName, float volume) {
     @autoreleasepool {
         initSoundCache();
 
@@ -41,17 +41,19 @@ static void playSystemSound(const char* soundName) {
         // Stop any currently playing instance and restart
         if (sound != nil) {
             [sound stop];
+            [sound setVolume:volume];
             [sound play];
         }
     }
 }
 
-// Play a sound file from disk
-static int playSoundFile(const char* filePath) {
+// Play a sound file from disk at the given volume (0.0-1.0)
+static int playSoundFile(const char* filePath, float volume) {
     @autoreleasepool {
         NSString *path = [NSString stringWithUTF8String:filePath];
         NSSound *sound = [[NSSound alloc] initWithContentsOfFile:path byReference:NO];
         if (sound != nil) {
+            [sound setVolume:volume];
             [sound play];
 
             // Keep sound alive in a temporary set for the duration of playback
@@ -68,61 +70,65 @@ static int playSoundFile(const char* filePath) {
 import "C"
 import (
 	"fmt"
+	"os"
 	"unsafe"
 )
 
 // darwinFeedback implements audio feedback using macOS NSSound
 type darwinFeedback struct {
 	enabled bool
+	opts    FeedbackOptions
 }
 
 // newPlatformFeedback creates a new macOS audio feedback instance
-func newPlatformFeedback() (Feedback, error) {
+func newPlatformFeedback(opts FeedbackOptions) (Feedback, error) {
 	return &darwinFeedback{
 		enabled: true,
+		opts:    opts,
 	}, nil
 }
 
 // PlayStartSound plays the sound when recording starts
-// Uses "Tink" system sound (a short, ascending beep)
+// Uses "Tink" system sound (a short, ascending beep) unless configured otherwise
 func (f *darwinFeedback) PlayStartSound() error {
 	if !f.enabled {
 		return nil
 	}
-
-	soundName := C.CString("Tink")
-	defer C.free(unsafe.Pointer(soundName))
-
-	C.playSystemSound(soundName)
-	return nil
+	return f.play(f.opts.StartSoundFile, f.opts.StartSound)
 }
 
 // PlayStopSound plays the sound when recording stops
-// Uses "Pop" system sound (a short, neutral beep)
+// Uses "Pop" system sound (a short, neutral beep) unless configured otherwise
 func (f *darwinFeedback) PlayStopSound() error {
 	if !f.enabled {
 		return nil
 	}
-
-	soundName := C.CString("Pop")
-	defer C.free(unsafe.Pointer(soundName))
-
-	C.playSystemSound(soundName)
-	return nil
+	return f.play(f.opts.StopSoundFile, f.opts.StopSound)
 }
 
 // PlayCompleteSound plays the sound when transcription completes
-// Uses "Glass" system sound (a pleasant "ding" sound)
+// Uses "Glass" system sound (a pleasant "ding" sound) unless configured otherwise
 func (f *darwinFeedback) PlayCompleteSound() error {
 	if !f.enabled {
 		return nil
 	}
+	return f.play(f.opts.CompleteSoundFile, f.opts.CompleteSound)
+}
 
-	soundName := C.CString("Glass")
-	defer C.free(unsafe.Pointer(soundName))
-
-	C.playSystemSound(soundName)
-	return nil
+// play plays the custom sound file if one is configured and present,
+// falling back to the named system sound otherwise
+func (f *darwinFeedback) play(file, systemSound string) error {
+	if file != "" {
+		if _, err := os.Stat(file); err == nil {
+			cPath := C.CString(file)
+			defer C.free(unsafe.Pointer(cPath))
+
+			if C.playSoundFile(cPath, C.float(f.opts.Volume)) == 0 {
+				return nil
+			}
+		}
+	}
+	return playSound(systemSound, f.opts.Volume)
 }
 
 // Close releases any resources
@@ -140,12 +146,12 @@ func (f *darwinFeedback) Enable() {
 	f.enabled = true
 }
 
-// playSound is a helper function to play a specific system sound
-func playSound(soundName string) error {
+// playSound is a helper function to play a specific system sound at a volume (0.0-1.0)
+func playSound(soundName string, volume float64) error {
 	cName := C.CString(soundName)
 	defer C.free(unsafe.Pointer(cName))
 
-	C.playSystemSound(cName)
+	C.playSystemSound(cName, C.float(volume))
 	return nil
 }
 
@@ -176,7 +182,7 @@ func PlaySoundByName(name string) error {
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
-	C.playSystemSound(cName)
+	C.playSystemSound(cName, C.float(1.0))
 	return nil
 }
 
@@ -9,7 +9,7 @@ import "fmt"
 type noopFeedback struct{}
 
 // newPlatformFeedback creates a no-op feedback instance for unsupported platforms
-func newPlatformFeedback() (Feedback, error) {
+func newPlatformFeedback(_ FeedbackOptions) (Feedback, error) {
 	return &noopFeedback{}, fmt.Errorf("audio feedback is not supported on this platform")
 }
 
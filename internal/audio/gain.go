@@ -195,3 +195,34 @@ func ApplyGain(audioData []byte, gainDB float64, preventClipping bool) ([]byte,
 
 	return outputData, nil
 }
+
+// NormalizeAudio scales 16-bit samples so the loudest one reaches targetPeak
+// (a fraction of full scale, e.g. 0.9). Quiet audio is boosted; audio that
+// already peaks at or above the target, silence and malformed data are returned unchanged.
+func NormalizeAudio(data []byte, targetPeak float64) []byte {
+	if len(data) == 0 || len(data)%2 != 0 || targetPeak <= 0 {
+		return data
+	}
+	if targetPeak > 1 {
+		targetPeak = 1
+	}
+
+	var peak float64
+	for i := 0; i+1 < len(data); i += 2 {
+		sample := math.Abs(float64(int16(binary.LittleEndian.Uint16(data[i : i+2]))))
+		if sample > peak {
+			peak = sample
+		}
+	}
+
+	target := targetPeak * 32767.0
+	if peak == 0 || peak >= target {
+		return data
+	}
+
+	normalized, err := ApplyGain(data, 20*math.Log10(target/peak), true)
+	if err != nil {
+		return data
+	}
+	return normalized
+}
@@ -1,6 +1,7 @@
 package audio
 
 import (
+	"bytes"
 	"encoding/binary"
 	"math"
 	"testing"
@@ -446,3 +447,90 @@ func TestProcessAudioGain_ResultLevel(t *testing.T) {
 		t.Errorf("ResultingLevelDB = %.1f, want ~%.1f (target)", gainResult.ResultingLevelDB, targetLevel)
 	}
 }
+
+// sineWithPeak generates 100ms of a 440 Hz sine whose peak is the given amplitude
+func sineWithPeak(peak float64) []byte {
+	samples := make([]int16, 1600)
+	for i := range samples {
+		// Quarter-period offset so the first sample hits the peak exactly
+		samples[i] = int16(math.Round(peak * math.Cos(2*math.Pi*440*float64(i)/16000)))
+	}
+	return pcm(samples...)
+}
+
+// peakOf returns the largest absolute sample value
+func peakOf(data []byte) int {
+	peak := 0
+	for _, s := range samplesOf(data) {
+		v := int(s)
+		if v < 0 {
+			v = -v
+		}
+		if v > peak {
+			peak = v
+		}
+	}
+	return peak
+}
+
+func TestNormalizeAudio_ScalesHalfAmplitudeSine(t *testing.T) {
+	input := sineWithPeak(16384)
+
+	output := NormalizeAudio(input, 0.9)
+
+	wantPeak := 0.9 * 32767
+	if got := float64(peakOf(output)); math.Abs(got-wantPeak) > 1 {
+		t.Errorf("peak after normalization = %.0f, want %.0f", got, wantPeak)
+	}
+
+	// Every sample is scaled by the same factor
+	factor := wantPeak / 16384
+	in, out := samplesOf(input), samplesOf(output)
+	for i := range in {
+		if want := float64(in[i]) * factor; math.Abs(float64(out[i])-want) > 1 {
+			t.Fatalf("sample %d = %d, want %.0f (factor %.3f)", i, out[i], want, factor)
+		}
+	}
+}
+
+func TestNormalizeAudio_LeavesLoudSignalUnchanged(t *testing.T) {
+	for _, peak := range []float64{0.95 * 32767, 32767} {
+		input := sineWithPeak(peak)
+
+		output := NormalizeAudio(input, 0.9)
+
+		if !bytes.Equal(output, input) {
+			t.Errorf("signal peaking at %.0f was changed by normalization", peak)
+		}
+		if got := peakOf(output); got > 32767 {
+			t.Errorf("peak after normalization = %d, clipped", got)
+		}
+	}
+}
+
+func TestNormalizeAudio_NeverClips(t *testing.T) {
+	output := NormalizeAudio(sineWithPeak(1000), 1.5)
+
+	if got := peakOf(output); got != 32767 {
+		t.Errorf("peak with target above full scale = %d, want 32767", got)
+	}
+}
+
+func TestNormalizeAudio_Unchanged(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"silence", make([]byte, 320)},
+		{"odd length", []byte{0x10, 0x00, 0x20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeAudio(tt.data, 0.9); !bytes.Equal(got, tt.data) {
+				t.Errorf("NormalizeAudio() = %v, want input unchanged", got)
+			}
+		})
+	}
+}
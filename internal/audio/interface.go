@@ -7,6 +7,9 @@ import "github.com/gen2brain/malgo"
 type DeviceInfo interface {
 	Name() string
 	IsDefault() uint32
+	// NativeFormats lists the formats the device captures natively (empty if unknown).
+	// A SampleRate or Channels of 0 means any value is supported.
+	NativeFormats() []malgo.DataFormat
 }
 
 // MalgoDeviceInfo wraps malgo.DeviceInfo to implement our DeviceInfo interface
@@ -24,6 +27,11 @@ func (m MalgoDeviceInfo) IsDefault() uint32 {
 	return m.info.IsDefault
 }
 
+// NativeFormats implements DeviceInfo
+func (m MalgoDeviceInfo) NativeFormats() []malgo.DataFormat {
+	return m.info.Formats
+}
+
 // DeviceEnumerator is an interface for enumerating audio devices
 // This allows for mocking in tests
 type DeviceEnumerator interface {
@@ -42,9 +50,13 @@ func (m *MalgoContext) Devices(deviceType malgo.DeviceType) ([]DeviceInfo, error
 		return nil, err
 	}
 
-	// Wrap malgo.DeviceInfo in our interface
+	// Wrap malgo.DeviceInfo in our interface. Enumeration doesn't always report the
+	// native formats, so query each device for them (keeping what we have on failure).
 	result := make([]DeviceInfo, len(infos))
 	for i := range infos {
+		if detailed, err := m.ctx.DeviceInfo(deviceType, infos[i].ID, malgo.Shared); err == nil && len(detailed.Formats) > 0 {
+			infos[i].Formats = detailed.Formats
+		}
 		result[i] = MalgoDeviceInfo{info: &infos[i]}
 	}
 
@@ -61,9 +73,10 @@ func (m *MalgoContext) Free() {
 	m.ctx.Free()
 }
 
-// NewMalgoContext creates a new MalgoContext
-func NewMalgoContext() (*MalgoContext, error) {
-	ctx, err := malgo.InitContext(nil, malgo.ContextConfig{}, nil)
+// NewMalgoContext creates a new MalgoContext using the named audio backend
+// ("auto" or empty lets miniaudio choose)
+func NewMalgoContext(backend string) (*MalgoContext, error) {
+	ctx, err := initContext(backend)
 	if err != nil {
 		return nil, err
 	}
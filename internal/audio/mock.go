@@ -19,6 +19,7 @@ func (m *MockDeviceEnumerator) Devices(deviceType malgo.DeviceType) ([]DeviceInf
 type MockDeviceInfo struct {
 	name      string
 	isDefault uint32
+	formats   []malgo.DataFormat
 }
 
 // Name returns the device name
@@ -31,6 +32,11 @@ func (m MockDeviceInfo) IsDefault() uint32 {
 	return m.isDefault
 }
 
+// NativeFormats returns the device's native formats
+func (m MockDeviceInfo) NativeFormats() []malgo.DataFormat {
+	return m.formats
+}
+
 // NewMockDeviceInfo creates a new MockDeviceInfo
 func NewMockDeviceInfo(name string, isDefault bool) DeviceInfo {
 	defaultValue := uint32(0)
@@ -43,6 +49,16 @@ func NewMockDeviceInfo(name string, isDefault bool) DeviceInfo {
 	}
 }
 
+// NewMockDeviceInfoWithFormats creates a MockDeviceInfo that reports native formats,
+// given as sample rate and channel count pairs
+func NewMockDeviceInfoWithFormats(name string, isDefault bool, formats ...[2]uint32) DeviceInfo {
+	info := NewMockDeviceInfo(name, isDefault).(MockDeviceInfo)
+	for _, f := range formats {
+		info.formats = append(info.formats, malgo.DataFormat{Format: malgo.FormatS16, SampleRate: f[0], Channels: f[1]})
+	}
+	return info
+}
+
 // CreateMockEnumerator creates a mock enumerator with predefined devices
 func CreateMockEnumerator(devices []DeviceInfo, err error) *MockDeviceEnumerator {
 	return &MockDeviceEnumerator{
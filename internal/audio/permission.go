@@ -0,0 +1,44 @@
+package audio
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// permissionProbeDuration is how long CheckMicrophonePermission records
+const permissionProbeDuration = 500 * time.Millisecond
+
+// ErrMicrophoneDenied is returned when a microphone records nothing but digital silence,
+// which is what macOS delivers to apps that were denied microphone access
+var ErrMicrophoneDenied = errors.New("microphone recorded only silence; microphone access is likely denied.\n" +
+	"Grant access for your terminal in System Settings > Privacy & Security > Microphone, then restart the terminal")
+
+// CheckMicrophonePermission records briefly from the default microphone of the named
+// audio backend to confirm OpenScribe can hear it. On first use this also makes macOS
+// ask for permission.
+func CheckMicrophonePermission(backend string) error {
+	device, err := GetDefaultMicrophone(backend)
+	if err != nil {
+		return fmt.Errorf("no microphone to test: %w", err)
+	}
+
+	recorder := NewRecorder(device.Name, backend)
+	audioData, err := recorder.RecordDuration(permissionProbeDuration)
+	if err != nil {
+		return fmt.Errorf("failed to record from %s: %w", device.Name, err)
+	}
+
+	return checkCapturedAudio(audioData)
+}
+
+// checkCapturedAudio reports ErrMicrophoneDenied for an empty or all-zero capture.
+// Even a quiet room leaves some noise in the samples of a working microphone.
+func checkCapturedAudio(audioData []byte) error {
+	for _, b := range audioData {
+		if b != 0 {
+			return nil
+		}
+	}
+	return ErrMicrophoneDenied
+}
@@ -0,0 +1,32 @@
+package audio
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckCapturedAudio(t *testing.T) {
+	quietRoom := constantChunk(500*time.Millisecond, 0)
+	quietRoom[1234] = 0x01 // A single LSB of noise is enough to prove the mic is live
+
+	tests := []struct {
+		name       string
+		audio      []byte
+		wantDenied bool
+	}{
+		{"nothing captured", nil, true},
+		{"all zeros", constantChunk(500*time.Millisecond, 0), true},
+		{"quiet room noise", quietRoom, false},
+		{"speech", sineWave(440, 16000, 0.5), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCapturedAudio(tt.audio)
+			if denied := errors.Is(err, ErrMicrophoneDenied); denied != tt.wantDenied {
+				t.Errorf("checkCapturedAudio() error = %v, want denied = %v", err, tt.wantDenied)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package audio
 
 import (
 	"fmt"
+	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gen2brain/malgo"
@@ -11,39 +13,156 @@ import (
 // Recorder handles audio recording from a microphone
 type Recorder struct {
 	deviceName     string
-	sampleRate     uint32
+	backend        string // audio_backend name ("auto" or empty lets miniaudio choose)
+	sampleRate     uint32 // Output rate returned by Stop (whisper-compatible)
+	captureRate    uint32 // Rate the device actually captured at
 	channels       uint32
 	isRecording    bool
 	audioData      []byte
+	capturedBytes  int // Length of the current or last recording; audioData is handed off by Stop
 	audioDataMutex sync.Mutex
 	device         *malgo.Device
 	context        *malgo.AllocatedContext
+
+	// Voice-activity auto-stop (disabled when silenceTimeout is 0)
+	silenceTimeout     time.Duration
+	silenceThresholdDB float64
+	silenceDetected    chan struct{}
+
+	// level holds the float64 bits of the normalized RMS of the most recent frames
+	level atomic.Uint64
+
+	// Device initialization retries (see SetInitRetry)
+	initAttempts int
+	initBackoff  time.Duration
+	onInitRetry  func(attempt int, err error)
 }
 
-// NewRecorder creates a new audio recorder
-func NewRecorder(deviceName string) *Recorder {
+// Default device initialization retries: 3 attempts, 200ms then 400ms apart
+const (
+	DefaultInitAttempts = 3
+	DefaultInitBackoff  = 200 * time.Millisecond
+)
+
+// NewRecorder creates a new audio recorder for the named device on the named audio
+// backend ("auto" or empty lets miniaudio choose)
+func NewRecorder(deviceName, backend string) *Recorder {
 	return &Recorder{
 		deviceName:  deviceName,
+		backend:     backend,
 		sampleRate:  16000, // Whisper-compatible sample rate
+		captureRate: 16000, // Updated to the device rate on Start
 		channels:    1,     // Mono
 		isRecording: false,
 		audioData:   make([]byte, 0),
+
+		initAttempts: DefaultInitAttempts,
+		initBackoff:  DefaultInitBackoff,
 	}
 }
 
-// Start begins recording audio
+// SetInitRetry makes Start try to initialize the audio device up to attempts times,
+// waiting backoff before the first retry and doubling it before each later one.
+// onRetry, if set, is called with each failure that is retried. Must be called before Start.
+func (r *Recorder) SetInitRetry(attempts int, backoff time.Duration, onRetry func(attempt int, err error)) {
+	r.initAttempts = attempts
+	r.initBackoff = backoff
+	r.onInitRetry = onRetry
+}
+
+// SetSilenceDetection enables auto-stop signalling: once the audio level stays below
+// thresholdDB (dBFS) for timeout, the channel returned by SilenceDetected is closed.
+// A timeout of 0 disables detection. Must be called before Start.
+func (r *Recorder) SetSilenceDetection(timeout time.Duration, thresholdDB float64) {
+	r.silenceTimeout = timeout
+	r.silenceThresholdDB = thresholdDB
+}
+
+// SilenceDetected returns a channel that is closed when the silence timeout is reached.
+// Returns nil (blocks forever in a select) when detection is disabled or not recording.
+func (r *Recorder) SilenceDetected() <-chan struct{} {
+	return r.silenceDetected
+}
+
+// Start begins recording audio. A device that fails to initialize (common right after
+// waking from sleep) is retried as configured with SetInitRetry.
 func (r *Recorder) Start() error {
 	if r.isRecording {
 		return fmt.Errorf("already recording")
 	}
 
-	// Initialize audio context
-	ctx, err := malgo.InitContext(nil, malgo.ContextConfig{}, nil)
+	// Reset audio data buffer
+	r.audioDataMutex.Lock()
+	r.audioData = make([]byte, 0)
+	r.capturedBytes = 0
+	r.audioDataMutex.Unlock()
+
+	// Silence detection is set up once the capture rate is known (before the device starts)
+	var detector *silenceDetector
+	r.silenceDetected = nil
+	silenceSignalled := false
+
+	// Callback to capture audio data
+	onRecvFrames := func(_, pSample []byte, _ uint32) {
+		r.level.Store(math.Float64bits(chunkRMS(pSample)))
+
+		r.audioDataMutex.Lock()
+		r.audioData = append(r.audioData, pSample...)
+		r.capturedBytes = len(r.audioData)
+		if detector != nil && !silenceSignalled && detector.process(pSample) {
+			silenceSignalled = true
+			close(r.silenceDetected)
+		}
+		r.audioDataMutex.Unlock()
+	}
+	callbacks := malgo.DeviceCallbacks{Data: onRecvFrames}
+
+	var ctx *malgo.AllocatedContext
+	var device *malgo.Device
+	err := retryWithBackoff(r.initAttempts, r.initBackoff, func() error {
+		var initErr error
+		ctx, device, initErr = r.initDevice(callbacks)
+		return initErr
+	}, r.onInitRetry)
 	if err != nil {
-		return fmt.Errorf("failed to initialize audio context: %w\n\nPlease check:\n  1. Your audio drivers are properly installed\n  2. System Preferences > Security & Privacy > Privacy > Microphone includes your terminal app\n  3. No other application is exclusively using the audio system", err)
+		return err
 	}
 	r.context = ctx
 
+	r.captureRate = device.SampleRate()
+	if r.captureRate == 0 {
+		r.captureRate = r.sampleRate
+	}
+
+	// Set up silence detection for auto-stop
+	if r.silenceTimeout > 0 {
+		detector = newSilenceDetector(r.silenceTimeout, r.silenceThresholdDB, r.captureRate, r.channels)
+		r.silenceDetected = make(chan struct{})
+	}
+
+	err = device.Start()
+	if err != nil {
+		device.Uninit()
+		_ = ctx.Uninit()
+		ctx.Free()
+		return fmt.Errorf("failed to start audio recording: %w\n\nPossible causes:\n  1. The microphone is disconnected or disabled\n  2. Microphone permissions not granted\n  3. Another application has exclusive access to the microphone\n\nPlease check System Preferences > Security & Privacy > Privacy > Microphone", err)
+	}
+
+	r.device = device
+	r.isRecording = true
+
+	return nil
+}
+
+// initDevice initializes an audio context and the capture device. On failure it
+// frees whatever it initialized, so it can simply be called again.
+func (r *Recorder) initDevice(callbacks malgo.DeviceCallbacks) (*malgo.AllocatedContext, *malgo.Device, error) {
+	// Initialize audio context
+	ctx, err := initContext(r.backend)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize audio context: %w\n\nPlease check:\n  1. Your audio drivers are properly installed\n  2. System Preferences > Security & Privacy > Privacy > Microphone includes your terminal app\n  3. No other application is exclusively using the audio system", err)
+	}
+
 	// Find the device to use
 	var deviceInfo *malgo.DeviceInfo
 	if r.deviceName != "" {
@@ -52,7 +171,7 @@ func (r *Recorder) Start() error {
 		if devicesErr != nil {
 			_ = ctx.Uninit()
 			ctx.Free()
-			return fmt.Errorf("failed to enumerate devices: %w", devicesErr)
+			return nil, nil, fmt.Errorf("failed to enumerate devices: %w", devicesErr)
 		}
 
 		found := false
@@ -84,7 +203,7 @@ func (r *Recorder) Start() error {
 			errMsg += "     $ openscribe config --set-microphone \"<name>\"\n"
 			errMsg += "  3. Use the default microphone (leave config empty)"
 
-			return fmt.Errorf("%s", errMsg)
+			return nil, nil, fmt.Errorf("%s", errMsg)
 		}
 	}
 
@@ -99,40 +218,22 @@ func (r *Recorder) Start() error {
 		deviceConfig.Capture.DeviceID = deviceInfo.ID.Pointer()
 	}
 
-	// Reset audio data buffer
-	r.audioDataMutex.Lock()
-	r.audioData = make([]byte, 0)
-	r.audioDataMutex.Unlock()
-
-	// Callback to capture audio data
-	onRecvFrames := func(_, pSample []byte, _ uint32) {
-		r.audioDataMutex.Lock()
-		r.audioData = append(r.audioData, pSample...)
-		r.audioDataMutex.Unlock()
-	}
-
-	// Initialize and start device
-	device, err := malgo.InitDevice(ctx.Context, deviceConfig, malgo.DeviceCallbacks{
-		Data: onRecvFrames,
-	})
+	// Initialize device at 16kHz, falling back to the device's native rate
+	// (resampled in Stop) for microphones that don't support 16kHz capture
+	device, err := malgo.InitDevice(ctx.Context, deviceConfig, callbacks)
 	if err != nil {
-		_ = ctx.Uninit()
-		ctx.Free()
-		return fmt.Errorf("failed to initialize audio device: %w\n\nPossible causes:\n  1. The microphone is being used by another application\n  2. The microphone permissions are not granted\n  3. The audio device configuration is incompatible\n\nTry:\n  - Closing other apps that might use the microphone\n  - Granting microphone permissions in System Preferences\n  - Using the default microphone by removing the config setting", err)
+		deviceConfig.SampleRate = 0 // Use the device's native rate
+		if nativeDevice, nativeErr := malgo.InitDevice(ctx.Context, deviceConfig, callbacks); nativeErr == nil {
+			device, err = nativeDevice, nil
+		}
 	}
-
-	err = device.Start()
 	if err != nil {
-		device.Uninit()
 		_ = ctx.Uninit()
 		ctx.Free()
-		return fmt.Errorf("failed to start audio recording: %w\n\nPossible causes:\n  1. The microphone is disconnected or disabled\n  2. Microphone permissions not granted\n  3. Another application has exclusive access to the microphone\n\nPlease check System Preferences > Security & Privacy > Privacy > Microphone", err)
+		return nil, nil, fmt.Errorf("failed to initialize audio device: %w\n\nPossible causes:\n  1. The microphone is being used by another application\n  2. The microphone permissions are not granted\n  3. The audio device configuration is incompatible\n\nTry:\n  - Closing other apps that might use the microphone\n  - Granting microphone permissions in System Preferences\n  - Using the default microphone by removing the config setting", err)
 	}
 
-	r.device = device
-	r.isRecording = true
-
-	return nil
+	return ctx, device, nil
 }
 
 // Stop ends the recording and returns the captured audio data
@@ -156,16 +257,49 @@ func (r *Recorder) Stop() ([]byte, error) {
 	}
 
 	r.isRecording = false
+	r.level.Store(0)
 
-	// Return the captured audio data
+	// Hand the captured audio over instead of copying it, so a long recording isn't
+	// held in memory twice. Capture itself still buffers in memory: the pipeline
+	// processes the audio as a whole before it is written with a WAVWriter.
 	r.audioDataMutex.Lock()
-	data := make([]byte, len(r.audioData))
-	copy(data, r.audioData)
+	data := r.audioData
+	r.audioData = nil
 	r.audioDataMutex.Unlock()
 
+	// Convert native-rate captures to the output rate
+	if r.captureRate != r.sampleRate {
+		data = Resample(data, r.captureRate, r.sampleRate, r.channels)
+	}
+
 	return data, nil
 }
 
+// Snapshot returns a copy of the audio captured so far, starting at byte offset.
+// It is safe to call while recording, which lets callers process audio incrementally.
+// The data is at the capture rate (see GetCaptureSampleRate), not resampled.
+func (r *Recorder) Snapshot(offset int) []byte {
+	r.audioDataMutex.Lock()
+	defer r.audioDataMutex.Unlock()
+
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(r.audioData) {
+		return []byte{}
+	}
+
+	data := make([]byte, len(r.audioData)-offset)
+	copy(data, r.audioData[offset:])
+	return data
+}
+
+// Level returns the normalized RMS level (0.0-1.0) of the most recently captured frames.
+// It is updated continuously while recording and is safe to call from any goroutine.
+func (r *Recorder) Level() float64 {
+	return math.Float64frombits(r.level.Load())
+}
+
 // IsRecording returns whether the recorder is currently recording
 func (r *Recorder) IsRecording() bool {
 	return r.isRecording
@@ -176,11 +310,53 @@ func (r *Recorder) GetSampleRate() uint32 {
 	return r.sampleRate
 }
 
+// GetCaptureSampleRate returns the rate the device actually captured at.
+// This differs from GetSampleRate when the microphone doesn't support 16kHz
+// and audio is resampled after capture.
+func (r *Recorder) GetCaptureSampleRate() uint32 {
+	return r.captureRate
+}
+
 // GetChannels returns the number of audio channels
 func (r *Recorder) GetChannels() uint32 {
 	return r.channels
 }
 
+// Duration returns the length of the audio captured so far (or by the last recording),
+// computed from the number of samples rather than wall-clock time
+func (r *Recorder) Duration() time.Duration {
+	r.audioDataMutex.Lock()
+	n := r.capturedBytes
+	r.audioDataMutex.Unlock()
+	return AudioDuration(n, r.captureRate, r.channels)
+}
+
+// AudioDuration returns the playback length of n bytes of 16-bit PCM audio
+func AudioDuration(n int, sampleRate, channels uint32) time.Duration {
+	if sampleRate == 0 || channels == 0 {
+		return 0
+	}
+	frames := int64(n) / int64(2*channels)
+	return time.Duration(frames) * time.Second / time.Duration(sampleRate)
+}
+
+// retryWithBackoff calls fn up to attempts times (at least once) until it succeeds,
+// sleeping backoff before the first retry and doubling it before each later one.
+// onRetry, if set, is called with each failure that is retried. Returns the last error.
+func retryWithBackoff(attempts int, backoff time.Duration, fn func() error, onRetry func(attempt int, err error)) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil || attempt >= attempts {
+			return err
+		}
+		if onRetry != nil {
+			onRetry(attempt, err)
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
 // RecordDuration records audio for a specific duration
 func (r *Recorder) RecordDuration(duration time.Duration) ([]byte, error) {
 	if err := r.Start(); err != nil {
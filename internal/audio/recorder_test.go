@@ -0,0 +1,116 @@
+package audio
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestAudioDuration(t *testing.T) {
+	tests := []struct {
+		name       string
+		bytes      int
+		sampleRate uint32
+		channels   uint32
+		want       time.Duration
+	}{
+		{"one second mono 16kHz", 32000, 16000, 1, time.Second},
+		{"half second stereo 48kHz", 96000, 48000, 2, 500 * time.Millisecond},
+		{"44.1kHz mono", 44100 * 2 * 3, 44100, 1, 3 * time.Second},
+		{"partial frame ignored", 32001, 16000, 1, time.Second},
+		{"empty", 0, 16000, 1, 0},
+		{"zero sample rate", 32000, 0, 1, 0},
+		{"zero channels", 32000, 16000, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AudioDuration(tt.bytes, tt.sampleRate, tt.channels); got != tt.want {
+				t.Errorf("AudioDuration(%d, %d, %d) = %v, want %v", tt.bytes, tt.sampleRate, tt.channels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecorderDuration(t *testing.T) {
+	recorder := NewRecorder("", BackendAuto)
+	if got := recorder.Duration(); got != 0 {
+		t.Errorf("Duration() before recording = %v, want 0", got)
+	}
+
+	// Feed 2.5 seconds of frames at a 48kHz capture rate, as the device callback would
+	recorder.captureRate = 48000
+	recorder.audioData = make([]byte, 48000*2*5/2)
+	recorder.capturedBytes = len(recorder.audioData)
+
+	if got := recorder.Duration(); got != 2500*time.Millisecond {
+		t.Errorf("Duration() = %v, want 2.5s", got)
+	}
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	errInit := errors.New("device init failed")
+
+	tests := []struct {
+		name        string
+		attempts    int
+		failures    int // Calls that fail before the first success
+		wantErr     bool
+		wantCalls   int
+		wantRetries []int
+	}{
+		{"succeeds first time", 3, 0, false, 1, nil},
+		{"succeeds after retries", 3, 2, false, 3, []int{1, 2}},
+		{"gives up with last error", 3, 5, true, 3, []int{1, 2}},
+		{"single attempt", 1, 1, true, 1, nil},
+		{"zero attempts still tries once", 0, 0, false, 1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			var retries []int
+			err := retryWithBackoff(tt.attempts, time.Millisecond, func() error {
+				calls++
+				if calls <= tt.failures {
+					return fmt.Errorf("attempt %d: %w", calls, errInit)
+				}
+				return nil
+			}, func(attempt int, err error) {
+				retries = append(retries, attempt)
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("retryWithBackoff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && err.Error() != fmt.Sprintf("attempt %d: %v", tt.wantCalls, errInit) {
+				t.Errorf("retryWithBackoff() error = %v, want the last attempt's error", err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("called %d times, want %d", calls, tt.wantCalls)
+			}
+			if fmt.Sprint(retries) != fmt.Sprint(tt.wantRetries) {
+				t.Errorf("retries = %v, want %v", retries, tt.wantRetries)
+			}
+		})
+	}
+}
+
+func TestRetryWithBackoff_DoublesBackoff(t *testing.T) {
+	var times []time.Time
+	_ = retryWithBackoff(3, 20*time.Millisecond, func() error {
+		times = append(times, time.Now())
+		return errors.New("busy")
+	}, nil)
+
+	if len(times) != 3 {
+		t.Fatalf("called %d times, want 3", len(times))
+	}
+	if gap := times[1].Sub(times[0]); gap < 20*time.Millisecond {
+		t.Errorf("first backoff = %v, want at least 20ms", gap)
+	}
+	if gap := times[2].Sub(times[1]); gap < 40*time.Millisecond {
+		t.Errorf("second backoff = %v, want at least 40ms", gap)
+	}
+}
@@ -0,0 +1,150 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+// DefaultSilenceThresholdDB is the level below which audio is considered silence
+const DefaultSilenceThresholdDB = -45.0
+
+// silenceDetector tracks how long captured audio has stayed below a level threshold.
+// Elapsed time is derived from the amount of audio processed, not the wall clock,
+// so it is independent of callback scheduling.
+type silenceDetector struct {
+	thresholdDB    float64
+	timeoutBytes   int
+	silentBytes    int
+	bytesPerSecond int
+}
+
+// newSilenceDetector creates a detector for 16-bit PCM audio in the given format
+func newSilenceDetector(timeout time.Duration, thresholdDB float64, sampleRate, channels uint32) *silenceDetector {
+	bytesPerSecond := int(sampleRate) * int(channels) * 2
+	return &silenceDetector{
+		thresholdDB:    thresholdDB,
+		timeoutBytes:   int(timeout.Seconds() * float64(bytesPerSecond)),
+		bytesPerSecond: bytesPerSecond,
+	}
+}
+
+// process feeds a chunk of audio and reports whether the silence timeout has been reached
+func (d *silenceDetector) process(chunk []byte) bool {
+	if chunkLevelDB(chunk) < d.thresholdDB {
+		d.silentBytes += len(chunk)
+	} else {
+		d.silentBytes = 0
+	}
+	return d.silentBytes >= d.timeoutBytes
+}
+
+// chunkLevelDB returns the RMS level of 16-bit little-endian PCM audio in dBFS
+func chunkLevelDB(chunk []byte) float64 {
+	rms := chunkRMS(chunk)
+	if rms == 0 {
+		return -120.0
+	}
+	return 20 * math.Log10(rms)
+}
+
+// chunkRMS returns the RMS of 16-bit little-endian PCM audio normalized to full scale (0.0-1.0)
+func chunkRMS(chunk []byte) float64 {
+	numSamples := len(chunk) / 2
+	if numSamples == 0 {
+		return 0
+	}
+
+	var sumSquares float64
+	for i := 0; i < numSamples; i++ {
+		sample := float64(int16(uint16(chunk[i*2]) | uint16(chunk[i*2+1])<<8))
+		sumSquares += sample * sample
+	}
+
+	return math.Min(math.Sqrt(sumSquares/float64(numSamples))/32768.0, 1.0)
+}
+
+// trimWindow is the analysis window TrimSilence uses to classify audio as silent
+const trimWindow = 10 * time.Millisecond
+
+// TrimSilence removes leading and trailing audio whose level is below thresholdDB (dBFS).
+// Audio is classified in 10ms windows, so a single quiet sample in the middle of speech
+// never causes a cut. Returns an empty slice if the whole recording is below the threshold.
+func TrimSilence(data []byte, sampleRate, channels uint32, thresholdDB float64) []byte {
+	frameSize := int(channels) * 2
+	if frameSize == 0 || sampleRate == 0 {
+		return data
+	}
+
+	windowBytes := int(trimWindow.Seconds()*float64(sampleRate)) * frameSize
+	if windowBytes == 0 {
+		windowBytes = frameSize
+	}
+
+	// Ignore a trailing partial frame
+	usable := len(data) - len(data)%frameSize
+
+	start := -1
+	end := 0
+	for offset := 0; offset < usable; offset += windowBytes {
+		windowEnd := min(offset+windowBytes, usable)
+		if chunkLevelDB(data[offset:windowEnd]) >= thresholdDB {
+			if start < 0 {
+				start = offset
+			}
+			end = windowEnd
+		}
+	}
+
+	if start < 0 {
+		return []byte{}
+	}
+	return data[start:end]
+}
+
+// Noise gate timing: levels are measured per window, and the gate fades open
+// quickly (attack) but closes slowly (release) so word endings aren't clipped
+const (
+	gateWindow  = 10 * time.Millisecond
+	gateAttack  = 2 * time.Millisecond
+	gateRelease = 50 * time.Millisecond
+)
+
+// NoiseGate silences mono 16-bit PCM audio wherever its level is below thresholdDB (dBFS),
+// removing background hiss and hum between words. Levels are measured in 10ms windows
+// and the gain ramps between open and closed to avoid audible clicks.
+func NoiseGate(data []byte, sampleRate uint32, thresholdDB float64) []byte {
+	numSamples := len(data) / 2
+	if sampleRate == 0 || numSamples == 0 {
+		return data
+	}
+
+	perSample := func(d time.Duration) int { return max(int(d.Seconds()*float64(sampleRate)), 1) }
+	windowSamples := perSample(gateWindow)
+	attackStep := 1 / float64(perSample(gateAttack))
+	releaseStep := 1 / float64(perSample(gateRelease))
+
+	out := make([]byte, len(data))
+	copy(out, data)
+
+	gain := 0.0
+	for start := 0; start < numSamples; start += windowSamples {
+		end := min(start+windowSamples, numSamples)
+		target := 0.0
+		if chunkLevelDB(data[start*2:end*2]) >= thresholdDB {
+			target = 1
+		}
+
+		for i := start; i < end; i++ {
+			if gain < target {
+				gain = math.Min(gain+attackStep, target)
+			} else if gain > target {
+				gain = math.Max(gain-releaseStep, target)
+			}
+			sample := float64(int16(binary.LittleEndian.Uint16(data[i*2:])))
+			binary.LittleEndian.PutUint16(out[i*2:], uint16(int16(math.Round(sample*gain))))
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,203 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+// constantChunk returns duration worth of 16kHz mono audio at a constant amplitude
+func constantChunk(duration time.Duration, amplitude int16) []byte {
+	numSamples := int(duration.Seconds() * 16000)
+	data := make([]byte, numSamples*2)
+	for i := 0; i < numSamples; i++ {
+		binary.LittleEndian.PutUint16(data[i*2:], uint16(amplitude))
+	}
+	return data
+}
+
+func TestSilenceDetector_TriggersAfterTimeout(t *testing.T) {
+	detector := newSilenceDetector(time.Second, DefaultSilenceThresholdDB, 16000, 1)
+	silence := constantChunk(250*time.Millisecond, 0)
+
+	for i := 0; i < 3; i++ {
+		if detector.process(silence) {
+			t.Fatalf("detector triggered after %d chunks, before the 1s timeout", i+1)
+		}
+	}
+	if !detector.process(silence) {
+		t.Error("detector did not trigger after 1s of silence")
+	}
+}
+
+func TestSilenceDetector_SpeechResetsTimer(t *testing.T) {
+	detector := newSilenceDetector(time.Second, DefaultSilenceThresholdDB, 16000, 1)
+	silence := constantChunk(500*time.Millisecond, 0)
+	speech := constantChunk(100*time.Millisecond, 8000) // about -12 dBFS
+
+	detector.process(silence)
+	if detector.process(speech) {
+		t.Fatal("detector triggered on speech")
+	}
+	if detector.process(silence) {
+		t.Error("detector triggered before a full timeout of silence after speech")
+	}
+	if !detector.process(silence) {
+		t.Error("detector did not trigger after 1s of silence following speech")
+	}
+}
+
+func TestChunkLevelDB(t *testing.T) {
+	if got := chunkLevelDB(nil); got != -120.0 {
+		t.Errorf("chunkLevelDB(nil) = %f, want -120.0", got)
+	}
+	if got := chunkLevelDB(constantChunk(10*time.Millisecond, 0)); got != -120.0 {
+		t.Errorf("chunkLevelDB(silence) = %f, want -120.0", got)
+	}
+
+	// Should agree with AnalyzeLevel
+	chunk := constantChunk(10*time.Millisecond, 1000)
+	metrics, err := AnalyzeLevel(chunk, 16000)
+	if err != nil {
+		t.Fatalf("AnalyzeLevel failed: %v", err)
+	}
+	if diff := chunkLevelDB(chunk) - metrics.DecibelsFS; diff > 0.001 || diff < -0.001 {
+		t.Errorf("chunkLevelDB = %f, AnalyzeLevel = %f", chunkLevelDB(chunk), metrics.DecibelsFS)
+	}
+}
+
+func TestChunkRMS_Normalized(t *testing.T) {
+	if got := chunkRMS(constantChunk(10*time.Millisecond, 0)); got != 0 {
+		t.Errorf("chunkRMS(silence) = %f, want 0", got)
+	}
+	if got := chunkRMS(constantChunk(10*time.Millisecond, -32768)); got != 1.0 {
+		t.Errorf("chunkRMS(full scale) = %f, want 1.0", got)
+	}
+	if got := chunkRMS(constantChunk(10*time.Millisecond, 16384)); got != 0.5 {
+		t.Errorf("chunkRMS(half scale) = %f, want 0.5", got)
+	}
+}
+
+func TestRecorderLevel_ZeroWhenIdle(t *testing.T) {
+	recorder := NewRecorder("", BackendAuto)
+	if got := recorder.Level(); got != 0 {
+		t.Errorf("Level() before recording = %f, want 0", got)
+	}
+}
+
+func TestTrimSilence(t *testing.T) {
+	silence := constantChunk(500*time.Millisecond, 0)
+	loud := constantChunk(time.Second, 8000)
+
+	var data []byte
+	data = append(data, silence...)
+	data = append(data, loud...)
+	data = append(data, silence...)
+
+	trimmed := TrimSilence(data, 16000, 1, DefaultSilenceThresholdDB)
+	if len(trimmed) != len(loud) {
+		t.Errorf("TrimSilence() length = %d, want %d", len(trimmed), len(loud))
+	}
+}
+
+func TestTrimSilence_KeepsQuietGapsInsideSpeech(t *testing.T) {
+	silence := constantChunk(300*time.Millisecond, 0)
+	loud := constantChunk(200*time.Millisecond, 8000)
+
+	var data []byte
+	data = append(data, silence...)
+	data = append(data, loud...)
+	data = append(data, silence...) // pause between words
+	data = append(data, loud...)
+
+	trimmed := TrimSilence(data, 16000, 1, DefaultSilenceThresholdDB)
+	want := len(loud)*2 + len(silence)
+	if len(trimmed) != want {
+		t.Errorf("TrimSilence() length = %d, want %d", len(trimmed), want)
+	}
+}
+
+func TestTrimSilence_AllSilent(t *testing.T) {
+	trimmed := TrimSilence(constantChunk(time.Second, 10), 16000, 1, DefaultSilenceThresholdDB)
+	if len(trimmed) != 0 {
+		t.Errorf("TrimSilence() of silence returned %d bytes, want 0", len(trimmed))
+	}
+}
+
+func TestTrimSilence_Stereo(t *testing.T) {
+	// 100ms silence + 100ms loud, 2 channels
+	silence := make([]byte, 1600*2*2)
+	loud := make([]byte, 1600*2*2)
+	for i := 0; i < len(loud); i += 2 {
+		binary.LittleEndian.PutUint16(loud[i:], uint16(8000))
+	}
+
+	data := append(append([]byte{}, silence...), loud...)
+	trimmed := TrimSilence(data, 16000, 2, DefaultSilenceThresholdDB)
+	if len(trimmed) != len(loud) {
+		t.Errorf("TrimSilence() stereo length = %d, want %d", len(trimmed), len(loud))
+	}
+	if len(trimmed)%4 != 0 {
+		t.Errorf("TrimSilence() stereo length %d is not frame aligned", len(trimmed))
+	}
+}
+
+func TestNoiseGate_AttenuatesNoiseAroundBurst(t *testing.T) {
+	noise := constantChunk(300*time.Millisecond, 60) // about -55 dBFS hiss
+	burst := sineWave(440, 16000, 0.2)               // about -13 dBFS speech
+	data := append(append(append([]byte{}, noise...), burst...), noise...)
+
+	gated := NoiseGate(data, 16000, -40)
+
+	if len(gated) != len(data) {
+		t.Fatalf("NoiseGate() changed length: %d → %d", len(data), len(gated))
+	}
+
+	// Leading noise is silenced entirely
+	if level := chunkLevelDB(gated[:len(noise)]); level > -100 {
+		t.Errorf("leading noise level = %.1f dBFS, want silenced", level)
+	}
+
+	// Trailing noise is silenced once the release has finished
+	releaseBytes := int(gateRelease.Seconds()*16000) * 2
+	tail := gated[len(noise)+len(burst)+releaseBytes:]
+	if level := chunkLevelDB(tail); level > -100 {
+		t.Errorf("trailing noise level = %.1f dBFS, want silenced", level)
+	}
+
+	// The burst passes through untouched after the short attack
+	attackBytes := int(gateAttack.Seconds()*16000) * 2
+	gotBurst := gated[len(noise)+attackBytes : len(noise)+len(burst)]
+	wantBurst := burst[attackBytes:]
+	if !bytes.Equal(gotBurst, wantBurst) {
+		t.Error("burst was altered by the noise gate")
+	}
+}
+
+func TestNoiseGate_RampsInsteadOfCutting(t *testing.T) {
+	data := append(constantChunk(100*time.Millisecond, 8000), constantChunk(100*time.Millisecond, 50)...)
+
+	gated := samplesOf(NoiseGate(data, 16000, -40))
+
+	// The release fades the quiet part out rather than zeroing it at once
+	quietStart := 1600
+	if gated[quietStart] == 0 || gated[quietStart] > 50 {
+		t.Errorf("first gated sample = %d, want a faded value in (0, 50]", gated[quietStart])
+	}
+	for i := quietStart + 1; i < len(gated); i++ {
+		if gated[i] > gated[i-1] {
+			t.Fatalf("gain increased during release at sample %d", i)
+		}
+	}
+}
+
+func TestNoiseGate_Unchanged(t *testing.T) {
+	loud := sineWave(440, 16000, 0.1)
+	if got := NoiseGate(loud[:2], 0, -40); !bytes.Equal(got, loud[:2]) {
+		t.Error("NoiseGate() with sample rate 0 modified the audio")
+	}
+	if got := NoiseGate(nil, 16000, -40); len(got) != 0 {
+		t.Errorf("NoiseGate(nil) = %v, want empty", got)
+	}
+}
@@ -3,6 +3,8 @@ package audio
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
+	"math"
 	"os"
 )
 
@@ -24,52 +26,109 @@ type WAVHeader struct {
 }
 
 // SaveWAV saves audio data as a WAV file
-func SaveWAV(filename string, audioData []byte, sampleRate, channels uint32) error {
-	file, err := os.Create(filename)
+func SaveWAV(filename string, audioData []byte, sampleRate, channels uint32) (err error) {
+	w, err := NewWAVWriter(filename, sampleRate, channels)
 	if err != nil {
-		return fmt.Errorf("failed to create WAV file: %w", err)
+		return err
 	}
 	defer func() {
-		if closeErr := file.Close(); closeErr != nil && err == nil {
-			err = fmt.Errorf("failed to close WAV file: %w", closeErr)
+		if closeErr := w.Close(); closeErr != nil && err == nil {
+			err = closeErr
 		}
 	}()
 
+	return w.Write(audioData)
+}
+
+// WAVWriter streams 16-bit PCM audio to a WAV file, so audio can be written as it
+// arrives instead of as one buffer. The header sizes are filled in by Close.
+// Recorder still buffers a recording in memory, since the pipeline processes it whole.
+type WAVWriter struct {
+	file     *os.File
+	header   WAVHeader
+	dataSize uint32
+}
+
+// NewWAVWriter creates a WAV file and writes a header whose sizes Close will patch
+func NewWAVWriter(filename string, sampleRate, channels uint32) (*WAVWriter, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create WAV file: %w", err)
+	}
+
 	bitsPerSample := uint16(16) // 16-bit audio
-	byteRate := sampleRate * uint32(channels) * uint32(bitsPerSample) / 8
-	blockAlign := uint16(channels) * bitsPerSample / 8
-	dataSize := uint32(len(audioData))
-
-	// Create WAV header
-	header := WAVHeader{
-		ChunkID:       [4]byte{'R', 'I', 'F', 'F'},
-		ChunkSize:     36 + dataSize,
-		Format:        [4]byte{'W', 'A', 'V', 'E'},
-		Subchunk1ID:   [4]byte{'f', 'm', 't', ' '},
-		Subchunk1Size: 16,
-		AudioFormat:   1, // PCM
-		NumChannels:   uint16(channels),
-		SampleRate:    sampleRate,
-		ByteRate:      byteRate,
-		BlockAlign:    blockAlign,
-		BitsPerSample: bitsPerSample,
-		Subchunk2ID:   [4]byte{'d', 'a', 't', 'a'},
-		Subchunk2Size: dataSize,
+	w := &WAVWriter{
+		file: file,
+		header: WAVHeader{
+			ChunkID:       [4]byte{'R', 'I', 'F', 'F'},
+			ChunkSize:     36,
+			Format:        [4]byte{'W', 'A', 'V', 'E'},
+			Subchunk1ID:   [4]byte{'f', 'm', 't', ' '},
+			Subchunk1Size: 16,
+			AudioFormat:   1, // PCM
+			NumChannels:   uint16(channels),
+			SampleRate:    sampleRate,
+			ByteRate:      sampleRate * uint32(channels) * uint32(bitsPerSample) / 8,
+			BlockAlign:    uint16(channels) * bitsPerSample / 8,
+			BitsPerSample: bitsPerSample,
+			Subchunk2ID:   [4]byte{'d', 'a', 't', 'a'},
+		},
 	}
 
 	// Write header
-	if err := binary.Write(file, binary.LittleEndian, &header); err != nil {
-		return fmt.Errorf("failed to write WAV header: %w", err)
+	if err := binary.Write(file, binary.LittleEndian, &w.header); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to write WAV header: %w", err)
 	}
 
-	// Write audio data
-	if _, err := file.Write(audioData); err != nil {
+	return w, nil
+}
+
+// Write appends audio data to the file
+func (w *WAVWriter) Write(audioData []byte) error {
+	if uint64(w.dataSize)+uint64(len(audioData)) > math.MaxUint32-36 {
+		return fmt.Errorf("failed to write audio data: WAV files are limited to 4 GB")
+	}
+	n, err := w.file.Write(audioData)
+	w.dataSize += uint32(n)
+	if err != nil {
 		return fmt.Errorf("failed to write audio data: %w", err)
 	}
+	return nil
+}
+
+// Close fills in the header sizes and closes the file
+func (w *WAVWriter) Close() error {
+	w.header.ChunkSize = 36 + w.dataSize
+	w.header.Subchunk2Size = w.dataSize
 
+	_, err := w.file.Seek(0, io.SeekStart)
+	if err == nil {
+		err = binary.Write(w.file, binary.LittleEndian, &w.header)
+	}
+	if closeErr := w.file.Close(); closeErr != nil && err == nil {
+		return fmt.Errorf("failed to close WAV file: %w", closeErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update WAV header: %w", err)
+	}
 	return nil
 }
 
+// ReadWAVHeader reads and validates the header of a WAV file without loading its audio data
+func ReadWAVHeader(filename string) (*WAVHeader, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open WAV file: %w", err)
+	}
+	defer func() {
+		_ = file.Close() // Read-only operation, error not critical
+	}()
+
+	header, _, err := readWAVChunks(file)
+	return header, err
+}
+
 // LoadWAV loads audio data from a WAV file
 func LoadWAV(filename string) ([]byte, uint32, uint32, error) {
 	file, err := os.Open(filename)
@@ -80,22 +139,134 @@ func LoadWAV(filename string) ([]byte, uint32, uint32, error) {
 		_ = file.Close() // Read-only operation, error not critical
 	}()
 
-	// Read header
-	var header WAVHeader
-	if err := binary.Read(file, binary.LittleEndian, &header); err != nil {
-		return nil, 0, 0, fmt.Errorf("failed to read WAV header: %w", err)
-	}
-
-	// Validate WAV file
-	if string(header.ChunkID[:]) != "RIFF" || string(header.Format[:]) != "WAVE" {
-		return nil, 0, 0, fmt.Errorf("not a valid WAV file")
+	header, _, err := readWAVChunks(file)
+	if err != nil {
+		return nil, 0, 0, err
 	}
 
-	// Read audio data
+	// Read audio data; a single Read may return less than asked for
 	audioData := make([]byte, header.Subchunk2Size)
-	if _, err := file.Read(audioData); err != nil {
+	if _, err := io.ReadFull(file, audioData); err != nil {
 		return nil, 0, 0, fmt.Errorf("failed to read audio data: %w", err)
 	}
 
 	return audioData, header.SampleRate, uint32(header.NumChannels), nil
 }
+
+// readWAVChunks walks the chunks of a RIFF/WAVE stream up to the "data" chunk,
+// skipping any others (LIST, fact, bext, ...) along the way. It returns the header
+// with the "fmt " and "data" fields filled in and the offset of the audio data;
+// r is left positioned at the start of the audio data.
+func readWAVChunks(r io.ReadSeeker) (*WAVHeader, int64, error) {
+	var header WAVHeader
+	riff := make([]byte, 12)
+	if _, err := io.ReadFull(r, riff); err != nil {
+		return nil, 0, fmt.Errorf("failed to read WAV header: %w", err)
+	}
+	copy(header.ChunkID[:], riff[0:4])
+	header.ChunkSize = binary.LittleEndian.Uint32(riff[4:8])
+	copy(header.Format[:], riff[8:12])
+	if string(header.ChunkID[:]) != "RIFF" || string(header.Format[:]) != "WAVE" {
+		return nil, 0, fmt.Errorf("not a valid WAV file")
+	}
+
+	offset := int64(len(riff))
+	foundFmt := false
+	chunk := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			if !foundFmt {
+				return nil, 0, fmt.Errorf("invalid WAV file: no fmt chunk")
+			}
+			return nil, 0, fmt.Errorf("invalid WAV file: no data chunk")
+		}
+		offset += int64(len(chunk))
+		id := string(chunk[0:4])
+		size := binary.LittleEndian.Uint32(chunk[4:8])
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, 0, fmt.Errorf("invalid WAV file: fmt chunk too short (%d bytes)", size)
+			}
+			format := make([]byte, size+size%2) // Chunks are padded to an even size
+			if _, err := io.ReadFull(r, format); err != nil {
+				return nil, 0, fmt.Errorf("failed to read WAV header: %w", err)
+			}
+			copy(header.Subchunk1ID[:], chunk[0:4])
+			header.Subchunk1Size = size
+			header.AudioFormat = binary.LittleEndian.Uint16(format[0:2])
+			header.NumChannels = binary.LittleEndian.Uint16(format[2:4])
+			header.SampleRate = binary.LittleEndian.Uint32(format[4:8])
+			header.ByteRate = binary.LittleEndian.Uint32(format[8:12])
+			header.BlockAlign = binary.LittleEndian.Uint16(format[12:14])
+			header.BitsPerSample = binary.LittleEndian.Uint16(format[14:16])
+			offset += int64(len(format))
+			foundFmt = true
+		case "data":
+			if !foundFmt {
+				return nil, 0, fmt.Errorf("invalid WAV file: data chunk before fmt chunk")
+			}
+			copy(header.Subchunk2ID[:], chunk[0:4])
+			header.Subchunk2Size = size
+			return &header, offset, nil
+		default:
+			// Skip chunks we don't need (LIST, fact, bext, ...)
+			skip := int64(size) + int64(size%2)
+			if _, err := r.Seek(skip, io.SeekCurrent); err != nil {
+				return nil, 0, fmt.Errorf("failed to skip %q chunk: %w", id, err)
+			}
+			offset += skip
+		}
+	}
+}
+
+// wavHeaderSize is the size of the canonical 44-byte header SaveWAV writes, the smallest valid one
+const wavHeaderSize = 44
+
+// ValidateWAV checks that a WAV file is complete and in a format the transcription
+// backends accept: 16-bit PCM, mono or stereo, at a sample rate between 8 and 192 kHz,
+// with a data chunk that holds at least one sample and isn't truncated.
+func ValidateWAV(filename string) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return fmt.Errorf("failed to open WAV file: %w", err)
+	}
+	if info.Size() < wavHeaderSize {
+		return fmt.Errorf("WAV file is empty or truncated (%d bytes, header needs %d)", info.Size(), wavHeaderSize)
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("failed to open WAV file: %w", err)
+	}
+	defer func() {
+		_ = file.Close() // Read-only operation, error not critical
+	}()
+
+	header, dataOffset, err := readWAVChunks(file)
+	if err != nil {
+		return err
+	}
+	if header.AudioFormat != 1 || header.BitsPerSample != 16 {
+		return fmt.Errorf("unsupported WAV format: need 16-bit PCM, got format %d with %d bits per sample", header.AudioFormat, header.BitsPerSample)
+	}
+	if header.NumChannels != 1 && header.NumChannels != 2 {
+		return fmt.Errorf("unsupported WAV format: need mono or stereo, got %d channels", header.NumChannels)
+	}
+	if header.SampleRate < 8000 || header.SampleRate > 192000 {
+		return fmt.Errorf("unsupported WAV sample rate: %d Hz", header.SampleRate)
+	}
+
+	if header.Subchunk2Size == 0 {
+		return fmt.Errorf("WAV file contains no audio (the recording may have failed)")
+	}
+	if actual := info.Size() - dataOffset; actual < int64(header.Subchunk2Size) {
+		return fmt.Errorf("WAV file is truncated: header declares %d bytes of audio but only %d are present", header.Subchunk2Size, actual)
+	}
+	if header.BlockAlign != header.NumChannels*2 || header.Subchunk2Size%uint32(header.BlockAlign) != 0 {
+		return fmt.Errorf("WAV file is corrupt: audio data isn't a whole number of %d-channel samples", header.NumChannels)
+	}
+
+	return nil
+}
@@ -2,8 +2,10 @@ package audio
 
 import (
 	"bytes"
+	"encoding/binary"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -127,3 +129,242 @@ func TestSaveWAV_ValidFormats(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateWAV(t *testing.T) {
+	tmpDir := t.TempDir()
+	validFile := filepath.Join(tmpDir, "valid.wav")
+	if err := SaveWAV(validFile, make([]byte, 3200), 16000, 1); err != nil {
+		t.Fatalf("Failed to save WAV file: %v", err)
+	}
+	valid, err := os.ReadFile(validFile)
+	if err != nil {
+		t.Fatalf("Failed to read WAV file: %v", err)
+	}
+
+	// corrupt returns a copy of the valid file with the header field at offset set to value
+	corrupt := func(offset int, value []byte) []byte {
+		data := append([]byte{}, valid...)
+		copy(data[offset:], value)
+		return data
+	}
+
+	// A header declaring an empty data chunk, as SaveWAV writes for an empty buffer
+	noAudio := corrupt(40, []byte{0, 0, 0, 0})[:44]
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{"valid", valid, ""},
+		{"empty file", nil, "empty or truncated"},
+		{"header only partly written", valid[:20], "empty or truncated"},
+		{"not RIFF", corrupt(0, []byte("RIFX")), "not a valid WAV file"},
+		{"not WAVE", corrupt(8, []byte("AVI ")), "not a valid WAV file"},
+		{"no data chunk", corrupt(36, []byte("LIST")), "no data chunk"},
+		{"float samples", corrupt(20, []byte{3, 0}), "need 16-bit PCM"},
+		{"8-bit samples", corrupt(34, []byte{8, 0}), "need 16-bit PCM"},
+		{"six channels", corrupt(22, []byte{6, 0}), "need mono or stereo"},
+		{"zero sample rate", corrupt(24, []byte{0, 0, 0, 0}), "sample rate"},
+		{"no audio", noAudio, "contains no audio"},
+		{"truncated audio", valid[:1000], "truncated"},
+		{"odd data size", corrupt(40, []byte{0x7F, 0x0C, 0, 0}), "corrupt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, "test.wav")
+			if err := os.WriteFile(path, tt.data, 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			err := ValidateWAV(path)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateWAV() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateWAV() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateWAV_NonExistentFile(t *testing.T) {
+	if err := ValidateWAV("/nonexistent/file.wav"); err == nil {
+		t.Error("Expected error for non-existent file, got nil")
+	}
+}
+
+// riffChunk encodes one RIFF chunk, padded to an even size
+func riffChunk(id string, payload []byte) []byte {
+	chunk := append([]byte(id), 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(chunk[4:], uint32(len(payload)))
+	chunk = append(chunk, payload...)
+	if len(payload)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+// fmtPayload encodes a 16-bit PCM fmt chunk, with extra bytes as in WAVE_FORMAT_EX headers
+func fmtPayload(sampleRate uint32, channels uint16, extra int) []byte {
+	payload := make([]byte, 16+extra)
+	binary.LittleEndian.PutUint16(payload[0:], 1)
+	binary.LittleEndian.PutUint16(payload[2:], channels)
+	binary.LittleEndian.PutUint32(payload[4:], sampleRate)
+	binary.LittleEndian.PutUint32(payload[8:], sampleRate*uint32(channels)*2)
+	binary.LittleEndian.PutUint16(payload[12:], channels*2)
+	binary.LittleEndian.PutUint16(payload[14:], 16)
+	return payload
+}
+
+// writeChunkedWAV writes a RIFF/WAVE file made of the given chunks
+func writeChunkedWAV(t *testing.T, chunks ...[]byte) string {
+	t.Helper()
+	body := []byte("WAVE")
+	for _, chunk := range chunks {
+		body = append(body, chunk...)
+	}
+	data := append([]byte("RIFF"), 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(data[4:], uint32(len(body)))
+	data = append(data, body...)
+
+	path := filepath.Join(t.TempDir(), "chunked.wav")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to write WAV file: %v", err)
+	}
+	return path
+}
+
+func TestLoadWAV_SkipsChunksBeforeData(t *testing.T) {
+	// 10 seconds of 48kHz stereo, large enough that a single short read would lose data
+	audioData := make([]byte, 48000*2*2*10)
+	for i := range audioData {
+		audioData[i] = byte(i * 7)
+	}
+
+	path := writeChunkedWAV(t,
+		riffChunk("fmt ", fmtPayload(48000, 2, 2)),
+		riffChunk("LIST", []byte("INFOISFT\x0e\x00\x00\x00Lavf60.16.100\x00")),
+		riffChunk("fact", []byte{0x80, 0x3e, 0, 0}),
+		riffChunk("bext", make([]byte, 7)), // Odd size, followed by a pad byte
+		riffChunk("data", audioData),
+	)
+
+	loaded, sampleRate, channels, err := LoadWAV(path)
+	if err != nil {
+		t.Fatalf("LoadWAV() error = %v", err)
+	}
+	if sampleRate != 48000 || channels != 2 {
+		t.Errorf("LoadWAV() format = %d Hz, %d channels, want 48000 Hz, 2 channels", sampleRate, channels)
+	}
+	if !bytes.Equal(loaded, audioData) {
+		t.Errorf("LoadWAV() returned %d bytes not matching the %d written", len(loaded), len(audioData))
+	}
+
+	if err := ValidateWAV(path); err != nil {
+		t.Errorf("ValidateWAV() error = %v, want nil", err)
+	}
+}
+
+func TestLoadWAV_TruncatedData(t *testing.T) {
+	data := riffChunk("data", make([]byte, 3200))
+	path := writeChunkedWAV(t, riffChunk("fmt ", fmtPayload(16000, 1, 0)), data[:1000])
+
+	if _, _, _, err := LoadWAV(path); err == nil {
+		t.Error("Expected error when loading a truncated WAV file, got nil")
+	}
+	if err := ValidateWAV(path); err == nil || !strings.Contains(err.Error(), "truncated") {
+		t.Errorf("ValidateWAV() error = %v, want error containing 'truncated'", err)
+	}
+}
+
+func TestReadWAVHeader_ChunkErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		chunks  [][]byte
+		wantErr string
+	}{
+		{"no fmt chunk", [][]byte{riffChunk("LIST", make([]byte, 4))}, "no fmt chunk"},
+		{"data before fmt", [][]byte{riffChunk("data", make([]byte, 4)), riffChunk("fmt ", fmtPayload(16000, 1, 0))}, "data chunk before fmt chunk"},
+		{"short fmt chunk", [][]byte{riffChunk("fmt ", make([]byte, 8))}, "fmt chunk too short"},
+		{"no data chunk", [][]byte{riffChunk("fmt ", fmtPayload(16000, 1, 0))}, "no data chunk"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadWAVHeader(writeChunkedWAV(t, tt.chunks...))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ReadWAVHeader() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWAVWriter_MatchesSaveWAV(t *testing.T) {
+	tmpDir := t.TempDir()
+	audioData := make([]byte, 44100*2*2)
+	for i := range audioData {
+		audioData[i] = byte(i * 13)
+	}
+
+	savedFile := filepath.Join(tmpDir, "saved.wav")
+	if err := SaveWAV(savedFile, audioData, 44100, 2); err != nil {
+		t.Fatalf("SaveWAV() error = %v", err)
+	}
+
+	// Stream the same data in uneven pieces, as capture callbacks deliver it
+	streamedFile := filepath.Join(tmpDir, "streamed.wav")
+	w, err := NewWAVWriter(streamedFile, 44100, 2)
+	if err != nil {
+		t.Fatalf("NewWAVWriter() error = %v", err)
+	}
+	for offset, size := 0, 1; offset < len(audioData); offset, size = offset+size, size*3+1 {
+		if err := w.Write(audioData[offset:min(offset+size, len(audioData))]); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	saved, err := os.ReadFile(savedFile)
+	if err != nil {
+		t.Fatalf("Failed to read saved file: %v", err)
+	}
+	streamed, err := os.ReadFile(streamedFile)
+	if err != nil {
+		t.Fatalf("Failed to read streamed file: %v", err)
+	}
+	if !bytes.Equal(streamed, saved) {
+		t.Errorf("streamed file (%d bytes) differs from SaveWAV output (%d bytes)", len(streamed), len(saved))
+	}
+}
+
+func TestWAVWriter_NoData(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.wav")
+	w, err := NewWAVWriter(path, 16000, 1)
+	if err != nil {
+		t.Fatalf("NewWAVWriter() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	header, err := ReadWAVHeader(path)
+	if err != nil {
+		t.Fatalf("ReadWAVHeader() error = %v", err)
+	}
+	if header.ChunkSize != 36 || header.Subchunk2Size != 0 {
+		t.Errorf("header sizes = %d/%d, want 36/0", header.ChunkSize, header.Subchunk2Size)
+	}
+}
+
+func TestNewWAVWriter_InvalidPath(t *testing.T) {
+	if _, err := NewWAVWriter("/nonexistent/dir/file.wav", 16000, 1); err == nil {
+		t.Error("Expected error when creating WAV file in a missing directory, got nil")
+	}
+}
@@ -33,17 +33,13 @@ func runAudioTest(durationSeconds int) {
 		os.Exit(1)
 	}
 
-	// Determine which microphone to use
-	micName := cfg.Microphone
-	if micName == "" {
-		fmt.Println("No microphone configured, using system default...")
-		device, deviceErr := audio.GetDefaultMicrophone()
-		if deviceErr != nil {
-			fmt.Fprintf(os.Stderr, "Error getting default microphone: %v\n", deviceErr)
-			os.Exit(1)
-		}
-		micName = device.Name
+	// Select the best available microphone based on preferences
+	device, err := audio.SelectMicrophone(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error selecting microphone: %v\n", err)
+		os.Exit(1)
 	}
+	micName := device.Name
 
 	fmt.Printf("Microphone: %s\n", micName)
 	fmt.Printf("Duration: %d seconds\n", durationSeconds)
@@ -51,7 +47,7 @@ func runAudioTest(durationSeconds int) {
 	fmt.Printf("Channels: 1 (mono)\n\n")
 
 	// Create recorder
-	recorder := audio.NewRecorder(micName)
+	recorder := audio.NewRecorder(micName, cfg.AudioBackend)
 
 	// Start recording
 	fmt.Printf("Starting recording...\n")
@@ -60,6 +56,11 @@ func runAudioTest(durationSeconds int) {
 		os.Exit(1)
 	}
 
+	if recorder.GetCaptureSampleRate() != recorder.GetSampleRate() {
+		fmt.Printf("Microphone captures at %d Hz, audio will be resampled to %d Hz\n",
+			recorder.GetCaptureSampleRate(), recorder.GetSampleRate())
+	}
+
 	fmt.Printf("Recording for %d seconds...\n", durationSeconds)
 
 	// Show progress
@@ -89,8 +90,15 @@ func runAudioTest(durationSeconds int) {
 	filename := fmt.Sprintf("test-recording-%s.wav", time.Now().Format("20060102-150405"))
 	filepath := filepath.Join(cacheDir, filename)
 
+	// Save as mono, like the transcription path does
+	channels := recorder.GetChannels()
+	if channels > 1 {
+		audioData = audio.DownmixToMono(audioData, channels)
+		channels = 1
+	}
+
 	fmt.Printf("Saving to: %s\n", filepath)
-	err = audio.SaveWAV(filepath, audioData, recorder.GetSampleRate(), recorder.GetChannels())
+	err = audio.SaveWAV(filepath, audioData, recorder.GetSampleRate(), channels)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error saving WAV file: %v\n", err)
 		os.Exit(1)
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Cache management",
+	Long:  `Manage the temporary recordings kept in the cache directory.`,
+}
+
+var cacheCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove old temporary recordings",
+	Long: `Remove temporary recordings (recording_*.wav) older than cache_retention_hours from
+the cache directory. "openscribe start" does this automatically; verbose mode keeps the
+recordings for debugging, so they build up between runs. Use --all to remove every recording.
+
+Examples:
+  openscribe cache clean
+  openscribe cache clean --all`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		all, _ := cmd.Flags().GetBool("all")
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+			os.Exit(1)
+		}
+
+		retention := cfg.CacheRetention()
+		if all {
+			retention = 0
+		} else if retention == 0 {
+			fmt.Println("Recordings are kept (cache_retention_hours is 0). Use --all to remove them anyway.")
+			return
+		}
+
+		removed, err := config.CleanCache(retention)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error cleaning cache: %v\n", err)
+			os.Exit(1)
+		}
+
+		cacheDir, _ := config.GetCacheDir()
+		fmt.Printf("✓ Removed %d recording(s) from %s\n", removed, cacheDir)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheCleanCmd)
+
+	cacheCleanCmd.Flags().Bool("all", false, "Remove every recording, regardless of age")
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/alexandrelam/openscribe/internal/audio"
+	"github.com/alexandrelam/openscribe/internal/config"
+	"github.com/alexandrelam/openscribe/internal/hotkey"
+	"github.com/alexandrelam/openscribe/internal/models"
+	"github.com/alexandrelam/openscribe/internal/transcription"
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish]",
+	Short: "Generate a shell completion script",
+	Long: `Generate a completion script for bash, zsh or fish. Besides commands and flags,
+it completes model names, microphones, hotkeys and language codes.
+
+Examples:
+  # Bash (needs the bash-completion package), for the current shell
+  source <(openscribe completion bash)
+
+  # Zsh, for every new shell
+  openscribe completion zsh > "${fpath[1]}/_openscribe"
+
+  # Fish, for every new shell
+  openscribe completion fish > ~/.config/fish/completions/openscribe.fish`,
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs:             []string{"bash", "zsh", "fish"},
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+		switch args[0] {
+		case "bash":
+			err = rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			err = rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			err = rootCmd.GenFishCompletion(os.Stdout, true)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating completion script: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
+
+// registerCompletions attaches dynamic value completion to flags and arguments.
+// It runs from Execute, once every command's init has defined its flags.
+func registerCompletions() error {
+	flags := []struct {
+		cmd  *cobra.Command
+		flag string
+		fn   cobra.CompletionFunc
+	}{
+		{configCmd, "set-model", completeModels},
+		{configCmd, "set-microphone", completeMicrophones},
+		{configCmd, "add-preference", completeMicrophones},
+		{configCmd, "set-hotkey", completeHotkeys},
+		{configCmd, "set-language", completeLanguages},
+		{startCmd, "model", completeModels},
+		{startCmd, "microphone", completeMicrophones},
+		{startCmd, "language", completeLanguages},
+		{startCmd, "backend", cobra.FixedCompletions([]string{"whisper", "moonshine", "openai"}, cobra.ShellCompDirectiveNoFileComp)},
+		{recordCmd, "model", completeModels},
+		{recordCmd, "language", completeLanguages},
+		{transcribeCmd, "model", completeModels},
+		{transcribeCmd, "language", completeLanguages},
+		{setupCmd, "model", completeModels},
+		{micLevelCmd, "microphone", completeMicrophones},
+		{modelsListCmd, "backend", cobra.FixedCompletions([]string{"whisper", "moonshine"}, cobra.ShellCompDirectiveNoFileComp)},
+		{modelsDownloadCmd, "backend", cobra.FixedCompletions([]string{"whisper", "moonshine"}, cobra.ShellCompDirectiveNoFileComp)},
+	}
+	for _, f := range flags {
+		if err := f.cmd.RegisterFlagCompletionFunc(f.flag, f.fn); err != nil {
+			return fmt.Errorf("failed to register completion for %s --%s: %w", f.cmd.Name(), f.flag, err)
+		}
+	}
+
+	modelsDownloadCmd.ValidArgsFunction = completeDownloadModel
+	modelsDeleteCmd.ValidArgsFunction = completeDownloadedModels
+	modelsVerifyCmd.ValidArgsFunction = completeDownloadedModels
+	return nil
+}
+
+// completeModels offers the Whisper model names, with their descriptions
+func completeModels(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	ordered := models.OrderedModels()
+	completions := make([]cobra.Completion, 0, len(ordered))
+	for _, name := range ordered {
+		completions = append(completions, cobra.CompletionWithDesc(string(name), models.AvailableModels[name].Description))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeDownloadModel offers the models of the backend chosen with --backend
+func completeDownloadModel(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if backend, _ := cmd.Flags().GetString("backend"); backend == "moonshine" {
+		completions := make([]cobra.Completion, 0, len(models.MoonshineModelOrder))
+		for _, name := range models.MoonshineModelOrder {
+			completions = append(completions, cobra.CompletionWithDesc(string(name), models.AvailableMoonshineModels[name].Description))
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeModels(cmd, args, toComplete)
+}
+
+// completeDownloadedModels offers the Whisper models already on disk
+func completeDownloadedModels(_ *cobra.Command, args []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	downloaded, err := models.ListDownloadedModels()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	completions := make([]cobra.Completion, 0, len(downloaded))
+	for _, name := range downloaded {
+		completions = append(completions, string(name))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeMicrophones offers the names of the connected microphones
+func completeMicrophones(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	backend := audio.BackendAuto
+	if cfg, err := config.Load(); err == nil {
+		backend = cfg.AudioBackend
+	}
+	devices, err := audio.ListMicrophones(backend)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	completions := make([]cobra.Completion, 0, len(devices))
+	for _, device := range devices {
+		completions = append(completions, device.Name)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeHotkeys offers the single-key trigger names
+func completeHotkeys(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	keys := hotkey.GetAvailableKeys()
+	sort.Strings(keys)
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeLanguages offers "auto" and the language codes Whisper understands
+func completeLanguages(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	completions := []cobra.Completion{cobra.CompletionWithDesc("auto", "Detect the language")}
+	for _, code := range transcription.LanguageCodes() {
+		completions = append(completions, cobra.CompletionWithDesc(code, transcription.Languages[code]))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
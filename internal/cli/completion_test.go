@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alexandrelam/openscribe/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func TestCompleteModels(t *testing.T) {
+	completions, directive := completeModels(configCmd, nil, "")
+	if directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("directive = %v, want ShellCompDirectiveNoFileComp", directive)
+	}
+
+	ordered := models.OrderedModels()
+	if len(completions) != len(ordered) {
+		t.Fatalf("got %d completions, want one per model (%d)", len(completions), len(ordered))
+	}
+	for i, name := range ordered {
+		want := string(name) + "\t" + models.AvailableModels[name].Description
+		if completions[i] != want {
+			t.Errorf("completions[%d] = %q, want %q", i, completions[i], want)
+		}
+	}
+}
+
+func TestCompleteDownloadModel_Moonshine(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("backend", "moonshine", "")
+
+	completions, _ := completeDownloadModel(cmd, nil, "")
+	if len(completions) != len(models.MoonshineModelOrder) {
+		t.Fatalf("got %d completions, want %d Moonshine models", len(completions), len(models.MoonshineModelOrder))
+	}
+	for i, name := range models.MoonshineModelOrder {
+		if !strings.HasPrefix(completions[i], string(name)+"\t") {
+			t.Errorf("completions[%d] = %q, want Moonshine model %s", i, completions[i], name)
+		}
+	}
+
+	if completions, _ := completeDownloadModel(cmd, []string{"base"}, ""); len(completions) != 0 {
+		t.Errorf("completions after the model argument = %v, want none", completions)
+	}
+}
+
+func TestCompleteLanguages(t *testing.T) {
+	completions, _ := completeLanguages(configCmd, nil, "")
+	if len(completions) < 2 || !strings.HasPrefix(completions[0], "auto\t") {
+		t.Fatalf("completions = %v, want auto first", completions)
+	}
+
+	found := false
+	for _, c := range completions {
+		if c == "fr\tFrench" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("completions do not offer fr\\tFrench: %v", completions)
+	}
+}
+
+func TestRegisterCompletions(t *testing.T) {
+	// Fails when a registered flag no longer exists
+	if err := registerCompletions(); err != nil {
+		t.Fatalf("registerCompletions() error: %v", err)
+	}
+	if modelsDownloadCmd.ValidArgsFunction == nil {
+		t.Error("models download has no argument completion")
+	}
+}
@@ -1,10 +1,12 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"strconv"
+	"strings"
 
 	"github.com/alexandrelam/openscribe/internal/audio"
 	"github.com/alexandrelam/openscribe/internal/config"
@@ -12,6 +14,9 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// configProfile is the profile edited or shown by the config command (empty = active profile)
+var configProfile string
+
 var configCmd = &cobra.Command{
 	Use:   "config",
 	Short: "Configuration management",
@@ -21,6 +26,8 @@ var configCmd = &cobra.Command{
 		if !cmd.Flags().Changed("show") &&
 			!cmd.Flags().Changed("open") &&
 			!cmd.Flags().Changed("list-microphones") &&
+			!cmd.Flags().Changed("list-audio-backends") &&
+			!cmd.Flags().Changed("set-audio-backend") &&
 			!cmd.Flags().Changed("list-hotkeys") &&
 			!cmd.Flags().Changed("list-sounds") &&
 			!cmd.Flags().Changed("test-sounds") &&
@@ -32,10 +39,23 @@ var configCmd = &cobra.Command{
 			!cmd.Flags().Changed("set-openai-model") &&
 			!cmd.Flags().Changed("enable-audio-feedback") &&
 			!cmd.Flags().Changed("disable-audio-feedback") &&
+			!cmd.Flags().Changed("enable-notifications") &&
+			!cmd.Flags().Changed("disable-notifications") &&
+			!cmd.Flags().Changed("enable-logging") &&
+			!cmd.Flags().Changed("disable-logging") &&
+			!cmd.Flags().Changed("set-start-sound") &&
+			!cmd.Flags().Changed("set-stop-sound") &&
+			!cmd.Flags().Changed("set-complete-sound") &&
+			!cmd.Flags().Changed("set-feedback-volume") &&
 			!cmd.Flags().Changed("show-preferences") &&
 			!cmd.Flags().Changed("add-preference") &&
 			!cmd.Flags().Changed("remove-preference") &&
-			!cmd.Flags().Changed("clear-preferences") {
+			!cmd.Flags().Changed("clear-preferences") &&
+			!cmd.Flags().Changed("list-profiles") &&
+			!cmd.Flags().Changed("use-profile") &&
+			!cmd.Flags().Changed("restore") &&
+			!cmd.Flags().Changed("export") &&
+			!cmd.Flags().Changed("import") {
 			_ = cmd.Help()
 			return
 		}
@@ -46,6 +66,37 @@ var configCmd = &cobra.Command{
 			return
 		}
 
+		// Handle profile management
+		if cmd.Flags().Changed("list-profiles") {
+			handleListProfiles()
+			return
+		}
+
+		if cmd.Flags().Changed("use-profile") {
+			value, _ := cmd.Flags().GetString("use-profile")
+			handleUseProfile(value)
+			return
+		}
+
+		// Handle --restore flag
+		if cmd.Flags().Changed("restore") {
+			handleRestoreConfig()
+			return
+		}
+
+		// Handle --export and --import flags
+		if cmd.Flags().Changed("export") {
+			value, _ := cmd.Flags().GetString("export")
+			handleExportConfig(value)
+			return
+		}
+
+		if cmd.Flags().Changed("import") {
+			value, _ := cmd.Flags().GetString("import")
+			handleImportConfig(value)
+			return
+		}
+
 		// Handle --show flag
 		if cmd.Flags().Changed("show") {
 			handleShowConfig()
@@ -87,6 +138,62 @@ var configCmd = &cobra.Command{
 			return
 		}
 
+		// Handle feedback sound selection
+		if cmd.Flags().Changed("enable-notifications") {
+			handleSetNotifications(true)
+			return
+		}
+
+		if cmd.Flags().Changed("disable-notifications") {
+			handleSetNotifications(false)
+			return
+		}
+
+		if cmd.Flags().Changed("enable-logging") {
+			handleSetLogging(true)
+			return
+		}
+
+		if cmd.Flags().Changed("disable-logging") {
+			handleSetLogging(false)
+			return
+		}
+
+		if cmd.Flags().Changed("set-start-sound") {
+			value, _ := cmd.Flags().GetString("set-start-sound")
+			handleSetSound("start", value)
+			return
+		}
+
+		if cmd.Flags().Changed("set-stop-sound") {
+			value, _ := cmd.Flags().GetString("set-stop-sound")
+			handleSetSound("stop", value)
+			return
+		}
+
+		if cmd.Flags().Changed("set-complete-sound") {
+			value, _ := cmd.Flags().GetString("set-complete-sound")
+			handleSetSound("complete", value)
+			return
+		}
+
+		if cmd.Flags().Changed("set-feedback-volume") {
+			value, _ := cmd.Flags().GetFloat64("set-feedback-volume")
+			handleSetFeedbackVolume(value)
+			return
+		}
+
+		if cmd.Flags().Changed("list-audio-backends") {
+			handleListAudioBackends()
+			return
+		}
+
+		if cmd.Flags().Changed("set-audio-backend") {
+			value, _ := cmd.Flags().GetString("set-audio-backend")
+			handleSetAudioBackend(value)
+			return
+		}
+
 		// Handle set commands
 		if cmd.Flags().Changed("set-microphone") {
 			value, _ := cmd.Flags().GetString("set-microphone")
@@ -149,13 +256,138 @@ var configCmd = &cobra.Command{
 	},
 }
 
+// loadConfig loads the configuration for the profile selected with --profile,
+// creating the profile if it does not exist yet
+func loadConfig() (*config.Config, error) {
+	if configProfile == "" {
+		return config.Load()
+	}
+	return config.LoadOrCreateProfile(configProfile)
+}
+
+func handleExportConfig(path string) {
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	if err := cfg.Export(path); err != nil {
+		fmt.Fprintf(os.Stderr, "Error exporting configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Configuration exported to %s\n", path)
+	if cfg.OpenAIAPIKey != "" {
+		fmt.Println("Note: the file contains your OpenAI API key; keep it private.")
+	}
+	fmt.Println("Import it on another machine with:")
+	fmt.Printf("  openscribe config --import %q\n", path)
+}
+
+func handleImportConfig(path string) {
+	cfg, err := config.Import(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error importing configuration: %v\n", err)
+		fmt.Fprintln(os.Stderr, "The current configuration was left unchanged.")
+		os.Exit(1)
+	}
+
+	fmt.Printf("Configuration imported from %s!\n", path)
+	fmt.Println("The previous configuration was kept as a backup; run --restore to undo.")
+	fmt.Println()
+	fmt.Print(cfg.String())
+}
+
+func handleListProfiles() {
+	cfg, err := config.LoadProfile(config.DefaultProfile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Configuration profiles:")
+	for _, name := range cfg.ProfileNames() {
+		marker := " "
+		if name == cfg.ActiveProfile {
+			marker = "*"
+		}
+		fmt.Printf("  %s %s\n", marker, name)
+	}
+
+	fmt.Println("\nTo switch profiles, use:")
+	fmt.Println("  openscribe config --use-profile <name>")
+}
+
+func handleUseProfile(name string) {
+	cfg, err := config.LoadProfile(config.DefaultProfile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	found := false
+	for _, profile := range cfg.ProfileNames() {
+		if profile == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		fmt.Fprintf(os.Stderr, "Error: unknown profile: %s\n", name)
+		fmt.Fprintf(os.Stderr, "Create it first with: openscribe config --profile %s --set-model <model>\n", name)
+		os.Exit(1)
+	}
+
+	cfg.ActiveProfile = name
+
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := cfg.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Active profile set to: %s\n", name)
+	fmt.Println("Configuration saved successfully!")
+}
+
 func handleShowConfig() {
-	cfg, err := config.Load()
+	cfg, err := config.LoadProfile(configProfile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		os.Exit(1)
 	}
 
+	if jsonOutput {
+		settings, err := cfg.ToMap()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		printJSON(settings)
+		return
+	}
+
+	fmt.Print(cfg.String())
+}
+
+func handleRestoreConfig() {
+	cfg, err := config.RestoreBackup()
+	if errors.Is(err, config.ErrNoBackup) {
+		fmt.Fprintln(os.Stderr, "Error: No configuration backup found (one is created each time the configuration is saved)")
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error restoring configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Configuration restored from backup!")
+	fmt.Println("The replaced configuration was kept as the new backup; run --restore again to undo.")
+	fmt.Println()
 	fmt.Print(cfg.String())
 }
 
@@ -187,9 +419,14 @@ func handleOpenConfig() {
 }
 
 func handleListMicrophones() {
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
 	fmt.Println("Detecting available microphones...")
 
-	devices, err := audio.ListMicrophones()
+	devices, err := audio.ListMicrophones(cfg.AudioBackend)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error listing microphones: %v\n", err)
 		os.Exit(1)
@@ -207,6 +444,13 @@ func handleListMicrophones() {
 			defaultMarker = " (default)"
 		}
 		fmt.Printf("  %d. %s%s\n", i+1, device.Name, defaultMarker)
+		if caps := device.Capabilities(); caps != "" {
+			note := ""
+			if !device.CanCapture(16000, 1) {
+				note = " (resampled to 16kHz mono)"
+			}
+			fmt.Printf("     %s%s\n", caps, note)
+		}
 	}
 
 	fmt.Println("\nTo set preferences:")
@@ -216,6 +460,59 @@ func handleListMicrophones() {
 	fmt.Println("  openscribe config --set-microphone \"<microphone name>\"")
 }
 
+func handleListAudioBackends() {
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	current := cfg.AudioBackend
+	if current == "" {
+		current = audio.BackendAuto
+	}
+
+	fmt.Println("Available audio backends:")
+	for _, name := range append([]string{audio.BackendAuto}, audio.ListBackends()...) {
+		marker := " "
+		if name == current {
+			marker = "*"
+		}
+		fmt.Printf("  %s %s\n", marker, name)
+	}
+
+	fmt.Println("\nauto tries the backends in the order listed.")
+	fmt.Println("To choose one, use:")
+	fmt.Println("  openscribe config --set-audio-backend <name>")
+}
+
+func handleSetAudioBackend(name string) {
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := audio.ValidateBackend(name, audio.ListBackends()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if name == audio.BackendAuto {
+		name = ""
+	}
+	cfg.AudioBackend = name
+
+	if err := cfg.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving configuration: %v\n", err)
+		os.Exit(1)
+	}
+	if name == "" {
+		fmt.Println("Audio backend set to: auto")
+	} else {
+		fmt.Printf("Audio backend set to: %s\n", name)
+	}
+	fmt.Println("Microphone names can differ between backends; check them with: openscribe config --list-microphones")
+}
+
 func handleListHotkeys() {
 	fmt.Println("Available hotkeys:")
 
@@ -224,12 +521,17 @@ func handleListHotkeys() {
 		fmt.Printf("  %d. %s\n", i+1, key)
 	}
 
+	fmt.Println("\nKey combinations (single press):")
+	fmt.Println("  Modifiers: Cmd, Shift, Ctrl, Opt")
+	fmt.Printf("  Keys:      %s\n", strings.Join(hotkey.GetComboKeys(), ", "))
+	fmt.Println("  Example:   Cmd+Shift+Space")
+
 	fmt.Println("\nTo set a hotkey, use:")
 	fmt.Println("  openscribe config --set-hotkey \"Right Option\"")
 }
 
 func handleSetConfig(key, value string) {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		os.Exit(1)
@@ -240,7 +542,7 @@ func handleSetConfig(key, value string) {
 	case "microphone":
 		// Validate that the microphone exists
 		if value != "" {
-			device, err := audio.FindMicrophoneByNameOrIndex(value)
+			device, err := audio.FindMicrophoneByNameOrIndex(value, cfg.AudioBackend)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				fmt.Println("\nRun 'openscribe config --list-microphones' to see available devices.")
@@ -299,14 +601,81 @@ func handleListSounds() {
 	fmt.Println("  - Start recording: Tink (short ascending beep)")
 	fmt.Println("  - Stop recording: Pop (short neutral beep)")
 	fmt.Println("  - Transcription complete: Glass (pleasant ding)")
+	fmt.Println("\nTo change a sound, run:")
+	fmt.Println("  openscribe config --set-start-sound Hero")
 	fmt.Println("\nTo test the sounds, run:")
 	fmt.Println("  openscribe config --test-sounds")
 }
 
+func handleSetSound(event, name string) {
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch event {
+	case "start":
+		cfg.StartSound = name
+	case "stop":
+		cfg.StopSound = name
+	case "complete":
+		cfg.CompleteSound = name
+	}
+
+	// Validate before saving
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := cfg.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if name == "" {
+		fmt.Printf("Sound for %s reset to default.\n", event)
+	} else {
+		fmt.Printf("Sound for %s set to: %s\n", event, name)
+	}
+	fmt.Println("Configuration saved successfully!")
+}
+
+func handleSetFeedbackVolume(volume float64) {
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg.FeedbackVolume = volume
+
+	// Validate before saving
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := cfg.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Feedback volume set to: %.0f%%\n", volume*100)
+	fmt.Println("Configuration saved successfully!")
+}
+
 func handleTestSounds() {
 	fmt.Println("Testing audio feedback sounds...")
 
-	feedback, err := audio.NewFeedback()
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	feedback, err := audio.NewFeedback(audio.FeedbackOptionsFromConfig(cfg))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing audio feedback: %v\n", err)
 		os.Exit(1)
@@ -317,7 +686,7 @@ func handleTestSounds() {
 		}
 	}()
 
-	fmt.Println("Playing start sound (Tink)...")
+	fmt.Println("Playing start sound...")
 	if err := feedback.PlayStartSound(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error playing start sound: %v\n", err)
 	}
@@ -330,7 +699,7 @@ func handleTestSounds() {
 		_ = i // prevent empty block warning
 	}
 
-	fmt.Println("Playing stop sound (Pop)...")
+	fmt.Println("Playing stop sound...")
 	if err := feedback.PlayStopSound(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error playing stop sound: %v\n", err)
 	}
@@ -341,7 +710,7 @@ func handleTestSounds() {
 		_ = i // prevent empty block warning
 	}
 
-	fmt.Println("Playing complete sound (Glass)...")
+	fmt.Println("Playing complete sound...")
 	if err := feedback.PlayCompleteSound(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error playing complete sound: %v\n", err)
 	}
@@ -350,7 +719,7 @@ func handleTestSounds() {
 }
 
 func handleSetAudioFeedback(enabled bool) {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		os.Exit(1)
@@ -371,8 +740,53 @@ func handleSetAudioFeedback(enabled bool) {
 	fmt.Println("Configuration saved successfully!")
 }
 
+func handleSetNotifications(enabled bool) {
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg.DesktopNotifications = enabled
+
+	if err := cfg.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if enabled {
+		fmt.Println("Desktop notifications enabled!")
+	} else {
+		fmt.Println("Desktop notifications disabled.")
+	}
+	fmt.Println("Configuration saved successfully!")
+}
+
+func handleSetLogging(enabled bool) {
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg.LoggingEnabled = enabled
+
+	if err := cfg.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if enabled {
+		fmt.Println("Transcription logging enabled!")
+	} else {
+		fmt.Println("Transcription logging disabled: transcriptions won't be written to disk.")
+		fmt.Println("Existing history is kept; remove it with: openscribe logs clear")
+	}
+	fmt.Println("Configuration saved successfully!")
+}
+
 func handleShowPreferences() {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		os.Exit(1)
@@ -402,7 +816,7 @@ func handleAddPreference(name string) {
 		os.Exit(1)
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		os.Exit(1)
@@ -417,7 +831,7 @@ func handleAddPreference(name string) {
 	}
 
 	// Check if device is currently connected (warning, not error)
-	devices, err := audio.ListMicrophones()
+	devices, err := audio.ListMicrophones(cfg.AudioBackend)
 	if err == nil {
 		found := false
 		for _, device := range devices {
@@ -459,7 +873,7 @@ func handleRemovePreference(nameOrIndex string) {
 		os.Exit(1)
 	}
 
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		os.Exit(1)
@@ -518,7 +932,7 @@ func handleRemovePreference(nameOrIndex string) {
 }
 
 func handleClearPreferences() {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		os.Exit(1)
@@ -537,7 +951,7 @@ func handleClearPreferences() {
 }
 
 func handleSetOpenAIAPIKey(key string) {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		os.Exit(1)
@@ -562,7 +976,7 @@ func handleSetOpenAIAPIKey(key string) {
 }
 
 func handleSetOpenAIModel(model string) {
-	cfg, err := config.Load()
+	cfg, err := loadConfig()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		os.Exit(1)
@@ -589,12 +1003,25 @@ func init() {
 	// Add flags for the config command
 	configCmd.Flags().Bool("show", false, "Display current configuration")
 	configCmd.Flags().Bool("open", false, "Open configuration file in default editor")
+	configCmd.Flags().Bool("restore", false, "Restore the configuration saved before the last change")
+	configCmd.Flags().String("export", "", "Write the current configuration to a file, to import on another machine")
+	configCmd.Flags().String("import", "", "Replace the configuration with one written by --export")
 	configCmd.Flags().Bool("list-microphones", false, "List available microphones")
+	configCmd.Flags().Bool("list-audio-backends", false, "List available audio backends (audio APIs)")
+	configCmd.Flags().String("set-audio-backend", "", "Set the audio backend used to record (auto or a name from --list-audio-backends)")
 	configCmd.Flags().Bool("list-hotkeys", false, "List available hotkeys")
 	configCmd.Flags().Bool("list-sounds", false, "List available system sounds")
 	configCmd.Flags().Bool("test-sounds", false, "Test audio feedback sounds")
 	configCmd.Flags().Bool("enable-audio-feedback", false, "Enable audio feedback")
 	configCmd.Flags().Bool("disable-audio-feedback", false, "Disable audio feedback")
+	configCmd.Flags().Bool("enable-notifications", false, "Show a notification with the text when a transcription completes")
+	configCmd.Flags().Bool("disable-notifications", false, "Disable transcription notifications")
+	configCmd.Flags().Bool("enable-logging", false, "Save transcriptions to the transcription log")
+	configCmd.Flags().Bool("disable-logging", false, "Stop saving transcriptions to disk")
+	configCmd.Flags().String("set-start-sound", "", "Set the system sound played when recording starts (empty = default)")
+	configCmd.Flags().String("set-stop-sound", "", "Set the system sound played when recording stops (empty = default)")
+	configCmd.Flags().Float64("set-feedback-volume", 1.0, "Set audio feedback volume (0.0-1.0)")
+	configCmd.Flags().String("set-complete-sound", "", "Set the system sound played when transcription completes (empty = default)")
 	configCmd.Flags().String("set-microphone", "", "Set default microphone")
 	configCmd.Flags().String("set-model", "", "Set default model")
 	configCmd.Flags().String("set-language", "", "Set default language")
@@ -607,4 +1034,9 @@ func init() {
 	configCmd.Flags().String("add-preference", "", "Add a microphone to the preferences list")
 	configCmd.Flags().String("remove-preference", "", "Remove a microphone from preferences (by name or index)")
 	configCmd.Flags().Bool("clear-preferences", false, "Clear all preferred microphones")
+
+	// Add flags for profile management
+	configCmd.Flags().StringVar(&configProfile, "profile", "", "Profile to show or modify (default: active profile)")
+	configCmd.Flags().Bool("list-profiles", false, "List configuration profiles")
+	configCmd.Flags().String("use-profile", "", "Set the active configuration profile")
 }
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+	"github.com/alexandrelam/openscribe/internal/daemon"
+	"github.com/alexandrelam/openscribe/internal/doctor"
+	"github.com/spf13/cobra"
+)
+
+// stopTimeout is how long stop waits for the background process to exit
+const stopTimeout = 5 * time.Second
+
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop OpenScribe running in the background",
+	Long:  `Stop the background OpenScribe process started with 'openscribe start --daemon'.`,
+	Run: func(_ *cobra.Command, _ []string) {
+		pid, err := daemon.Stop()
+		if errors.Is(err, daemon.ErrNotRunning) {
+			fmt.Println("OpenScribe is not running in the background.")
+			return
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Wait for the clean shutdown to finish
+		deadline := time.Now().Add(stopTimeout)
+		for time.Now().Before(deadline) {
+			if _, running, _ := daemon.Status(); !running {
+				fmt.Printf("✓ Stopped OpenScribe (PID %d)\n", pid)
+				return
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		fmt.Fprintf(os.Stderr, "Error: OpenScribe (PID %d) did not stop within %s\n", pid, stopTimeout)
+		os.Exit(1)
+	},
+}
+
+var statusCmd = &cobra.Command{
+	Use:     "status",
+	Aliases: []string{"doctor"},
+	Short:   "Check whether OpenScribe is ready and running",
+	Long: `Check that everything OpenScribe needs is in place (transcription backend, model,
+accessibility permissions, microphone, disk space) and whether it is running in the background.
+Exits with a non-zero status if anything critical is missing.`,
+	Run: func(_ *cobra.Command, _ []string) {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+			os.Exit(1)
+		}
+
+		pid, running, err := daemon.Status()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		results := doctor.Run(doctor.DefaultChecks(cfg))
+		ready := !doctor.HasCriticalFailure(results)
+
+		if jsonOutput {
+			printJSON(struct {
+				Running bool            `json:"running"`
+				PID     int             `json:"pid,omitempty"`
+				Ready   bool            `json:"ready"`
+				Checks  []doctor.Result `json:"checks"`
+			}{running, pid, ready, results})
+			if !ready {
+				os.Exit(1)
+			}
+			return
+		}
+
+		if running {
+			logPath, _ := config.GetDaemonLogPath()
+			fmt.Printf("OpenScribe is running in the background (PID %d)\n", pid)
+			fmt.Printf("Output: %s\n", logPath)
+		} else {
+			fmt.Println("OpenScribe is not running in the background.")
+		}
+
+		fmt.Println()
+		fmt.Println("Readiness checks:")
+		for _, r := range results {
+			mark := "✓"
+			if !r.OK && r.Critical {
+				mark = "✗"
+			} else if !r.OK {
+				mark = "⚠"
+			}
+			fmt.Printf("  %s %s: %s\n", mark, r.Name, r.Detail)
+			if !r.OK && r.Hint != "" {
+				fmt.Printf("      → %s\n", r.Hint)
+			}
+		}
+		fmt.Println()
+
+		if !ready {
+			fmt.Fprintln(os.Stderr, "OpenScribe is not ready. Fix the items marked ✗ above.")
+			os.Exit(1)
+		}
+		if !running {
+			fmt.Println("OpenScribe is ready. Start it with 'openscribe start' (or --daemon to run in the background).")
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(stopCmd)
+	rootCmd.AddCommand(statusCmd)
+}
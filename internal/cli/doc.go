@@ -1,10 +1,14 @@
 // Package cli implements the command-line interface for OpenScribe using Cobra.
 //
 // This package provides all CLI commands:
-//   - start: Start the transcription service with hotkey activation
+//   - start: Start the transcription service with hotkey activation (--daemon to run in the background)
+//   - record: Record for a fixed duration and transcribe, without a hotkey
+//   - stop: Stop the background service
+//   - status (doctor): Check readiness and whether the background service is running
+//   - init: Interactive first-run wizard (microphone, hotkey, language, model)
 //   - setup: Initial setup (download models, verify whisper-cpp)
 //   - config: Configuration management (microphones, models, language, hotkeys)
-//   - models: Model management (list, download)
+//   - models: Model management (list, download, recommend)
 //   - logs: Transcription history viewing
 //   - version: Show version information
 //
@@ -28,13 +32,23 @@
 //	# With options
 //	openscribe start --model base --language en --no-paste
 //
+//	# Record for 10 seconds without a hotkey
+//	openscribe record --duration 10
+//
+//	# Run in the background
+//	openscribe start --daemon
+//	openscribe status
+//	openscribe stop
+//
 //	# Configuration
 //	openscribe config --set-microphone "MacBook Pro Microphone"
 //	openscribe config --set-hotkey "Right Option"
+//	openscribe config --profile work --set-model medium
 //
 //	# Model management
 //	openscribe models list
 //	openscribe models download small
+//	openscribe models recommend
 //
 //	# View history
 //	openscribe logs show -n 10
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/alexandrelam/openscribe/internal/audio"
+	"github.com/alexandrelam/openscribe/internal/config"
+	"github.com/alexandrelam/openscribe/internal/hotkey"
+	"github.com/alexandrelam/openscribe/internal/models"
+	"github.com/alexandrelam/openscribe/internal/transcription"
+	"github.com/spf13/cobra"
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Interactively choose a microphone, hotkey, language and model",
+	Long: `Walk through the main settings and save them to the configuration:
+  - The microphone to record with (added first to your preferred microphones)
+  - The hotkey that starts and stops recording
+  - The default language (or auto-detect)
+  - The Whisper model, with a recommendation for this Mac
+
+Press Enter to keep the value shown in brackets. Run 'openscribe setup' afterwards
+to install whisper.cpp and download the chosen model.
+
+Examples:
+  openscribe init`,
+	Run: func(_ *cobra.Command, _ []string) {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+			os.Exit(1)
+		}
+
+		var microphones []string
+		if devices, err := audio.ListMicrophones(cfg.AudioBackend); err == nil {
+			for _, device := range devices {
+				microphones = append(microphones, device.Name)
+			}
+		}
+
+		keys := hotkey.GetAvailableKeys()
+		sort.Strings(keys)
+
+		model, reason := models.RecommendModel()
+		wizard := initWizard{
+			prompt:      newPrompter(os.Stdin, os.Stdout),
+			microphones: microphones,
+			hotkeys:     keys,
+			model:       model,
+			modelReason: reason,
+		}
+		if err := wizard.run(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "\nError: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println()
+		if save, err := wizard.prompt.confirm("Save these settings?", true); err != nil || !save {
+			fmt.Println("Configuration left unchanged.")
+			return
+		}
+
+		if err := cfg.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving configuration: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("✓ Configuration saved!")
+		if downloaded, _ := models.IsModelDownloaded(models.ModelSize(cfg.Model)); !downloaded {
+			fmt.Printf("Download the %s model with: openscribe setup --model %s\n", cfg.Model, cfg.Model)
+		} else {
+			fmt.Println("Start dictating with: openscribe start")
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+}
+
+// initWizard asks for the main settings. The hardware-dependent inputs are fields so the
+// flow can be tested with a scripted prompter.
+type initWizard struct {
+	prompt      *prompter
+	microphones []string // Connected microphones
+	hotkeys     []string // Available hotkey names, sorted
+	model       models.ModelSize
+	modelReason string
+}
+
+// run asks each question and applies the answers to cfg, which is validated at the end
+func (w initWizard) run(cfg *config.Config) error {
+	out := w.prompt.out
+	fmt.Fprintln(out, "OpenScribe Setup")
+	fmt.Fprintln(out, "================")
+
+	// Microphone
+	fmt.Fprintln(out, "\nMicrophone:")
+	if len(w.microphones) == 0 {
+		fmt.Fprintln(out, "  No microphones found; the system default will be used.")
+	} else {
+		options := append([]string{"System default"}, w.microphones...)
+		choice, err := w.prompt.choose("Record with", options, 0)
+		if err != nil {
+			return err
+		}
+		if choice > 0 {
+			cfg.PreferredMicrophones = preferMicrophone(cfg.PreferredMicrophones, w.microphones[choice-1])
+		}
+	}
+
+	// Hotkey
+	fmt.Fprintln(out, "\nHotkey:")
+	current := 0
+	if len(cfg.Triggers) > 0 {
+		for i, key := range w.hotkeys {
+			if key == cfg.Triggers[0] {
+				current = i
+			}
+		}
+	}
+	choice, err := w.prompt.choose("Start and stop recording with", w.hotkeys, current)
+	if err != nil {
+		return err
+	}
+	cfg.Triggers = []string{w.hotkeys[choice]}
+
+	// Language
+	fmt.Fprintln(out, "\nLanguage (a code such as en or fr, or \"auto\" to detect it):")
+	def := cfg.Language
+	if def == "" {
+		def = "auto"
+	}
+	for {
+		answer, err := w.prompt.ask("Language", def)
+		if err != nil {
+			return err
+		}
+		answer = strings.ToLower(answer)
+		if answer == "auto" {
+			cfg.Language = ""
+			break
+		}
+		if _, ok := transcription.Languages[answer]; ok {
+			cfg.Language = answer
+			break
+		}
+		fmt.Fprintf(out, "Unknown language code %q (supported: %s)\n", answer, strings.Join(transcription.LanguageCodes(), ", "))
+	}
+
+	// Model
+	// The English-only and quantized variants can be set later with config --set-model
+	fmt.Fprintf(out, "\nModel (%s):\n", w.modelReason)
+	names := models.ModelOrder
+	options := make([]string, len(names))
+	recommended := 0
+	for i, name := range names {
+		options[i] = fmt.Sprintf("%-12s %s", name, models.AvailableModels[name].Description)
+		if name == w.model {
+			recommended = i
+		}
+	}
+	choice, err = w.prompt.choose("Transcribe with", options, recommended)
+	if err != nil {
+		return err
+	}
+	cfg.Model = string(names[choice])
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	return nil
+}
+
+// preferMicrophone moves name to the front of the preferred microphones
+func preferMicrophone(preferred []string, name string) []string {
+	result := []string{name}
+	for _, existing := range preferred {
+		if existing != name {
+			result = append(result, existing)
+		}
+	}
+	return result
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+	"github.com/alexandrelam/openscribe/internal/models"
+)
+
+func newTestWizard(input string) (initWizard, *bytes.Buffer) {
+	var out bytes.Buffer
+	return initWizard{
+		prompt:      newPrompter(strings.NewReader(input), &out),
+		microphones: []string{"MacBook Pro Microphone", "Blue Yeti"},
+		hotkeys:     []string{"Left Option", "Right Command", "Right Option"},
+		model:       models.Small,
+		modelReason: "8 CPU cores detected, recommending small",
+	}, &out
+}
+
+func TestInitWizard(t *testing.T) {
+	ordered := models.ModelOrder
+	tests := []struct {
+		name          string
+		input         string
+		wantMics      []string
+		wantTrigger   string
+		wantLanguage  string
+		wantModel     string
+		outputContent string
+	}{
+		{
+			name:         "defaults",
+			input:        "\n\n\n\n",
+			wantMics:     []string{"AirPods Pro"},
+			wantTrigger:  "Right Option",
+			wantLanguage: "",
+			wantModel:    "small",
+		},
+		{
+			name:          "explicit answers",
+			input:         "3\n1\nfr\n" + modelIndex(ordered, models.Medium) + "\n",
+			wantMics:      []string{"Blue Yeti", "AirPods Pro"},
+			wantTrigger:   "Left Option",
+			wantLanguage:  "fr",
+			wantModel:     "medium",
+			outputContent: "recommending small",
+		},
+		{
+			name:          "invalid answers are asked again",
+			input:         "9\n2\nx\n2\nklingon\nEN\n\n",
+			wantMics:      []string{"MacBook Pro Microphone", "AirPods Pro"},
+			wantTrigger:   "Right Command",
+			wantLanguage:  "en",
+			wantModel:     "small",
+			outputContent: `Unknown language code "klingon"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wizard, out := newTestWizard(tt.input)
+			cfg := config.DefaultConfig()
+			cfg.PreferredMicrophones = []string{"AirPods Pro"}
+			cfg.Triggers = []string{"Right Option"}
+
+			if err := wizard.run(cfg); err != nil {
+				t.Fatalf("run() error = %v\noutput:\n%s", err, out)
+			}
+			if !reflect.DeepEqual(cfg.PreferredMicrophones, tt.wantMics) {
+				t.Errorf("PreferredMicrophones = %q, want %q", cfg.PreferredMicrophones, tt.wantMics)
+			}
+			if len(cfg.Triggers) != 1 || cfg.Triggers[0] != tt.wantTrigger {
+				t.Errorf("Triggers = %q, want [%s]", cfg.Triggers, tt.wantTrigger)
+			}
+			if cfg.Language != tt.wantLanguage {
+				t.Errorf("Language = %q, want %q", cfg.Language, tt.wantLanguage)
+			}
+			if cfg.Model != tt.wantModel {
+				t.Errorf("Model = %q, want %q", cfg.Model, tt.wantModel)
+			}
+			if !strings.Contains(out.String(), tt.outputContent) {
+				t.Errorf("output does not contain %q:\n%s", tt.outputContent, out)
+			}
+		})
+	}
+}
+
+func TestInitWizard_InputEnds(t *testing.T) {
+	wizard, _ := newTestWizard("2\n")
+	cfg := config.DefaultConfig()
+	if err := wizard.run(cfg); !errors.Is(err, errNoInput) {
+		t.Errorf("run() error = %v, want errNoInput", err)
+	}
+}
+
+func TestPrompterConfirm(t *testing.T) {
+	tests := []struct {
+		input string
+		def   bool
+		want  bool
+	}{
+		{"y\n", false, true},
+		{"YES\n", false, true},
+		{"n\n", true, false},
+		{"\n", true, true},
+		{"\n", false, false},
+	}
+	for _, tt := range tests {
+		p := newPrompter(strings.NewReader(tt.input), &bytes.Buffer{})
+		got, err := p.confirm("Continue?", tt.def)
+		if err != nil || got != tt.want {
+			t.Errorf("confirm(%q, default %t) = %t, %v, want %t", tt.input, tt.def, got, err, tt.want)
+		}
+	}
+}
+
+// modelIndex returns the 1-based menu number of a model
+func modelIndex(ordered []models.ModelSize, model models.ModelSize) string {
+	for i, m := range ordered {
+		if m == model {
+			return strconv.Itoa(i + 1)
+		}
+	}
+	return ""
+}
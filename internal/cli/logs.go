@@ -1,34 +1,127 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/alexandrelam/openscribe/internal/config"
+	"github.com/alexandrelam/openscribe/internal/keyboard"
 	"github.com/alexandrelam/openscribe/internal/logging"
+	"github.com/alexandrelam/openscribe/internal/textproc"
 	"github.com/spf13/cobra"
 )
 
 var logsCmd = &cobra.Command{
 	Use:   "logs",
 	Short: "Log management",
-	Long:  `View and manage transcription logs.`,
+	Long: `View and manage transcription logs.
+
+With encrypt_log on, the commands that read the log ask for its passphrase
+(or read it from OPENSCRIBE_LOG_PASSPHRASE).`,
+	PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+		// Clearing removes the files without reading them
+		if cmd == logsClearCmd {
+			return
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			return
+		}
+		if _, err := unlockLog(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
 }
 
 var logsShowCmd = &cobra.Command{
 	Use:   "show",
 	Short: "Display recent transcription logs",
-	Long:  `Show recent transcription logs from the log file.`,
+	Long: `Show recent transcription logs from the log file. Use --all to include rotated logs,
+and --by-app to group them by the application they were dictated into.
+
+--since and --before accept an RFC3339 time, a YYYY-MM-DD date (local midnight), or a
+time relative to now such as 90m, 24h or 7d. --tail applies to the matching entries.
+
+Examples:
+  openscribe logs show --since 24h
+  openscribe logs show --since 2024-03-01 --before 2024-04-01 -n 50`,
 	Run: func(cmd *cobra.Command, _ []string) {
 		tail, _ := cmd.Flags().GetInt("tail")
+		all, _ := cmd.Flags().GetBool("all")
+		byApp, _ := cmd.Flags().GetBool("by-app")
+		since, _ := cmd.Flags().GetString("since")
+		before, _ := cmd.Flags().GetString("before")
 
-		// Get transcription entries
-		entries, err := logging.GetTranscriptions(tail)
+		var filter logging.SearchOptions
+		if since != "" {
+			t, err := parseTimeFilter(since)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --since value %q: %v\n", since, err)
+				os.Exit(1)
+			}
+			filter.Since = t
+		}
+		if before != "" {
+			t, err := parseTimeFilter(before)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --before value %q: %v\n", before, err)
+				os.Exit(1)
+			}
+			filter.Until = t
+		}
+		filtered := since != "" || before != ""
+
+		// Get transcription entries; time filters need them all before tailing
+		getEntries, countEntries := logging.GetTranscriptions, logging.CountTranscriptions
+		if all {
+			getEntries, countEntries = logging.GetTranscriptionHistory, logging.CountTranscriptionHistory
+		}
+		limit := tail
+		if filtered {
+			limit = 0
+		}
+		entries, err := getEntries(limit)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error reading logs: %v\n", err)
 			os.Exit(1)
 		}
 
+		var total int
+		if filtered {
+			matching := entries[:0]
+			for _, entry := range entries {
+				if filter.Matches(entry) {
+					matching = append(matching, entry)
+				}
+			}
+			total = len(matching)
+			if tail > 0 && len(matching) > tail {
+				matching = matching[len(matching)-tail:]
+			}
+			entries = matching
+		} else {
+			total, _ = countEntries()
+		}
+
+		if jsonOutput {
+			printJSON(entries)
+			return
+		}
+
+		printLoggingDisabledNote()
+		if len(entries) == 0 && filtered {
+			fmt.Println("No transcriptions found in that time range.")
+			return
+		}
 		if len(entries) == 0 {
 			fmt.Println("No transcription logs found.")
 			fmt.Println()
@@ -39,22 +132,22 @@ var logsShowCmd = &cobra.Command{
 
 		// Display entries
 		fmt.Printf("Showing %d transcription(s):\n\n", len(entries))
-		for i, entry := range entries {
-			fmt.Printf("─────────────────────────────────────────────────────────────\n")
-			fmt.Printf("[%d] %s\n", i+1, entry.Timestamp.Format("2006-01-02 15:04:05"))
-			fmt.Printf("Duration: %.2f seconds | Model: %s | Language: %s\n",
-				entry.Duration, entry.Model, entry.Language)
-			fmt.Printf("\nTranscription:\n%s\n", entry.Text)
+		if byApp {
+			printLogEntriesByApp(entries)
+		} else {
+			printLogEntries(entries)
 		}
-		fmt.Printf("─────────────────────────────────────────────────────────────\n")
 
 		// Show total count
-		total, _ := logging.CountTranscriptions()
+		kind := "total"
+		if filtered {
+			kind = "matching"
+		}
 		if total > len(entries) {
-			fmt.Printf("\nShowing %d of %d total transcriptions.\n", len(entries), total)
+			fmt.Printf("\nShowing %d of %d %s transcriptions.\n", len(entries), total, kind)
 			fmt.Printf("Use --tail/-n flag to show more: openscribe logs show -n %d\n", total)
 		} else {
-			fmt.Printf("\nTotal transcriptions: %d\n", total)
+			fmt.Printf("\nTotal %s transcriptions: %d\n", kind, total)
 		}
 
 		// Show log file location
@@ -69,7 +162,7 @@ var logsClearCmd = &cobra.Command{
 	Long:  `Delete all transcription logs.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		// Get count before clearing
-		count, err := logging.CountTranscriptions()
+		count, err := logging.CountTranscriptionHistory()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error reading logs: %v\n", err)
 			os.Exit(1)
@@ -94,11 +187,369 @@ var logsClearCmd = &cobra.Command{
 	},
 }
 
+var logsUndoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Remove the most recent transcription from the log",
+	Long: `Remove the most recent transcription from the current log file, e.g. after a
+throwaway test dictation. The recording kept with it (keep_recordings) is deleted
+too. Rotated logs are not changed.`,
+	Run: func(_ *cobra.Command, _ []string) {
+		entry, err := logging.RemoveLast()
+		if errors.Is(err, logging.ErrNoTranscriptions) {
+			fmt.Println("No transcription logs to remove.")
+			return
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error removing transcription: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			printJSON(entry)
+			return
+		}
+
+		fmt.Printf("✓ Removed transcription from %s:\n", entry.Timestamp.Format("2006-01-02 15:04:05"))
+		fmt.Println(entry.Text)
+	},
+}
+
+var logsSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search transcription logs",
+	Long: `Search transcription logs by text, date range, and language.
+
+Dates use the YYYY-MM-DD format (local time). --until includes the whole day.
+
+Examples:
+  openscribe logs search --query "meeting"
+  openscribe logs search --query "meeting" --since 2024-01-01 --lang en`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		query, _ := cmd.Flags().GetString("query")
+		since, _ := cmd.Flags().GetString("since")
+		until, _ := cmd.Flags().GetString("until")
+		lang, _ := cmd.Flags().GetString("lang")
+
+		opts := logging.SearchOptions{Query: query, Language: lang}
+		if since != "" {
+			t, err := time.ParseInLocation("2006-01-02", since, time.Local)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --since date %q (expected YYYY-MM-DD)\n", since)
+				os.Exit(1)
+			}
+			opts.Since = t
+		}
+		if until != "" {
+			t, err := time.ParseInLocation("2006-01-02", until, time.Local)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --until date %q (expected YYYY-MM-DD)\n", until)
+				os.Exit(1)
+			}
+			opts.Until = t.AddDate(0, 0, 1)
+		}
+
+		entries, err := logging.SearchTranscriptions(opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error searching logs: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(entries) == 0 {
+			fmt.Println("No matching transcriptions found.")
+			return
+		}
+
+		fmt.Printf("Found %d matching transcription(s):\n\n", len(entries))
+		printLogEntries(entries)
+	},
+}
+
+var logsStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show transcription statistics",
+	Long: `Summarize your transcription history (including rotated logs): totals, models, languages, and busiest day.
+Use --by-app to also count transcriptions per application.`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		byApp, _ := cmd.Flags().GetBool("by-app")
+
+		stats, err := logging.TranscriptionStats()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading logs: %v\n", err)
+			os.Exit(1)
+		}
+
+		if stats.TotalEntries == 0 {
+			fmt.Println("No transcription logs found.")
+			return
+		}
+
+		fmt.Println("Transcription Statistics:")
+		fmt.Println()
+		fmt.Printf("  Transcriptions:  %d\n", stats.TotalEntries)
+		fmt.Printf("  Audio recorded:  %s\n", time.Duration(stats.TotalDuration*float64(time.Second)).Round(time.Second))
+		fmt.Printf("  Words:           %d\n", stats.TotalWords)
+		fmt.Printf("  Busiest day:     %s (%d transcriptions)\n", stats.BusiestDay, stats.BusiestDayCount)
+
+		fmt.Println()
+		fmt.Println("By model:")
+		printCounts(stats.ByModel)
+
+		fmt.Println()
+		fmt.Println("By language:")
+		printCounts(stats.ByLanguage)
+
+		if byApp {
+			fmt.Println()
+			fmt.Println("By app:")
+			printCounts(stats.ByApp)
+		}
+	},
+}
+
+var logsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export transcription logs as CSV or JSON",
+	Long: `Write the full transcription history (including rotated logs) to stdout as CSV or a JSON array.
+
+Examples:
+  openscribe logs export --format csv > transcriptions.csv
+  openscribe logs export --format json > transcriptions.json`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		format, _ := cmd.Flags().GetString("format")
+
+		if err := logging.ExportTranscriptions(os.Stdout, format); err != nil {
+			fmt.Fprintf(os.Stderr, "Error exporting logs: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var logsPasteCmd = &cobra.Command{
+	Use:   "paste [index]",
+	Short: "Paste a previous transcription again",
+	Long: `Paste a logged transcription at the cursor, using the configured paste mode.
+
+The index counts back from the most recent transcription in the current log:
+1 (the default) is the latest, 2 the one before, and so on.
+
+Examples:
+  openscribe logs paste
+  openscribe logs paste 3`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		index := 1
+		if len(args) == 1 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid index %q (expected a number)\n", args[0])
+				os.Exit(1)
+			}
+			index = n
+		}
+
+		entry, err := logging.GetTranscription(index)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		pasteMode, err := keyboard.ParsePasteMode(cfg.PasteMode)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		kb, err := keyboard.NewWithOptions(keyboard.OptionsFromConfig(cfg))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Failed to initialize keyboard simulation: %v\n", err)
+			os.Exit(1)
+		}
+		defer func() { _ = kb.Close() }()
+
+		// Without accessibility permissions, copy to the clipboard instead (needs none)
+		pasteMode, fellBack := keyboard.FallbackPasteMode(kb, pasteMode)
+
+		text := entry.Text
+		if cfg.StripNewlines {
+			text = keyboard.StripNewlines(text)
+		}
+		text = textproc.Apply(text, textproc.OptionsFromConfig(cfg))
+		if err := keyboard.Insert(kb, pasteMode, text); err != nil {
+			fmt.Fprintf(os.Stderr, "Error pasting transcription: %v\n", err)
+			os.Exit(1)
+		}
+
+		timestamp := entry.Timestamp.Format("2006-01-02 15:04:05")
+		switch {
+		case fellBack:
+			fmt.Printf("✓ Copied transcription from %s to clipboard (grant Accessibility to auto-paste)\n", timestamp)
+		case pasteMode == keyboard.PasteModeCopy:
+			fmt.Printf("✓ Copied transcription from %s to clipboard\n", timestamp)
+		default:
+			fmt.Printf("✓ Pasted transcription from %s\n", timestamp)
+		}
+	},
+}
+
+// followPollInterval is how often logs follow checks the log file for new entries
+const followPollInterval = 500 * time.Millisecond
+
+var logsFollowCmd = &cobra.Command{
+	Use:   "follow",
+	Short: "Follow transcription logs live",
+	Long: `Show recent transcription logs, then print new transcriptions as they are logged.
+Keeps following across log rotation and clearing. Press Ctrl+C to stop.`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		tail, _ := cmd.Flags().GetInt("tail")
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		ticker := time.NewTicker(followPollInterval)
+		defer ticker.Stop()
+
+		logPath, _ := config.GetTranscriptionLogPath()
+		fmt.Printf("Following %s (Ctrl+C to stop)\n\n", logPath)
+
+		count := 0
+		err := logging.FollowTranscriptions(ctx, tail, ticker.C, func(entry logging.TranscriptionEntry) {
+			count++
+			printLogEntry(count, entry)
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error following logs: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// printCounts prints a count table sorted by count (highest first), then name
+func printCounts(counts map[string]int) {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	for _, name := range names {
+		label := name
+		if label == "" {
+			label = "(unknown)"
+		}
+		fmt.Printf("  %-16s %d\n", label, counts[name])
+	}
+}
+
+// parseTimeFilter parses a --since/--before value: an RFC3339 time, a YYYY-MM-DD date
+// (local midnight), or a time relative to now such as 90m, 24h or 7d
+func parseTimeFilter(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+
+	// Relative times count back from now; time.ParseDuration has no day unit
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return time.Now().AddDate(0, 0, -n), nil
+		}
+	} else if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return time.Now().Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("expected an RFC3339 time, a YYYY-MM-DD date, or a relative time like 24h or 7d")
+}
+
+// printLogEntries prints transcription entries separated by horizontal rules
+func printLogEntries(entries []logging.TranscriptionEntry) {
+	for i, entry := range entries {
+		printLogEntry(i+1, entry)
+	}
+	fmt.Printf("─────────────────────────────────────────────────────────────\n")
+}
+
+// printLogEntriesByApp prints transcription entries under a heading per application
+func printLogEntriesByApp(entries []logging.TranscriptionEntry) {
+	apps, groups := logging.GroupByApp(entries)
+	for _, app := range apps {
+		label := app
+		if label == "" {
+			label = "(unknown)"
+		}
+		fmt.Printf("%s (%d):\n", label, len(groups[app]))
+		printLogEntries(groups[app])
+		fmt.Println()
+	}
+}
+
+// printLogEntry prints one numbered transcription entry below a horizontal rule
+func printLogEntry(n int, entry logging.TranscriptionEntry) {
+	fmt.Printf("─────────────────────────────────────────────────────────────\n")
+	fmt.Printf("[%d] %s\n", n, entry.Timestamp.Format("2006-01-02 15:04:05"))
+	fmt.Printf("Duration: %.2f seconds | Model: %s | Language: %s\n",
+		entry.Duration, entry.Model, entry.Language)
+	if entry.App != "" {
+		fmt.Printf("App: %s\n", entry.App)
+	}
+	if entry.AudioPath != "" {
+		fmt.Printf("Audio: %s\n", entry.AudioPath)
+	}
+	fmt.Printf("\nTranscription:\n%s\n", entry.Text)
+}
+
 func init() {
 	rootCmd.AddCommand(logsCmd)
 	logsCmd.AddCommand(logsShowCmd)
 	logsCmd.AddCommand(logsClearCmd)
+	logsCmd.AddCommand(logsUndoCmd)
+	logsCmd.AddCommand(logsSearchCmd)
+	logsCmd.AddCommand(logsStatsCmd)
+	logsCmd.AddCommand(logsExportCmd)
+	logsCmd.AddCommand(logsFollowCmd)
+	logsCmd.AddCommand(logsPasteCmd)
 
 	// Add flags for logs show command
 	logsShowCmd.Flags().IntP("tail", "n", 10, "Show last N transcriptions")
+	logsShowCmd.Flags().BoolP("all", "a", false, "Include rotated log files (transcriptions.log.1, .2, ...)")
+	logsShowCmd.Flags().Bool("by-app", false, "Group transcriptions by the application they were dictated into")
+	logsShowCmd.Flags().String("since", "", "Only show transcriptions at or after this time (RFC3339, YYYY-MM-DD, or relative like 24h, 7d)")
+	logsShowCmd.Flags().String("before", "", "Only show transcriptions before this time (RFC3339, YYYY-MM-DD, or relative like 24h, 7d)")
+
+	// Add flags for logs stats command
+	logsStatsCmd.Flags().Bool("by-app", false, "Also count transcriptions per application")
+
+	// Add flags for logs search command
+	logsSearchCmd.Flags().StringP("query", "q", "", "Case-insensitive text to search for")
+	logsSearchCmd.Flags().String("since", "", "Only show transcriptions on or after this date (YYYY-MM-DD)")
+	logsSearchCmd.Flags().String("until", "", "Only show transcriptions on or before this date (YYYY-MM-DD)")
+	logsSearchCmd.Flags().StringP("lang", "l", "", "Only show transcriptions in this language (e.g. en)")
+
+	// Add flags for logs follow command
+	logsFollowCmd.Flags().IntP("tail", "n", 10, "Show last N transcriptions before following")
+
+	// Add flags for logs export command
+	logsExportCmd.Flags().StringP("format", "f", logging.ExportFormatCSV, "Export format (csv or json)")
+}
+
+// printLoggingDisabledNote explains why new transcriptions are missing when logging_enabled is off
+func printLoggingDisabledNote() {
+	cfg, err := config.Load()
+	if err != nil || cfg.LoggingEnabled {
+		return
+	}
+	fmt.Println("Transcription logging is disabled (logging_enabled: false), so new transcriptions aren't saved.")
+	fmt.Println("Turn it back on with: openscribe config --enable-logging")
+	fmt.Println()
 }
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeFilter(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		approx  bool // Relative to now; compared within a tolerance
+		wantErr bool
+	}{
+		{"rfc3339", "2024-03-01T09:30:00Z", time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC), false, false},
+		{"rfc3339 with offset", "2024-03-01T09:30:00+02:00", time.Date(2024, 3, 1, 7, 30, 0, 0, time.UTC), false, false},
+		{"date is local midnight", "2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local), false, false},
+		{"hours", "24h", now.Add(-24 * time.Hour), true, false},
+		{"compound duration", "1h30m", now.Add(-90 * time.Minute), true, false},
+		{"days", "7d", now.AddDate(0, 0, -7), true, false},
+		{"surrounding spaces", " 2d ", now.AddDate(0, 0, -2), true, false},
+		{"empty", "", time.Time{}, false, true},
+		{"garbage", "yesterday", time.Time{}, false, true},
+		{"negative duration", "-24h", time.Time{}, false, true},
+		{"negative days", "-7d", time.Time{}, false, true},
+		{"fractional days", "1.5d", time.Time{}, false, true},
+		{"invalid date", "2024-13-01", time.Time{}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTimeFilter(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTimeFilter(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.approx {
+				if diff := got.Sub(tt.want); diff < -time.Minute || diff > time.Minute {
+					t.Errorf("parseTimeFilter(%q) = %v, want about %v", tt.value, got, tt.want)
+				}
+			} else if !got.Equal(tt.want) {
+				t.Errorf("parseTimeFilter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package cli
 
 import (
 	"fmt"
+	"os"
 
+	"github.com/alexandrelam/openscribe/internal/config"
 	"github.com/alexandrelam/openscribe/internal/logging"
 	"github.com/spf13/cobra"
 )
@@ -12,6 +14,14 @@ var logtestCmd = &cobra.Command{
 	Short:  "Test logging functionality (for development)",
 	Hidden: true, // Hide from main help
 	Run: func(_ *cobra.Command, _ []string) {
+		// Write encrypted entries when encrypt_log is on
+		if cfg, err := config.Load(); err == nil {
+			if _, err := unlockLog(cfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
 		fmt.Println("Creating test transcription logs...")
 
 		testEntries := []struct {
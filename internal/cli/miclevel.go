@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/audio"
+	"github.com/alexandrelam/openscribe/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// Meter display range in dBFS; anything quieter shows an empty bar
+const (
+	meterFloorDB = -60.0
+	meterWidth   = 40
+)
+
+var micLevelCmd = &cobra.Command{
+	Use:    "mic-level",
+	Short:  "Show a live audio level meter for the microphone",
+	Long:   `Displays a live ASCII VU meter for the selected microphone so you can check it is picking up sound. Press Ctrl+C to stop.`,
+	Hidden: true, // Hidden command for testing purposes
+	Run: func(cmd *cobra.Command, _ []string) {
+		micName, _ := cmd.Flags().GetString("microphone")
+		runMicLevel(micName)
+	},
+}
+
+func runMicLevel(micName string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if micName != "" {
+		cfg.PreferredMicrophones = []string{micName}
+	}
+
+	device, err := audio.SelectMicrophone(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error selecting microphone: %v\n", err)
+		os.Exit(1)
+	}
+
+	recorder := audio.NewRecorder(device.Name, cfg.AudioBackend)
+	if err := recorder.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error starting recording: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Microphone: %s\n", device.Name)
+	fmt.Println("Speak to test the level. Press Ctrl+C to stop.")
+	fmt.Println()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sigChan:
+			fmt.Println()
+			if _, err := recorder.Stop(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error stopping recording: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case <-ticker.C:
+			fmt.Printf("\r%s", renderLevelMeter(recorder.Level()))
+		}
+	}
+}
+
+// renderLevelMeter draws a normalized RMS level as a dBFS-scaled ASCII bar
+func renderLevelMeter(level float64) string {
+	db := -120.0
+	if level > 0 {
+		db = 20 * math.Log10(level)
+	}
+
+	filled := int(math.Round((db - meterFloorDB) / -meterFloorDB * meterWidth))
+	filled = max(0, min(filled, meterWidth))
+
+	return fmt.Sprintf("[%s%s] %6.1f dBFS", strings.Repeat("#", filled), strings.Repeat("-", meterWidth-filled), db)
+}
+
+func init() {
+	rootCmd.AddCommand(micLevelCmd)
+
+	micLevelCmd.Flags().StringP("microphone", "m", "", "Microphone to test (defaults to the configured selection)")
+}
@@ -1,10 +1,16 @@
 package cli
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"time"
+	"os/signal"
+	"strings"
+	"syscall"
 
+	"github.com/alexandrelam/openscribe/internal/config"
 	"github.com/alexandrelam/openscribe/internal/models"
 	"github.com/spf13/cobra"
 )
@@ -32,8 +38,15 @@ var modelsListCmd = &cobra.Command{
 var modelsDownloadCmd = &cobra.Command{
 	Use:   "download [model]",
 	Short: "Download a specific model",
-	Long:  `Download a specific model. Use --backend to select whisper (default) or moonshine.`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Download a specific model. Use --backend to select whisper (default) or moonshine.
+Use --parallel to fetch a Whisper model over several connections, which can be
+faster for the large models; servers without range support fall back to one stream.
+
+Examples:
+  openscribe models download small
+  openscribe models download large --parallel 4
+  openscribe models download --backend moonshine base`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) == 0 {
 			backend, _ := cmd.Flags().GetString("backend")
@@ -41,7 +54,7 @@ var modelsDownloadCmd = &cobra.Command{
 				fmt.Println("Please specify a model to download (tiny, base)")
 				fmt.Println("\nExample: openscribe models download --backend moonshine base")
 			} else {
-				fmt.Println("Please specify a model to download (tiny, base, small, medium, large)")
+				fmt.Println("Please specify a model to download (tiny, base, small, medium, large-turbo, large, or a quantized variant like small-q5_1)")
 				fmt.Println("\nExample: openscribe models download small")
 			}
 			return
@@ -50,22 +63,77 @@ var modelsDownloadCmd = &cobra.Command{
 		if backend == "moonshine" {
 			downloadMoonshineModel(args[0])
 		} else {
-			downloadModel(args[0])
+			parallel, _ := cmd.Flags().GetInt("parallel")
+			downloadModel(args[0], parallel)
 		}
 	},
 }
 
+var modelsDeleteCmd = &cobra.Command{
+	Use:     "delete <model>",
+	Aliases: []string{"remove", "rm"},
+	Short:   "Delete a downloaded model",
+	Long: `Delete a downloaded Whisper model to free disk space.
+Deleting the model currently set in your configuration requires --force,
+since 'openscribe start' would fail until another model is configured or downloaded.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		force, _ := cmd.Flags().GetBool("force")
+		yes, _ := cmd.Flags().GetBool("yes")
+		deleteModel(args[0], force, yes)
+	},
+}
+
+var modelsVerifyCmd = &cobra.Command{
+	Use:   "verify [model]",
+	Short: "Verify downloaded models",
+	Long: `Check the size and checksum of a downloaded Whisper model, or of all downloaded models.
+Exits with a non-zero status if any model fails verification.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		verifyModels(args)
+	},
+}
+
+var modelsRecommendCmd = &cobra.Command{
+	Use:   "recommend",
+	Short: "Recommend a model for this machine",
+	Long: `Suggest a Whisper model based on this machine's memory and CPU cores.
+Larger models are more accurate but slower and need more RAM.`,
+	Args: cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		recommendModel()
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(modelsCmd)
 	modelsCmd.AddCommand(modelsListCmd)
 	modelsCmd.AddCommand(modelsDownloadCmd)
+	modelsCmd.AddCommand(modelsDeleteCmd)
+	modelsCmd.AddCommand(modelsVerifyCmd)
+	modelsCmd.AddCommand(modelsRecommendCmd)
+
+	modelsDeleteCmd.Flags().Bool("force", false, "Allow deleting the currently configured model")
+	modelsDeleteCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
 
 	// Add --backend flag to subcommands
 	modelsListCmd.Flags().String("backend", "whisper", "Backend to list models for (whisper or moonshine)")
 	modelsDownloadCmd.Flags().String("backend", "whisper", "Backend to download models for (whisper or moonshine)")
+	modelsDownloadCmd.Flags().Int("parallel", 1, fmt.Sprintf("Number of concurrent connections for Whisper models (1-%d)", models.MaxConnections))
 }
 
 func listModels() {
+	if jsonOutput {
+		statuses, err := models.ListModelStatus()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error checking downloaded models: %v\n", err)
+			os.Exit(1)
+		}
+		printJSON(statuses)
+		return
+	}
+
 	fmt.Println("Available Whisper Models:")
 	fmt.Println()
 
@@ -80,16 +148,42 @@ func listModels() {
 		downloadedMap[model] = true
 	}
 
-	modelOrder := []models.ModelSize{models.Tiny, models.Base, models.Small, models.Medium, models.Large}
+	for _, modelName := range models.ModelOrder {
+		info := models.AvailableModels[modelName]
+		status := " "
+		if downloadedMap[modelName] {
+			status = "✓"
+		}
 
-	for _, modelName := range modelOrder {
+		fmt.Printf("  [%s] %-12s %s\n", status, info.Name, info.Description)
+	}
+
+	fmt.Println()
+	fmt.Println("English-only Models (faster and more accurate for English):")
+	fmt.Println()
+
+	for _, modelName := range models.EnglishModelOrder {
 		info := models.AvailableModels[modelName]
 		status := " "
 		if downloadedMap[modelName] {
 			status = "✓"
 		}
 
-		fmt.Printf("  [%s] %-8s %s\n", status, info.Name, info.Description)
+		fmt.Printf("  [%s] %-12s %s\n", status, info.Name, info.Description)
+	}
+
+	fmt.Println()
+	fmt.Println("Quantized Models (lower memory use, minimal accuracy loss):")
+	fmt.Println()
+
+	for _, modelName := range models.QuantizedModelOrder {
+		info := models.AvailableModels[modelName]
+		status := " "
+		if downloadedMap[modelName] {
+			status = "✓"
+		}
+
+		fmt.Printf("  [%s] %-12s %s\n", status, info.Name, info.Description)
 	}
 
 	fmt.Println()
@@ -102,6 +196,16 @@ func listModels() {
 }
 
 func listMoonshineModels() {
+	if jsonOutput {
+		statuses, err := models.ListMoonshineModelStatus()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error checking downloaded models: %v\n", err)
+			os.Exit(1)
+		}
+		printJSON(statuses)
+		return
+	}
+
 	fmt.Println("Available Moonshine Models:")
 	fmt.Println()
 
@@ -116,9 +220,7 @@ func listMoonshineModels() {
 		downloadedMap[model] = true
 	}
 
-	modelOrder := []models.MoonshineModelSize{models.MoonshineTiny, models.MoonshineBase, models.MoonshineSmallStreaming, models.MoonshineMediumStreaming}
-
-	for _, modelName := range modelOrder {
+	for _, modelName := range models.MoonshineModelOrder {
 		info := models.AvailableMoonshineModels[modelName]
 		status := " "
 		if downloadedMap[modelName] {
@@ -137,12 +239,16 @@ func listMoonshineModels() {
 	}
 }
 
-func downloadModel(modelName string) {
+func downloadModel(modelName string, parallel int) {
 	model, err := models.ParseModelSize(modelName)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	if err := validateParallel(parallel); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	isDownloaded, err := models.IsModelDownloaded(model)
 	if err != nil {
@@ -159,35 +265,7 @@ func downloadModel(modelName string) {
 	fmt.Printf("Downloading %s model (%d MB)...\n", modelInfo.Name, modelInfo.SizeMB)
 	fmt.Println()
 
-	startTime := time.Now()
-
-	progressCallback := func(downloaded, total int64, percent float64) {
-		elapsed := time.Since(startTime).Seconds()
-		bytesPerSecond := float64(downloaded) / elapsed
-
-		barWidth := 40
-		filled := int(percent / 100.0 * float64(barWidth))
-		bar := ""
-		for i := 0; i < barWidth; i++ {
-			if i < filled {
-				bar += "="
-			} else if i == filled {
-				bar += ">"
-			} else {
-				bar += " "
-			}
-		}
-
-		downloadedStr := models.FormatBytes(downloaded)
-		totalStr := models.FormatBytes(total)
-		speedStr := models.FormatSpeed(bytesPerSecond)
-		eta := models.EstimateTimeRemaining(downloaded, total, bytesPerSecond)
-
-		fmt.Printf("\r[%s] %.1f%% - %s / %s - %s - ETA: %s",
-			bar, percent, downloadedStr, totalStr, speedStr, eta)
-	}
-
-	if err := models.DownloadModel(model, progressCallback); err != nil {
+	if err := downloadWithProgress(model, parallel, ""); err != nil {
 		fmt.Fprintf(os.Stderr, "\n\nError downloading model: %v\n", err)
 		os.Exit(1)
 	}
@@ -200,6 +278,37 @@ func downloadModel(modelName string) {
 	fmt.Printf("  Location: %s\n", modelPath)
 }
 
+// downloadWithProgress downloads a Whisper model over the given number of connections,
+// drawing a progress bar prefixed by indent on the current line. Ctrl+C cancels the download.
+func downloadWithProgress(model models.ModelSize, connections int, indent string) error {
+	progressCallback := func(downloaded, total int64, percent, bytesPerSecond float64) {
+		fmt.Printf("\r%s%s", indent, models.RenderProgressBar(downloaded, total, percent, bytesPerSecond))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := models.DownloadModelWithOptions(ctx, model, models.DownloadOptions{Connections: connections}, progressCallback)
+	exitIfCancelled(err)
+	return err
+}
+
+// validateParallel checks the --parallel connection count
+func validateParallel(parallel int) error {
+	if parallel < 1 || parallel > models.MaxConnections {
+		return fmt.Errorf("--parallel must be between 1 and %d", models.MaxConnections)
+	}
+	return nil
+}
+
+// exitIfCancelled exits when a download was cancelled with Ctrl+C
+func exitIfCancelled(err error) {
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintln(os.Stderr, "\n\nDownload cancelled.")
+		os.Exit(1)
+	}
+}
+
 func downloadMoonshineModel(modelName string) {
 	model, err := models.ParseMoonshineModelSize(modelName)
 	if err != nil {
@@ -222,33 +331,15 @@ func downloadMoonshineModel(modelName string) {
 	fmt.Printf("Downloading Moonshine %s model (%d files)...\n", info.Name, len(info.RequiredFiles))
 	fmt.Println()
 
-	startTime := time.Now()
-
-	progressCallback := func(downloaded, total int64, percent float64) {
-		elapsed := time.Since(startTime).Seconds()
-		if elapsed == 0 {
-			elapsed = 0.001
-		}
-		bytesPerSecond := float64(downloaded) / elapsed
-
-		barWidth := 40
-		filled := int(percent / 100.0 * float64(barWidth))
-		bar := ""
-		for i := 0; i < barWidth; i++ {
-			if i < filled {
-				bar += "="
-			} else if i == filled {
-				bar += ">"
-			} else {
-				bar += " "
-			}
-		}
-
-		speedStr := models.FormatSpeed(bytesPerSecond)
-		fmt.Printf("\r[%s] %.1f%% - %s", bar, percent, speedStr)
+	progressCallback := func(downloaded, total int64, percent, bytesPerSecond float64) {
+		fmt.Printf("\r%s", models.RenderProgressBar(downloaded, total, percent, bytesPerSecond))
 	}
 
-	if err := models.DownloadMoonshineModel(model, progressCallback); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := models.DownloadMoonshineModel(ctx, model, progressCallback); err != nil {
+		exitIfCancelled(err)
 		fmt.Fprintf(os.Stderr, "\n\nError downloading moonshine model: %v\n", err)
 		os.Exit(1)
 	}
@@ -260,3 +351,148 @@ func downloadMoonshineModel(modelName string) {
 	modelDir, _ := models.GetMoonshineModelDir(model)
 	fmt.Printf("  Location: %s\n", modelDir)
 }
+
+func deleteModel(modelName string, force, yes bool) {
+	model, err := models.ParseModelSize(modelName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	modelPath, err := models.GetModelPath(model)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	info, err := os.Stat(modelPath)
+	if os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: model '%s' is not downloaded\n", modelName)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking model: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Refuse to delete the configured model unless forced
+	if cfg, cfgErr := config.Load(); cfgErr == nil && (cfg.Backend == "" || cfg.Backend == "whisper") && cfg.Model == modelName {
+		if !force {
+			fmt.Fprintf(os.Stderr, "Error: '%s' is the model in your configuration; 'openscribe start' will fail without it.\n", modelName)
+			fmt.Fprintf(os.Stderr, "Switch models first (openscribe config --set-model <model>) or re-run with --force.\n")
+			os.Exit(1)
+		}
+		fmt.Printf("⚠️  '%s' is your configured model. 'openscribe start' will fail until you download it again or switch models.\n", modelName)
+	}
+
+	if !yes && !confirm(fmt.Sprintf("Delete %s model (%s)?", modelName, models.FormatBytes(info.Size()))) {
+		fmt.Println("Aborted.")
+		return
+	}
+
+	if err := models.DeleteModel(model); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✓ Deleted model '%s', freed %s\n", modelName, models.FormatBytes(info.Size()))
+}
+
+func verifyModels(args []string) {
+	var toVerify []models.ModelSize
+	if len(args) == 1 {
+		model, err := models.ParseModelSize(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		toVerify = []models.ModelSize{model}
+	} else {
+		downloaded, err := models.ListDownloadedModels()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error checking downloaded models: %v\n", err)
+			os.Exit(1)
+		}
+		if len(downloaded) == 0 {
+			fmt.Println("No models downloaded yet. Run 'openscribe setup' or 'openscribe models download <model>'")
+			return
+		}
+		toVerify = downloaded
+	}
+
+	fmt.Println("Verifying models (this can take a while for large models)...")
+	fmt.Println()
+
+	results := models.VerifyModels(toVerify)
+	for _, r := range results {
+		status := "✓ PASS"
+		detail := ""
+		switch {
+		case r.Err != nil:
+			status = "✗ FAIL"
+			detail = "  " + r.Err.Error()
+		case r.SizeOnly:
+			status = "? SIZE"
+			detail = "  size only, no known checksum (re-download to record one)"
+		}
+		fmt.Printf("  %s  %-12s %10s%s\n", status, r.Model, models.FormatBytes(r.Size), detail)
+	}
+	fmt.Println()
+
+	if failed := models.CountFailed(results); failed > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d models failed verification. Delete and re-download them with 'openscribe models delete <model>' and 'openscribe models download <model>'.\n", failed, len(results))
+		os.Exit(1)
+	}
+	sizeOnly := 0
+	for _, r := range results {
+		if r.SizeOnly {
+			sizeOnly++
+		}
+	}
+	if sizeOnly > 0 {
+		fmt.Printf("All %d models passed, but %d could only be checked by size (no known checksum).\n", len(results), sizeOnly)
+		return
+	}
+	fmt.Printf("All %d models passed verification.\n", len(results))
+}
+
+// confirm asks a yes/no question on stdin, defaulting to no
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// recommendModel prints the model suggested for this machine and how to get it
+func recommendModel() {
+	model, reason := models.RecommendModel()
+	downloaded, err := models.IsModelDownloaded(model)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking model: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		printJSON(struct {
+			Model      models.ModelSize `json:"model"`
+			Reason     string           `json:"reason"`
+			Downloaded bool             `json:"downloaded"`
+		}{model, reason, downloaded})
+		return
+	}
+
+	info := models.AvailableModels[model]
+	fmt.Printf("Recommended model: %s (%d MB)\n", model, info.SizeMB)
+	fmt.Printf("  %s\n", reason)
+	fmt.Println()
+	if downloaded {
+		fmt.Println("✓ Already downloaded")
+	} else {
+		fmt.Printf("Download it with: openscribe models download %s\n", model)
+	}
+	fmt.Printf("Use it with: openscribe config --set-model %s\n", model)
+}
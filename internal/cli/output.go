@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alexandrelam/openscribe/internal/progress"
+	"github.com/spf13/cobra"
+)
+
+// jsonOutput is set by the global --json flag. Commands that support it
+// print their data with printJSON instead of the human-readable format.
+var jsonOutput bool
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v interface{}) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// newTranscribeSpinner returns the spinner shown on out while transcribing; it stays
+// off with --no-progress or when out isn't a terminal
+func newTranscribeSpinner(cmd *cobra.Command, out io.Writer) *progress.Spinner {
+	spinner := progress.New(out, "Transcribing...")
+	if noProgress, _ := cmd.Flags().GetBool("no-progress"); noProgress {
+		spinner.Enabled = false
+	}
+	return spinner
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+	"github.com/alexandrelam/openscribe/internal/logging"
+	"golang.org/x/term"
+)
+
+// logPassphraseEnv supplies the transcription log passphrase without a prompt
+const logPassphraseEnv = "OPENSCRIBE_LOG_PASSPHRASE"
+
+// unlockLog sets the passphrase of the encrypted transcription log when encrypt_log is on
+// and checks it against the existing entries. Returns the passphrase ("" when encryption is off).
+func unlockLog(cfg *config.Config) (string, error) {
+	if !cfg.EncryptLog {
+		return "", nil
+	}
+	passphrase, err := readLogPassphrase()
+	if err != nil {
+		return "", err
+	}
+	if err := logging.SetPassphrase(passphrase); err != nil {
+		return "", err
+	}
+	if _, err := logging.CountTranscriptionHistory(); err != nil {
+		return "", err
+	}
+	return passphrase, nil
+}
+
+// readLogPassphrase returns OPENSCRIBE_LOG_PASSPHRASE, or asks for the passphrase on the terminal without echoing it
+func readLogPassphrase() (string, error) {
+	if passphrase := os.Getenv(logPassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("the transcription log is encrypted: set %s or run from a terminal to enter the passphrase", logPassphraseEnv)
+	}
+	fmt.Fprint(os.Stderr, "Transcription log passphrase: ")
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	if len(passphrase) == 0 {
+		return "", errors.New("the passphrase must not be empty")
+	}
+	return string(passphrase), nil
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// errNoInput is returned by prompter when the input ends before an answer is given
+var errNoInput = errors.New("no input (setup cancelled)")
+
+// prompter asks questions on out and reads the answers from in, one per line.
+// Tests drive it with a scripted reader.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// newPrompter creates a prompter reading answers from in
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{in: bufio.NewReader(in), out: out}
+}
+
+// readLine reads one trimmed answer
+func (p *prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && line == "" {
+		if errors.Is(err, io.EOF) {
+			return "", errNoInput
+		}
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// ask asks a free-form question; an empty answer returns def
+func (p *prompter) ask(question, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+	answer, err := p.readLine()
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// choose lists options and asks for one by number, re-asking until the answer is valid.
+// An empty answer returns def. Returns the index of the chosen option.
+func (p *prompter) choose(question string, options []string, def int) (int, error) {
+	for i, option := range options {
+		marker := "  "
+		if i == def {
+			marker = "> "
+		}
+		fmt.Fprintf(p.out, "  %s%d. %s\n", marker, i+1, option)
+	}
+	for {
+		answer, err := p.ask(question, strconv.Itoa(def+1))
+		if err != nil {
+			return 0, err
+		}
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+		fmt.Fprintf(p.out, "Please enter a number between 1 and %d.\n", len(options))
+	}
+}
+
+// confirm asks a yes/no question; an empty answer returns def
+func (p *prompter) confirm(question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	fmt.Fprintf(p.out, "%s [%s]: ", question, hint)
+	answer, err := p.readLine()
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "":
+		return def, nil
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/audio"
+	"github.com/alexandrelam/openscribe/internal/config"
+	"github.com/alexandrelam/openscribe/internal/keyboard"
+	"github.com/alexandrelam/openscribe/internal/logging"
+	"github.com/alexandrelam/openscribe/internal/pipeline"
+	"github.com/alexandrelam/openscribe/internal/transcription"
+	"github.com/spf13/cobra"
+)
+
+var recordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "Record for a fixed duration and transcribe",
+	Long: `Record from the microphone for a fixed number of seconds, then transcribe.
+
+Unlike start, no hotkey is needed: recording begins immediately. The text is
+printed to stdout (status messages go to stderr) and pasted at the cursor when
+auto-paste is enabled, so the command can be used from scripts.
+
+Examples:
+  openscribe record --duration 10
+  openscribe record -d 5 --no-paste --language fr > note.txt`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		runRecord(cmd)
+	},
+}
+
+func runRecord(cmd *cobra.Command) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	applyStartOverrides(cmd, cfg)
+
+	seconds, _ := cmd.Flags().GetInt("duration")
+	duration := time.Duration(seconds) * time.Second
+	if seconds <= 0 || duration > cfg.MaxRecordingDuration() {
+		fmt.Fprintf(os.Stderr, "Error: --duration must be between 1 and %d seconds (max_recording_seconds)\n", cfg.MaxRecordingSeconds)
+		os.Exit(1)
+	}
+
+	selectedDevice, err := audio.SelectMicrophone(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error selecting microphone: %v\n", err)
+		os.Exit(1)
+	}
+
+	transcriber, _, modelSize, _ := prepareTranscriber(cfg)
+
+	pasteMode, err := keyboard.ParsePasteMode(cfg.PasteMode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	logging.SetRotation(logging.RotationFromConfig(cfg))
+	logging.SetEnabled(cfg.LoggingEnabled)
+	if cfg.LoggingEnabled {
+		if _, err := unlockLog(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	var kb keyboard.Keyboard
+	if cfg.AutoPaste {
+		kb, err = keyboard.NewWithOptions(keyboard.OptionsFromConfig(cfg))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Failed to initialize keyboard simulation: %v\n", err)
+			os.Exit(1)
+		}
+		defer func() {
+			if err := kb.Close(); err != nil && cfg.Verbose {
+				fmt.Fprintf(os.Stderr, "Warning: Failed to close keyboard: %v\n", err)
+			}
+		}()
+
+		// Without accessibility permissions, copy to the clipboard instead (needs none)
+		if mode, fellBack := keyboard.FallbackPasteMode(kb, pasteMode); fellBack {
+			pasteMode = mode
+			fmt.Fprintf(os.Stderr, "⚠️  Accessibility permissions not granted: the text will be copied to the clipboard (grant Accessibility to auto-paste)\n")
+		}
+	}
+
+	recorder := newRecorder(cfg, selectedDevice.Name)
+	fmt.Fprintf(os.Stderr, "🔴 Recording for %ds from %s...\n", seconds, selectedDevice.Name)
+	audioData, err := recorder.RecordDuration(duration)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error recording audio: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprintln(os.Stderr, "⏹  Recording stopped. Transcribing...")
+
+	p := &pipeline.Pipeline{
+		Config:      cfg,
+		Transcriber: transcriber,
+		Model:       modelSize,
+		Timeout:     transcribeTimeoutFlag(cmd),
+		Keyboard:    kb,
+		PasteMode:   pasteMode,
+		Progress:    newTranscribeSpinner(cmd, os.Stderr),
+		Out:         os.Stderr,
+	}
+	result, err := p.Process(pipeline.Recording{
+		Audio:      audioData,
+		SampleRate: recorder.GetSampleRate(),
+		Channels:   recorder.GetChannels(),
+		Duration:   recorder.Duration().Seconds(),
+	})
+	if errors.Is(err, pipeline.ErrNoAudio) || errors.Is(err, pipeline.ErrNoSpeech) {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", err)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		printJSON(result)
+		return
+	}
+	fmt.Println(result.Text)
+}
+
+func init() {
+	rootCmd.AddCommand(recordCmd)
+
+	recordCmd.Flags().IntP("duration", "d", 10, "Recording length in seconds")
+	recordCmd.Flags().String("model", "", "Override model selection")
+	recordCmd.Flags().StringP("language", "l", "", "Override language setting")
+	recordCmd.Flags().Bool("no-paste", false, "Only print the text, don't paste it")
+	recordCmd.Flags().Bool("no-progress", false, "Don't show a spinner while transcribing")
+	recordCmd.Flags().Duration("transcribe-timeout", transcription.DefaultTimeout, "Kill whisper-cli if the transcription takes longer than this")
+}
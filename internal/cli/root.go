@@ -19,6 +19,10 @@ and automatically pastes the transcribed text at the current cursor position.`,
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
+	if err := registerCompletions(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
@@ -26,5 +30,6 @@ func Execute() {
 }
 
 func init() {
-	// Global flags can be added here
+	// Global flags
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output JSON instead of text (config --show, models list, logs show, status)")
 }
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/audio"
+	"github.com/alexandrelam/openscribe/internal/config"
+	"github.com/alexandrelam/openscribe/internal/pipeline"
+	"github.com/alexandrelam/openscribe/internal/transcription"
+	"github.com/spf13/cobra"
+)
+
+var testCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Record a few seconds and transcribe them (end-to-end check)",
+	Long: `Record from the microphone for a few seconds and transcribe the audio with the
+configured backend and model, then print the recognized text and how long each step took.
+
+This checks the whole chain (microphone, model, whisper-cli or other backend) in one
+go. Nothing is pasted or added to the transcription history.
+
+Examples:
+  openscribe test
+  openscribe test --duration 5 --model base --language en`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		runTest(cmd)
+	},
+}
+
+func runTest(cmd *cobra.Command) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	applyStartOverrides(cmd, cfg)
+
+	seconds, _ := cmd.Flags().GetInt("duration")
+	duration := time.Duration(seconds) * time.Second
+	if seconds <= 0 || duration > cfg.MaxRecordingDuration() {
+		fmt.Fprintf(os.Stderr, "Error: --duration must be between 1 and %d seconds (max_recording_seconds)\n", cfg.MaxRecordingSeconds)
+		os.Exit(1)
+	}
+
+	selectedDevice, err := audio.SelectMicrophone(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error selecting microphone: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Same model and backend checks (and help) as start
+	transcriber, backend, modelSize, moonModel := prepareTranscriber(cfg)
+	backendDisplay := fmt.Sprintf("%s (%s)", backend, cfg.Model)
+	switch backend {
+	case "moonshine":
+		backendDisplay = fmt.Sprintf("%s (%s)", backend, moonModel)
+	case "openai":
+		backendDisplay = backend
+	}
+
+	p := &pipeline.Pipeline{
+		Config:      cfg,
+		Transcriber: transcriber,
+		Model:       modelSize,
+		Timeout:     transcribeTimeoutFlag(cmd),
+		Out:         os.Stderr,
+	}
+
+	fmt.Fprintf(os.Stderr, "🔴 Recording for %ds from %s... say something\n", seconds, selectedDevice.Name)
+	result, err := p.RoundTrip(newRecorder(cfg, selectedDevice.Name), duration)
+	if errors.Is(err, pipeline.ErrNoAudio) || errors.Is(err, pipeline.ErrNoSpeech) {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", err)
+		fmt.Fprintf(os.Stderr, "Check the microphone with: openscribe mic-level\n")
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		printJSON(result)
+		return
+	}
+	fmt.Printf("\nRecognized: %s\n\n", result.Text)
+	fmt.Printf("Backend:       %s\n", backendDisplay)
+	fmt.Printf("Language:      %s\n", result.Language)
+	fmt.Printf("Recorded:      %.1fs\n", result.Recorded.Seconds())
+	fmt.Printf("Transcription: %.2fs\n", result.Transcribe.Seconds())
+	fmt.Printf("\n✓ Microphone, model and backend are working\n")
+}
+
+func init() {
+	rootCmd.AddCommand(testCmd)
+
+	testCmd.Flags().IntP("duration", "d", 3, "Recording length in seconds")
+	testCmd.Flags().StringP("microphone", "m", "", "Override microphone selection")
+	testCmd.Flags().String("model", "", "Override model selection")
+	testCmd.Flags().StringP("language", "l", "", "Override language setting")
+	testCmd.Flags().Duration("transcribe-timeout", transcription.DefaultTimeout, "Kill whisper-cli if the transcription takes longer than this")
+}
@@ -3,8 +3,8 @@ package cli
 import (
 	"fmt"
 	"os"
-	"time"
 
+	"github.com/alexandrelam/openscribe/internal/audio"
 	"github.com/alexandrelam/openscribe/internal/config"
 	"github.com/alexandrelam/openscribe/internal/models"
 	"github.com/spf13/cobra"
@@ -17,24 +17,51 @@ var setupCmd = &cobra.Command{
 This command will:
   - Check for or download whisper.cpp
   - Compile whisper.cpp if needed
-  - Download the default small model
-  - Create necessary configuration directories`,
-	Run: func(_ *cobra.Command, _ []string) {
-		runSetup()
+  - Download a model (small unless --model is given) and make it the default
+  - Create necessary configuration directories
+  - Check that the microphone can be recorded (macOS asks for permission the first time)
+
+Examples:
+  openscribe setup
+  openscribe setup --model base
+  openscribe setup --model large --parallel 4
+  openscribe setup --skip-model`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		modelName, _ := cmd.Flags().GetString("model")
+		skipModel, _ := cmd.Flags().GetBool("skip-model")
+		parallel, _ := cmd.Flags().GetInt("parallel")
+
+		model, err := models.ParseModelSizeOrDefault(modelName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := validateParallel(parallel); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		runSetup(model, skipModel, parallel, !cmd.Flags().Changed("model"))
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(setupCmd)
+
+	setupCmd.Flags().String("model", string(models.DefaultModel), "Model to download and make the default (e.g., base, medium, small.en)")
+	setupCmd.Flags().Bool("skip-model", false, "Don't download a model now")
+	setupCmd.Flags().Int("parallel", 1, fmt.Sprintf("Number of concurrent connections for the model download (1-%d)", models.MaxConnections))
+	setupCmd.MarkFlagsMutuallyExclusive("model", "skip-model")
 }
 
-func runSetup() {
+// runSetup runs the setup steps; recommend prints the hardware-based model suggestion
+// when the user didn't pick a model explicitly
+func runSetup(model models.ModelSize, skipModel bool, parallel int, recommend bool) {
 	fmt.Println("OpenScribe Setup")
 	fmt.Println("================")
 	fmt.Println()
 
 	// Step 1: Ensure directories exist
-	fmt.Println("[1/4] Creating directories...")
+	fmt.Println("[1/5] Creating directories...")
 	if err := config.EnsureDirectories(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating directories: %v\n", err)
 		os.Exit(1)
@@ -43,7 +70,7 @@ func runSetup() {
 	fmt.Println()
 
 	// Step 2: Check for Homebrew
-	fmt.Println("[2/4] Checking for Homebrew...")
+	fmt.Println("[2/5] Checking for Homebrew...")
 	if err := models.CheckHomebrew(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		fmt.Println()
@@ -55,7 +82,7 @@ func runSetup() {
 	fmt.Println()
 
 	// Step 3: Check for whisper-cli
-	fmt.Println("[3/4] Checking for whisper-cpp...")
+	fmt.Println("[3/5] Checking for whisper-cpp...")
 
 	installed, err := models.IsWhisperCppInstalled()
 	if err != nil {
@@ -79,56 +106,73 @@ func runSetup() {
 	}
 	fmt.Println()
 
-	// Step 4: Download default model
-	fmt.Println("[4/4] Downloading default model (small)...")
+	// Step 4: Download the model
+	if skipModel {
+		fmt.Println("[4/5] Skipping model download (--skip-model)")
+		fmt.Println("  Download one later with: openscribe models download <model>")
+	} else {
+		setupModel(model, parallel)
+		if recommend {
+			if suggested, reason := models.RecommendModel(); suggested != model {
+				fmt.Printf("  Tip: %s\n", reason)
+				fmt.Printf("  Switch with: openscribe models download %s && openscribe config --set-model %s\n", suggested, suggested)
+			}
+		}
+	}
+
+	// Step 5: Check microphone access
+	fmt.Println()
+	fmt.Println("[5/5] Checking microphone access...")
+	backend := audio.BackendAuto
+	if cfg, err := config.Load(); err == nil {
+		backend = cfg.AudioBackend
+	}
+	if err := audio.CheckMicrophonePermission(backend); err != nil {
+		// Don't fail setup: the microphone may simply not be connected yet
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+		fmt.Println("  Recordings will be silent until this is fixed; run 'openscribe setup' again afterwards to re-check.")
+	} else {
+		fmt.Println("✓ Microphone is recording audio")
+	}
 
-	defaultModel := models.Small
-	isDownloaded, err := models.IsModelDownloaded(defaultModel)
+	// Final summary
+	fmt.Println()
+	fmt.Println("================")
+	fmt.Println("Setup Complete!")
+	fmt.Println("================")
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Println("  1. Configure your microphone (optional):")
+	fmt.Println("     $ openscribe config --list-microphones")
+	fmt.Println()
+	fmt.Println("  2. Start OpenScribe:")
+	fmt.Println("     $ openscribe start")
+	fmt.Println()
+	fmt.Println("  3. View available models:")
+	fmt.Println("     $ openscribe models list")
+	fmt.Println()
+}
+
+// setupModel downloads model if needed and makes it the configured default
+func setupModel(model models.ModelSize, parallel int) {
+	fmt.Printf("[4/5] Downloading model (%s)...\n", model)
+
+	isDownloaded, err := models.IsModelDownloaded(model)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error checking model: %v\n", err)
 		os.Exit(1)
 	}
 
 	if isDownloaded {
-		fmt.Println("✓ Model 'small' already downloaded")
-		modelPath, _ := models.GetModelPath(defaultModel)
+		fmt.Printf("✓ Model '%s' already downloaded\n", model)
+		modelPath, _ := models.GetModelPath(model)
 		fmt.Printf("  Location: %s\n", modelPath)
 	} else {
-		modelInfo := models.AvailableModels[defaultModel]
+		modelInfo := models.AvailableModels[model]
 		fmt.Printf("  Downloading %s model (%d MB)...\n", modelInfo.Name, modelInfo.SizeMB)
 		fmt.Println()
 
-		// Progress tracking
-		startTime := time.Now()
-		progressCallback := func(downloaded, total int64, percent float64) {
-			elapsed := time.Since(startTime).Seconds()
-			bytesPerSecond := float64(downloaded) / elapsed
-
-			// Calculate progress bar
-			barWidth := 40
-			filled := int(percent / 100.0 * float64(barWidth))
-			bar := ""
-			for i := 0; i < barWidth; i++ {
-				if i < filled {
-					bar += "="
-				} else if i == filled {
-					bar += ">"
-				} else {
-					bar += " "
-				}
-			}
-
-			// Format output
-			downloadedStr := models.FormatBytes(downloaded)
-			totalStr := models.FormatBytes(total)
-			speedStr := models.FormatSpeed(bytesPerSecond)
-			eta := models.EstimateTimeRemaining(downloaded, total, bytesPerSecond)
-
-			fmt.Printf("\r  [%s] %.1f%% - %s / %s - %s - ETA: %s",
-				bar, percent, downloadedStr, totalStr, speedStr, eta)
-		}
-
-		if err := models.DownloadModel(defaultModel, progressCallback); err != nil {
+		if err := downloadWithProgress(model, parallel, "  "); err != nil {
 			fmt.Fprintf(os.Stderr, "\n\nError downloading model: %v\n", err)
 			os.Exit(1)
 		}
@@ -143,28 +187,13 @@ func runSetup() {
 	if err != nil {
 		// Don't fail setup if config loading fails, just warn
 		fmt.Fprintf(os.Stderr, "Warning: Could not update config file: %v\n", err)
-	} else if cfg.Model != string(defaultModel) {
+	} else if cfg.Model != string(model) {
 		// Update the model in config to match what was downloaded
-		cfg.Model = string(defaultModel)
+		cfg.Model = string(model)
 		if saveErr := cfg.Save(); saveErr != nil {
 			fmt.Fprintf(os.Stderr, "Warning: Could not save updated config: %v\n", saveErr)
+		} else {
+			fmt.Printf("✓ Default model set to '%s'\n", model)
 		}
 	}
-
-	// Final summary
-	fmt.Println()
-	fmt.Println("================")
-	fmt.Println("Setup Complete!")
-	fmt.Println("================")
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Println("  1. Configure your microphone (optional):")
-	fmt.Println("     $ openscribe config --list-microphones")
-	fmt.Println()
-	fmt.Println("  2. Start OpenScribe:")
-	fmt.Println("     $ openscribe start")
-	fmt.Println()
-	fmt.Println("  3. View available models:")
-	fmt.Println("     $ openscribe models list")
-	fmt.Println()
 }
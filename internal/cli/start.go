@@ -1,78 +1,128 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
-	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/alexandrelam/openscribe/internal/audio"
 	"github.com/alexandrelam/openscribe/internal/config"
+	"github.com/alexandrelam/openscribe/internal/daemon"
 	"github.com/alexandrelam/openscribe/internal/hotkey"
 	"github.com/alexandrelam/openscribe/internal/keyboard"
 	"github.com/alexandrelam/openscribe/internal/logging"
 	"github.com/alexandrelam/openscribe/internal/models"
+	"github.com/alexandrelam/openscribe/internal/notify"
+	"github.com/alexandrelam/openscribe/internal/pipeline"
 	"github.com/alexandrelam/openscribe/internal/transcription"
 	"github.com/spf13/cobra"
 )
 
-const (
-	// MaxRecordingDuration is the maximum allowed recording duration (5 minutes)
-	MaxRecordingDuration = 5 * time.Minute
-	// RecordingTimeoutWarning is when we warn the user about timeout (4 minutes)
-	RecordingTimeoutWarning = 4 * time.Minute
-)
+// configReloadInterval is how often the config file is checked for changes
+const configReloadInterval = 2 * time.Second
+
+// shutdownTimeout bounds how long Ctrl+C waits for the last recording to be transcribed
+const shutdownTimeout = 15 * time.Second
 
 var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start the OpenScribe service",
 	Long: `Start OpenScribe and begin listening for trigger activation.
-Once started, double-press any configured trigger (default: Right Option) to start/stop recording.
-Triggers can be keyboard keys (e.g., Right Option) or mouse buttons (e.g., Forward Button).`,
+Once started, double-press any configured trigger (default: Right Option) to start/stop recording,
+press it once when trigger_mode is set to "single", or hold it to record when hotkey_mode is set to "hold".
+Triggers can be keyboard keys (e.g., Right Option) or mouse buttons (e.g., Forward Button).
+Edits to the config file are picked up while running: model, language, prompt, auto-paste,
+verbose output and triggers apply right away, microphone changes apply to the next recording.
+With --output stdout (or output_mode: stdout) each transcription is printed on its own line on
+stdout and status messages go to stderr, e.g. openscribe start --output stdout | tee notes.txt`,
 	Run: func(cmd *cobra.Command, _ []string) {
 		runStart(cmd)
 	},
 }
 
 func runStart(cmd *cobra.Command) {
-	// Load configuration
-	cfg, err := config.Load()
+	// Load configuration (the active profile unless --profile is given)
+	profile, _ := cmd.Flags().GetString("profile")
+	cfg, err := config.LoadProfile(profile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Apply command-line overrides
-	if cmd.Flags().Changed("microphone") {
-		micOverride, _ := cmd.Flags().GetString("microphone")
-		// When --microphone flag is used, it takes priority over preferences
-		// Prepend it to the preferences list
-		cfg.PreferredMicrophones = append([]string{micOverride}, cfg.PreferredMicrophones...)
+	// Run in the background: re-exec as a detached child with the same arguments
+	if runDaemon, _ := cmd.Flags().GetBool("daemon"); runDaemon && !daemon.IsChild() {
+		// The background process can't prompt: check the log passphrase here and pass it on
+		passphrase, err := unlockLog(cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if passphrase != "" {
+			_ = os.Setenv(logPassphraseEnv, passphrase)
+		}
+		startDaemon()
+		return
 	}
-	if cmd.Flags().Changed("backend") {
-		cfg.Backend, _ = cmd.Flags().GetString("backend")
+	if daemon.IsChild() {
+		defer func() {
+			if err := daemon.Cleanup(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}()
+	} else if pid, running, _ := daemon.Status(); running {
+		fmt.Fprintf(os.Stderr, "Error: OpenScribe is already running in the background (PID %d)\n", pid)
+		fmt.Fprintf(os.Stderr, "Stop it first with: openscribe stop\n")
+		os.Exit(1)
 	}
-	if cmd.Flags().Changed("model") {
-		modelOverride, _ := cmd.Flags().GetString("model")
-		// When backend is moonshine, --model sets the moonshine model
-		if cfg.Backend == "moonshine" {
-			cfg.MoonshineModel = modelOverride
-		} else {
-			cfg.Model = modelOverride
+
+	// Only one instance may listen for hotkeys, or every dictation would be pasted twice
+	if err := config.AcquireInstanceLock(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := config.ReleaseInstanceLock(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}()
+
+	// Apply command-line overrides
+	applyStartOverrides(cmd, cfg)
+	if cmd.Flags().Changed("threads") || cmd.Flags().Changed("paste-delay-ms") {
+		if err := cfg.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
 	}
-	if cmd.Flags().Changed("language") {
-		cfg.Language, _ = cmd.Flags().GetString("language")
+	outputMode, err := pipeline.ParseOutputMode(cfg.OutputMode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
-	if cmd.Flags().Changed("no-paste") {
-		noPaste, _ := cmd.Flags().GetBool("no-paste")
-		cfg.AutoPaste = !noPaste
+
+	// With stdout output only transcriptions go to stdout, so they can be piped;
+	// every status message goes to stderr
+	var out io.Writer = os.Stdout
+	if outputMode == pipeline.OutputStdout {
+		out = os.Stderr
 	}
-	if cmd.Flags().Changed("verbose") {
-		cfg.Verbose, _ = cmd.Flags().GetBool("verbose")
+
+	// Remove temporary recordings left behind by earlier runs
+	if retention := cfg.CacheRetention(); retention > 0 {
+		removed, err := config.CleanCache(retention)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to clean cache: %v\n", err)
+		} else if removed > 0 && cfg.Verbose {
+			fmt.Fprintf(out, "Removed %d old recording(s) from the cache\n", removed)
+		}
 	}
 
 	// Select the best available microphone based on preferences
@@ -82,6 +132,366 @@ func runStart(cmd *cobra.Command) {
 		os.Exit(1)
 	}
 
+	// Check the backend's model and create the transcriber
+	transcriber, backend, modelSize, moonModel := prepareTranscriber(cfg)
+
+	// Display current configuration
+	language := cfg.Language
+	if language == "" {
+		language = "auto-detect"
+	}
+
+	// Resolve how triggers control recording (toggle or hold)
+	hotkeyMode, err := hotkey.ParseMode(cfg.HotkeyMode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	triggerMode, err := hotkey.ParseTriggerMode(cfg.TriggerMode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Verb describing how a trigger toggles recording, for status messages
+	pressAction, readyAction := "double-press", "Double-press"
+	if triggerMode == hotkey.SinglePress {
+		pressAction, readyAction = "press", "Press"
+	}
+
+	// Format triggers for display
+	var triggersDisplay string
+	if len(cfg.Triggers) == 1 {
+		triggersDisplay = cfg.Triggers[0]
+	} else {
+		triggersDisplay = fmt.Sprintf("[%s]", cfg.Triggers[0])
+		for _, trigger := range cfg.Triggers[1:] {
+			triggersDisplay += fmt.Sprintf(" or [%s]", trigger)
+		}
+	}
+
+	fmt.Fprintf(out, "OpenScribe v%s Starting...\n", Version)
+	fmt.Fprintf(out, "  Build:           %s (%s)\n", GitCommit, BuildDate)
+	fmt.Fprintf(out, "  Backend:         %s\n", backend)
+	fmt.Fprintf(out, "  Microphone:      %s\n", selectedDevice.Name)
+	switch backend {
+	case "moonshine":
+		fmt.Fprintf(out, "  Model:           %s (moonshine)\n", moonModel)
+	case "openai":
+		om := cfg.OpenAIModel
+		if om == "" {
+			om = "gpt-4o-transcribe"
+		}
+		fmt.Fprintf(out, "  Model:           %s (openai)\n", om)
+	default:
+		fmt.Fprintf(out, "  Model:           %s\n", cfg.Model)
+	}
+	fmt.Fprintf(out, "  Language:        %s\n", language)
+	if cfg.Prompt != "" {
+		fmt.Fprintf(out, "  Prompt:          %q\n", cfg.Prompt)
+	}
+	triggerAction := pressAction
+	if hotkeyMode == hotkey.ModeHold {
+		triggerAction = "hold"
+	}
+	fmt.Fprintf(out, "  Triggers:        %s (%s)\n", triggersDisplay, triggerAction)
+	if outputMode == pipeline.OutputPaste {
+		fmt.Fprintf(out, "  Auto-paste:      %t\n", cfg.AutoPaste)
+		if cfg.AutoPaste && cfg.PasteDelayMs > 0 {
+			fmt.Fprintf(out, "  Paste Delay:     %s\n", cfg.PasteDelay())
+		}
+	} else {
+		fmt.Fprintf(out, "  Output:          %s\n", outputMode)
+	}
+	fmt.Fprintf(out, "  Audio Feedback:  %t\n", cfg.AudioFeedback)
+	if !cfg.LoggingEnabled {
+		fmt.Fprintln(out, "  Logging:         disabled")
+	}
+	fmt.Fprintln(out)
+
+	// Initialize audio feedback if enabled
+	var feedback audio.Feedback
+	if cfg.AudioFeedback {
+		var err error
+		feedback, err = audio.NewFeedback(audio.FeedbackOptionsFromConfig(cfg))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to initialize audio feedback: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Continuing without audio feedback...\n\n")
+		} else {
+			defer func() {
+				if err := feedback.Close(); err != nil && cfg.Verbose {
+					fmt.Fprintf(os.Stderr, "Warning: Failed to close audio feedback: %v\n", err)
+				}
+			}()
+		}
+	}
+
+	// Initialize keyboard simulation if auto-paste is enabled
+	pasteMode, err := keyboard.ParsePasteMode(cfg.PasteMode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	logging.SetRotation(logging.RotationFromConfig(cfg))
+	logging.SetEnabled(cfg.LoggingEnabled)
+	if cfg.LoggingEnabled {
+		if _, err := unlockLog(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	var kb keyboard.Keyboard
+	clipboardFallback := false // Copying instead of pasting, for lack of accessibility permissions
+	defer func() {
+		// kb may also be created later, when a config reload enables auto-paste
+		if kb == nil {
+			return
+		}
+		if err := kb.Close(); err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to close keyboard: %v\n", err)
+		}
+	}()
+	if cfg.AutoPaste && outputMode == pipeline.OutputPaste {
+		var err error
+		kb, err = keyboard.NewWithOptions(keyboard.OptionsFromConfig(cfg))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Failed to initialize keyboard simulation: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Without accessibility permissions, copy to the clipboard instead (needs none),
+		// unless --wait-permissions gives the user time to grant them
+		if mode, fellBack := keyboard.FallbackPasteMode(kb, pasteMode); fellBack {
+			keyboard.RequestPermissions()
+			if wait, _ := cmd.Flags().GetBool("wait-permissions"); !wait || !waitForPermissions(out, cmd, kb) {
+				pasteMode, clipboardFallback = mode, true
+				fmt.Fprintf(os.Stderr, "⚠️  Accessibility permissions not granted: transcriptions will be copied to the clipboard instead of pasted.\n")
+				fmt.Fprintf(os.Stderr, "   Grant them in System Preferences > Security & Privacy > Privacy > Accessibility,\n")
+				fmt.Fprintf(os.Stderr, "   then restart OpenScribe to auto-paste.\n\n")
+			}
+		}
+	}
+
+	// The recording state machine, driven by the trigger listener
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	session := &pipeline.Session{
+		Pipeline: &pipeline.Pipeline{
+			Config:       cfg,
+			Transcriber:  transcriber,
+			Model:        modelSize,
+			Timeout:      transcribeTimeoutFlag(cmd),
+			Output:       outputMode,
+			Keyboard:     kb,
+			PasteMode:    pasteMode,
+			Stdout:       os.Stdout,
+			Out:          out,
+			Feedback:     feedback,
+			Notifier:     notify.System{},
+			Progress:     newTranscribeSpinner(cmd, os.Stderr),
+			FrontmostApp: keyboard.FrontmostAppName,
+			DryRun:       dryRun,
+		},
+		// Called with the session locked, so reloads can switch the microphone safely
+		NewRecorder: func() pipeline.Recorder {
+			return newRecorder(cfg, selectedDevice.Name)
+		},
+		MaxDuration:  cfg.MaxRecordingDuration(),
+		WarningAfter: pipeline.WarningTime(cfg.MaxRecordingDuration()),
+		MinDuration:  cfg.MinRecordingDuration(),
+		StopHint:     stopHintFor(hotkeyMode, triggerMode),
+		OnResult: func(result *pipeline.Result, err error) {
+			printPipelineResult(out, cfg, outputMode, pasteMode, clipboardFallback, result, err)
+		},
+	}
+
+	// newTriggerListener creates and starts a listener for the given triggers
+	newTriggerListener := func(triggers []string, mode hotkey.Mode, trigger hotkey.TriggerMode) (*hotkey.MultiListener, error) {
+		var l *hotkey.MultiListener
+		var err error
+		if mode == hotkey.ModeHold {
+			l, err = hotkey.NewMultiHoldListener(triggers, session.HandlePress, session.HandleRelease)
+		} else {
+			l, err = hotkey.NewMultiListener(triggers, session.HandleToggle)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create trigger listener: %w", err)
+		}
+		l.SetTriggerMode(trigger)
+
+		if err := l.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start trigger listener: %w", err)
+		}
+		return l, nil
+	}
+
+	// Create and start multi-trigger listener (listenerMu guards swaps on reload)
+	var listenerMu sync.Mutex
+	listener, err := newTriggerListener(cfg.Triggers, hotkeyMode, triggerMode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "\nNote: Trigger detection requires accessibility permissions.\n")
+		fmt.Fprintf(os.Stderr, "Please grant accessibility permissions in System Preferences > Security & Privacy > Privacy > Accessibility\n")
+		os.Exit(1)
+	}
+	defer func() {
+		listenerMu.Lock()
+		current := listener
+		listenerMu.Unlock()
+		current.Stop()
+	}()
+
+	// reloadConfig applies config file changes that are safe to pick up while running.
+	// An invalid config is ignored and the current settings are kept.
+	reloadConfig := func() {
+		next, err := config.LoadProfile(profile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Config reload failed, keeping current settings: %v\n", err)
+			return
+		}
+		applyStartOverrides(cmd, next)
+		if err := next.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Invalid config, keeping current settings: %v\n", err)
+			return
+		}
+
+		// Only switch to a model that is ready to use
+		nextModelSize := session.Pipeline.Model
+		if backend == "whisper" && next.Model != cfg.Model {
+			size, err := models.ParseModelSize(next.Model)
+			if err == nil {
+				var downloaded bool
+				downloaded, err = models.IsModelDownloaded(size)
+				if err == nil && !downloaded {
+					err = fmt.Errorf("not downloaded (run: openscribe models download %s)", next.Model)
+				}
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  Keeping model '%s', cannot switch to '%s': %v\n", cfg.Model, next.Model, err)
+				next.Model = cfg.Model
+			} else {
+				nextModelSize = size
+			}
+		}
+
+		// Re-register triggers: start the new listener before stopping the old one
+		nextHotkeyMode, nextTriggerMode := hotkeyMode, triggerMode
+		if !slices.Equal(next.Triggers, cfg.Triggers) || next.HotkeyMode != cfg.HotkeyMode || next.TriggerMode != cfg.TriggerMode {
+			nextHotkeyMode, err = hotkey.ParseMode(next.HotkeyMode)
+			if err == nil {
+				nextTriggerMode, err = hotkey.ParseTriggerMode(next.TriggerMode)
+			}
+			var nextListener *hotkey.MultiListener
+			if err == nil {
+				nextListener, err = newTriggerListener(next.Triggers, nextHotkeyMode, nextTriggerMode)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  Keeping current triggers: %v\n", err)
+				next.Triggers, next.HotkeyMode, next.TriggerMode = cfg.Triggers, cfg.HotkeyMode, cfg.TriggerMode
+				nextHotkeyMode, nextTriggerMode = hotkeyMode, triggerMode
+			} else {
+				listenerMu.Lock()
+				previous := listener
+				listener = nextListener
+				listenerMu.Unlock()
+				previous.Stop()
+			}
+		}
+
+		// Apply settings between recordings; new recordings wait meanwhile
+		session.WhenIdle(func() {
+			applied, restart, err := cfg.ApplyReload(next)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  Config reload failed: %v\n", err)
+				return
+			}
+			session.Pipeline.Model = nextModelSize
+			hotkeyMode, triggerMode = nextHotkeyMode, nextTriggerMode
+			session.StopHint = stopHintFor(hotkeyMode, triggerMode)
+
+			for _, key := range applied {
+				switch key {
+				case "microphone", "preferred_microphones":
+					// Takes effect with the next recording
+					device, err := audio.SelectMicrophone(cfg)
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "⚠️  Keeping microphone %s: %v\n", selectedDevice.Name, err)
+					} else {
+						selectedDevice = device
+					}
+				case "auto_paste":
+					if cfg.AutoPaste && kb == nil && outputMode == pipeline.OutputPaste {
+						kb, err = keyboard.NewWithOptions(keyboard.OptionsFromConfig(cfg))
+						if err != nil {
+							fmt.Fprintf(os.Stderr, "⚠️  Auto-paste stays off, failed to initialize keyboard simulation: %v\n", err)
+							cfg.AutoPaste = false
+							kb = nil
+						} else if mode, fellBack := keyboard.FallbackPasteMode(kb, pasteMode); fellBack {
+							pasteMode, clipboardFallback = mode, true
+							session.Pipeline.PasteMode = mode
+							fmt.Fprintf(os.Stderr, "⚠️  Accessibility permissions not granted: transcriptions will be copied to the clipboard instead of pasted\n")
+						}
+						session.Pipeline.Keyboard = kb
+					}
+				case "max_recording_seconds":
+					// Takes effect with the next recording
+					session.MaxDuration = cfg.MaxRecordingDuration()
+					session.WarningAfter = pipeline.WarningTime(session.MaxDuration)
+				case "min_recording_ms":
+					session.MinDuration = cfg.MinRecordingDuration()
+				case "logging_enabled":
+					logging.SetEnabled(cfg.LoggingEnabled)
+				}
+			}
+
+			if len(applied) > 0 {
+				fmt.Fprintf(out, "🔄 Config reloaded: %s\n", strings.Join(applied, ", "))
+			}
+			if len(restart) > 0 {
+				fmt.Fprintf(out, "   Restart OpenScribe to apply: %s\n", strings.Join(restart, ", "))
+			}
+		})
+	}
+	// Watch the config file and reload it on change
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	go func() {
+		ticker := time.NewTicker(configReloadInterval)
+		defer ticker.Stop()
+		if err := config.Watch(watchCtx, ticker.C, reloadConfig); err != nil && cfg.Verbose {
+			fmt.Fprintf(os.Stderr, "Warning: Config reload disabled: %v\n", err)
+		}
+	}()
+
+	if hotkeyMode == hotkey.ModeHold {
+		fmt.Fprintln(out, "Ready! Hold any configured trigger to record, release to transcribe...")
+	} else {
+		fmt.Fprintf(out, "Ready! %s any configured trigger to start recording...\n", readyAction)
+	}
+	if dryRun {
+		fmt.Fprintln(out, "Dry run: transcriptions will be printed, not pasted or logged.")
+	}
+	fmt.Fprintln(out, "Press Ctrl+C to exit.")
+	fmt.Fprintln(out)
+
+	// Set up signal handling for graceful shutdown
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	// Wait for interrupt signal
+	<-sigChan
+
+	fmt.Fprintln(out, "\n\nShutting down...")
+
+	// Treat an interrupt during a recording like a final stop, so the audio isn't lost
+	if err := session.Shutdown(shutdownTimeout); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// prepareTranscriber checks that the configured backend is ready to use (model downloaded,
+// API key set) and creates its transcriber. Exits with instructions when it isn't.
+func prepareTranscriber(cfg *config.Config) (transcription.Transcriber, string, models.ModelSize, string) {
 	// Parse model size and check downloads based on backend
 	var modelSize models.ModelSize
 	var moonModel string
@@ -136,6 +546,14 @@ func runStart(cmd *cobra.Command) {
 			}
 			os.Exit(1)
 		}
+
+		// Quick size-only check; the full checksum runs after download
+		if err := models.ValidateModel(modelSize, false); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Model '%s' is invalid: %v\n", cfg.Model, err)
+			fmt.Fprintf(os.Stderr, "Delete it and download it again:\n")
+			fmt.Fprintf(os.Stderr, "  $ openscribe models download %s\n", cfg.Model)
+			os.Exit(1)
+		}
 	} else if backend == "moonshine" {
 		moonModel = cfg.MoonshineModel
 		if moonModel == "" {
@@ -177,557 +595,190 @@ func runStart(cmd *cobra.Command) {
 		os.Exit(1)
 	}
 
-	// Display current configuration
-	language := cfg.Language
-	if language == "" {
-		language = "auto-detect"
-	}
+	return transcriber, backend, modelSize, moonModel
+}
 
-	// Format triggers for display
-	var triggersDisplay string
-	if len(cfg.Triggers) == 1 {
-		triggersDisplay = cfg.Triggers[0]
-	} else {
-		triggersDisplay = fmt.Sprintf("[%s]", cfg.Triggers[0])
-		for _, trigger := range cfg.Triggers[1:] {
-			triggersDisplay += fmt.Sprintf(" or [%s]", trigger)
+// newRecorder creates a recorder for the named microphone on the configured audio_backend
+// that retries a failed device initialization device_init_attempts times, reporting
+// retries in verbose mode
+func newRecorder(cfg *config.Config, deviceName string) *audio.Recorder {
+	recorder := audio.NewRecorder(deviceName, cfg.AudioBackend)
+	attempts := cfg.DeviceInitAttempts
+	recorder.SetInitRetry(attempts, audio.DefaultInitBackoff, func(attempt int, err error) {
+		if cfg.Verbose {
+			reason, _, _ := strings.Cut(err.Error(), "\n")
+			fmt.Fprintf(os.Stderr, "⚠️  Microphone failed to initialize (attempt %d/%d), retrying: %s\n", attempt, attempts, reason)
 		}
-	}
+	})
+	return recorder
+}
 
-	fmt.Printf("OpenScribe v%s Starting...\n", Version)
-	fmt.Printf("  Build:           %s (%s)\n", GitCommit, BuildDate)
-	fmt.Printf("  Backend:         %s\n", backend)
-	fmt.Printf("  Microphone:      %s\n", selectedDevice.Name)
-	switch backend {
-	case "moonshine":
-		fmt.Printf("  Model:           %s (moonshine)\n", moonModel)
-	case "openai":
-		om := cfg.OpenAIModel
-		if om == "" {
-			om = "gpt-4o-transcribe"
-		}
-		fmt.Printf("  Model:           %s (openai)\n", om)
-	default:
-		fmt.Printf("  Model:           %s\n", cfg.Model)
+// stopHintFor describes how the user stops a recording, for status messages
+func stopHintFor(hotkeyMode hotkey.Mode, triggerMode hotkey.TriggerMode) string {
+	if hotkeyMode == hotkey.ModeHold {
+		return "release hotkey to stop"
 	}
-	fmt.Printf("  Language:        %s\n", language)
-	fmt.Printf("  Triggers:        %s (double-press)\n", triggersDisplay)
-	fmt.Printf("  Auto-paste:      %t\n", cfg.AutoPaste)
-	fmt.Printf("  Audio Feedback:  %t\n", cfg.AudioFeedback)
-	fmt.Println()
-
-	// Initialize audio feedback if enabled
-	var feedback audio.Feedback
-	if cfg.AudioFeedback {
-		var err error
-		feedback, err = audio.NewFeedback()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to initialize audio feedback: %v\n", err)
-			fmt.Fprintf(os.Stderr, "Continuing without audio feedback...\n\n")
-		} else {
-			defer func() {
-				if err := feedback.Close(); err != nil && cfg.Verbose {
-					fmt.Fprintf(os.Stderr, "Warning: Failed to close audio feedback: %v\n", err)
-				}
-			}()
-		}
+	if triggerMode == hotkey.SinglePress {
+		return "press hotkey again to stop"
 	}
+	return "double-press hotkey again to stop"
+}
 
-	// Initialize keyboard simulation if auto-paste is enabled
-	var kb keyboard.Keyboard
-	if cfg.AutoPaste {
-		var err error
-		kb, err = keyboard.New()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Failed to initialize keyboard simulation: %v\n", err)
-			os.Exit(1)
-		}
-		defer func() {
-			if err := kb.Close(); err != nil && cfg.Verbose {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to close keyboard: %v\n", err)
-			}
-		}()
+// transcribeTimeoutFlag returns the --transcribe-timeout flag value
+func transcribeTimeoutFlag(cmd *cobra.Command) time.Duration {
+	timeout, _ := cmd.Flags().GetDuration("transcribe-timeout")
+	return timeout
+}
 
-		// Check accessibility permissions
-		if err := kb.CheckPermissions(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Accessibility permissions not granted.\n\n")
-			fmt.Fprintf(os.Stderr, "Auto-paste requires accessibility permissions to simulate keyboard input.\n")
-			fmt.Fprintf(os.Stderr, "Please grant permissions in:\n")
-			fmt.Fprintf(os.Stderr, "  System Preferences > Security & Privacy > Privacy > Accessibility\n\n")
-			fmt.Fprintf(os.Stderr, "Add 'Terminal' (or your terminal app) to the list of allowed applications.\n\n")
-			fmt.Fprintf(os.Stderr, "Alternatively, run with --no-paste to disable auto-paste:\n")
-			fmt.Fprintf(os.Stderr, "  openscribe start --no-paste\n\n")
-
-			// Prompt user to grant permissions
-			fmt.Fprintf(os.Stderr, "Would you like to open System Preferences now? This will prompt for permissions.\n")
-			fmt.Fprintf(os.Stderr, "After granting permissions, please restart OpenScribe.\n\n")
-			keyboard.RequestPermissions()
-			os.Exit(1)
-		}
+// applyStartOverrides applies the start command's flags on top of the loaded configuration
+func applyStartOverrides(cmd *cobra.Command, cfg *config.Config) {
+	if cmd.Flags().Changed("microphone") {
+		micOverride, _ := cmd.Flags().GetString("microphone")
+		// When --microphone flag is used, it takes priority over preferences
+		// Prepend it to the preferences list
+		cfg.PreferredMicrophones = append([]string{micOverride}, cfg.PreferredMicrophones...)
 	}
-
-	// State management
-	var (
-		mu               sync.Mutex
-		isRecording      bool
-		isTranscribing   bool
-		recorder         *audio.Recorder
-		recordStart      time.Time
-		timeoutTimer     *time.Timer
-		warningTimer     *time.Timer
-		transcribingLock sync.Mutex // Separate lock for transcription state
-	)
-
-	// Create hotkey callback
-	hotkeyCallback := func() {
-		// Check if currently transcribing
-		transcribingLock.Lock()
-		if isTranscribing {
-			transcribingLock.Unlock()
-			fmt.Println("⚠️  Transcription in progress, please wait...")
-			return
-		}
-		transcribingLock.Unlock()
-
-		mu.Lock()
-		defer mu.Unlock()
-
-		if !isRecording {
-			// Start recording
-			isRecording = true
-			recordStart = time.Now()
-			fmt.Println("🔴 Recording started... (double-press hotkey again to stop)")
-			fmt.Printf("   Maximum recording time: %.0f minutes\n", MaxRecordingDuration.Minutes())
-
-			// Play start sound
-			if feedback != nil {
-				if err := feedback.PlayStartSound(); err != nil && cfg.Verbose {
-					fmt.Fprintf(os.Stderr, "Warning: Failed to play start sound: %v\n", err)
-				}
-			}
-
-			// Create and start recorder
-			recorder = audio.NewRecorder(selectedDevice.Name)
-			if err := recorder.Start(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error starting recording: %v\n", err)
-				isRecording = false
-				return
-			}
-
-			// Set up warning timer (4 minutes)
-			warningTimer = time.AfterFunc(RecordingTimeoutWarning, func() {
-				fmt.Printf("\n⚠️  Warning: Recording has been running for %.0f minutes\n", RecordingTimeoutWarning.Minutes())
-				fmt.Printf("   Will auto-stop in %.0f minute\n", (MaxRecordingDuration - RecordingTimeoutWarning).Minutes())
-			})
-
-			// Set up automatic timeout (5 minutes)
-			timeoutTimer = time.AfterFunc(MaxRecordingDuration, func() {
-				mu.Lock()
-
-				if !isRecording {
-					mu.Unlock()
-					return
-				}
-
-				fmt.Printf("\n⏱️  Recording automatically stopped after %.0f minutes (max duration)\n", MaxRecordingDuration.Minutes())
-				fmt.Println("⏹  Recording stopped. Transcribing...")
-
-				// Trigger the stop recording logic
-				isRecording = false
-				recordDuration := time.Since(recordStart).Seconds()
-				currentRecorder := recorder
-
-				mu.Unlock()
-
-				// Play stop sound
-				if feedback != nil {
-					if err := feedback.PlayStopSound(); err != nil && cfg.Verbose {
-						fmt.Fprintf(os.Stderr, "Warning: Failed to play stop sound: %v\n", err)
-					}
-				}
-
-				// Mark as transcribing
-				transcribingLock.Lock()
-				isTranscribing = true
-				transcribingLock.Unlock()
-
-				// Stop recorder and get audio data
-				audioData, err := currentRecorder.Stop()
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error stopping recording: %v\n", err)
-					transcribingLock.Lock()
-					isTranscribing = false
-					transcribingLock.Unlock()
-					return
-				}
-
-				if len(audioData) == 0 {
-					fmt.Fprintf(os.Stderr, "Warning: No audio data captured\n")
-					transcribingLock.Lock()
-					isTranscribing = false
-					transcribingLock.Unlock()
-					return
-				}
-
-				// Analyze audio levels
-				levelMetrics, err := audio.AnalyzeLevel(audioData, currentRecorder.GetSampleRate())
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: Failed to analyze audio level: %v\n", err)
-				} else {
-					// Display audio levels if verbose mode or ShowAudioLevels is enabled
-					if cfg.Verbose || cfg.ShowAudioLevels {
-						fmt.Printf("🔊 Audio level: %.1f dBFS (peak: %d)\n",
-							levelMetrics.DecibelsFS, levelMetrics.PeakAmplitude)
-					}
-
-					// Check if gain control is needed
-					if cfg.AutoGain && levelMetrics.DecibelsFS < cfg.MinThresholdDB {
-						fmt.Printf("⚠️  Low audio level detected (%.1f dBFS), applying gain...\n",
-							levelMetrics.DecibelsFS)
-
-						// Create gain control config
-						gainConfig := audio.GainControlConfig{
-							Enabled:         true,
-							TargetLevelDB:   cfg.TargetLevelDB,
-							MinThresholdDB:  cfg.MinThresholdDB,
-							MaxGainDB:       cfg.MaxGainDB,
-							PreventClipping: true,
-						}
-
-						// Apply gain control
-						processedAudio, gainResult, err := audio.ProcessAudioGain(audioData, levelMetrics, gainConfig)
-						if err != nil {
-							fmt.Fprintf(os.Stderr, "Warning: Failed to apply gain control: %v\n", err)
-						} else {
-							audioData = processedAudio
-							fmt.Printf("✓ Gain applied: +%.1f dB (level now: %.1f dBFS)\n",
-								gainResult.GainAppliedDB, gainResult.ResultingLevelDB)
-						}
-					} else if !cfg.AutoGain && levelMetrics.DecibelsFS < cfg.MinThresholdDB {
-						// Warn if audio is low but auto-gain is disabled
-						fmt.Printf("⚠️  Low audio level detected (%.1f dBFS). Consider increasing microphone volume or enabling auto_gain in config.\n",
-							levelMetrics.DecibelsFS)
-					}
-				}
-
-				// Save audio to temporary WAV file
-				cacheDir, err := config.GetCacheDir()
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error getting cache directory: %v\n", err)
-					transcribingLock.Lock()
-					isTranscribing = false
-					transcribingLock.Unlock()
-					return
-				}
-
-				// Ensure cache directory exists
-				if err := os.MkdirAll(cacheDir, 0755); err != nil {
-					fmt.Fprintf(os.Stderr, "Error creating cache directory: %v\n", err)
-					transcribingLock.Lock()
-					isTranscribing = false
-					transcribingLock.Unlock()
-					return
-				}
-
-				// Create temporary WAV file
-				timestamp := time.Now().Format("20060102_150405")
-				wavPath := filepath.Join(cacheDir, fmt.Sprintf("recording_%s.wav", timestamp))
-
-				if err := audio.SaveWAV(wavPath, audioData, currentRecorder.GetSampleRate(), currentRecorder.GetChannels()); err != nil {
-					fmt.Fprintf(os.Stderr, "Error saving audio file: %v\n", err)
-					transcribingLock.Lock()
-					isTranscribing = false
-					transcribingLock.Unlock()
-					return
-				}
-
-				if cfg.Verbose {
-					fmt.Printf("Audio saved to: %s\n", wavPath)
-				}
-
-				// Transcribe audio
-				opts := transcription.Options{
-					Model:    modelSize,
-					Language: cfg.Language,
-					Verbose:  cfg.Verbose,
-				}
-				result, err := transcriber.TranscribeFile(wavPath, opts)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error transcribing audio: %v\n", err)
-					_ = os.Remove(wavPath)
-					transcribingLock.Lock()
-					isTranscribing = false
-					transcribingLock.Unlock()
-					return
-				}
-
-				// Clean up WAV file (unless verbose mode)
-				if !cfg.Verbose {
-					_ = os.Remove(wavPath)
-				}
-
-				// Play complete sound
-				if feedback != nil {
-					if err := feedback.PlayCompleteSound(); err != nil && cfg.Verbose {
-						fmt.Fprintf(os.Stderr, "Warning: Failed to play complete sound: %v\n", err)
-					}
-				}
-
-				transcriptionText := result.Text
-				if transcriptionText == "" {
-					fmt.Println("⚠️  No speech detected in recording")
-					transcribingLock.Lock()
-					isTranscribing = false
-					transcribingLock.Unlock()
-					return
-				}
-
-				fmt.Printf("Transcription: \"%s\"\n", transcriptionText)
-
-				// Auto-paste if enabled
-				if cfg.AutoPaste && kb != nil {
-					if err := kb.PasteText(transcriptionText); err != nil {
-						fmt.Fprintf(os.Stderr, "Warning: Failed to paste text: %v\n", err)
-					} else {
-						fmt.Println("✅ Text pasted to cursor position!")
-					}
-				} else {
-					fmt.Println("✅ Transcription complete!")
-				}
-
-				// Log transcription
-				if err := logging.LogTranscription(recordDuration, cfg.Model, result.Language, transcriptionText); err != nil {
-					if cfg.Verbose {
-						fmt.Fprintf(os.Stderr, "Warning: Failed to log transcription: %v\n", err)
-					}
-				} else {
-					logPath, _ := config.GetTranscriptionLogPath()
-					timestamp := time.Now().Format("2006-01-02 15:04:05")
-					fmt.Printf("\n[%s] Logged to %s\n", timestamp, logPath)
-				}
-
-				// Clear transcribing flag
-				transcribingLock.Lock()
-				isTranscribing = false
-				transcribingLock.Unlock()
-			})
+	if cmd.Flags().Changed("backend") {
+		cfg.Backend, _ = cmd.Flags().GetString("backend")
+	}
+	if cmd.Flags().Changed("model") {
+		modelOverride, _ := cmd.Flags().GetString("model")
+		// When backend is moonshine, --model sets the moonshine model
+		if cfg.Backend == "moonshine" {
+			cfg.MoonshineModel = modelOverride
 		} else {
-			// Stop recording
-			isRecording = false
-			recordDuration := time.Since(recordStart).Seconds()
-
-			// Cancel timers
-			if timeoutTimer != nil {
-				timeoutTimer.Stop()
-			}
-			if warningTimer != nil {
-				warningTimer.Stop()
-			}
-
-			fmt.Println("⏹  Recording stopped. Transcribing...")
-
-			// Play stop sound
-			if feedback != nil {
-				if err := feedback.PlayStopSound(); err != nil && cfg.Verbose {
-					fmt.Fprintf(os.Stderr, "Warning: Failed to play stop sound: %v\n", err)
-				}
-			}
-
-			// Mark as transcribing
-			transcribingLock.Lock()
-			isTranscribing = true
-			transcribingLock.Unlock()
-
-			// Stop recorder and get audio data
-			audioData, err := recorder.Stop()
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error stopping recording: %v\n", err)
-				transcribingLock.Lock()
-				isTranscribing = false
-				transcribingLock.Unlock()
-				return
-			}
-
-			if len(audioData) == 0 {
-				fmt.Fprintf(os.Stderr, "Warning: No audio data captured\n")
-				transcribingLock.Lock()
-				isTranscribing = false
-				transcribingLock.Unlock()
-				return
-			}
-
-			// Analyze audio levels
-			levelMetrics, err := audio.AnalyzeLevel(audioData, recorder.GetSampleRate())
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to analyze audio level: %v\n", err)
-			} else {
-				// Display audio levels if verbose mode or ShowAudioLevels is enabled
-				if cfg.Verbose || cfg.ShowAudioLevels {
-					fmt.Printf("🔊 Audio level: %.1f dBFS (peak: %d)\n",
-						levelMetrics.DecibelsFS, levelMetrics.PeakAmplitude)
-				}
-
-				// Check if gain control is needed
-				if cfg.AutoGain && levelMetrics.DecibelsFS < cfg.MinThresholdDB {
-					fmt.Printf("⚠️  Low audio level detected (%.1f dBFS), applying gain...\n",
-						levelMetrics.DecibelsFS)
-
-					// Create gain control config
-					gainConfig := audio.GainControlConfig{
-						Enabled:         true,
-						TargetLevelDB:   cfg.TargetLevelDB,
-						MinThresholdDB:  cfg.MinThresholdDB,
-						MaxGainDB:       cfg.MaxGainDB,
-						PreventClipping: true,
-					}
-
-					// Apply gain control
-					processedAudio, gainResult, err := audio.ProcessAudioGain(audioData, levelMetrics, gainConfig)
-					if err != nil {
-						fmt.Fprintf(os.Stderr, "Warning: Failed to apply gain control: %v\n", err)
-					} else {
-						audioData = processedAudio
-						fmt.Printf("✓ Gain applied: +%.1f dB (level now: %.1f dBFS)\n",
-							gainResult.GainAppliedDB, gainResult.ResultingLevelDB)
-					}
-				} else if !cfg.AutoGain && levelMetrics.DecibelsFS < cfg.MinThresholdDB {
-					// Warn if audio is low but auto-gain is disabled
-					fmt.Printf("⚠️  Low audio level detected (%.1f dBFS). Consider increasing microphone volume or enabling auto_gain in config.\n",
-						levelMetrics.DecibelsFS)
-				}
-			}
-
-			// Save audio to temporary WAV file
-			cacheDir, err := config.GetCacheDir()
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error getting cache directory: %v\n", err)
-				transcribingLock.Lock()
-				isTranscribing = false
-				transcribingLock.Unlock()
-				return
-			}
-
-			// Ensure cache directory exists
-			if err := os.MkdirAll(cacheDir, 0755); err != nil {
-				fmt.Fprintf(os.Stderr, "Error creating cache directory: %v\n", err)
-				transcribingLock.Lock()
-				isTranscribing = false
-				transcribingLock.Unlock()
-				return
-			}
-
-			// Create temporary WAV file
-			timestamp := time.Now().Format("20060102_150405")
-			wavPath := filepath.Join(cacheDir, fmt.Sprintf("recording_%s.wav", timestamp))
-
-			if err := audio.SaveWAV(wavPath, audioData, recorder.GetSampleRate(), recorder.GetChannels()); err != nil {
-				fmt.Fprintf(os.Stderr, "Error saving audio file: %v\n", err)
-				transcribingLock.Lock()
-				isTranscribing = false
-				transcribingLock.Unlock()
-				return
-			}
-
-			if cfg.Verbose {
-				fmt.Printf("Audio saved to: %s\n", wavPath)
-			}
-
-			// Transcribe audio
-			opts := transcription.Options{
-				Model:    modelSize,
-				Language: cfg.Language,
-				Verbose:  cfg.Verbose,
-			}
-			result, err := transcriber.TranscribeFile(wavPath, opts)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error transcribing audio: %v\n", err)
-				// Clean up WAV file
-				_ = os.Remove(wavPath)
-				transcribingLock.Lock()
-				isTranscribing = false
-				transcribingLock.Unlock()
-				return
-			}
-
-			// Clean up WAV file (unless verbose mode)
-			if !cfg.Verbose {
-				_ = os.Remove(wavPath)
-			}
-
-			// Play complete sound when transcription is done
-			if feedback != nil {
-				if err := feedback.PlayCompleteSound(); err != nil && cfg.Verbose {
-					fmt.Fprintf(os.Stderr, "Warning: Failed to play complete sound: %v\n", err)
-				}
-			}
-
-			transcription := result.Text
-			if transcription == "" {
-				fmt.Println("⚠️  No speech detected in recording")
-				transcribingLock.Lock()
-				isTranscribing = false
-				transcribingLock.Unlock()
-				return
-			}
-
-			fmt.Printf("Transcription: \"%s\"\n", transcription)
-
-			// Auto-paste if enabled
-			if cfg.AutoPaste && kb != nil {
-				if err := kb.PasteText(transcription); err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: Failed to paste text: %v\n", err)
-				} else {
-					fmt.Println("✅ Text pasted to cursor position!")
-				}
-			} else {
-				fmt.Println("✅ Transcription complete!")
-			}
-
-			// Log transcription
-			if err := logging.LogTranscription(recordDuration, cfg.Model, result.Language, transcription); err != nil {
-				if cfg.Verbose {
-					fmt.Fprintf(os.Stderr, "Warning: Failed to log transcription: %v\n", err)
-				}
-			} else {
-				logPath, _ := config.GetTranscriptionLogPath()
-				timestamp := time.Now().Format("2006-01-02 15:04:05")
-				fmt.Printf("\n[%s] Logged to %s\n", timestamp, logPath)
-			}
-
-			// Clear transcribing flag
-			transcribingLock.Lock()
-			isTranscribing = false
-			transcribingLock.Unlock()
+			cfg.Model = modelOverride
 		}
 	}
+	if cmd.Flags().Changed("language") {
+		cfg.Language, _ = cmd.Flags().GetString("language")
+	}
+	if cmd.Flags().Changed("prompt") {
+		cfg.Prompt, _ = cmd.Flags().GetString("prompt")
+	}
+	if cmd.Flags().Changed("output") {
+		cfg.OutputMode, _ = cmd.Flags().GetString("output")
+	}
+	if cmd.Flags().Changed("no-paste") {
+		noPaste, _ := cmd.Flags().GetBool("no-paste")
+		cfg.AutoPaste = !noPaste
+	}
+	if cmd.Flags().Changed("paste-delay-ms") {
+		cfg.PasteDelayMs, _ = cmd.Flags().GetInt("paste-delay-ms")
+	}
+	if cmd.Flags().Changed("threads") {
+		cfg.Threads, _ = cmd.Flags().GetInt("threads")
+	}
+	if cmd.Flags().Changed("stream") {
+		cfg.Streaming, _ = cmd.Flags().GetBool("stream")
+	}
+	if cmd.Flags().Changed("verbose") {
+		cfg.Verbose, _ = cmd.Flags().GetBool("verbose")
+	}
+	if noLog, _ := cmd.Flags().GetBool("no-log"); noLog {
+		cfg.LoggingEnabled = false
+	}
+}
 
-	// Create and start multi-trigger listener
-	listener, err := hotkey.NewMultiListener(cfg.Triggers, hotkeyCallback)
+// startDaemon starts OpenScribe in the background and returns control to the shell
+func startDaemon() {
+	pid, err := daemon.Start(os.Args[1:])
+	if errors.Is(err, daemon.ErrAlreadyRunning) {
+		fmt.Fprintf(os.Stderr, "Error: OpenScribe is already running in the background (PID %d)\n", pid)
+		os.Exit(1)
+	}
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating trigger listener: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to start in the background: %v\n", err)
 		os.Exit(1)
 	}
 
-	if err := listener.Start(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error starting trigger listener: %v\n", err)
-		fmt.Fprintf(os.Stderr, "\nNote: Trigger detection requires accessibility permissions.\n")
-		fmt.Fprintf(os.Stderr, "Please grant accessibility permissions in System Preferences > Security & Privacy > Privacy > Accessibility\n")
-		os.Exit(1)
+	logPath, _ := config.GetDaemonLogPath()
+	fmt.Printf("✓ OpenScribe started in the background (PID %d)\n", pid)
+	fmt.Printf("  Output: %s\n", logPath)
+	fmt.Println("  Check it with 'openscribe status', stop it with 'openscribe stop'")
+}
+
+// printPipelineResult reports the outcome of processing a recording to out
+// clipboardFallback means the text is copied because accessibility permissions are missing.
+func printPipelineResult(out io.Writer, cfg *config.Config, outputMode pipeline.OutputMode, pasteMode keyboard.PasteMode, clipboardFallback bool, result *pipeline.Result, err error) {
+	switch {
+	case errors.Is(err, pipeline.ErrNoAudio):
+		fmt.Fprintf(os.Stderr, "Warning: No audio data captured\n")
+		return
+	case errors.Is(err, pipeline.ErrNoSpeech):
+		fmt.Fprintln(out, "⚠️  No speech detected in recording")
+		return
+	case errors.Is(err, pipeline.ErrTooShort):
+		fmt.Fprintln(out, "⚠️  Recording too short, ignored")
+		return
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(out, "Transcription: \"%s\"\n", result.Text)
+
+	switch {
+	case result.Pasted && outputMode == pipeline.OutputStdout:
+		fmt.Fprintln(out, "✅ Text written to stdout")
+	case result.Pasted && outputMode == pipeline.OutputClipboard:
+		fmt.Fprintln(out, "✅ Text copied to clipboard!")
+	case result.Pasted && clipboardFallback:
+		fmt.Fprintln(out, "✅ Text copied to clipboard (grant Accessibility to auto-paste)")
+	case result.Pasted && pasteMode == keyboard.PasteModeCopy:
+		fmt.Fprintln(out, "✅ Text copied to clipboard!")
+	case result.Pasted:
+		fmt.Fprintln(out, "✅ Text pasted to cursor position!")
+	case !cfg.AutoPaste || outputMode == pipeline.OutputNone:
+		fmt.Fprintln(out, "✅ Transcription complete!")
 	}
-	defer listener.Stop()
 
-	fmt.Println("Ready! Double-press any configured trigger to start recording...")
-	fmt.Println("Press Ctrl+C to exit.")
-	fmt.Println()
+	if result.Logged {
+		logPath, _ := config.GetTranscriptionLogPath()
+		timestamp := time.Now().Format("2006-01-02 15:04:05")
+		fmt.Fprintf(out, "\n[%s] Logged to %s\n", timestamp, logPath)
+	}
+}
 
-	// Set up signal handling for graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+// waitForPermissions polls for accessibility permissions for up to --wait-permissions-timeout,
+// showing how long it has waited. Ctrl+C stops waiting. Reports whether they were granted.
+func waitForPermissions(out io.Writer, cmd *cobra.Command, kb keyboard.Keyboard) bool {
+	timeout, _ := cmd.Flags().GetDuration("wait-permissions-timeout")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fmt.Fprintln(out, "Grant accessibility permissions in System Preferences > Security & Privacy > Privacy > Accessibility.")
+	started := time.Now()
+	done, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			fmt.Fprintf(out, "\r⏳ Waiting for accessibility permissions... %s / %s", time.Since(started).Round(time.Second), timeout)
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
 
-	// Wait for interrupt signal
-	<-sigChan
+	err := keyboard.WaitForPermissions(ctx, kb.CheckPermissions)
+	close(done)
+	<-stopped
+	fmt.Fprintln(out)
 
-	fmt.Println("\n\nShutting down...")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Stopped waiting for accessibility permissions after %s.\n", time.Since(started).Round(time.Second))
+		return false
+	}
+	fmt.Fprintln(out, "✓ Accessibility permissions granted")
+	fmt.Fprintln(out)
+	return true
 }
 
 func init() {
@@ -737,7 +788,20 @@ func init() {
 	startCmd.Flags().StringP("microphone", "m", "", "Override microphone selection")
 	startCmd.Flags().String("model", "", "Override model selection")
 	startCmd.Flags().StringP("language", "l", "", "Override language setting")
+	startCmd.Flags().String("prompt", "", "Initial prompt to bias transcription (names, jargon, acronyms)")
 	startCmd.Flags().Bool("no-paste", false, "Disable auto-paste")
+	startCmd.Flags().Bool("no-progress", false, "Don't show a spinner while transcribing")
+	startCmd.Flags().Bool("no-log", false, "Don't write transcriptions to the transcription log")
+	startCmd.Flags().Int("paste-delay-ms", 0, "Wait this many milliseconds before pasting, to switch windows (default: paste_delay_ms)")
+	startCmd.Flags().String("output", "", "Where transcriptions go: paste, clipboard, stdout or none (default: output_mode)")
+	startCmd.Flags().Bool("stream", false, "Show partial transcriptions while recording")
 	startCmd.Flags().BoolP("verbose", "v", false, "Enable verbose debug output")
+	startCmd.Flags().Int("threads", 0, "Number of CPU threads for whisper (0 = auto-detect)")
 	startCmd.Flags().String("backend", "", "Transcription backend (whisper, moonshine, or openai)")
+	startCmd.Flags().BoolP("daemon", "d", false, "Run in the background, detached from the terminal")
+	startCmd.Flags().Duration("transcribe-timeout", transcription.DefaultTimeout, "Kill whisper-cli if a transcription takes longer than this")
+	startCmd.Flags().String("profile", "", "Configuration profile to use (default: active profile)")
+	startCmd.Flags().Bool("dry-run", false, "Record and transcribe, but only print what would be pasted and logged")
+	startCmd.Flags().Bool("wait-permissions", false, "Wait for accessibility permissions to be granted instead of falling back to copying")
+	startCmd.Flags().Duration("wait-permissions-timeout", 2*time.Minute, "How long --wait-permissions waits before falling back to copying")
 }
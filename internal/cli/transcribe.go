@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -18,26 +19,52 @@ var transcribeCmd = &cobra.Command{
 	Long: `Transcribe an audio file using Whisper.
 
 This command is useful for testing transcription without recording.
-Provide the path to a WAV audio file (16kHz, mono recommended).`,
+Provide the path to an audio file. 16kHz mono WAV files are transcribed
+directly; other formats (m4a, mp3, ...) are converted with ffmpeg first.
+
+By default the result is printed. Use --output to write it to a file, or
+--output-dir to write it next to a derived name (audio.wav → audio.txt, .srt
+or .vtt depending on --format).
+
+Examples:
+  openscribe transcribe audio.wav --output notes/audio.txt
+  openscribe transcribe audio.wav --format srt --output-dir subtitles
+  openscribe transcribe audio.wav --no-log`,
 	Args: cobra.ExactArgs(1),
 	RunE: runTranscribe,
 }
 
 var (
-	transcribeModel    string
-	transcribeLanguage string
-	transcribeVerbose  bool
+	transcribeModel     string
+	transcribeLanguage  string
+	transcribeVerbose   bool
+	transcribeFormat    string
+	transcribeThreads   int
+	transcribeTimeout   time.Duration
+	transcribeOutput    string
+	transcribeOutputDir string
+	transcribeAppendLog bool
+	transcribeNoLog     bool
 )
 
 func init() {
-	transcribeCmd.Flags().StringVarP(&transcribeModel, "model", "m", "small", "Whisper model to use (tiny, base, small, medium, large)")
+	transcribeCmd.Flags().StringVarP(&transcribeModel, "model", "m", "small", "Whisper model to use (tiny, base, small, medium, large-turbo, large)")
 	transcribeCmd.Flags().StringVarP(&transcribeLanguage, "language", "l", "", "Language code (e.g., en, fr, es). Empty = auto-detect")
 	transcribeCmd.Flags().BoolVarP(&transcribeVerbose, "verbose", "v", false, "Enable verbose output from whisper")
+	transcribeCmd.Flags().IntVar(&transcribeThreads, "threads", 0, "Number of CPU threads for whisper (0 = auto-detect)")
+	transcribeCmd.Flags().DurationVar(&transcribeTimeout, "transcribe-timeout", transcription.DefaultTimeout, "Kill whisper-cli if the transcription takes longer than this")
+	transcribeCmd.Flags().StringVarP(&transcribeFormat, "format", "f", "text", "Output format (text, srt, vtt)")
+	transcribeCmd.Flags().StringVarP(&transcribeOutput, "output", "o", "", "Write the result to this file instead of printing it")
+	transcribeCmd.Flags().StringVar(&transcribeOutputDir, "output-dir", "", "Write the result to this directory, named after the audio file")
+	transcribeCmd.Flags().BoolVar(&transcribeAppendLog, "append-log", true, "Add the transcription to the transcription log")
+	transcribeCmd.Flags().Bool("no-progress", false, "Don't show a spinner while transcribing")
+	transcribeCmd.Flags().BoolVar(&transcribeNoLog, "no-log", false, "Don't add the transcription to the transcription log (same as --append-log=false)")
+	transcribeCmd.MarkFlagsMutuallyExclusive("output", "output-dir")
 
 	rootCmd.AddCommand(transcribeCmd)
 }
 
-func runTranscribe(_ *cobra.Command, args []string) error {
+func runTranscribe(cmd *cobra.Command, args []string) error {
 	audioPath := args[0]
 
 	// Check if file exists
@@ -45,6 +72,29 @@ func runTranscribe(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("audio file not found: %s", audioPath)
 	}
 
+	// Validate output format
+	if err := transcription.ValidateFormat(transcribeFormat); err != nil {
+		return err
+	}
+
+	// Resolve the output file, if any
+	outputPath := transcribeOutput
+	if transcribeOutputDir != "" {
+		outputPath = transcription.OutputPath(audioPath, transcribeOutputDir, transcribeFormat)
+	}
+
+	// Subtitles printed to stdout are meant to be redirected to a file, so only the
+	// subtitle body goes there; every other message goes to stderr
+	var out io.Writer = os.Stdout
+	if outputPath == "" && transcribeFormat != transcription.FormatText {
+		out = os.Stderr
+	}
+
+	// Validate thread count
+	if transcribeThreads < 0 || transcribeThreads > config.MaxThreads {
+		return fmt.Errorf("invalid thread count: %d (must be between 0 and %d)", transcribeThreads, config.MaxThreads)
+	}
+
 	// Parse model
 	modelSize, err := models.ParseModelSize(transcribeModel)
 	if err != nil {
@@ -64,47 +114,77 @@ func runTranscribe(_ *cobra.Command, args []string) error {
 	modelPath, _ := models.GetModelPath(modelSize)
 
 	// Display configuration
-	fmt.Printf("Transcribing audio file: %s\n", audioPath)
-	fmt.Printf("Using model: %s (%s)\n", modelSize, modelPath)
+	fmt.Fprintf(out, "Transcribing audio file: %s\n", audioPath)
+	fmt.Fprintf(out, "Using model: %s (%s)\n", modelSize, modelPath)
 	if transcribeLanguage != "" {
-		fmt.Printf("Language: %s\n", transcribeLanguage)
+		fmt.Fprintf(out, "Language: %s\n", transcribeLanguage)
 	} else {
-		fmt.Printf("Language: auto-detect\n")
+		fmt.Fprintf(out, "Language: auto-detect\n")
 	}
-	fmt.Println()
+	fmt.Fprintln(out)
 
 	// Create transcriber
-	transcriber, err := transcription.NewWhisperTranscriber()
+	var transcriber transcription.Transcriber
+	transcriber, err = transcription.NewWhisperTranscriber()
 	if err != nil {
 		return err
 	}
 
 	// Prepare options
 	opts := transcription.Options{
-		Model:    modelSize,
-		Language: transcribeLanguage,
-		Verbose:  transcribeVerbose,
+		Model:      modelSize,
+		Language:   transcribeLanguage,
+		Threads:    transcribeThreads,
+		Verbose:    transcribeVerbose,
+		Timestamps: transcribeFormat != transcription.FormatText,
+		Timeout:    transcribeTimeout,
 	}
 
-	// Transcribe
-	fmt.Println("Transcribing... (this may take a few seconds)")
+	// Transcribe; the spinner shows progress on a terminal, otherwise say what's happening
+	spinner := newTranscribeSpinner(cmd, os.Stderr)
+	if !spinner.Enabled {
+		fmt.Fprintln(out, "Transcribing... (this may take a few seconds)")
+	}
 	startTime := time.Now()
 
+	spinner.Start()
 	result, err := transcriber.TranscribeFile(audioPath, opts)
+	spinner.Stop()
 	if err != nil {
 		return fmt.Errorf("transcription failed: %w", err)
 	}
 
 	duration := time.Since(startTime)
 
-	// Display results
-	fmt.Println()
-	fmt.Println("=== Transcription Result ===")
-	fmt.Printf("Text: %s\n", result.Text)
+	// Display or write results
+	fmt.Fprintln(out)
+	if outputPath != "" {
+		if err := result.WriteOutput(outputPath, transcribeFormat); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "✓ Transcription written to: %s\n", outputPath)
+	} else {
+		fmt.Fprintln(out, "=== Transcription Result ===")
+		switch transcribeFormat {
+		case transcription.FormatSRT:
+			fmt.Print(result.ToSRT())
+		case transcription.FormatVTT:
+			fmt.Print(result.ToVTT())
+		default:
+			fmt.Fprintf(out, "Text: %s\n", result.Text)
+		}
+	}
 	if result.Language != "" {
-		fmt.Printf("Language: %s\n", result.Language)
+		fmt.Fprintf(out, "Language: %s\n", result.Language)
+	}
+	fmt.Fprintf(out, "Processing time: %.2f seconds\n", duration.Seconds())
+	if result.Timings.TotalMS > 0 {
+		fmt.Fprintf(out, "Timings: %s\n", result.Timings)
+	}
+
+	if !transcribeAppendLog || transcribeNoLog {
+		return nil
 	}
-	fmt.Printf("Processing time: %.2f seconds\n", duration.Seconds())
 
 	// Log the transcription
 	detectedLang := result.Language
@@ -119,13 +199,24 @@ func runTranscribe(_ *cobra.Command, args []string) error {
 	// In a real scenario, we'd parse the WAV file to get actual duration
 	audioDuration := duration.Seconds()
 
+	// Honor the configured log rotation and logging_enabled (defaults apply if the config can't be loaded)
+	if cfg, err := config.Load(); err == nil {
+		logging.SetRotation(logging.RotationFromConfig(cfg))
+		if !cfg.LoggingEnabled {
+			return nil
+		}
+		if _, err := unlockLog(cfg); err != nil {
+			return err
+		}
+	}
+
 	if err := logging.LogTranscription(audioDuration, string(modelSize), detectedLang, result.Text); err != nil {
 		fmt.Fprintf(os.Stderr, "\nWarning: Failed to log transcription: %v\n", err)
 	} else {
-		fmt.Println()
+		fmt.Fprintln(out)
 		logPath, _ := config.GetTranscriptionLogPath()
-		fmt.Printf("✓ Transcription logged to: %s\n", logPath)
-		fmt.Printf("  View logs with: openscribe logs show\n")
+		fmt.Fprintf(out, "✓ Transcription logged to: %s\n", logPath)
+		fmt.Fprintf(out, "  View logs with: openscribe logs show\n")
 	}
 
 	return nil
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrNoBackup is returned by RestoreBackup when there is no config.yaml.bak
+var ErrNoBackup = errors.New("no configuration backup found")
+
+// backupConfig copies the existing config file to config.yaml.bak.
+// Does nothing when there is no config file yet.
+func backupConfig(configPath string) error {
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := os.WriteFile(configPath+".bak", data, 0644); err != nil {
+		return fmt.Errorf("failed to write config backup: %w", err)
+	}
+	return nil
+}
+
+// RestoreBackup swaps config.yaml.bak with config.yaml and reloads the configuration.
+// The replaced config becomes the new backup, so a restore can be undone by restoring again.
+func RestoreBackup() (*Config, error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config path: %w", err)
+	}
+	backupPath, err := GetConfigBackupPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config backup path: %w", err)
+	}
+
+	backup, err := os.ReadFile(backupPath)
+	if os.IsNotExist(err) {
+		return nil, ErrNoBackup
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config backup: %w", err)
+	}
+
+	current, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := writeFileAtomic(configPath, backup, 0644); err != nil {
+		return nil, fmt.Errorf("failed to restore config file: %w", err)
+	}
+	if current != nil {
+		if err := os.WriteFile(backupPath, current, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write config backup: %w", err)
+		}
+	} else if err := os.Remove(backupPath); err != nil {
+		return nil, fmt.Errorf("failed to remove config backup: %w", err)
+	}
+
+	return Load()
+}
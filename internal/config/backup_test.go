@@ -0,0 +1,108 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestSave_CreatesBackup(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	backupPath, _ := GetConfigBackupPath()
+
+	cfg := DefaultConfig()
+	cfg.Model = "small"
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("first Save() error = %v", err)
+	}
+	if _, err := os.Stat(backupPath); !os.IsNotExist(err) {
+		t.Errorf("backup should not exist after the first save, stat error = %v", err)
+	}
+
+	configPath, _ := GetConfigPath()
+	previous, _ := os.ReadFile(configPath)
+
+	cfg.Model = "medium"
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("second Save() error = %v", err)
+	}
+
+	backup, err := os.ReadFile(backupPath)
+	if err != nil {
+		t.Fatalf("backup not created on second save: %v", err)
+	}
+	if string(backup) != string(previous) {
+		t.Errorf("backup = %q, want previous config %q", backup, previous)
+	}
+}
+
+func TestSave_BackupFailureDoesNotBlockSave(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := DefaultConfig()
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("first Save() error = %v", err)
+	}
+
+	// A directory at the backup path makes the backup write fail
+	backupPath, _ := GetConfigBackupPath()
+	if err := os.Mkdir(backupPath, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	cfg.Model = "medium"
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() with failing backup error = %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Model != "medium" {
+		t.Errorf("Model = %q, want %q", loaded.Model, "medium")
+	}
+}
+
+func TestRestoreBackup(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := DefaultConfig()
+	cfg.PreferredMicrophones = []string{"USB Microphone"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("first Save() error = %v", err)
+	}
+	cfg.PreferredMicrophones = nil
+	cfg.Model = "medium"
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("second Save() error = %v", err)
+	}
+
+	restored, err := RestoreBackup()
+	if err != nil {
+		t.Fatalf("RestoreBackup() error = %v", err)
+	}
+	if len(restored.PreferredMicrophones) != 1 || restored.PreferredMicrophones[0] != "USB Microphone" {
+		t.Errorf("PreferredMicrophones = %v, want [USB Microphone]", restored.PreferredMicrophones)
+	}
+	if restored.Model != DefaultConfig().Model {
+		t.Errorf("Model = %q, want %q", restored.Model, DefaultConfig().Model)
+	}
+
+	// The replaced config becomes the backup, so restoring again undoes the restore
+	undone, err := RestoreBackup()
+	if err != nil {
+		t.Fatalf("second RestoreBackup() error = %v", err)
+	}
+	if undone.Model != "medium" {
+		t.Errorf("Model after undo = %q, want %q", undone.Model, "medium")
+	}
+}
+
+func TestRestoreBackup_NoBackup(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := RestoreBackup(); !errors.Is(err, ErrNoBackup) {
+		t.Errorf("RestoreBackup() error = %v, want ErrNoBackup", err)
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// recordingPattern matches the temporary recordings written to the cache directory
+const recordingPattern = "recording_*.wav"
+
+// CacheRetention returns how long temporary recordings are kept (0 = forever)
+func (c *Config) CacheRetention() time.Duration {
+	return time.Duration(c.CacheRetentionHours) * time.Hour
+}
+
+// CleanCache removes temporary recordings (recording_*.wav) from the cache directory
+// that were last modified more than olderThan ago, and returns how many it removed.
+// A missing cache directory is not an error.
+func CleanCache(olderThan time.Duration) (int, error) {
+	cacheDir, err := GetCacheDir()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get cache directory: %w", err)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(cacheDir, recordingPattern))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list cache directory: %w", err)
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	removed := 0
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, fmt.Errorf("failed to remove %s: %w", filepath.Base(path), err)
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -3,15 +3,47 @@ package config
 
 import (
 	"fmt"
+	"io"
 	"log"
+	"net/url"
 	"os"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/alexandrelam/openscribe/internal/hotkey"
 	"gopkg.in/yaml.v3"
 )
 
+// MaxThreads is the upper bound accepted for the whisper thread count
+const MaxThreads = 64
+
+// MaxPasteDelayMs is the upper bound accepted for the paste delays
+const MaxPasteDelayMs = 5000
+
+// MaxLogFiles is the upper bound accepted for the number of rotated log files kept
+const MaxLogFiles = 100
+
+// NormalizePeak is the peak level, as a fraction of full scale, that normalize scales recordings to
+const NormalizePeak = 0.9
+
+// MaxGainDBLimit bounds gain_db in both directions
+const MaxGainDBLimit = 40.0
+
+// MaxRecordingSecondsLimit is the upper bound accepted for max_recording_seconds (1 hour)
+const MaxRecordingSecondsLimit = 3600
+
+// MaxDeviceInitAttempts is the upper bound accepted for device_init_attempts
+const MaxDeviceInitAttempts = 10
+
+// CurrentConfigVersion is the config schema version written by this release (one per migration)
+const CurrentConfigVersion = 8
+
 // Config represents the application configuration
 type Config struct {
+	// ConfigVersion is the schema version of the file; older configs are migrated on load
+	ConfigVersion int `yaml:"config_version"`
+
 	// Microphone is the selected audio input device (LEGACY - for backward compatibility)
 	// Deprecated: Use PreferredMicrophones instead
 	Microphone string `yaml:"microphone,omitempty"`
@@ -21,27 +53,100 @@ type Config struct {
 	// If empty, falls back to Microphone field or system default
 	PreferredMicrophones []string `yaml:"preferred_microphones,omitempty"`
 
-	// Model is the Whisper model to use (tiny, base, small, medium, large)
+	// Model is the Whisper model to use (tiny, base, small, medium, large, an English-only
+	// variant like base.en, or a quantized variant like small-q5_1)
 	Model string `yaml:"model"`
 
+	// ModelMirrorURL is an optional base URL that Whisper models are downloaded from instead of
+	// Hugging Face. The model file name is appended (or substituted for "{filename}" if present).
+	ModelMirrorURL string `yaml:"model_mirror_url,omitempty"`
+
 	// Language is the target language for transcription (empty = auto-detect)
 	Language string `yaml:"language"`
 
+	// DetectLanguages restricts auto-detection to these language codes (e.g. en, fr) when
+	// Language is empty: the most likely one is detected first, then used to transcribe.
+	// Whisper backend only.
+	DetectLanguages []string `yaml:"detect_languages,omitempty"`
+
 	// Hotkey is the keyboard shortcut for activation (LEGACY - for backward compatibility)
 	// Deprecated: Use Triggers instead
 	Hotkey string `yaml:"hotkey,omitempty"`
 
 	// Triggers is an array of keyboard/mouse triggers for activation
-	// Examples: "Right Option", "Forward Button", "Back Button"
-	// Any trigger can be double-pressed to start/stop recording
+	// Examples: "Right Option", "Forward Button", "Back Button", "Cmd+Shift+Space"
+	// Key combinations fire on a single press; any other trigger can be double-pressed to start/stop recording
 	Triggers []string `yaml:"triggers,omitempty"`
 
+	// HotkeyMode selects how triggers control recording:
+	// "toggle" (double-press to start/stop, default) or "hold" (record while held).
+	// Caps Lock can't be used in hold mode: macOS reports no release for it.
+	HotkeyMode string `yaml:"hotkey_mode"`
+
+	// TriggerMode selects how many presses toggle recording in toggle mode:
+	// "double" (default) or "single". Single press is faster but an accidental tap
+	// starts or stops a recording, so it is best paired with a dedicated key like F13.
+	TriggerMode string `yaml:"trigger_mode"`
+
+	// OutputMode is where transcribed text goes: "paste" (at the cursor, per auto_paste
+	// and paste_mode; default), "clipboard" (copy only), "stdout" (print it, for piping)
+	// or "none" (only log it)
+	OutputMode string `yaml:"output_mode"`
+
 	// AutoPaste determines whether to automatically paste transcribed text
 	AutoPaste bool `yaml:"auto_paste"`
 
+	// PasteMode selects how text is inserted when auto-paste is enabled:
+	// "clipboard" (Cmd+V, default), "type" (types each character, for apps that intercept Cmd+V)
+	// or "copy" (leaves the text on the clipboard to paste manually). With auto_paste off, nothing is inserted.
+	PasteMode string `yaml:"paste_mode"`
+
+	// PasteDelayMs waits this many milliseconds before pasting at the cursor, so there is
+	// time to switch to the target window (0 = paste immediately)
+	PasteDelayMs int `yaml:"paste_delay_ms"`
+
+	// StripNewlines collapses multi-line transcriptions into a single line before pasting
+	StripNewlines bool `yaml:"strip_newlines"`
+
+	// CapitalizeFirst upper-cases the first letter of each transcription before pasting
+	CapitalizeFirst bool `yaml:"capitalize_first"`
+
+	// EnsurePunctuation ends each pasted transcription with a period if it has no terminal punctuation
+	EnsurePunctuation bool `yaml:"ensure_punctuation"`
+
+	// TrailingSpace adds a space after each pasted transcription so consecutive dictations don't run together
+	TrailingSpace bool `yaml:"trailing_space"`
+
+	// PasteSettleMs is the delay in milliseconds between setting the clipboard and sending Cmd+V
+	PasteSettleMs int `yaml:"paste_settle_ms"`
+
+	// ClipboardRestoreMs is the delay in milliseconds between Cmd+V and restoring the original
+	// clipboard. Increase it if slow apps paste your previous clipboard instead of the transcription.
+	ClipboardRestoreMs int `yaml:"clipboard_restore_ms"`
+
 	// AudioFeedback determines whether to play sounds on state changes
 	AudioFeedback bool `yaml:"audio_feedback"`
 
+	// StartSound, StopSound and CompleteSound are the macOS system sounds played
+	// for each event (empty = use the default: Tink, Pop, Glass)
+	StartSound    string `yaml:"start_sound"`
+	StopSound     string `yaml:"stop_sound"`
+	CompleteSound string `yaml:"complete_sound"`
+
+	// StartSoundFile, StopSoundFile and CompleteSoundFile are optional paths to custom
+	// sound files (WAV, AIFF, MP3, M4A, CAF) played instead of the system sounds
+	StartSoundFile    string `yaml:"start_sound_file,omitempty"`
+	StopSoundFile     string `yaml:"stop_sound_file,omitempty"`
+	CompleteSoundFile string `yaml:"complete_sound_file,omitempty"`
+
+	// DesktopNotifications shows a Notification Center banner with the text when a
+	// transcription completes (off by default)
+	DesktopNotifications bool `yaml:"desktop_notifications"`
+
+	// FeedbackVolume is the feedback sound volume from 0.0 to 1.0.
+	// Configs from before it existed are migrated to 1.0
+	FeedbackVolume float64 `yaml:"feedback_volume"`
+
 	// Backend selects the transcription engine ("whisper", "moonshine", or "openai")
 	Backend string `yaml:"backend"`
 
@@ -54,9 +159,86 @@ type Config struct {
 	// OpenAIModel is the OpenAI model to use for transcription (e.g., "gpt-4o-transcribe", "whisper-1")
 	OpenAIModel string `yaml:"openai_model,omitempty"`
 
+	// Threads is the number of CPU threads whisper uses (0 = auto-detect)
+	Threads int `yaml:"threads"`
+
+	// Prompt is an optional initial prompt used to bias transcription vocabulary
+	// (names, jargon, acronyms). Empty = no prompt
+	Prompt string `yaml:"prompt,omitempty"`
+
+	// Replacements fix words the model consistently gets wrong. They are applied
+	// to the text before it is pasted and logged
+	Replacements []Replacement `yaml:"replacements,omitempty"`
+
+	// Redact lists words or phrases to mask in pasted and logged text (whole words, any case)
+	Redact []string `yaml:"redact,omitempty"`
+
+	// RedactMask replaces each redacted word. Empty = asterisks of the same length
+	RedactMask string `yaml:"redact_mask,omitempty"`
+
+	// NoSpeechThreshold discards a transcription when the backend's no-speech
+	// probability exceeds it (0 = disabled). Only applies to backends that report it
+	NoSpeechThreshold float64 `yaml:"no_speech_threshold"`
+
+	// SilenceTimeoutSeconds auto-stops recording after this many seconds of silence (0 = disabled)
+	SilenceTimeoutSeconds float64 `yaml:"silence_timeout_seconds"`
+
+	// MaxRecordingSeconds auto-stops and transcribes a recording that runs this long,
+	// so one left on by accident can't grow without bound
+	MaxRecordingSeconds int `yaml:"max_recording_seconds"`
+
+	// MinRecordingMs ignores recordings shorter than this many milliseconds, so an
+	// accidental double-press isn't transcribed and logged (0 = transcribe everything)
+	MinRecordingMs int `yaml:"min_recording_ms"`
+
+	// AudioBackend is the audio API used to list microphones and record ("auto" or
+	// empty lets miniaudio choose; see openscribe config --list-audio-backends)
+	AudioBackend string `yaml:"audio_backend,omitempty"`
+
+	// DeviceInitAttempts is how many times to try initializing the microphone before a
+	// recording fails (it can fail intermittently right after waking from sleep)
+	DeviceInitAttempts int `yaml:"device_init_attempts"`
+
+	// CacheRetentionHours is how long temporary recordings are kept in the cache
+	// directory before start cleans them up (0 = keep them)
+	CacheRetentionHours int `yaml:"cache_retention_hours"`
+
+	// KeepRecordings moves each transcribed recording to the recordings directory and
+	// references it from its log entry, instead of deleting it. Nothing is kept while
+	// logging_enabled is off, since no log entry would reference the recording.
+	KeepRecordings bool `yaml:"keep_recordings"`
+
+	// SilenceThresholdDB is the level in dBFS below which audio counts as silence (e.g., -45.0)
+	SilenceThresholdDB float64 `yaml:"silence_threshold_db"`
+
+	// TrimSilence removes leading/trailing audio below SilenceThresholdDB before transcription
+	TrimSilence bool `yaml:"trim_silence"`
+
+	// NoiseGateDB silences audio below this level in dBFS before transcription,
+	// so background hum isn't transcribed as words (0 = disabled)
+	NoiseGateDB float64 `yaml:"noise_gate_db,omitempty"`
+
+	// Streaming shows partial transcriptions while recording (re-transcribes buffered audio every few seconds)
+	Streaming bool `yaml:"streaming"`
+
 	// Verbose enables detailed debug output
 	Verbose bool `yaml:"verbose"`
 
+	// LoggingEnabled writes each transcription to the transcription log; turn it off
+	// to keep transcribed text off the disk
+	LoggingEnabled bool `yaml:"logging_enabled"`
+
+	// EncryptLog encrypts new transcription log entries with a key derived from a
+	// passphrase, which is never stored: it comes from OPENSCRIBE_LOG_PASSPHRASE or a prompt
+	EncryptLog bool `yaml:"encrypt_log"`
+
+	// LogMaxSizeMB rotates the transcription log once it exceeds this size (0 = never rotate)
+	LogMaxSizeMB int `yaml:"log_max_size_mb"`
+
+	// LogMaxFiles is how many rotated log files (transcriptions.log.1, .2, ...) are kept;
+	// the oldest is deleted when a rotation would exceed it (0 = discard the log on rotation)
+	LogMaxFiles int `yaml:"log_max_files"`
+
 	// Audio gain control settings
 	// AutoGain enables automatic audio level normalization to improve transcription quality
 	AutoGain bool `yaml:"auto_gain"`
@@ -73,35 +255,165 @@ type Config struct {
 	// This prevents excessive amplification of very quiet audio
 	MaxGainDB float64 `yaml:"max_gain_db"`
 
+	// Normalize scales every recording so its peak reaches NormalizePeak, instead of
+	// the level-triggered auto gain
+	Normalize bool `yaml:"normalize"`
+
+	// GainDB is a fixed gain in dB applied to every recording (0 = off);
+	// an alternative to Normalize for a mic that is always too quiet or too loud
+	GainDB float64 `yaml:"gain_db,omitempty"`
+
 	// ShowAudioLevels displays audio level information for all recordings
 	// When false, levels are only shown in verbose mode
 	ShowAudioLevels bool `yaml:"show_audio_levels"`
+
+	// ActiveProfile selects the profile used when none is given ("default" = the settings above)
+	ActiveProfile string `yaml:"active_profile"`
+
+	// Profiles are named sets of settings layered on top of the settings above;
+	// anything a profile doesn't set is inherited, e.g. {code: {model: base.en, auto_paste: false}}
+	Profiles map[string]map[string]interface{} `yaml:"profiles,omitempty"`
+
+	// profile is the named profile this Config was loaded from ("" = the top-level settings).
+	// Save writes changes back to that profile.
+	profile string
+
+	// keys are the top-level settings present in the file this Config was parsed from,
+	// so migrations can tell a missing setting from one set to its zero value.
+	// Cleared once the config is upgraded.
+	keys map[string]bool
+}
+
+// validSystemSounds lists the macOS system sounds usable for audio feedback.
+// Mirrors audio.ListSystemSounds (the audio package imports config, not the reverse).
+var validSystemSounds = map[string]bool{
+	"Basso":     true,
+	"Blow":      true,
+	"Bottle":    true,
+	"Frog":      true,
+	"Funk":      true,
+	"Glass":     true,
+	"Hero":      true,
+	"Morse":     true,
+	"Ping":      true,
+	"Pop":       true,
+	"Purr":      true,
+	"Sosumi":    true,
+	"Submarine": true,
+	"Tink":      true,
+}
+
+// validateSoundFile checks that path is a readable file in an audio format NSSound can play
+func validateSoundFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("sound file not found: %s", path)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory, not a sound file", path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("sound file is not readable: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	header := make([]byte, 12)
+	n, _ := io.ReadFull(file, header)
+	if !isAudioHeader(header[:n]) {
+		return fmt.Errorf("%s is not a supported audio file (expected WAV, AIFF, MP3, M4A or CAF)", path)
+	}
+
+	return nil
+}
+
+// isAudioHeader recognizes the magic bytes of common audio container formats
+func isAudioHeader(h []byte) bool {
+	switch {
+	case len(h) >= 12 && string(h[0:4]) == "RIFF" && string(h[8:12]) == "WAVE":
+		return true
+	case len(h) >= 12 && string(h[0:4]) == "FORM" && (string(h[8:12]) == "AIFF" || string(h[8:12]) == "AIFC"):
+		return true
+	case len(h) >= 4 && string(h[0:4]) == "caff":
+		return true
+	case len(h) >= 8 && string(h[4:8]) == "ftyp": // M4A/AAC (MPEG-4 container)
+		return true
+	case len(h) >= 3 && string(h[0:3]) == "ID3": // MP3 with ID3 tag
+		return true
+	case len(h) >= 2 && h[0] == 0xFF && h[1]&0xE0 == 0xE0: // MP3 frame sync
+		return true
+	}
+	return false
+}
+
+// Replacement rewrites text matching From to To. Literal rules match whole words,
+// ignoring case; with Regex set, From is a regular expression and To may use $1 etc.
+type Replacement struct {
+	From  string `yaml:"from"`
+	To    string `yaml:"to"`
+	Regex bool   `yaml:"regex,omitempty"`
 }
 
 // DefaultConfig returns a Config with default values
 func DefaultConfig() *Config {
 	return &Config{
-		Microphone:           "",         // Empty means use system default (legacy)
-		PreferredMicrophones: []string{}, // Empty means use system default
-		Model:                "small",
-		Language:             "",          // Empty means auto-detect
-		Hotkey:               "",          // Legacy field (deprecated)
-		Triggers:             []string{"Right Option"},
-		AutoPaste:            true,
-		AudioFeedback:        true,
-		Backend:              "whisper",
-		MoonshineModel:       "",
-		Verbose:              false,
-		AutoGain:             true,   // Enable automatic gain control by default
-		TargetLevelDB:        -18.0,  // Optimal speech level for transcription (-18 dBFS)
-		MinThresholdDB:       -35.0,  // Below this is considered too quiet for good transcription
-		MaxGainDB:            25.0,   // Maximum 25 dB of gain (allows recovery from -43 dBFS)
-		ShowAudioLevels:      false,  // Only show in verbose mode by default
+		Microphone:            "",         // Empty means use system default (legacy)
+		PreferredMicrophones:  []string{}, // Empty means use system default
+		Model:                 "small",
+		Language:              "", // Empty means auto-detect
+		Hotkey:                "", // Legacy field (deprecated)
+		Triggers:              []string{"Right Option"},
+		HotkeyMode:            "toggle",
+		TriggerMode:           "double",
+		OutputMode:            "paste",
+		AutoPaste:             true,
+		PasteMode:             "clipboard",
+		StripNewlines:         false,
+		CapitalizeFirst:       false,
+		EnsurePunctuation:     false,
+		TrailingSpace:         false,
+		PasteSettleMs:         10,
+		ClipboardRestoreMs:    50,
+		AudioFeedback:         true,
+		StartSound:            "Tink",
+		StopSound:             "Pop",
+		CompleteSound:         "Glass",
+		FeedbackVolume:        1.0,
+		Backend:               "whisper",
+		MoonshineModel:        "",
+		Threads:               0,   // Auto-detect from CPU count
+		NoSpeechThreshold:     0.6, // Matches whisper's own no-speech threshold
+		SilenceTimeoutSeconds: 0,   // Disabled: stop with a double-press
+		MaxRecordingSeconds:   300, // 5 minutes
+		MinRecordingMs:        300, // Shorter than any real utterance
+		CacheRetentionHours:   24,
+		DeviceInitAttempts:    3,
+		SilenceThresholdDB:    -45.0, // Quiet room noise sits well below speech
+		TrimSilence:           false,
+		Streaming:             false,
+		Verbose:               false,
+		LoggingEnabled:        true,
+		LogMaxSizeMB:          10,
+		LogMaxFiles:           5,
+		AutoGain:              true,  // Enable automatic gain control by default
+		TargetLevelDB:         -18.0, // Optimal speech level for transcription (-18 dBFS)
+		MinThresholdDB:        -35.0, // Below this is considered too quiet for good transcription
+		MaxGainDB:             25.0,  // Maximum 25 dB of gain (allows recovery from -43 dBFS)
+		ShowAudioLevels:       false, // Only show in verbose mode by default
+		ActiveProfile:         DefaultProfile,
+		ConfigVersion:         CurrentConfigVersion,
 	}
 }
 
-// Load reads the configuration from disk, creating it with defaults if it doesn't exist
+// Load reads the configuration from disk, creating it with defaults if it doesn't exist.
+// The active profile is applied.
 func Load() (*Config, error) {
+	return LoadProfile("")
+}
+
+// loadBase reads the top-level settings from disk, creating them with defaults if the file doesn't exist
+func loadBase() (*Config, error) {
 	// Ensure directories exist first
 	if err := EnsureDirectories(); err != nil {
 		return nil, fmt.Errorf("failed to create directories: %w", err)
@@ -128,8 +440,8 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	cfg := &Config{}
-	if err := yaml.Unmarshal(data, cfg); err != nil {
+	cfg, err := parseConfig(data)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
@@ -144,47 +456,241 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
-// migrate handles backward compatibility by auto-migrating legacy fields
-// to their new equivalents. This ensures seamless upgrade for existing users.
+// parseConfig parses a config file, remembering which settings it contains
+func parseConfig(data []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	cfg.keys = make(map[string]bool, len(raw))
+	for key := range raw {
+		cfg.keys[key] = true
+	}
+	return cfg, nil
+}
+
+// migrations upgrade a config one schema version at a time: migrations[i] turns a
+// version i config into version i+1. Append new migrations; never reorder or remove them.
+var migrations = []func(*Config){
+	migrateMicrophone,         // 0 → 1
+	migrateHotkey,             // 1 → 2
+	migrateMissingDefaults,    // 2 → 3
+	migrateCacheRetention,     // 3 → 4
+	migrateDeviceInitAttempts, // 4 → 5
+	migrateMinRecording,       // 5 → 6
+	migrateLoggingEnabled,     // 6 → 7
+	migrateNoSpeechThreshold,  // 7 → 8
+}
+
+// migrate handles backward compatibility by applying, in order, every migration newer
+// than the config's version, then saves the upgraded config once. This ensures
+// seamless upgrade for existing users.
 func (c *Config) migrate() {
-	needsSave := false
+	if !c.upgrade() {
+		return
+	}
+	if err := c.Save(); err != nil {
+		log.Printf("[CONFIG] Warning: Failed to save migrated config: %v", err)
+	}
+}
 
-	// Auto-migrate: Microphone → PreferredMicrophones
+// upgrade applies the migrations newer than the config's version without saving.
+// Returns false when the config is already current.
+func (c *Config) upgrade() bool {
+	defer func() { c.keys = nil }()
+
+	from := c.ConfigVersion
+	if from >= len(migrations) {
+		return false
+	}
+
+	for _, m := range migrations[from:] {
+		m(c)
+	}
+	c.ConfigVersion = len(migrations)
+	log.Printf("[CONFIG] Migrated config from version %d to %d", from, c.ConfigVersion)
+	return true
+}
+
+// migrateMicrophone moves the legacy microphone field to preferred_microphones
+func migrateMicrophone(c *Config) {
 	if len(c.PreferredMicrophones) == 0 && c.Microphone != "" {
 		c.PreferredMicrophones = []string{c.Microphone}
 		log.Printf("[CONFIG] Migrated legacy 'microphone' field to 'preferred_microphones': %s", c.Microphone)
-		needsSave = true
 	}
+}
 
-	// Auto-migrate: Hotkey → Triggers
+// migrateHotkey moves the legacy hotkey field to triggers
+func migrateHotkey(c *Config) {
 	if len(c.Triggers) == 0 && c.Hotkey != "" {
 		c.Triggers = []string{c.Hotkey}
 		log.Printf("[CONFIG] Migrated legacy 'hotkey' field to 'triggers': %s", c.Hotkey)
-		needsSave = true
 	}
+}
 
-	// Auto-migrate: Add gain control defaults if missing (zero values)
-	// This handles configs created before gain control was added
+// migrateMissingDefaults fills in the defaults of settings added before configs were
+// versioned, which older configs leave at their zero values
+func migrateMissingDefaults(c *Config) {
+	defaults := DefaultConfig()
+
+	// Gain control (configs created before gain control was added)
 	if c.TargetLevelDB == 0 && c.MinThresholdDB == 0 && c.MaxGainDB == 0 {
-		defaults := DefaultConfig()
 		c.TargetLevelDB = defaults.TargetLevelDB
 		c.MinThresholdDB = defaults.MinThresholdDB
 		c.MaxGainDB = defaults.MaxGainDB
 		log.Printf("[CONFIG] Migrated gain control settings to defaults (target: %.1f dBFS, threshold: %.1f dBFS, max gain: %.1f dB)",
 			c.TargetLevelDB, c.MinThresholdDB, c.MaxGainDB)
-		needsSave = true
 	}
 
-	// Save migrated config if any migrations occurred
-	if needsSave {
-		if err := c.Save(); err != nil {
-			log.Printf("[CONFIG] Warning: Failed to save migrated config: %v", err)
-		}
+	// Feedback volume (configs created before volume control was added)
+	if c.FeedbackVolume == 0 {
+		c.FeedbackVolume = defaults.FeedbackVolume
+		log.Printf("[CONFIG] Migrated feedback volume to default (%.1f)", c.FeedbackVolume)
+	}
+
+	// Explicit paste mode (configs created when auto_paste always used the clipboard)
+	if c.PasteMode == "" {
+		c.PasteMode = defaults.PasteMode
+		log.Printf("[CONFIG] Migrated paste mode to default (%s)", c.PasteMode)
+	}
+
+	// Paste delays (configs created before they were configurable)
+	if c.PasteSettleMs == 0 && c.ClipboardRestoreMs == 0 {
+		c.PasteSettleMs = defaults.PasteSettleMs
+		c.ClipboardRestoreMs = defaults.ClipboardRestoreMs
+		log.Printf("[CONFIG] Migrated paste delays to defaults (settle: %dms, restore: %dms)", c.PasteSettleMs, c.ClipboardRestoreMs)
+	}
+
+	// Log rotation (configs created before logs were rotated)
+	if c.LogMaxSizeMB == 0 && c.LogMaxFiles == 0 {
+		c.LogMaxSizeMB = defaults.LogMaxSizeMB
+		c.LogMaxFiles = defaults.LogMaxFiles
+		log.Printf("[CONFIG] Migrated log rotation to defaults (%d MB, %d files)", c.LogMaxSizeMB, c.LogMaxFiles)
+	}
+
+	// Existing flat configs become the default profile
+	if c.ActiveProfile == "" {
+		c.ActiveProfile = DefaultProfile
+		log.Printf("[CONFIG] Migrated settings to the %q profile", DefaultProfile)
+	}
+
+	// Silence threshold (configs created before auto-stop was added)
+	if c.SilenceThresholdDB == 0 {
+		c.SilenceThresholdDB = defaults.SilenceThresholdDB
+		log.Printf("[CONFIG] Migrated silence threshold to default (%.1f dBFS)", c.SilenceThresholdDB)
+	}
+
+	// Max recording duration (configs created before it was configurable)
+	if c.MaxRecordingSeconds == 0 {
+		c.MaxRecordingSeconds = defaults.MaxRecordingSeconds
+		log.Printf("[CONFIG] Migrated max recording duration to default (%ds)", c.MaxRecordingSeconds)
+	}
+}
+
+// migrateCacheRetention turns on cache cleanup, added after configs were versioned,
+// unless the config already sets cache_retention_hours (0 keeps recordings)
+func migrateCacheRetention(c *Config) {
+	if c.keys["cache_retention_hours"] {
+		return
+	}
+	c.CacheRetentionHours = DefaultConfig().CacheRetentionHours
+	log.Printf("[CONFIG] Migrated cache retention to default (%dh)", c.CacheRetentionHours)
+}
+
+// migrateDeviceInitAttempts turns on retrying microphone initialization, unless the
+// config already sets a valid device_init_attempts
+func migrateDeviceInitAttempts(c *Config) {
+	if c.keys["device_init_attempts"] && c.DeviceInitAttempts > 0 {
+		return
+	}
+	c.DeviceInitAttempts = DefaultConfig().DeviceInitAttempts
+	log.Printf("[CONFIG] Migrated device init attempts to default (%d)", c.DeviceInitAttempts)
+}
+
+// migrateMinRecording turns on ignoring accidental taps, unless the config already
+// sets min_recording_ms (0 keeps every recording)
+func migrateMinRecording(c *Config) {
+	if c.keys["min_recording_ms"] {
+		return
+	}
+	c.MinRecordingMs = DefaultConfig().MinRecordingMs
+	log.Printf("[CONFIG] Migrated minimum recording length to default (%dms)", c.MinRecordingMs)
+}
+
+// migrateLoggingEnabled keeps logging on for configs written before it could be turned
+// off, unless they already set logging_enabled
+func migrateLoggingEnabled(c *Config) {
+	if c.keys["logging_enabled"] {
+		return
+	}
+	c.LoggingEnabled = true
+	log.Printf("[CONFIG] Migrated transcription logging to default (enabled)")
+}
+
+// migrateNoSpeechThreshold turns on discarding no-speech transcriptions for configs
+// that don't set no_speech_threshold (read from them, it was 0 and disabled)
+func migrateNoSpeechThreshold(c *Config) {
+	if c.keys["no_speech_threshold"] {
+		return
+	}
+	c.NoSpeechThreshold = DefaultConfig().NoSpeechThreshold
+	log.Printf("[CONFIG] Migrated no-speech threshold to default (%.1f)", c.NoSpeechThreshold)
+}
+
+// MaxRecordingDuration returns the length after which a recording is stopped automatically
+func (c *Config) MaxRecordingDuration() time.Duration {
+	return time.Duration(c.MaxRecordingSeconds) * time.Second
+}
+
+// PasteDelay returns how long to wait before pasting at the cursor (0 = no delay)
+func (c *Config) PasteDelay() time.Duration {
+	return time.Duration(c.PasteDelayMs) * time.Millisecond
+}
+
+// MinRecordingDuration returns the length below which a recording is ignored (0 = none)
+func (c *Config) MinRecordingDuration() time.Duration {
+	return time.Duration(c.MinRecordingMs) * time.Millisecond
+}
+
+// ToMap returns the settings keyed by their config.yaml names (for JSON output),
+// with the OpenAI API key masked
+func (c *Config) ToMap() (map[string]interface{}, error) {
+	masked := *c
+	if masked.OpenAIAPIKey != "" {
+		masked.OpenAIAPIKey = maskAPIKey(masked.OpenAIAPIKey)
+	}
+
+	data, err := yaml.Marshal(&masked)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	settings := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to convert config: %w", err)
 	}
+	return settings, nil
 }
 
-// Save writes the configuration to disk
+// maskAPIKey shows only the start and end of an API key
+func maskAPIKey(key string) string {
+	if len(key) <= 11 {
+		return "***"
+	}
+	return key[:7] + "..." + key[len(key)-4:]
+}
+
+// Save writes the configuration to disk.
+// A Config loaded from a named profile saves its changes to that profile.
 func (c *Config) Save() error {
+	if c.profile != "" {
+		return c.saveProfile()
+	}
+
 	configPath, err := GetConfigPath()
 	if err != nil {
 		return fmt.Errorf("failed to get config path: %w", err)
@@ -201,14 +707,56 @@ func (c *Config) Save() error {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
+	// Keep the previous version around; a failed backup must not block the save
+	if err := backupConfig(configPath); err != nil {
+		log.Printf("[CONFIG] Warning: Failed to back up config: %v", err)
+	}
+
 	// Write to file with appropriate permissions (0644)
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	if err := writeFileAtomic(configPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
 	return nil
 }
 
+// writeFileAtomic writes data to a temporary file next to path and renames it into place,
+// so a crash or full disk mid-write never leaves a truncated file behind
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tempFile := path + ".tmp"
+
+	f, err := os.OpenFile(tempFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tempFile)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tempFile)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tempFile)
+		return err
+	}
+
+	// OpenFile only applies perm to new files; a stale temp file may have other bits
+	if err := os.Chmod(tempFile, perm); err != nil {
+		_ = os.Remove(tempFile)
+		return err
+	}
+
+	if err := os.Rename(tempFile, path); err != nil {
+		_ = os.Remove(tempFile)
+		return err
+	}
+	return nil
+}
+
 // Validate checks if the configuration values are valid
 func (c *Config) Validate() error {
 	// Validate preferred microphones
@@ -236,6 +784,33 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid backend: %s (must be one of: whisper, moonshine, openai)", c.Backend)
 	}
 
+	// Validate model mirror URL
+	if c.ModelMirrorURL != "" {
+		u, err := url.Parse(c.ModelMirrorURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid model_mirror_url: %s (must be an http or https URL)", c.ModelMirrorURL)
+		}
+	}
+
+	// Validate replacements
+	for i, r := range c.Replacements {
+		if strings.TrimSpace(r.From) == "" {
+			return fmt.Errorf("replacements[%d].from cannot be empty", i)
+		}
+		if r.Regex {
+			if _, err := regexp.Compile(r.From); err != nil {
+				return fmt.Errorf("invalid regex in replacements[%d]: %w", i, err)
+			}
+		}
+	}
+
+	// Validate redacted words
+	for i, word := range c.Redact {
+		if strings.TrimSpace(word) == "" {
+			return fmt.Errorf("redact[%d] cannot be empty", i)
+		}
+	}
+
 	// Validate OpenAI backend requirements
 	if c.Backend == "openai" && c.OpenAIAPIKey == "" {
 		return fmt.Errorf("openai backend requires openai_api_key to be set. Use: openscribe config --set-openai-api-key <key>")
@@ -243,23 +818,38 @@ func (c *Config) Validate() error {
 
 	// Validate model (only enforce whisper model names when backend is whisper)
 	if c.Backend == "" || c.Backend == "whisper" {
+		// Mirrors models.AvailableModels (config can't import models)
 		validModels := map[string]bool{
-			"tiny":   true,
-			"base":   true,
-			"small":  true,
-			"medium": true,
-			"large":  true,
+			"tiny":        true,
+			"base":        true,
+			"small":       true,
+			"medium":      true,
+			"large":       true,
+			"large-turbo": true,
+			"tiny.en":     true,
+			"base.en":     true,
+			"small.en":    true,
+			"medium.en":   true,
+			"tiny-q5_1":   true,
+			"tiny-q8_0":   true,
+			"base-q5_1":   true,
+			"base-q8_0":   true,
+			"small-q5_1":  true,
+			"small-q8_0":  true,
+			"medium-q5_0": true,
+			"medium-q8_0": true,
+			"large-q5_0":  true,
 		}
 		if c.Model != "" && !validModels[c.Model] {
-			return fmt.Errorf("invalid model: %s (must be one of: tiny, base, small, medium, large)", c.Model)
+			return fmt.Errorf("invalid model: %s (must be one of: tiny, base, small, medium, large-turbo, large, an English-only variant such as base.en, or a quantized variant such as small-q5_1)", c.Model)
 		}
 	}
 
 	// Validate moonshine model if backend is moonshine
 	if c.Backend == "moonshine" && c.MoonshineModel != "" {
 		validMoonshineModels := map[string]bool{
-			"tiny":            true,
-			"base":            true,
+			"tiny":             true,
+			"base":             true,
 			"small-streaming":  true,
 			"medium-streaming": true,
 		}
@@ -273,7 +863,7 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("triggers cannot be empty - at least one trigger is required")
 	}
 
-	// Valid trigger names (keyboard modifiers + mouse buttons)
+	// Valid trigger names (keyboard modifiers, Caps Lock, F13-F19 + mouse buttons)
 	validTriggers := map[string]bool{
 		"Left Option":    true,
 		"Right Option":   true,
@@ -283,6 +873,14 @@ func (c *Config) Validate() error {
 		"Right Command":  true,
 		"Left Control":   true,
 		"Right Control":  true,
+		"Caps Lock":      true,
+		"F13":            true,
+		"F14":            true,
+		"F15":            true,
+		"F16":            true,
+		"F17":            true,
+		"F18":            true,
+		"F19":            true,
 		"Forward Button": true,
 		"Back Button":    true,
 	}
@@ -302,17 +900,178 @@ func (c *Config) Validate() error {
 		}
 		seenTriggers[lowerTrigger] = true
 
+		// Key combinations (e.g. "Cmd+Shift+Space") are parsed by the hotkey package
+		if hotkey.IsCombo(trimmed) {
+			if _, err := hotkey.ParseCombo(trimmed); err != nil {
+				return fmt.Errorf("invalid trigger: %w", err)
+			}
+			continue
+		}
+
 		// Validate trigger name
 		if !validTriggers[trimmed] {
-			return fmt.Errorf("invalid trigger: %s (must be one of: Left Option, Right Option, Left Shift, Right Shift, Left Command, Right Command, Left Control, Right Control, Forward Button, Back Button)", trimmed)
+			return fmt.Errorf("invalid trigger: %s (must be one of: Left Option, Right Option, Left Shift, Right Shift, Left Command, Right Command, Left Control, Right Control, Caps Lock, F13-F19, Forward Button, Back Button, or a key combination like Cmd+Shift+Space)", trimmed)
 		}
 	}
 
+	// Validate feedback sounds (empty means use the default)
+	for name, sound := range map[string]string{
+		"start_sound":    c.StartSound,
+		"stop_sound":     c.StopSound,
+		"complete_sound": c.CompleteSound,
+	} {
+		if sound != "" && !validSystemSounds[sound] {
+			return fmt.Errorf("invalid %s: %s (run 'openscribe config --list-sounds' to see available sounds)", name, sound)
+		}
+	}
+
+	// Validate custom feedback sound files
+	for name, path := range map[string]string{
+		"start_sound_file":    c.StartSoundFile,
+		"stop_sound_file":     c.StopSoundFile,
+		"complete_sound_file": c.CompleteSoundFile,
+	} {
+		if path == "" {
+			continue
+		}
+		if err := validateSoundFile(path); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+
+	// Validate feedback volume
+	if c.FeedbackVolume < 0 || c.FeedbackVolume > 1 {
+		return fmt.Errorf("feedback_volume must be between 0.0 and 1.0")
+	}
+
+	// Validate hotkey mode (empty means toggle)
+	if c.HotkeyMode != "" && c.HotkeyMode != "toggle" && c.HotkeyMode != "hold" {
+		return fmt.Errorf("invalid hotkey_mode: %s (must be toggle or hold)", c.HotkeyMode)
+	}
+
+	// Caps Lock reports no release, so a hold-mode recording could never stop
+	if c.HotkeyMode == "hold" {
+		for _, trigger := range c.Triggers {
+			if strings.TrimSpace(trigger) == "Caps Lock" {
+				return fmt.Errorf("Caps Lock cannot be used with hotkey_mode: hold (it reports no key release); use toggle mode or another trigger")
+			}
+		}
+	}
+
+	// Validate trigger mode (empty means double)
+	if c.TriggerMode != "" && c.TriggerMode != "double" && c.TriggerMode != "single" {
+		return fmt.Errorf("invalid trigger_mode: %s (must be double or single)", c.TriggerMode)
+	}
+
+	// Validate paste mode (empty means clipboard)
+	if c.PasteMode != "" && c.PasteMode != "clipboard" && c.PasteMode != "type" && c.PasteMode != "copy" {
+		return fmt.Errorf("invalid paste_mode: %s (must be clipboard, type or copy)", c.PasteMode)
+	}
+
+	// Validate the paste delay (0 disables it)
+	if c.PasteDelayMs < 0 || c.PasteDelayMs > MaxPasteDelayMs {
+		return fmt.Errorf("paste_delay_ms must be between 0 and %d", MaxPasteDelayMs)
+	}
+
+	// Validate output mode (empty means paste)
+	switch c.OutputMode {
+	case "", "paste", "clipboard", "stdout", "none":
+	default:
+		return fmt.Errorf("invalid output_mode: %s (must be paste, clipboard, stdout or none)", c.OutputMode)
+	}
+
+	// Validate clipboard paste delays
+	if c.PasteSettleMs < 0 || c.PasteSettleMs > MaxPasteDelayMs {
+		return fmt.Errorf("paste_settle_ms must be between 0 and %d", MaxPasteDelayMs)
+	}
+	if c.ClipboardRestoreMs < 0 || c.ClipboardRestoreMs > MaxPasteDelayMs {
+		return fmt.Errorf("clipboard_restore_ms must be between 0 and %d", MaxPasteDelayMs)
+	}
+
+	// Validate profiles
+	if err := c.validateProfiles(); err != nil {
+		return err
+	}
+
+	// Validate log rotation
+	if c.LogMaxSizeMB < 0 {
+		return fmt.Errorf("log_max_size_mb must be non-negative (0 = never rotate)")
+	}
+	if c.LogMaxFiles < 0 || c.LogMaxFiles > MaxLogFiles {
+		return fmt.Errorf("log_max_files must be between 0 and %d", MaxLogFiles)
+	}
+
 	// Warn if both legacy Hotkey and new Triggers are set
 	if c.Hotkey != "" && len(c.Triggers) > 0 {
 		log.Printf("[CONFIG] Warning: Both 'hotkey' (legacy) and 'triggers' are set. Using 'triggers' field.")
 	}
 
+	// Validate whisper thread count
+	if c.Threads < 0 {
+		return fmt.Errorf("threads must be non-negative (0 = auto-detect)")
+	}
+	if c.Threads > MaxThreads {
+		return fmt.Errorf("threads is too high (%d), maximum is %d", c.Threads, MaxThreads)
+	}
+
+	// Validate silence auto-stop settings
+	if c.SilenceTimeoutSeconds < 0 {
+		return fmt.Errorf("silence_timeout_seconds must be non-negative (0 = disabled)")
+	}
+	if c.SilenceThresholdDB > 0 {
+		return fmt.Errorf("silence_threshold_db must be negative (dBFS scale, 0 = max level)")
+	}
+
+	// Validate the noise gate (a level, or 0 to disable it)
+	if c.NoiseGateDB > 0 {
+		return fmt.Errorf("noise_gate_db must be negative (dBFS scale, 0 = disabled)")
+	}
+
+	// Validate the recording length limit
+	if c.MaxRecordingSeconds < 1 || c.MaxRecordingSeconds > MaxRecordingSecondsLimit {
+		return fmt.Errorf("max_recording_seconds must be between 1 and %d", MaxRecordingSecondsLimit)
+	}
+
+	// Validate the auto-detection candidates (whisper-cli rejects codes it doesn't know)
+	seenLanguages := make(map[string]bool)
+	for _, code := range c.DetectLanguages {
+		if code == "" || code == "auto" || code != strings.ToLower(strings.TrimSpace(code)) {
+			return fmt.Errorf("invalid language in detect_languages: %q (use lowercase codes such as en or fr)", code)
+		}
+		if seenLanguages[code] {
+			return fmt.Errorf("duplicate language in detect_languages: %s", code)
+		}
+		seenLanguages[code] = true
+	}
+
+	// Validate the minimum recording length (0 disables it)
+	if c.MinRecordingMs < 0 || c.MinRecordingMs >= c.MaxRecordingSeconds*1000 {
+		return fmt.Errorf("min_recording_ms must be between 0 and max_recording_seconds (%dms)", c.MaxRecordingSeconds*1000)
+	}
+
+	// Validate the audio backend name (availability is checked when setting it)
+	// Mirrors audio's backend names (config can't import audio)
+	switch c.AudioBackend {
+	case "", "auto", "coreaudio", "wasapi", "dsound", "winmm", "pulseaudio", "alsa", "jack", "oss", "sndio", "audio4":
+	default:
+		return fmt.Errorf("invalid audio_backend: %s (see openscribe config --list-audio-backends)", c.AudioBackend)
+	}
+
+	// Validate microphone initialization attempts
+	if c.DeviceInitAttempts < 1 || c.DeviceInitAttempts > MaxDeviceInitAttempts {
+		return fmt.Errorf("device_init_attempts must be between 1 and %d", MaxDeviceInitAttempts)
+	}
+
+	// Validate the cache retention (0 keeps recordings)
+	if c.CacheRetentionHours < 0 {
+		return fmt.Errorf("cache_retention_hours must be 0 (keep recordings) or positive")
+	}
+
+	// Validate no-speech threshold (a probability)
+	if c.NoSpeechThreshold < 0 || c.NoSpeechThreshold > 1 {
+		return fmt.Errorf("no_speech_threshold must be between 0 and 1 (0 = disabled)")
+	}
+
 	// Note: We don't validate language codes as Whisper supports many languages
 	// and we don't want to restrict users to a predefined list
 
@@ -332,6 +1091,12 @@ func (c *Config) Validate() error {
 	if c.MaxGainDB > 40 {
 		return fmt.Errorf("max_gain_db is too high (%.1f dB), maximum recommended is 40 dB", c.MaxGainDB)
 	}
+	if c.GainDB < -MaxGainDBLimit || c.GainDB > MaxGainDBLimit {
+		return fmt.Errorf("gain_db must be between %.0f and %.0f dB (0 = off)", -MaxGainDBLimit, MaxGainDBLimit)
+	}
+	if c.Normalize && c.GainDB != 0 {
+		return fmt.Errorf("normalize and gain_db are alternatives: set gain_db to 0 or disable normalize")
+	}
 
 	return nil
 }
@@ -342,6 +1107,12 @@ func (c *Config) String() string {
 	modelsDir, _ := GetModelsDir()
 	cacheDir, _ := GetCacheDir()
 	logsDir, _ := GetLogsDir()
+	recordings := "not kept"
+	if c.KeepRecordings && !c.LoggingEnabled {
+		recordings = "not kept (logging disabled)"
+	} else if c.KeepRecordings {
+		recordings, _ = GetRecordingsDir()
+	}
 
 	microphone := c.Microphone
 	if microphone == "" {
@@ -362,6 +1133,9 @@ func (c *Config) String() string {
 	language := c.Language
 	if language == "" {
 		language = "auto-detect"
+		if len(c.DetectLanguages) > 0 {
+			language += " (" + strings.Join(c.DetectLanguages, ", ") + ")"
+		}
 	}
 
 	// Format triggers list
@@ -375,12 +1149,89 @@ func (c *Config) String() string {
 		}
 	}
 
+	pasteMode := c.PasteMode
+	if pasteMode == "" {
+		pasteMode = "clipboard"
+	}
+	outputMode := c.OutputMode
+	if outputMode == "" {
+		outputMode = "paste"
+	}
+
+	hotkeyMode := c.HotkeyMode
+	if hotkeyMode == "" {
+		hotkeyMode = "toggle"
+	}
+	if hotkeyMode == "toggle" {
+		triggerMode := c.TriggerMode
+		if triggerMode == "" {
+			triggerMode = "double"
+		}
+		hotkeyMode += fmt.Sprintf(" (%s press)", triggerMode)
+	}
+
 	// Show legacy hotkey if present
 	var hotkeyDisplay string
 	if c.Hotkey != "" {
 		hotkeyDisplay = fmt.Sprintf("  Hotkey (legacy):  %s\n", c.Hotkey)
 	}
 
+	threads := "auto"
+	if c.Threads > 0 {
+		threads = fmt.Sprintf("%d", c.Threads)
+	}
+
+	soundOrDefault := func(file, name, def string) string {
+		if file != "" {
+			return file
+		}
+		if name == "" {
+			return def
+		}
+		return name
+	}
+	sounds := fmt.Sprintf("start=%s, stop=%s, complete=%s",
+		soundOrDefault(c.StartSoundFile, c.StartSound, "Tink"),
+		soundOrDefault(c.StopSoundFile, c.StopSound, "Pop"),
+		soundOrDefault(c.CompleteSoundFile, c.CompleteSound, "Glass"))
+
+	silenceStop := "disabled"
+	if c.SilenceTimeoutSeconds > 0 {
+		silenceStop = fmt.Sprintf("after %.1fs below %.1f dBFS", c.SilenceTimeoutSeconds, c.SilenceThresholdDB)
+	}
+
+	pasteDelay := "none"
+	if c.PasteDelayMs > 0 {
+		pasteDelay = fmt.Sprintf("%dms", c.PasteDelayMs)
+	}
+
+	minRecording := "disabled"
+	if c.MinRecordingMs > 0 {
+		minRecording = fmt.Sprintf("%dms", c.MinRecordingMs)
+	}
+
+	noiseGate := "disabled"
+	if c.NoiseGateDB < 0 {
+		noiseGate = fmt.Sprintf("below %.1f dBFS", c.NoiseGateDB)
+	}
+
+	manualGain := "off"
+	if c.Normalize {
+		manualGain = fmt.Sprintf("normalize to %.0f%% peak", NormalizePeak*100)
+	} else if c.GainDB != 0 {
+		manualGain = fmt.Sprintf("%+.1f dB", c.GainDB)
+	}
+
+	logRotation := "disabled"
+	if c.LogMaxSizeMB > 0 {
+		logRotation = fmt.Sprintf("at %d MB, keep %d file(s)", c.LogMaxSizeMB, c.LogMaxFiles)
+	}
+
+	prompt := "(none)"
+	if c.Prompt != "" {
+		prompt = fmt.Sprintf("%q", c.Prompt)
+	}
+
 	backend := c.Backend
 	if backend == "" {
 		backend = "whisper"
@@ -405,36 +1256,83 @@ func (c *Config) String() string {
 		}
 		keyDisplay := "(not set)"
 		if c.OpenAIAPIKey != "" {
-			keyDisplay = c.OpenAIAPIKey[:7] + "..." + c.OpenAIAPIKey[len(c.OpenAIAPIKey)-4:]
+			keyDisplay = maskAPIKey(c.OpenAIAPIKey)
 		}
 		openaiDisplay = fmt.Sprintf("\n  OpenAI Model:    %s\n  OpenAI API Key:  %s", om, keyDisplay)
 	}
 
+	// Show the enabled text transforms
+	var transforms []string
+	if c.CapitalizeFirst {
+		transforms = append(transforms, "capitalize first")
+	}
+	if c.EnsurePunctuation {
+		transforms = append(transforms, "ensure punctuation")
+	}
+	if c.TrailingSpace {
+		transforms = append(transforms, "trailing space")
+	}
+	cacheRetention := "recordings kept"
+	if c.CacheRetentionHours > 0 {
+		cacheRetention = fmt.Sprintf("recordings removed after %dh", c.CacheRetentionHours)
+	}
+
+	textFormat := "(none)"
+	if len(transforms) > 0 {
+		textFormat = strings.Join(transforms, ", ")
+	}
+
 	return fmt.Sprintf(`Current Configuration:
 
 Settings:
+  Profile:         %s
   Backend:         %s%s%s
   Microphone:      %s (legacy)
   Preferred Mics:  %s
   Model:           %s
   Language:        %s
-  Triggers:        %s%s  Auto-paste:      %t
+  Prompt:          %s
+  Replacements:    %d
+  Redacted Words:  %d
+  Hotkey Mode:     %s
+  Triggers:        %s%s  Output:          %s
+  Auto-paste:      %t
+  Paste Mode:      %s
+  Paste Delay:     %s
+  Strip Newlines:  %t
+  Text Format:     %s
   Audio Feedback:  %t
+  Sounds:          %s
+  Feedback Volume: %.0f%%
+  Notifications:   %t
+  Threads:         %s
+  Streaming:       %t
   Verbose:         %t
+  Logging:         %t
+  Log Encryption:  %t
+  Log Rotation:    %s
 
 Audio Gain Control:
   Auto Gain:       %t
   Target Level:    %.1f dBFS
   Min Threshold:   %.1f dBFS
   Max Gain:        %.1f dB
+  Manual Gain:     %s
   Show Levels:     %t
+  Silence Stop:    %s
+  Max Recording:   %ds
+  Min Recording:   %s
+  Trim Silence:    %t
+  Noise Gate:      %s
 
 Paths:
   Config:          %s
   Models:          %s
-  Cache:           %s
+  Cache:           %s (%s)
   Logs:            %s
+  Recordings:      %s
 `,
+		c.Profile(),
 		backend,
 		moonshineDisplay,
 		openaiDisplay,
@@ -442,19 +1340,44 @@ Paths:
 		preferredMics,
 		c.Model,
 		language,
+		prompt,
+		len(c.Replacements),
+		len(c.Redact),
+		hotkeyMode,
 		triggers,
 		hotkeyDisplay,
+		outputMode,
 		c.AutoPaste,
+		pasteMode,
+		pasteDelay,
+		c.StripNewlines,
+		textFormat,
 		c.AudioFeedback,
+		sounds,
+		c.FeedbackVolume*100,
+		c.DesktopNotifications,
+		threads,
+		c.Streaming,
 		c.Verbose,
+		c.LoggingEnabled,
+		c.EncryptLog,
+		logRotation,
 		c.AutoGain,
 		c.TargetLevelDB,
 		c.MinThresholdDB,
 		c.MaxGainDB,
+		manualGain,
 		c.ShowAudioLevels,
+		silenceStop,
+		c.MaxRecordingSeconds,
+		minRecording,
+		c.TrimSilence,
+		noiseGate,
 		configPath,
 		modelsDir,
 		cacheDir,
+		cacheRetention,
 		logsDir,
+		recordings,
 	)
 }
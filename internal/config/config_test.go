@@ -1,9 +1,13 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -186,7 +190,7 @@ func TestSave_CreatesDirectories(t *testing.T) {
 }
 
 func TestValidate_ValidModels(t *testing.T) {
-	validModels := []string{"tiny", "base", "small", "medium", "large"}
+	validModels := []string{"tiny", "base", "small", "medium", "large", "large-turbo", "base.en", "medium.en", "small-q5_1", "medium-q8_0", "large-q5_0"}
 
 	for _, model := range validModels {
 		t.Run(model, func(t *testing.T) {
@@ -202,7 +206,7 @@ func TestValidate_ValidModels(t *testing.T) {
 }
 
 func TestValidate_InvalidModel(t *testing.T) {
-	invalidModels := []string{"invalid", "extra-large", "xl", "SMALL", "tiny-en"}
+	invalidModels := []string{"invalid", "extra-large", "xl", "SMALL", "tiny-en", "large.en", "small-q4_0"}
 
 	for _, model := range invalidModels {
 		t.Run(model, func(t *testing.T) {
@@ -795,3 +799,1010 @@ func TestString_WithGainControl(t *testing.T) {
 		t.Error("String() should contain default target level '-18.0 dBFS'")
 	}
 }
+
+func TestValidate_Threads(t *testing.T) {
+	tests := []struct {
+		name    string
+		threads int
+		wantErr bool
+	}{
+		{"Auto-detect", 0, false},
+		{"Single thread", 1, false},
+		{"Maximum", MaxThreads, false},
+		{"Negative", -1, true},
+		{"Too many", MaxThreads + 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Threads = tt.threads
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() with Threads=%d error = %v, wantErr %v", tt.threads, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_NoSpeechThreshold(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold float64
+		wantErr   bool
+	}{
+		{"Disabled", 0, false},
+		{"Default", 0.6, false},
+		{"Maximum", 1, false},
+		{"Negative", -0.1, true},
+		{"Above one", 1.5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.NoSpeechThreshold = tt.threshold
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() with NoSpeechThreshold=%v error = %v, wantErr %v", tt.threshold, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_FeedbackSounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{"Defaults", func(c *Config) {}, false},
+		{"Empty uses default", func(c *Config) { c.StartSound = "" }, false},
+		{"Valid custom sound", func(c *Config) { c.CompleteSound = "Hero" }, false},
+		{"Unknown start sound", func(c *Config) { c.StartSound = "Trumpet" }, true},
+		{"Unknown stop sound", func(c *Config) { c.StopSound = "tink" }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_FeedbackVolume(t *testing.T) {
+	tests := []struct {
+		name    string
+		volume  float64
+		wantErr bool
+	}{
+		{"Full volume", 1.0, false},
+		{"Half volume", 0.5, false},
+		{"Silent", 0, false},
+		{"Negative", -0.1, true},
+		{"Above maximum", 1.5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.FeedbackVolume = tt.volume
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() with FeedbackVolume=%v error = %v, wantErr %v", tt.volume, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_CapsLockHoldMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		triggers []string
+		wantErr  bool
+	}{
+		{"Caps Lock toggle", "toggle", []string{"Caps Lock"}, false},
+		{"Caps Lock hold", "hold", []string{"Caps Lock"}, true},
+		{"Caps Lock among others in hold", "hold", []string{"Right Option", "Caps Lock"}, true},
+		{"Other key hold", "hold", []string{"Right Option"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.HotkeyMode = tt.mode
+			cfg.Triggers = tt.triggers
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() with HotkeyMode=%s, Triggers=%v error = %v, wantErr %v", tt.mode, tt.triggers, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_FeedbackSoundFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	wavPath := filepath.Join(dir, "ping.wav")
+	wavHeader := append([]byte("RIFF\x24\x00\x00\x00WAVE"), make([]byte, 32)...)
+	if err := os.WriteFile(wavPath, wavHeader, 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	textPath := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(textPath, []byte("definitely not audio"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{"No custom files", func(c *Config) {}, false},
+		{"Valid WAV file", func(c *Config) { c.StartSoundFile = wavPath }, false},
+		{"Missing file", func(c *Config) { c.StopSoundFile = filepath.Join(dir, "missing.wav") }, true},
+		{"Directory", func(c *Config) { c.CompleteSoundFile = dir }, true},
+		{"Not an audio file", func(c *Config) { c.StartSoundFile = textPath }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_HotkeyMode(t *testing.T) {
+	tests := []struct {
+		mode    string
+		wantErr bool
+	}{
+		{"", false},
+		{"toggle", false},
+		{"hold", false},
+		{"press", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.HotkeyMode = tt.mode
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() with HotkeyMode=%q error = %v, wantErr %v", tt.mode, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_TriggerMode(t *testing.T) {
+	tests := []struct {
+		mode    string
+		wantErr bool
+	}{
+		{"", false},
+		{"double", false},
+		{"single", false},
+		{"triple", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.TriggerMode = tt.mode
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() with TriggerMode=%q error = %v, wantErr %v", tt.mode, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_PasteMode(t *testing.T) {
+	tests := []struct {
+		mode    string
+		wantErr bool
+	}{
+		{"", false},
+		{"clipboard", false},
+		{"type", false},
+		{"copy", false},
+		{"keystrokes", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.PasteMode = tt.mode
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() with PasteMode=%q error = %v, wantErr %v", tt.mode, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_PasteDelays(t *testing.T) {
+	tests := []struct {
+		name      string
+		settleMs  int
+		restoreMs int
+		wantErr   bool
+	}{
+		{"defaults", 10, 50, false},
+		{"zero delays", 0, 0, false},
+		{"slow app", 50, 500, false},
+		{"negative settle", -1, 50, true},
+		{"negative restore", 10, -1, true},
+		{"restore too long", 10, MaxPasteDelayMs + 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.PasteSettleMs = tt.settleMs
+			cfg.ClipboardRestoreMs = tt.restoreMs
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_PasteDelayMs(t *testing.T) {
+	tests := []struct {
+		ms      int
+		wantErr bool
+	}{
+		{0, false},
+		{3000, false},
+		{MaxPasteDelayMs, false},
+		{-1, true},
+		{MaxPasteDelayMs + 1, true},
+	}
+
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		cfg.PasteDelayMs = tt.ms
+		if err := cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate() with paste_delay_ms %d error = %v, wantErr %v", tt.ms, err, tt.wantErr)
+		}
+	}
+}
+
+func TestMigrate_PasteDefaults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Config written before the paste delays were configurable
+	yamlContent := `model: "small"
+triggers: ["Right Option"]
+auto_paste: true
+`
+	if err := EnsureDirectories(); err != nil {
+		t.Fatalf("EnsureDirectories() error = %v", err)
+	}
+	configPath, _ := GetConfigPath()
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if loaded.PasteSettleMs != 10 || loaded.ClipboardRestoreMs != 50 {
+		t.Errorf("paste delays = %dms/%dms, want 10ms/50ms", loaded.PasteSettleMs, loaded.ClipboardRestoreMs)
+	}
+	if !loaded.AutoPaste || loaded.PasteMode != "clipboard" {
+		t.Errorf("auto_paste = %t, paste_mode = %q, want true, \"clipboard\"", loaded.AutoPaste, loaded.PasteMode)
+	}
+	if loaded.LogMaxSizeMB != 10 || loaded.LogMaxFiles != 5 {
+		t.Errorf("log rotation = %d MB/%d files, want 10 MB/5 files", loaded.LogMaxSizeMB, loaded.LogMaxFiles)
+	}
+}
+
+func TestValidate_ModelMirrorURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{"unset", "", false},
+		{"https base URL", "https://models.example.com/whisper", false},
+		{"template", "http://10.0.0.5:8080/{filename}", false},
+		{"missing scheme", "models.example.com/whisper", true},
+		{"unsupported scheme", "ftp://models.example.com", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.ModelMirrorURL = tt.url
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() with ModelMirrorURL=%q error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_ComboTriggers(t *testing.T) {
+	tests := []struct {
+		name      string
+		triggers  []string
+		expectErr bool
+	}{
+		{"combo", []string{"Cmd+Shift+Space"}, false},
+		{"combo with single key", []string{"Right Option", "Ctrl+Opt+K"}, false},
+		{"unknown combo key", []string{"Cmd+F99"}, true},
+		{"combo without modifier", []string{"Foo+Space"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Triggers = tt.triggers
+
+			err := cfg.Validate()
+			if tt.expectErr && err == nil {
+				t.Errorf("Validate() with triggers %v expected error, got nil", tt.triggers)
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Validate() with triggers %v unexpected error: %v", tt.triggers, err)
+			}
+		})
+	}
+}
+
+func TestValidate_LogRotation(t *testing.T) {
+	tests := []struct {
+		name    string
+		sizeMB  int
+		files   int
+		wantErr bool
+	}{
+		{"defaults", 10, 5, false},
+		{"rotation disabled", 0, 5, false},
+		{"zero retention", 10, 0, false},
+		{"max files", 10, MaxLogFiles, false},
+		{"negative size", -1, 5, true},
+		{"negative files", 10, -1, true},
+		{"too many files", 10, MaxLogFiles + 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.LogMaxSizeMB = tt.sizeMB
+			cfg.LogMaxFiles = tt.files
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestToMap(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.OpenAIAPIKey = "sk-proj-abcdefghijklmnop1234"
+	cfg.Triggers = []string{"Right Option", "F13"}
+
+	settings, err := cfg.ToMap()
+	if err != nil {
+		t.Fatalf("ToMap() error = %v", err)
+	}
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		t.Fatalf("settings are not JSON-encodable: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if decoded["model"] != "small" {
+		t.Errorf("model = %v, want small", decoded["model"])
+	}
+	if decoded["auto_paste"] != true {
+		t.Errorf("auto_paste = %v, want true", decoded["auto_paste"])
+	}
+	if triggers, ok := decoded["triggers"].([]interface{}); !ok || len(triggers) != 2 {
+		t.Errorf("triggers = %v, want 2 triggers", decoded["triggers"])
+	}
+	if decoded["openai_api_key"] != "sk-proj...1234" {
+		t.Errorf("openai_api_key = %v, want it masked", decoded["openai_api_key"])
+	}
+	if cfg.OpenAIAPIKey != "sk-proj-abcdefghijklmnop1234" {
+		t.Error("ToMap() must not modify the config")
+	}
+}
+
+func TestMaskAPIKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"sk-proj-abcdefghijklmnop1234", "sk-proj...1234"},
+		{"short", "***"},
+		{"12345678901", "***"},
+	}
+	for _, tt := range tests {
+		if got := maskAPIKey(tt.key); got != tt.want {
+			t.Errorf("maskAPIKey(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	if err := os.WriteFile(path, []byte("old: true\n"), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	// A temp file left over from an interrupted write is replaced
+	if err := os.WriteFile(path+".tmp", []byte("partial"), 0600); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	if err := writeFileAtomic(path, []byte("new: true\n"), 0644); err != nil {
+		t.Fatalf("writeFileAtomic() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(data) != "new: true\n" {
+		t.Errorf("content = %q, want %q", data, "new: true\n")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat file: %v", err)
+	}
+	if info.Mode().Perm() != 0644 {
+		t.Errorf("permissions = %o, want 0644", info.Mode().Perm())
+	}
+
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temp file left behind, stat error = %v", err)
+	}
+}
+
+func TestWriteFileAtomic_RenameFailureKeepsOriginal(t *testing.T) {
+	dir := t.TempDir()
+	// Renaming a file over a non-empty directory fails
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.MkdirAll(filepath.Join(path, "child"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	if err := writeFileAtomic(path, []byte("new: true\n"), 0644); err == nil {
+		t.Fatal("writeFileAtomic() error = nil, want error")
+	}
+
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		t.Errorf("original should be untouched, stat = %v, %v", info, err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temp file left behind, stat error = %v", err)
+	}
+}
+
+func TestValidate_Replacements(t *testing.T) {
+	tests := []struct {
+		name         string
+		replacements []Replacement
+		wantErr      string
+	}{
+		{"none", nil, ""},
+		{"literal", []Replacement{{From: "cube and eddies", To: "Kubernetes"}}, ""},
+		{"regex", []Replacement{{From: `(\d+) percent`, To: "$1%", Regex: true}}, ""},
+		{"deletion", []Replacement{{From: "um", To: ""}}, ""},
+		{"empty from", []Replacement{{From: "a", To: "b"}, {From: " ", To: "x"}}, "replacements[1].from cannot be empty"},
+		{"invalid regex", []Replacement{{From: "(", To: "x", Regex: true}}, "invalid regex in replacements[0]"},
+		{"parenthesis as literal", []Replacement{{From: "(", To: "x"}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Replacements = tt.replacements
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Redact(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Redact = []string{"darn", "project falcon"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+
+	cfg.Redact = []string{"darn", " "}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "redact[1] cannot be empty") {
+		t.Errorf("Validate() error = %v, want error containing 'redact[1] cannot be empty'", err)
+	}
+}
+
+func TestValidate_MaxRecordingSeconds(t *testing.T) {
+	tests := []struct {
+		seconds int
+		wantErr bool
+	}{
+		{1, false},
+		{300, false},
+		{MaxRecordingSecondsLimit, false},
+		{0, true},
+		{-5, true},
+		{MaxRecordingSecondsLimit + 1, true},
+	}
+
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		cfg.MaxRecordingSeconds = tt.seconds
+		err := cfg.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Validate() with max_recording_seconds=%d error = %v, wantErr %v", tt.seconds, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidate_DetectLanguages(t *testing.T) {
+	tests := []struct {
+		languages []string
+		wantErr   bool
+	}{
+		{nil, false},
+		{[]string{"en", "fr"}, false},
+		{[]string{"yue"}, false},
+		{[]string{""}, true},
+		{[]string{"auto"}, true},
+		{[]string{"EN"}, true},
+		{[]string{"en", "en"}, true},
+	}
+
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		cfg.DetectLanguages = tt.languages
+		err := cfg.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Validate() with detect_languages=%q error = %v, wantErr %v", tt.languages, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidate_MinRecordingMs(t *testing.T) {
+	tests := []struct {
+		ms      int
+		wantErr bool
+	}{
+		{0, false},
+		{300, false},
+		{299999, false},
+		{-1, true},
+		{300000, true}, // Not shorter than max_recording_seconds (300s)
+	}
+
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		cfg.MinRecordingMs = tt.ms
+		err := cfg.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Validate() with min_recording_ms=%d error = %v, wantErr %v", tt.ms, err, tt.wantErr)
+		}
+	}
+}
+
+func TestLoad_MigratesMaxRecordingSeconds(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Configs from before max_recording_seconds existed predate the current schema
+	cfg := DefaultConfig()
+	cfg.ConfigVersion = 2
+	cfg.MaxRecordingSeconds = 0
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.MaxRecordingSeconds != 300 {
+		t.Errorf("MaxRecordingSeconds = %d, want 300", loaded.MaxRecordingSeconds)
+	}
+	if loaded.MaxRecordingDuration() != 5*time.Minute {
+		t.Errorf("MaxRecordingDuration() = %v, want 5m", loaded.MaxRecordingDuration())
+	}
+}
+
+func TestValidate_ManualGain(t *testing.T) {
+	tests := []struct {
+		name      string
+		normalize bool
+		gainDB    float64
+		wantErr   string
+	}{
+		{"off", false, 0, ""},
+		{"normalize", true, 0, ""},
+		{"boost", false, 12, ""},
+		{"cut", false, -6, ""},
+		{"too much gain", false, 41, "gain_db must be between"},
+		{"too much cut", false, -41, "gain_db must be between"},
+		{"both", true, 6, "normalize and gain_db are alternatives"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Normalize = tt.normalize
+			cfg.GainDB = tt.gainDB
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_NoiseGateDB(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.NoiseGateDB != 0 {
+		t.Errorf("default NoiseGateDB = %.1f, want 0 (disabled)", cfg.NoiseGateDB)
+	}
+
+	cfg.NoiseGateDB = -50
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+
+	cfg.NoiseGateDB = 3
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "noise_gate_db must be negative") {
+		t.Errorf("Validate() error = %v, want error containing 'noise_gate_db must be negative'", err)
+	}
+}
+
+func TestLoad_MigratesLegacyConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// A version 0 config written before preferred microphones and triggers existed
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatalf("GetConfigPath() error = %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	legacy := "microphone: USB Mic\nhotkey: Right Option\nmodel: small\n"
+	if err := os.WriteFile(configPath, []byte(legacy), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.ConfigVersion != CurrentConfigVersion {
+		t.Errorf("ConfigVersion = %d, want %d", cfg.ConfigVersion, CurrentConfigVersion)
+	}
+	if len(cfg.PreferredMicrophones) != 1 || cfg.PreferredMicrophones[0] != "USB Mic" {
+		t.Errorf("PreferredMicrophones = %v, want [USB Mic]", cfg.PreferredMicrophones)
+	}
+	if len(cfg.Triggers) != 1 || cfg.Triggers[0] != "Right Option" {
+		t.Errorf("Triggers = %v, want [Right Option]", cfg.Triggers)
+	}
+	defaults := DefaultConfig()
+	if cfg.FeedbackVolume != defaults.FeedbackVolume || cfg.PasteMode != defaults.PasteMode ||
+		cfg.MaxRecordingSeconds != defaults.MaxRecordingSeconds || cfg.ActiveProfile != DefaultProfile {
+		t.Errorf("migrated defaults not filled in: %+v", cfg)
+	}
+
+	// The upgrade is saved so it only runs once
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if want := fmt.Sprintf("config_version: %d", CurrentConfigVersion); !strings.Contains(string(data), want) {
+		t.Errorf("saved config missing %q:\n%s", want, data)
+	}
+}
+
+func TestMigrate_SkipsCurrentVersion(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// A zero feedback volume is only a missing setting in configs older than the current schema
+	cfg := DefaultConfig()
+	cfg.FeedbackVolume = 0
+	cfg.migrate()
+
+	if cfg.FeedbackVolume != 0 {
+		t.Errorf("FeedbackVolume = %v, want 0 (current config re-migrated)", cfg.FeedbackVolume)
+	}
+	path, _ := GetConfigPath()
+	if _, err := os.Stat(path); err == nil {
+		t.Error("migrate() saved a config that was already current")
+	}
+}
+
+func TestMigrate_RunsRemainingMigrationsInOrder(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// At version 1 the microphone migration has already run; later ones still apply
+	cfg := &Config{ConfigVersion: 1, Microphone: "USB Mic", Hotkey: "Right Option"}
+	cfg.migrate()
+
+	if len(cfg.PreferredMicrophones) != 0 {
+		t.Errorf("PreferredMicrophones = %v, want none (migration already applied)", cfg.PreferredMicrophones)
+	}
+	if len(cfg.Triggers) != 1 || cfg.Triggers[0] != "Right Option" {
+		t.Errorf("Triggers = %v, want [Right Option]", cfg.Triggers)
+	}
+	if cfg.ConfigVersion != CurrentConfigVersion {
+		t.Errorf("ConfigVersion = %d, want %d", cfg.ConfigVersion, CurrentConfigVersion)
+	}
+	if len(migrations) != CurrentConfigVersion {
+		t.Errorf("len(migrations) = %d, want CurrentConfigVersion (%d)", len(migrations), CurrentConfigVersion)
+	}
+}
+
+func TestMigrate_LoggingEnabled(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Configs from before logging_enabled existed keep logging on
+	cfg := &Config{ConfigVersion: 6}
+	cfg.migrate()
+	if !cfg.LoggingEnabled {
+		t.Error("LoggingEnabled = false after migrating a version 6 config, want true")
+	}
+
+	// An explicit false is kept
+	cfg, err := parseConfig([]byte("config_version: 6\nlogging_enabled: false\n"))
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	cfg.migrate()
+	if cfg.LoggingEnabled {
+		t.Error("LoggingEnabled = true after migrating an explicit false, want false")
+	}
+
+	// Turning it off sticks once the config is current
+	cfg = &Config{ConfigVersion: CurrentConfigVersion}
+	cfg.migrate()
+	if cfg.LoggingEnabled {
+		t.Error("LoggingEnabled = true for a current config, want it left off")
+	}
+}
+
+func TestMigrate_NoSpeechThreshold(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Configs that don't set it get the default
+	cfg, err := parseConfig([]byte("config_version: 7\nmodel: small\n"))
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	cfg.migrate()
+	if cfg.NoSpeechThreshold != DefaultConfig().NoSpeechThreshold {
+		t.Errorf("NoSpeechThreshold = %v after migrating a config without it, want %v", cfg.NoSpeechThreshold, DefaultConfig().NoSpeechThreshold)
+	}
+
+	// An explicit 0 (disabled) is kept
+	cfg, err = parseConfig([]byte("config_version: 7\nno_speech_threshold: 0\n"))
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	cfg.migrate()
+	if cfg.NoSpeechThreshold != 0 {
+		t.Errorf("NoSpeechThreshold = %v after migrating an explicit 0, want 0", cfg.NoSpeechThreshold)
+	}
+}
+
+func TestMigrate_CacheRetention(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Configs that don't set it get the default
+	cfg, err := parseConfig([]byte("config_version: 3\nmodel: small\n"))
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	cfg.migrate()
+	if cfg.CacheRetentionHours != DefaultConfig().CacheRetentionHours {
+		t.Errorf("CacheRetentionHours = %v after migrating a config without it, want %v", cfg.CacheRetentionHours, DefaultConfig().CacheRetentionHours)
+	}
+
+	// An explicit 0 (keep recordings) is kept
+	cfg, err = parseConfig([]byte("config_version: 3\ncache_retention_hours: 0\n"))
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	cfg.migrate()
+	if cfg.CacheRetentionHours != 0 {
+		t.Errorf("CacheRetentionHours = %v after migrating an explicit 0, want 0", cfg.CacheRetentionHours)
+	}
+}
+
+func TestMigrate_DeviceInitAttempts(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Configs that don't set it get the default
+	cfg, err := parseConfig([]byte("config_version: 4\nmodel: small\n"))
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	cfg.migrate()
+	if cfg.DeviceInitAttempts != DefaultConfig().DeviceInitAttempts {
+		t.Errorf("DeviceInitAttempts = %v after migrating a config without it, want %v", cfg.DeviceInitAttempts, DefaultConfig().DeviceInitAttempts)
+	}
+
+	// An explicit 1 (no retries) is kept
+	cfg, err = parseConfig([]byte("config_version: 4\ndevice_init_attempts: 1\n"))
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	cfg.migrate()
+	if cfg.DeviceInitAttempts != 1 {
+		t.Errorf("DeviceInitAttempts = %v after migrating an explicit 1, want 1", cfg.DeviceInitAttempts)
+	}
+
+	// 0 isn't a valid setting, so it still gets the default
+	cfg, err = parseConfig([]byte("config_version: 4\ndevice_init_attempts: 0\n"))
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	cfg.migrate()
+	if cfg.DeviceInitAttempts != DefaultConfig().DeviceInitAttempts {
+		t.Errorf("DeviceInitAttempts = %v after migrating 0, want %v", cfg.DeviceInitAttempts, DefaultConfig().DeviceInitAttempts)
+	}
+}
+
+func TestMigrate_MinRecording(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Configs that don't set it get the default
+	cfg, err := parseConfig([]byte("config_version: 5\nmodel: small\n"))
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	cfg.migrate()
+	if cfg.MinRecordingMs != DefaultConfig().MinRecordingMs {
+		t.Errorf("MinRecordingMs = %v after migrating a config without it, want %v", cfg.MinRecordingMs, DefaultConfig().MinRecordingMs)
+	}
+
+	// An explicit 0 (keep every recording) is kept
+	cfg, err = parseConfig([]byte("config_version: 5\nmin_recording_ms: 0\n"))
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	cfg.migrate()
+	if cfg.MinRecordingMs != 0 {
+		t.Errorf("MinRecordingMs = %v after migrating an explicit 0, want 0", cfg.MinRecordingMs)
+	}
+}
+
+func TestCleanCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cacheDir, err := GetCacheDir()
+	if err != nil {
+		t.Fatalf("GetCacheDir() error = %v", err)
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+
+	now := time.Now()
+	files := []struct {
+		name     string
+		age      time.Duration
+		wantKept bool
+	}{
+		{"recording_old.wav", 48 * time.Hour, false},
+		{"recording_stale.wav", 25 * time.Hour, false},
+		{"recording_recent.wav", time.Hour, true},
+		{"test_recording_old.wav", 48 * time.Hour, true}, // Not a temporary recording
+		{"recording_old.txt", 48 * time.Hour, true},
+	}
+	for _, f := range files {
+		path := filepath.Join(cacheDir, f.name)
+		if err := os.WriteFile(path, []byte("RIFF"), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		modTime := now.Add(-f.age)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Chtimes() error = %v", err)
+		}
+	}
+
+	removed, err := CleanCache(24 * time.Hour)
+	if err != nil {
+		t.Fatalf("CleanCache() error = %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("CleanCache() removed %d files, want 2", removed)
+	}
+	for _, f := range files {
+		_, err := os.Stat(filepath.Join(cacheDir, f.name))
+		if kept := err == nil; kept != f.wantKept {
+			t.Errorf("%s kept = %t, want %t", f.name, kept, f.wantKept)
+		}
+	}
+
+	// A zero age removes every remaining recording
+	if removed, err := CleanCache(0); err != nil || removed != 1 {
+		t.Errorf("CleanCache(0) = %d, %v; want 1, nil", removed, err)
+	}
+}
+
+func TestCleanCache_MissingDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	removed, err := CleanCache(time.Hour)
+	if err != nil || removed != 0 {
+		t.Errorf("CleanCache() = %d, %v; want 0, nil", removed, err)
+	}
+}
+
+func TestValidate_AudioBackend(t *testing.T) {
+	for _, name := range []string{"", "auto", "coreaudio", "pulseaudio"} {
+		cfg := DefaultConfig()
+		cfg.AudioBackend = name
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate() with audio_backend=%q error = %v", name, err)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.AudioBackend = "directsound"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() with an unknown audio_backend should fail")
+	}
+}
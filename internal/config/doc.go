@@ -8,6 +8,7 @@
 //   - ~/Library/Caches/openscribe/ (temporary files)
 //   - ~/Library/Logs/openscribe/ (log files)
 //   - Directory creation and validation
+//   - A single-instance lock for `openscribe start` (AcquireInstanceLock)
 //
 // The configuration file (config.yaml) stores user preferences including:
 //   - Microphone selection
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// InstanceRunningError is returned by AcquireInstanceLock when another process holds the lock
+type InstanceRunningError struct {
+	// PID of the process holding the lock (0 if it couldn't be read)
+	PID int
+}
+
+func (e *InstanceRunningError) Error() string {
+	if e.PID == 0 {
+		return "OpenScribe is already running"
+	}
+	return fmt.Sprintf("OpenScribe is already running (PID %d)", e.PID)
+}
+
+var (
+	instanceLockMu sync.Mutex
+	instanceLock   *os.File
+)
+
+// AcquireInstanceLock takes an exclusive lock on the instance lock file, so only one
+// `openscribe start` listens for hotkeys at a time. Returns *InstanceRunningError if
+// another instance holds it. The operating system releases the lock if the process dies.
+func AcquireInstanceLock() error {
+	if err := EnsureDirectories(); err != nil {
+		return fmt.Errorf("failed to ensure directories: %w", err)
+	}
+	lockPath, err := GetInstanceLockPath()
+	if err != nil {
+		return fmt.Errorf("failed to get lock file path: %w", err)
+	}
+
+	file, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open lock file: %w", err)
+	}
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		pid := readLockPID(file)
+		_ = file.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return &InstanceRunningError{PID: pid}
+		}
+		return fmt.Errorf("failed to lock %s: %w", lockPath, err)
+	}
+
+	// Record our PID so a second instance can say who holds the lock
+	if err := file.Truncate(0); err == nil {
+		_, _ = file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	}
+
+	instanceLockMu.Lock()
+	defer instanceLockMu.Unlock()
+	instanceLock = file
+	return nil
+}
+
+// ReleaseInstanceLock releases the lock taken by AcquireInstanceLock (a no-op if it isn't held).
+// The lock file itself is kept: removing it could let two instances lock different files.
+func ReleaseInstanceLock() error {
+	instanceLockMu.Lock()
+	defer instanceLockMu.Unlock()
+	if instanceLock == nil {
+		return nil
+	}
+
+	file := instanceLock
+	instanceLock = nil
+	_ = file.Truncate(0)
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_UN); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to unlock instance lock: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close instance lock: %w", err)
+	}
+	return nil
+}
+
+// readLockPID returns the PID recorded in the lock file, or 0 if there is none
+func readLockPID(file *os.File) int {
+	buf := make([]byte, 32)
+	n, _ := file.ReadAt(buf, 0)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(buf[:n])))
+	if err != nil || pid <= 0 {
+		return 0
+	}
+	return pid
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestInstanceLock(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := AcquireInstanceLock(); err != nil {
+		t.Fatalf("AcquireInstanceLock() error = %v", err)
+	}
+
+	// A second acquire fails while the first holds the lock, and names the holder
+	err := AcquireInstanceLock()
+	var running *InstanceRunningError
+	if !errors.As(err, &running) {
+		t.Fatalf("second AcquireInstanceLock() error = %v, want *InstanceRunningError", err)
+	}
+	if running.PID != os.Getpid() {
+		t.Errorf("InstanceRunningError.PID = %d, want %d", running.PID, os.Getpid())
+	}
+
+	if err := ReleaseInstanceLock(); err != nil {
+		t.Fatalf("ReleaseInstanceLock() error = %v", err)
+	}
+	if err := ReleaseInstanceLock(); err != nil {
+		t.Errorf("second ReleaseInstanceLock() error = %v, want nil", err)
+	}
+
+	// Once released, the lock can be taken again
+	if err := AcquireInstanceLock(); err != nil {
+		t.Fatalf("AcquireInstanceLock() after release error = %v", err)
+	}
+	if err := ReleaseInstanceLock(); err != nil {
+		t.Fatalf("ReleaseInstanceLock() error = %v", err)
+	}
+}
+
+func TestInstanceRunningError(t *testing.T) {
+	tests := []struct {
+		pid  int
+		want string
+	}{
+		{1234, "OpenScribe is already running (PID 1234)"},
+		{0, "OpenScribe is already running"},
+	}
+	for _, tt := range tests {
+		if got := (&InstanceRunningError{PID: tt.pid}).Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -23,6 +23,15 @@ func GetConfigPath() (string, error) {
 	return filepath.Join(appSupport, "config.yaml"), nil
 }
 
+// GetConfigBackupPath returns the path to the backup of the previous config file
+func GetConfigBackupPath() (string, error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return configPath + ".bak", nil
+}
+
 // GetModelsDir returns the models directory path
 func GetModelsDir() (string, error) {
 	appSupport, err := GetAppSupportDir()
@@ -59,6 +68,42 @@ func GetTranscriptionLogPath() (string, error) {
 	return filepath.Join(logsDir, "transcriptions.log"), nil
 }
 
+// GetDaemonLogPath returns the path to the log file that captures background (daemon) output
+func GetDaemonLogPath() (string, error) {
+	logsDir, err := GetLogsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(logsDir, "daemon.log"), nil
+}
+
+// GetRecordingsDir returns the directory where recordings are kept when keep_recordings is on
+func GetRecordingsDir() (string, error) {
+	appSupport, err := GetAppSupportDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appSupport, "recordings"), nil
+}
+
+// GetPIDPath returns the path to the PID file of the background (daemon) process
+func GetPIDPath() (string, error) {
+	appSupport, err := GetAppSupportDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appSupport, "openscribe.pid"), nil
+}
+
+// GetInstanceLockPath returns the path to the lock file held by the running `openscribe start`
+func GetInstanceLockPath() (string, error) {
+	appSupport, err := GetAppSupportDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appSupport, "openscribe.lock"), nil
+}
+
 // EnsureDirectories creates all necessary directories if they don't exist
 func EnsureDirectories() error {
 	// Get all directory paths
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Export writes the configuration as YAML to path, so it can be imported on another machine.
+// The file includes the OpenAI API key, if one is set.
+func (c *Config) Export(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := writeFileAtomic(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Import reads a configuration exported with Export, validates it and saves it as the
+// active configuration. An invalid file is rejected and the current configuration is left
+// untouched; a successful import can be undone with RestoreBackup.
+func Import(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+
+	cfg, err := parseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	// Files exported by older versions are upgraded like config.yaml
+	cfg.upgrade()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration in %s: %w", path, err)
+	}
+	if err := cfg.Save(); err != nil {
+		return nil, err
+	}
+	return Load()
+}
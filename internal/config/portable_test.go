@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	exportPath := filepath.Join(t.TempDir(), "openscribe.yaml")
+
+	cfg := DefaultConfig()
+	cfg.Model = "small"
+	cfg.Language = "fr"
+	cfg.PreferredMicrophones = []string{"Blue Yeti", "AirPods Pro"}
+	if err := cfg.Export(exportPath); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	// Import on a "new machine"
+	t.Setenv("HOME", t.TempDir())
+	imported, err := Import(exportPath)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	for name, got := range map[string]*Config{"Import()": imported, "Load()": loaded} {
+		if !reflect.DeepEqual(got, cfg) {
+			t.Errorf("%s = %+v, want %+v", name, got, cfg)
+		}
+	}
+}
+
+func TestImport_RejectsInvalidFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"invalid model", "model: huge\n"},
+		{"malformed yaml", "model: [small\n"},
+		{"empty file", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+
+			cfg := DefaultConfig()
+			cfg.Model = "medium"
+			if err := cfg.Save(); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			configPath, _ := GetConfigPath()
+			before, _ := os.ReadFile(configPath)
+
+			importPath := filepath.Join(t.TempDir(), "bad.yaml")
+			if err := os.WriteFile(importPath, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Import(importPath); err == nil {
+				t.Fatal("Import() error = nil, want an error")
+			}
+
+			after, _ := os.ReadFile(configPath)
+			if string(after) != string(before) {
+				t.Errorf("config.yaml changed by a rejected import:\n%s\nwant:\n%s", after, before)
+			}
+		})
+	}
+}
+
+func TestImport_MissingFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if _, err := Import(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Import() error = nil, want an error for a missing file")
+	}
+}
@@ -0,0 +1,202 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultProfile is the name of the profile made of the top-level settings in config.yaml
+const DefaultProfile = "default"
+
+// profileKeys are config.yaml keys that manage profiles and can't be set inside one
+var profileKeys = map[string]bool{
+	"config_version": true,
+	"active_profile": true,
+	"profiles":       true,
+}
+
+// LoadProfile loads the configuration with the named profile applied on top of the
+// top-level settings. An empty name uses the active profile; "default" uses the top-level settings.
+func LoadProfile(name string) (*Config, error) {
+	base, err := loadBase()
+	if err != nil {
+		return nil, err
+	}
+	return base.applyProfile(name, false)
+}
+
+// LoadOrCreateProfile is like LoadProfile, but a profile that doesn't exist yet starts
+// with every setting inherited; it is created when the returned Config is saved
+func LoadOrCreateProfile(name string) (*Config, error) {
+	base, err := loadBase()
+	if err != nil {
+		return nil, err
+	}
+	return base.applyProfile(name, true)
+}
+
+// Profile returns the name of the profile this configuration was loaded from
+func (c *Config) Profile() string {
+	if c.profile == "" {
+		return DefaultProfile
+	}
+	return c.profile
+}
+
+// ProfileNames returns the available profile names, "default" first
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{DefaultProfile}, names...)
+}
+
+// applyProfile returns a copy of the top-level settings c with the named profile layered on top
+func (c *Config) applyProfile(name string, create bool) (*Config, error) {
+	if name == "" {
+		name = c.ActiveProfile
+	}
+	if name == "" || name == DefaultProfile {
+		return c, nil
+	}
+	if err := validateProfileName(name); err != nil {
+		return nil, err
+	}
+
+	overrides, ok := c.Profiles[name]
+	if !ok && !create {
+		return nil, fmt.Errorf("profile %q not found (available: %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+
+	merged, err := mergeProfile(c, overrides)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile %q: %w", name, err)
+	}
+	merged.profile = name
+
+	if err := merged.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid profile %q: %w", name, err)
+	}
+	return merged, nil
+}
+
+// mergeProfile decodes the top-level settings of base with overrides applied.
+// Unknown setting names in overrides are rejected.
+func mergeProfile(base *Config, overrides map[string]interface{}) (*Config, error) {
+	settings, err := base.settingsMap()
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range overrides {
+		if profileKeys[key] {
+			return nil, fmt.Errorf("%s cannot be set inside a profile", key)
+		}
+		settings[key] = value
+	}
+
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal settings: %w", err)
+	}
+
+	merged := &Config{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(merged); err != nil {
+		return nil, err
+	}
+
+	merged.ConfigVersion = base.ConfigVersion
+	merged.ActiveProfile = base.ActiveProfile
+	merged.Profiles = base.Profiles
+	return merged, nil
+}
+
+// saveProfile stores the settings of c that differ from the top-level settings (plus any the
+// profile already set) as the overrides of c's profile, leaving the top-level settings untouched
+func (c *Config) saveProfile() error {
+	base, err := loadBase()
+	if err != nil {
+		return err
+	}
+
+	baseSettings, err := base.settingsMap()
+	if err != nil {
+		return err
+	}
+	settings, err := c.settingsMap()
+	if err != nil {
+		return err
+	}
+
+	overrides := make(map[string]interface{})
+	for key, value := range base.Profiles[c.profile] {
+		overrides[key] = value
+	}
+	for key, value := range settings {
+		if _, set := overrides[key]; set || !reflect.DeepEqual(baseSettings[key], value) {
+			overrides[key] = value
+		}
+	}
+	// Settings cleared in the profile (omitted when empty) must still override a top-level value
+	for key := range baseSettings {
+		if _, ok := settings[key]; !ok {
+			overrides[key] = nil
+		}
+	}
+
+	if base.Profiles == nil {
+		base.Profiles = make(map[string]map[string]interface{})
+	}
+	base.Profiles[c.profile] = overrides
+	return base.Save()
+}
+
+// settingsMap returns the settings keyed by their config.yaml names, without the profile keys
+func (c *Config) settingsMap() (map[string]interface{}, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	settings := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to convert config: %w", err)
+	}
+	for key := range profileKeys {
+		delete(settings, key)
+	}
+	return settings, nil
+}
+
+// validateProfiles checks profile names and that the active profile exists
+func (c *Config) validateProfiles() error {
+	for name := range c.Profiles {
+		if err := validateProfileName(name); err != nil {
+			return err
+		}
+	}
+	if c.ActiveProfile != "" && c.ActiveProfile != DefaultProfile {
+		if _, ok := c.Profiles[c.ActiveProfile]; !ok {
+			return fmt.Errorf("active_profile %q does not exist in profiles", c.ActiveProfile)
+		}
+	}
+	return nil
+}
+
+// validateProfileName rejects empty names and the reserved "default" name
+func validateProfileName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("profile name cannot be empty")
+	}
+	if name == DefaultProfile {
+		return fmt.Errorf("%q is reserved for the top-level settings and cannot be used as a profile name", DefaultProfile)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// writeConfigFile writes raw YAML to the config file in a temporary HOME
+func writeConfigFile(t *testing.T, yamlContent string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	if err := EnsureDirectories(); err != nil {
+		t.Fatalf("EnsureDirectories() error = %v", err)
+	}
+	configPath, _ := GetConfigPath()
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+}
+
+const profilesConfig = `model: "small"
+language: "fr"
+auto_paste: true
+prompt: "Meeting notes"
+triggers: ["Right Option"]
+active_profile: "default"
+profiles:
+  code:
+    model: "base.en"
+    language: "en"
+    auto_paste: false
+    prompt: ""
+  prose:
+    model: "medium"
+`
+
+func TestLoadProfile_Inheritance(t *testing.T) {
+	writeConfigFile(t, profilesConfig)
+
+	code, err := LoadProfile("code")
+	if err != nil {
+		t.Fatalf("LoadProfile(code) error = %v", err)
+	}
+	if code.Model != "base.en" || code.Language != "en" || code.AutoPaste || code.Prompt != "" {
+		t.Errorf("code profile = model %q, language %q, auto_paste %t, prompt %q; want its own overrides",
+			code.Model, code.Language, code.AutoPaste, code.Prompt)
+	}
+	// Unset fields come from the top-level settings, which carry the defaults
+	if len(code.Triggers) != 1 || code.Triggers[0] != "Right Option" {
+		t.Errorf("code.Triggers = %v, want inherited [Right Option]", code.Triggers)
+	}
+	if code.SilenceThresholdDB != DefaultConfig().SilenceThresholdDB {
+		t.Errorf("code.SilenceThresholdDB = %v, want the inherited default", code.SilenceThresholdDB)
+	}
+	if code.Profile() != "code" {
+		t.Errorf("Profile() = %q, want code", code.Profile())
+	}
+
+	prose, err := LoadProfile("prose")
+	if err != nil {
+		t.Fatalf("LoadProfile(prose) error = %v", err)
+	}
+	if prose.Model != "medium" || prose.Language != "fr" || !prose.AutoPaste || prose.Prompt != "Meeting notes" {
+		t.Errorf("prose profile = model %q, language %q, auto_paste %t, prompt %q; want medium with everything else inherited",
+			prose.Model, prose.Language, prose.AutoPaste, prose.Prompt)
+	}
+
+	def, err := LoadProfile(DefaultProfile)
+	if err != nil {
+		t.Fatalf("LoadProfile(default) error = %v", err)
+	}
+	if def.Model != "small" || def.Profile() != DefaultProfile {
+		t.Errorf("default profile = model %q (%s), want small", def.Model, def.Profile())
+	}
+
+	if _, err := LoadProfile("missing"); err == nil || !strings.Contains(err.Error(), "code, prose") {
+		t.Errorf("LoadProfile(missing) error = %v, want not found listing available profiles", err)
+	}
+}
+
+func TestLoad_UsesActiveProfile(t *testing.T) {
+	writeConfigFile(t, strings.Replace(profilesConfig, `active_profile: "default"`, `active_profile: "prose"`, 1))
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Model != "medium" || cfg.Profile() != "prose" {
+		t.Errorf("Load() = model %q (profile %s), want medium from the active prose profile", cfg.Model, cfg.Profile())
+	}
+
+	// An explicit profile wins over the active one
+	cfg, err = LoadProfile("code")
+	if err != nil {
+		t.Fatalf("LoadProfile(code) error = %v", err)
+	}
+	if cfg.Model != "base.en" {
+		t.Errorf("LoadProfile(code).Model = %q, want base.en", cfg.Model)
+	}
+}
+
+func TestProfile_SaveWritesOnlyToProfile(t *testing.T) {
+	writeConfigFile(t, profilesConfig)
+
+	work, err := LoadOrCreateProfile("work")
+	if err != nil {
+		t.Fatalf("LoadOrCreateProfile(work) error = %v", err)
+	}
+	if work.Model != "small" {
+		t.Errorf("new profile Model = %q, want inherited small", work.Model)
+	}
+
+	work.Model = "medium"
+	work.Prompt = "" // Clearing an inherited value must stick
+	if err := work.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	base, err := LoadProfile(DefaultProfile)
+	if err != nil {
+		t.Fatalf("LoadProfile(default) error = %v", err)
+	}
+	if base.Model != "small" || base.Prompt != "Meeting notes" {
+		t.Errorf("top-level settings changed: model %q, prompt %q", base.Model, base.Prompt)
+	}
+	if got := base.Profiles["work"]; len(got) != 2 || got["model"] != "medium" {
+		t.Errorf("work overrides = %v, want only model and prompt", got)
+	}
+
+	reloaded, err := LoadProfile("work")
+	if err != nil {
+		t.Fatalf("LoadProfile(work) error = %v", err)
+	}
+	if reloaded.Model != "medium" || reloaded.Prompt != "" || reloaded.Language != "fr" {
+		t.Errorf("work profile = model %q, prompt %q, language %q; want medium, empty, inherited fr",
+			reloaded.Model, reloaded.Prompt, reloaded.Language)
+	}
+
+	// Changing a top-level setting flows through to profiles that don't override it
+	base.Language = "de"
+	if err := base.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	reloaded, err = LoadProfile("work")
+	if err != nil {
+		t.Fatalf("LoadProfile(work) error = %v", err)
+	}
+	if reloaded.Language != "de" {
+		t.Errorf("work.Language = %q, want de inherited after the top-level change", reloaded.Language)
+	}
+}
+
+func TestLoadProfile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		wantErr string
+	}{
+		{"unknown setting", "    modle: base\n", "modle"},
+		{"invalid value", "    model: huge\n", "invalid model"},
+		{"nested profile keys", "    active_profile: other\n", "cannot be set inside a profile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeConfigFile(t, "model: small\ntriggers: [\"Right Option\"]\nprofiles:\n  bad:\n"+tt.profile)
+
+			_, err := LoadProfile("bad")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadProfile(bad) error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMigrate_FlatConfigBecomesDefaultProfile(t *testing.T) {
+	writeConfigFile(t, "model: base\nlanguage: en\ntriggers: [\"Right Option\"]\n")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.ActiveProfile != DefaultProfile || cfg.Profile() != DefaultProfile {
+		t.Errorf("active profile = %q (%s), want default", cfg.ActiveProfile, cfg.Profile())
+	}
+	if cfg.Model != "base" || cfg.Language != "en" {
+		t.Errorf("settings = model %q, language %q, want the flat settings kept", cfg.Model, cfg.Language)
+	}
+
+	configPath, _ := GetConfigPath()
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	if !strings.Contains(string(data), "active_profile: default") {
+		t.Errorf("migrated config does not record the default profile:\n%s", data)
+	}
+}
+
+func TestValidate_Profiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		active  string
+		names   []string
+		wantErr bool
+	}{
+		{"default only", DefaultProfile, nil, false},
+		{"existing active profile", "work", []string{"work"}, false},
+		{"missing active profile", "work", nil, true},
+		{"reserved name", DefaultProfile, []string{DefaultProfile}, true},
+		{"empty name", DefaultProfile, []string{" "}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.ActiveProfile = tt.active
+			for _, name := range tt.names {
+				if cfg.Profiles == nil {
+					cfg.Profiles = make(map[string]map[string]interface{})
+				}
+				cfg.Profiles[name] = map[string]interface{}{}
+			}
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// liveSettings are the settings a running "openscribe start" picks up without a restart,
+// keyed by their config.yaml names
+var liveSettings = map[string]func(c, next *Config){
+	"model":                 func(c, next *Config) { c.Model = next.Model },
+	"language":              func(c, next *Config) { c.Language = next.Language },
+	"detect_languages":      func(c, next *Config) { c.DetectLanguages = next.DetectLanguages },
+	"prompt":                func(c, next *Config) { c.Prompt = next.Prompt },
+	"replacements":          func(c, next *Config) { c.Replacements = next.Replacements },
+	"redact":                func(c, next *Config) { c.Redact = next.Redact },
+	"redact_mask":           func(c, next *Config) { c.RedactMask = next.RedactMask },
+	"auto_paste":            func(c, next *Config) { c.AutoPaste = next.AutoPaste },
+	"paste_delay_ms":        func(c, next *Config) { c.PasteDelayMs = next.PasteDelayMs },
+	"strip_newlines":        func(c, next *Config) { c.StripNewlines = next.StripNewlines },
+	"capitalize_first":      func(c, next *Config) { c.CapitalizeFirst = next.CapitalizeFirst },
+	"ensure_punctuation":    func(c, next *Config) { c.EnsurePunctuation = next.EnsurePunctuation },
+	"trailing_space":        func(c, next *Config) { c.TrailingSpace = next.TrailingSpace },
+	"logging_enabled":       func(c, next *Config) { c.LoggingEnabled = next.LoggingEnabled },
+	"verbose":               func(c, next *Config) { c.Verbose = next.Verbose },
+	"show_audio_levels":     func(c, next *Config) { c.ShowAudioLevels = next.ShowAudioLevels },
+	"desktop_notifications": func(c, next *Config) { c.DesktopNotifications = next.DesktopNotifications },
+	"triggers":              func(c, next *Config) { c.Triggers = next.Triggers },
+	"hotkey_mode":           func(c, next *Config) { c.HotkeyMode = next.HotkeyMode },
+	"trigger_mode":          func(c, next *Config) { c.TriggerMode = next.TriggerMode },
+	"max_recording_seconds": func(c, next *Config) { c.MaxRecordingSeconds = next.MaxRecordingSeconds },
+	"min_recording_ms":      func(c, next *Config) { c.MinRecordingMs = next.MinRecordingMs },
+	"microphone":            func(c, next *Config) { c.Microphone = next.Microphone },
+	"preferred_microphones": func(c, next *Config) { c.PreferredMicrophones = next.PreferredMicrophones },
+}
+
+// ApplyReload copies the settings that can change while running from next into c.
+// It returns the names of the settings it applied and of the changed settings
+// that only take effect after a restart, both sorted.
+func (c *Config) ApplyReload(next *Config) (applied, restart []string, err error) {
+	current, err := c.settingsMap()
+	if err != nil {
+		return nil, nil, err
+	}
+	updated, err := next.settingsMap()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changed := make(map[string]bool)
+	for key, value := range updated {
+		if !reflect.DeepEqual(current[key], value) {
+			changed[key] = true
+		}
+	}
+	for key := range current {
+		if _, ok := updated[key]; !ok {
+			changed[key] = true
+		}
+	}
+
+	for key := range changed {
+		if apply, ok := liveSettings[key]; ok {
+			apply(c, next)
+			applied = append(applied, key)
+		} else {
+			restart = append(restart, key)
+		}
+	}
+	sort.Strings(applied)
+	sort.Strings(restart)
+	return applied, restart, nil
+}
+
+// Watch calls fn whenever the config file's modification time or size changes.
+// The file is checked on every tick until ctx is done.
+func Watch(ctx context.Context, ticks <-chan time.Time, fn func()) error {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return fmt.Errorf("failed to get config path: %w", err)
+	}
+
+	last, _ := os.Stat(configPath)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticks:
+			info, err := os.Stat(configPath)
+			if err != nil {
+				// Missing mid-save or deleted; compare again once it's back
+				continue
+			}
+			if last == nil || !info.ModTime().Equal(last.ModTime()) || info.Size() != last.Size() {
+				last = info
+				fn()
+			}
+		}
+	}
+}
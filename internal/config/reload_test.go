@@ -0,0 +1,132 @@
+package config
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestApplyReload(t *testing.T) {
+	current := DefaultConfig()
+	next := DefaultConfig()
+	next.Model = "medium"
+	next.Language = "fr"
+	next.Triggers = []string{"Cmd+Shift+Space"}
+	next.PreferredMicrophones = []string{"USB Microphone"}
+	next.Backend = "openai"
+	next.Threads = 4
+
+	applied, restart, err := current.ApplyReload(next)
+	if err != nil {
+		t.Fatalf("ApplyReload() error = %v", err)
+	}
+
+	wantApplied := []string{"language", "model", "preferred_microphones", "triggers"}
+	if !reflect.DeepEqual(applied, wantApplied) {
+		t.Errorf("applied = %v, want %v", applied, wantApplied)
+	}
+	wantRestart := []string{"backend", "threads"}
+	if !reflect.DeepEqual(restart, wantRestart) {
+		t.Errorf("restart = %v, want %v", restart, wantRestart)
+	}
+
+	if current.Model != "medium" || current.Language != "fr" {
+		t.Errorf("model/language = %q/%q, want medium/fr", current.Model, current.Language)
+	}
+	if !reflect.DeepEqual(current.Triggers, next.Triggers) {
+		t.Errorf("Triggers = %v, want %v", current.Triggers, next.Triggers)
+	}
+	if !reflect.DeepEqual(current.PreferredMicrophones, next.PreferredMicrophones) {
+		t.Errorf("PreferredMicrophones = %v, want %v", current.PreferredMicrophones, next.PreferredMicrophones)
+	}
+
+	// Settings that need a restart keep their running values
+	if current.Backend != DefaultConfig().Backend {
+		t.Errorf("Backend = %q, want it unchanged", current.Backend)
+	}
+	if current.Threads != DefaultConfig().Threads {
+		t.Errorf("Threads = %d, want it unchanged", current.Threads)
+	}
+}
+
+func TestApplyReload_ClearedSetting(t *testing.T) {
+	current := DefaultConfig()
+	current.Prompt = "Meeting notes"
+	next := DefaultConfig()
+
+	applied, restart, err := current.ApplyReload(next)
+	if err != nil {
+		t.Fatalf("ApplyReload() error = %v", err)
+	}
+	if !reflect.DeepEqual(applied, []string{"prompt"}) || len(restart) != 0 {
+		t.Errorf("applied = %v, restart = %v, want [prompt] and none", applied, restart)
+	}
+	if current.Prompt != "" {
+		t.Errorf("Prompt = %q, want it cleared", current.Prompt)
+	}
+}
+
+func TestApplyReload_NoChanges(t *testing.T) {
+	applied, restart, err := DefaultConfig().ApplyReload(DefaultConfig())
+	if err != nil {
+		t.Fatalf("ApplyReload() error = %v", err)
+	}
+	if len(applied) != 0 || len(restart) != 0 {
+		t.Errorf("applied = %v, restart = %v, want none", applied, restart)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := DefaultConfig().Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	configPath, _ := GetConfigPath()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ticks := make(chan time.Time)
+	changes := make(chan struct{}, 10)
+	done := make(chan error)
+	go func() {
+		done <- Watch(ctx, ticks, func() { changes <- struct{}{} })
+	}()
+
+	// Unchanged file: no callback
+	ticks <- time.Now()
+	ticks <- time.Now()
+	if len(changes) != 0 {
+		t.Fatalf("callback called %d times for an unchanged file", len(changes))
+	}
+
+	// Modified file: one callback, even across several ticks. The new file is
+	// renamed into place so Watch never sees it half-written.
+	later := time.Now().Add(time.Minute)
+	newPath := configPath + ".new"
+	if err := os.WriteFile(newPath, []byte("model: medium\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := os.Chtimes(newPath, later, later); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+	if err := os.Rename(newPath, configPath); err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	ticks <- time.Now()
+	ticks <- time.Now()
+
+	// Missing file (mid-save): no callback
+	if err := os.Remove(configPath); err != nil {
+		t.Fatalf("Failed to remove config: %v", err)
+	}
+	ticks <- time.Now()
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if len(changes) != 1 {
+		t.Errorf("callback called %d times, want 1", len(changes))
+	}
+}
@@ -0,0 +1,186 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+// EnvChild is set in the environment of the background process
+const EnvChild = "OPENSCRIBE_DAEMON"
+
+var (
+	// ErrAlreadyRunning is returned by Start when the daemon is already running
+	ErrAlreadyRunning = errors.New("openscribe is already running in the background")
+	// ErrNotRunning is returned by Stop when no daemon is running
+	ErrNotRunning = errors.New("openscribe is not running in the background")
+)
+
+// IsChild reports whether the current process is the background process started by Start
+func IsChild() bool {
+	return os.Getenv(EnvChild) == "1"
+}
+
+// Start re-executes the current binary with args as a detached background process,
+// writing its output to the daemon log and its PID to the PID file.
+// Returns ErrAlreadyRunning (with the existing PID) if the daemon is already running.
+func Start(args []string) (int, error) {
+	if err := config.EnsureDirectories(); err != nil {
+		return 0, fmt.Errorf("failed to ensure directories: %w", err)
+	}
+
+	pidPath, err := config.GetPIDPath()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get PID file path: %w", err)
+	}
+	if pid, running, err := status(pidPath); err != nil {
+		return 0, err
+	} else if running {
+		return pid, ErrAlreadyRunning
+	}
+
+	logPath, err := config.GetDaemonLogPath()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get daemon log path: %w", err)
+	}
+	logFile, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open daemon log: %w", err)
+	}
+	defer func() {
+		_ = logFile.Close() // The child keeps its own copy of the descriptor
+	}()
+
+	executable, err := os.Executable()
+	if err != nil {
+		return 0, fmt.Errorf("failed to locate executable: %w", err)
+	}
+
+	cmd := exec.Command(executable, args...)
+	cmd.Env = append(os.Environ(), EnvChild+"=1")
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	// New session: the child no longer belongs to the terminal, so closing it doesn't send SIGHUP
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	if err := cmd.Start(); err != nil {
+		return 0, fmt.Errorf("failed to start background process: %w", err)
+	}
+	pid := cmd.Process.Pid
+
+	if err := writePIDFile(pidPath, pid); err != nil {
+		_ = cmd.Process.Kill()
+		return 0, err
+	}
+	if err := cmd.Process.Release(); err != nil {
+		return pid, fmt.Errorf("failed to detach background process: %w", err)
+	}
+
+	return pid, nil
+}
+
+// Stop sends SIGTERM to the background process, which shuts down cleanly.
+// Returns ErrNotRunning (and removes a stale PID file) if it isn't running.
+func Stop() (int, error) {
+	pidPath, err := config.GetPIDPath()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get PID file path: %w", err)
+	}
+
+	pid, running, err := status(pidPath)
+	if err != nil {
+		return 0, err
+	}
+	if !running {
+		return 0, ErrNotRunning
+	}
+
+	if err := syscall.Kill(pid, syscall.SIGTERM); err != nil {
+		return pid, fmt.Errorf("failed to stop process %d: %w", pid, err)
+	}
+	return pid, nil
+}
+
+// Status reports the PID of the background process and whether it is running.
+// A stale PID file (left by a crashed process) is removed.
+func Status() (int, bool, error) {
+	pidPath, err := config.GetPIDPath()
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get PID file path: %w", err)
+	}
+	return status(pidPath)
+}
+
+// Cleanup removes the PID file if it belongs to the current process.
+// The background process calls it on exit.
+func Cleanup() error {
+	pidPath, err := config.GetPIDPath()
+	if err != nil {
+		return fmt.Errorf("failed to get PID file path: %w", err)
+	}
+	return removePIDFileIfOwned(pidPath, os.Getpid())
+}
+
+// status reads the PID file at path and checks whether that process is alive.
+// The returned PID is 0 when the process isn't running.
+func status(pidPath string) (int, bool, error) {
+	pid, err := readPIDFile(pidPath)
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	if err == nil && processAlive(pid) {
+		return pid, true, nil
+	}
+
+	// Stale PID file (the process is gone) or garbage in it
+	if err := os.Remove(pidPath); err != nil && !os.IsNotExist(err) {
+		return 0, false, fmt.Errorf("failed to remove stale PID file: %w", err)
+	}
+	return 0, false, nil
+}
+
+// processAlive reports whether a process with pid exists (signal 0 only checks for existence)
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// readPIDFile parses the PID stored at path
+func readPIDFile(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("invalid PID file %s: %q", path, strings.TrimSpace(string(data)))
+	}
+	return pid, nil
+}
+
+// writePIDFile stores pid at path
+func writePIDFile(path string, pid int) error {
+	if err := os.WriteFile(path, []byte(strconv.Itoa(pid)+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write PID file: %w", err)
+	}
+	return nil
+}
+
+// removePIDFileIfOwned removes the PID file only if it records pid, so a process
+// never deletes the PID file of a newer daemon
+func removePIDFileIfOwned(path string, pid int) error {
+	recorded, err := readPIDFile(path)
+	if err != nil || recorded != pid {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove PID file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package daemon
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// exitedPID returns the PID of a process that has already exited
+func exitedPID(t *testing.T) int {
+	t.Helper()
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Skipf("cannot run helper process: %v", err)
+	}
+	return cmd.Process.Pid
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string // PID file content ("" = no file)
+		wantRunning bool
+		wantRemoved bool
+	}{
+		{"no PID file", "", false, false},
+		{"running process", "self", true, false},
+		{"stale PID", "exited", false, true},
+		{"garbage", "not-a-pid\n", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pidPath := filepath.Join(t.TempDir(), "openscribe.pid")
+			wantPID := 0
+			switch tt.content {
+			case "":
+			case "self":
+				wantPID = os.Getpid()
+				if err := writePIDFile(pidPath, wantPID); err != nil {
+					t.Fatalf("writePIDFile() error: %v", err)
+				}
+			case "exited":
+				if err := writePIDFile(pidPath, exitedPID(t)); err != nil {
+					t.Fatalf("writePIDFile() error: %v", err)
+				}
+			default:
+				if err := os.WriteFile(pidPath, []byte(tt.content), 0644); err != nil {
+					t.Fatalf("failed to write PID file: %v", err)
+				}
+			}
+
+			pid, running, err := status(pidPath)
+			if err != nil {
+				t.Fatalf("status() error: %v", err)
+			}
+			if running != tt.wantRunning || pid != wantPID {
+				t.Errorf("status() = %d, %t, want %d, %t", pid, running, wantPID, tt.wantRunning)
+			}
+
+			_, statErr := os.Stat(pidPath)
+			if removed := os.IsNotExist(statErr); tt.content != "" && removed != tt.wantRemoved {
+				t.Errorf("PID file removed = %t, want %t", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestPIDFileRoundTrip(t *testing.T) {
+	pidPath := filepath.Join(t.TempDir(), "openscribe.pid")
+
+	if err := writePIDFile(pidPath, 4242); err != nil {
+		t.Fatalf("writePIDFile() error: %v", err)
+	}
+	pid, err := readPIDFile(pidPath)
+	if err != nil || pid != 4242 {
+		t.Errorf("readPIDFile() = %d, %v, want 4242", pid, err)
+	}
+}
+
+func TestRemovePIDFileIfOwned(t *testing.T) {
+	pidPath := filepath.Join(t.TempDir(), "openscribe.pid")
+	if err := writePIDFile(pidPath, 4242); err != nil {
+		t.Fatalf("writePIDFile() error: %v", err)
+	}
+
+	// Another process's PID file is left alone
+	if err := removePIDFileIfOwned(pidPath, 1111); err != nil {
+		t.Fatalf("removePIDFileIfOwned() error: %v", err)
+	}
+	if _, err := os.Stat(pidPath); err != nil {
+		t.Fatal("expected a PID file owned by another process to be kept")
+	}
+
+	if err := removePIDFileIfOwned(pidPath, 4242); err != nil {
+		t.Fatalf("removePIDFileIfOwned() error: %v", err)
+	}
+	if _, err := os.Stat(pidPath); !os.IsNotExist(err) {
+		t.Error("expected the owned PID file to be removed")
+	}
+
+	// Missing file is not an error
+	if err := removePIDFileIfOwned(pidPath, 4242); err != nil {
+		t.Errorf("removePIDFileIfOwned() on missing file error: %v", err)
+	}
+}
+
+func TestStop_NotRunning(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := Stop(); err != ErrNotRunning {
+		t.Errorf("Stop() error = %v, want ErrNotRunning", err)
+	}
+}
@@ -0,0 +1,26 @@
+// Package daemon runs OpenScribe in the background, detached from the terminal.
+//
+// This package handles:
+//   - Re-executing the current binary as a detached child process
+//   - Redirecting the child's stdout/stderr to a log file
+//   - Tracking the child with a PID file to prevent double-starts
+//   - Stopping the child with SIGTERM and reporting whether it is running
+//
+// The child is started with the OPENSCRIBE_DAEMON environment variable set so
+// it knows it is the background process and removes the PID file on exit.
+//
+// File locations:
+//
+//	~/Library/Application Support/openscribe/openscribe.pid
+//	~/Library/Logs/openscribe/daemon.log
+//
+// Example usage:
+//
+//	pid, err := daemon.Start([]string{"start"})
+//	if errors.Is(err, daemon.ErrAlreadyRunning) {
+//	    fmt.Printf("Already running (PID %d)\n", pid)
+//	}
+//
+//	// Later, from another process
+//	pid, err = daemon.Stop()
+package daemon
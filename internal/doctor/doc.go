@@ -0,0 +1,23 @@
+// Package doctor checks whether OpenScribe is ready to run.
+//
+// This package handles:
+//   - Checking that the transcription backend (e.g. whisper-cli) is available
+//   - Checking that the configured model is downloaded
+//   - Checking accessibility permissions for hotkeys and pasting
+//   - Checking that a microphone is available
+//   - Checking free disk space in the models directory
+//
+// Each check is a function returning (ok bool, detail string) with its
+// dependencies injected, so checks can be tested with mocks. DefaultChecks
+// wires them to the real implementations for a configuration.
+//
+// Example usage:
+//
+//	results := doctor.Run(doctor.DefaultChecks(cfg))
+//	for _, r := range results {
+//	    fmt.Printf("%t %s: %s\n", r.OK, r.Name, r.Detail)
+//	}
+//	if doctor.HasCriticalFailure(results) {
+//	    os.Exit(1)
+//	}
+package doctor
@@ -0,0 +1,252 @@
+package doctor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexandrelam/openscribe/internal/audio"
+	"github.com/alexandrelam/openscribe/internal/config"
+	"github.com/alexandrelam/openscribe/internal/keyboard"
+	"github.com/alexandrelam/openscribe/internal/models"
+	"github.com/alexandrelam/openscribe/internal/transcription"
+)
+
+// LowDiskSpaceBytes is the free space below which the disk space check fails (1 GB)
+const LowDiskSpaceBytes = 1024 * 1024 * 1024
+
+// Check is a single readiness check
+type Check struct {
+	Name     string                          `json:"name"`
+	Critical bool                            `json:"critical"` // A failed critical check means OpenScribe can't run
+	Hint     string                          `json:"hint"`     // How to fix a failure
+	Run      func() (ok bool, detail string) `json:"-"`
+}
+
+// Result is the outcome of running a Check
+type Result struct {
+	Check
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail"`
+}
+
+// Run runs each check in order
+func Run(checks []Check) []Result {
+	results := make([]Result, 0, len(checks))
+	for _, c := range checks {
+		ok, detail := c.Run()
+		results = append(results, Result{Check: c, OK: ok, Detail: detail})
+	}
+	return results
+}
+
+// HasCriticalFailure reports whether any critical check failed
+func HasCriticalFailure(results []Result) bool {
+	for _, r := range results {
+		if r.Critical && !r.OK {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckTranscriber verifies the transcription backend can be created
+func CheckTranscriber(backend string, newTranscriber func() error) (bool, string) {
+	if err := newTranscriber(); err != nil {
+		return false, err.Error()
+	}
+	return true, fmt.Sprintf("%s backend available", backend)
+}
+
+// CheckModel verifies a model is downloaded
+func CheckModel(model string, isDownloaded func() (bool, error)) (bool, string) {
+	downloaded, err := isDownloaded()
+	if err != nil {
+		return false, err.Error()
+	}
+	if !downloaded {
+		return false, fmt.Sprintf("model %s is not downloaded", model)
+	}
+	return true, fmt.Sprintf("model %s is downloaded", model)
+}
+
+// CheckPermissions verifies accessibility permissions are granted
+func CheckPermissions(checkPermissions func() error) (bool, string) {
+	if err := checkPermissions(); err != nil {
+		return false, err.Error()
+	}
+	return true, "accessibility permissions granted"
+}
+
+// CheckMicrophones verifies at least one microphone is available
+func CheckMicrophones(listMicrophones func() ([]audio.Device, error)) (bool, string) {
+	devices, err := listMicrophones()
+	if err != nil {
+		return false, err.Error()
+	}
+	if len(devices) == 0 {
+		return false, "no microphones found"
+	}
+
+	names := make([]string, 0, len(devices))
+	for _, d := range devices {
+		name := d.Name
+		if d.IsDefault {
+			name += " (default)"
+		}
+		names = append(names, name)
+	}
+	return true, fmt.Sprintf("%d found: %s", len(devices), strings.Join(names, ", "))
+}
+
+// CheckFFmpeg verifies ffmpeg is available for converting non-WAV audio files
+func CheckFFmpeg(checkFFmpeg func() error) (bool, string) {
+	if err := checkFFmpeg(); err != nil {
+		return false, "ffmpeg not found, only 16kHz mono WAV files can be transcribed"
+	}
+	return true, "ffmpeg available"
+}
+
+// CheckDiskSpace verifies at least minBytes are free
+func CheckDiskSpace(availableDiskSpace func() (int64, error), minBytes int64) (bool, string) {
+	available, err := availableDiskSpace()
+	if err != nil {
+		return false, err.Error()
+	}
+	detail := fmt.Sprintf("%s available", models.FormatBytes(available))
+	if available < minBytes {
+		return false, fmt.Sprintf("%s (less than %s)", detail, models.FormatBytes(minBytes))
+	}
+	return true, detail
+}
+
+// DefaultChecks returns the readiness checks for cfg using the real system dependencies
+func DefaultChecks(cfg *config.Config) []Check {
+	backend := cfg.Backend
+	if backend == "" {
+		backend = "whisper"
+	}
+
+	checks := []Check{
+		{
+			Name:     "Transcription backend",
+			Critical: true,
+			Hint:     backendHint(backend),
+			Run: func() (bool, string) {
+				return CheckTranscriber(backend, func() error {
+					_, err := transcription.New(cfg)
+					return err
+				})
+			},
+		},
+	}
+
+	if check, ok := modelCheck(cfg, backend); ok {
+		checks = append(checks, check)
+	}
+
+	return append(checks,
+		Check{
+			Name:     "Accessibility permissions",
+			Critical: true,
+			Hint:     "Grant access in System Settings > Privacy & Security > Accessibility, then restart your terminal",
+			Run: func() (bool, string) {
+				return CheckPermissions(func() error {
+					kb, err := keyboard.New()
+					if err != nil {
+						return err
+					}
+					defer func() { _ = kb.Close() }()
+					return kb.CheckPermissions()
+				})
+			},
+		},
+		Check{
+			Name:     "Microphone",
+			Critical: true,
+			Hint:     "Connect a microphone and allow microphone access for your terminal in System Settings > Privacy & Security > Microphone",
+			Run: func() (bool, string) {
+				return CheckMicrophones(func() ([]audio.Device, error) {
+					return audio.ListMicrophones(cfg.AudioBackend)
+				})
+			},
+		},
+		Check{
+			Name:     "ffmpeg",
+			Critical: false,
+			Hint:     "Install ffmpeg to transcribe m4a, mp3 and other formats: brew install ffmpeg",
+			Run: func() (bool, string) {
+				return CheckFFmpeg(transcription.CheckFFmpeg)
+			},
+		},
+		Check{
+			Name:     "Disk space",
+			Critical: false,
+			Hint:     "Free up disk space or delete unused models with 'openscribe models delete <model>'",
+			Run: func() (bool, string) {
+				return CheckDiskSpace(func() (int64, error) {
+					modelsDir, err := config.GetModelsDir()
+					if err != nil {
+						return 0, err
+					}
+					return models.AvailableDiskSpace(modelsDir)
+				}, LowDiskSpaceBytes)
+			},
+		},
+	)
+}
+
+// modelCheck returns the model check for backends that use local models
+func modelCheck(cfg *config.Config, backend string) (Check, bool) {
+	switch backend {
+	case "whisper":
+		model := cfg.Model
+		if model == "" {
+			model = config.DefaultConfig().Model
+		}
+		return Check{
+			Name:     "Whisper model",
+			Critical: true,
+			Hint:     fmt.Sprintf("Run 'openscribe models download %s'", model),
+			Run: func() (bool, string) {
+				return CheckModel(model, func() (bool, error) {
+					size, err := models.ParseModelSize(model)
+					if err != nil {
+						return false, err
+					}
+					return models.IsModelDownloaded(size)
+				})
+			},
+		}, true
+	case "moonshine":
+		model := cfg.MoonshineModel
+		if model == "" {
+			model = string(models.MoonshineTiny)
+		}
+		return Check{
+			Name:     "Moonshine model",
+			Critical: true,
+			Hint:     fmt.Sprintf("Run 'openscribe models download --backend moonshine %s'", model),
+			Run: func() (bool, string) {
+				return CheckModel(model, func() (bool, error) {
+					return models.IsMoonshineModelDownloaded(models.MoonshineModelSize(model))
+				})
+			},
+		}, true
+	default:
+		return Check{}, false
+	}
+}
+
+// backendHint explains how to make a transcription backend available
+func backendHint(backend string) string {
+	switch backend {
+	case "whisper":
+		return "Install whisper-cpp: brew install whisper-cpp"
+	case "moonshine":
+		return "Build OpenScribe with -tags moonshine, or set 'backend: whisper' in the config file (openscribe config --open)"
+	case "openai":
+		return "Set your API key with 'openscribe config --set-openai-api-key <key>'"
+	default:
+		return "Set 'backend' to whisper, moonshine or openai in the config file (openscribe config --open)"
+	}
+}
@@ -0,0 +1,167 @@
+package doctor
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alexandrelam/openscribe/internal/audio"
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+func TestCheckTranscriber(t *testing.T) {
+	if ok, detail := CheckTranscriber("whisper", func() error { return nil }); !ok || !strings.Contains(detail, "whisper") {
+		t.Errorf("CheckTranscriber(available) = %t, %q", ok, detail)
+	}
+	ok, detail := CheckTranscriber("whisper", func() error { return errors.New("whisper-cli not found in PATH") })
+	if ok || detail != "whisper-cli not found in PATH" {
+		t.Errorf("CheckTranscriber(missing) = %t, %q, want false with the error", ok, detail)
+	}
+}
+
+func TestCheckModel(t *testing.T) {
+	tests := []struct {
+		name       string
+		downloaded bool
+		err        error
+		wantOK     bool
+		wantDetail string
+	}{
+		{"downloaded", true, nil, true, "model small is downloaded"},
+		{"missing", false, nil, false, "model small is not downloaded"},
+		{"error", false, errors.New("unknown model"), false, "unknown model"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, detail := CheckModel("small", func() (bool, error) { return tt.downloaded, tt.err })
+			if ok != tt.wantOK || detail != tt.wantDetail {
+				t.Errorf("CheckModel() = %t, %q, want %t, %q", ok, detail, tt.wantOK, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestCheckPermissions(t *testing.T) {
+	if ok, _ := CheckPermissions(func() error { return nil }); !ok {
+		t.Error("CheckPermissions(granted) = false, want true")
+	}
+	if ok, detail := CheckPermissions(func() error { return errors.New("not trusted") }); ok || detail != "not trusted" {
+		t.Errorf("CheckPermissions(denied) = %t, %q", ok, detail)
+	}
+}
+
+func TestCheckMicrophones(t *testing.T) {
+	tests := []struct {
+		name       string
+		devices    []audio.Device
+		err        error
+		wantOK     bool
+		wantDetail string
+	}{
+		{
+			name:       "devices found",
+			devices:    []audio.Device{{Name: "MacBook Pro Microphone", IsDefault: true}, {Name: "USB Mic"}},
+			wantOK:     true,
+			wantDetail: "2 found: MacBook Pro Microphone (default), USB Mic",
+		},
+		{name: "no devices", wantOK: false, wantDetail: "no microphones found"},
+		{name: "error", err: errors.New("audio context failed"), wantOK: false, wantDetail: "audio context failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, detail := CheckMicrophones(func() ([]audio.Device, error) { return tt.devices, tt.err })
+			if ok != tt.wantOK || detail != tt.wantDetail {
+				t.Errorf("CheckMicrophones() = %t, %q, want %t, %q", ok, detail, tt.wantOK, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestCheckFFmpeg(t *testing.T) {
+	if ok, detail := CheckFFmpeg(func() error { return nil }); !ok || detail != "ffmpeg available" {
+		t.Errorf("CheckFFmpeg(installed) = %t, %q", ok, detail)
+	}
+	if ok, detail := CheckFFmpeg(func() error { return errors.New("not found") }); ok || !strings.Contains(detail, "WAV") {
+		t.Errorf("CheckFFmpeg(missing) = %t, %q", ok, detail)
+	}
+}
+
+func TestCheckDiskSpace(t *testing.T) {
+	const gb = 1024 * 1024 * 1024
+
+	if ok, detail := CheckDiskSpace(func() (int64, error) { return 5 * gb, nil }, gb); !ok || detail != "5.0 GB available" {
+		t.Errorf("CheckDiskSpace(plenty) = %t, %q", ok, detail)
+	}
+	if ok, detail := CheckDiskSpace(func() (int64, error) { return gb / 2, nil }, gb); ok || !strings.Contains(detail, "less than") {
+		t.Errorf("CheckDiskSpace(low) = %t, %q", ok, detail)
+	}
+	if ok, _ := CheckDiskSpace(func() (int64, error) { return 0, errors.New("statfs failed") }, gb); ok {
+		t.Error("CheckDiskSpace(error) = true, want false")
+	}
+}
+
+func TestRunAndHasCriticalFailure(t *testing.T) {
+	pass := func() (bool, string) { return true, "fine" }
+	fail := func() (bool, string) { return false, "broken" }
+
+	results := Run([]Check{
+		{Name: "critical ok", Critical: true, Run: pass},
+		{Name: "warning", Critical: false, Run: fail},
+	})
+	if len(results) != 2 || !results[0].OK || results[1].OK || results[1].Detail != "broken" {
+		t.Fatalf("Run() = %+v", results)
+	}
+	if HasCriticalFailure(results) {
+		t.Error("HasCriticalFailure() = true with only a non-critical failure")
+	}
+
+	results = append(results, Run([]Check{{Name: "critical fail", Critical: true, Run: fail}})...)
+	if !HasCriticalFailure(results) {
+		t.Error("HasCriticalFailure() = false with a critical failure")
+	}
+}
+
+func TestDefaultChecks_ModelCheckPerBackend(t *testing.T) {
+	tests := []struct {
+		backend   string
+		wantModel string // Name of the model check, "" if none
+	}{
+		{"", "Whisper model"},
+		{"whisper", "Whisper model"},
+		{"moonshine", "Moonshine model"},
+		{"openai", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Backend = tt.backend
+
+			var modelCheck string
+			for _, c := range DefaultChecks(cfg) {
+				if strings.HasSuffix(c.Name, "model") {
+					modelCheck = c.Name
+				}
+			}
+			if modelCheck != tt.wantModel {
+				t.Errorf("model check = %q, want %q", modelCheck, tt.wantModel)
+			}
+		})
+	}
+}
+
+func TestResult_JSON(t *testing.T) {
+	results := Run([]Check{{Name: "Microphone", Critical: true, Hint: "Connect one", Run: func() (bool, string) { return false, "none" }}})
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	want := `[{"name":"Microphone","critical":true,"hint":"Connect one","ok":false,"detail":"none"}]`
+	if string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+}
@@ -0,0 +1,137 @@
+package hotkey
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ModifierMask is the set of modifier keys a key combination requires.
+// Values match the macOS CGEventFlags masks so they can be compared directly.
+type ModifierMask uint32
+
+// Modifier masks (kCGEventFlagMask*)
+const (
+	ModShift   ModifierMask = 0x20000
+	ModControl ModifierMask = 0x40000
+	ModOption  ModifierMask = 0x80000
+	ModCommand ModifierMask = 0x100000
+)
+
+// modifierNames maps the (lowercase) names accepted in a combination to modifier masks
+var modifierNames = map[string]ModifierMask{
+	"cmd":     ModCommand,
+	"command": ModCommand,
+	"shift":   ModShift,
+	"ctrl":    ModControl,
+	"control": ModControl,
+	"opt":     ModOption,
+	"option":  ModOption,
+	"alt":     ModOption,
+}
+
+// ComboKeyMap maps regular (non-modifier) key names to macOS virtual key codes.
+// These keys can only be used as the final key of a combination, e.g. "Cmd+Shift+Space".
+var ComboKeyMap = map[string]KeyCode{
+	"A": 0x00, "S": 0x01, "D": 0x02, "F": 0x03, "H": 0x04, "G": 0x05, "Z": 0x06,
+	"X": 0x07, "C": 0x08, "V": 0x09, "B": 0x0B, "Q": 0x0C, "W": 0x0D, "E": 0x0E,
+	"R": 0x0F, "Y": 0x10, "T": 0x11, "O": 0x1F, "U": 0x20, "I": 0x22, "P": 0x23,
+	"L": 0x25, "J": 0x26, "K": 0x28, "N": 0x2D, "M": 0x2E,
+	"1": 0x12, "2": 0x13, "3": 0x14, "4": 0x15, "5": 0x17,
+	"6": 0x16, "7": 0x1A, "8": 0x1C, "9": 0x19, "0": 0x1D,
+	"Space":  0x31,
+	"Return": 0x24,
+	"Tab":    0x30,
+	"Escape": 0x35,
+	"Delete": 0x33,
+}
+
+// Combo is a key combination: a regular key pressed while modifiers are held
+type Combo struct {
+	KeyCode   KeyCode
+	Modifiers ModifierMask
+}
+
+// IsCombo reports whether a trigger name is a key combination rather than a single key
+func IsCombo(name string) bool {
+	return strings.Contains(name, "+")
+}
+
+// ParseCombo parses a combination such as "Cmd+Shift+Space".
+// Modifiers come first (Cmd/Command, Shift, Ctrl/Control, Opt/Option/Alt, any case),
+// followed by exactly one key from ComboKeyMap. At least one modifier is required.
+func ParseCombo(name string) (Combo, error) {
+	parts := strings.Split(name, "+")
+	if len(parts) < 2 {
+		return Combo{}, fmt.Errorf("invalid key combination: %s (expected e.g. Cmd+Shift+Space)", name)
+	}
+
+	var combo Combo
+	for _, part := range parts[:len(parts)-1] {
+		mod, ok := modifierNames[strings.ToLower(strings.TrimSpace(part))]
+		if !ok {
+			return Combo{}, fmt.Errorf("invalid modifier %q in key combination: %s", part, name)
+		}
+		if combo.Modifiers&mod != 0 {
+			return Combo{}, fmt.Errorf("duplicate modifier %q in key combination: %s", part, name)
+		}
+		combo.Modifiers |= mod
+	}
+
+	key := strings.TrimSpace(parts[len(parts)-1])
+	keyCode, ok := lookupComboKey(key)
+	if !ok {
+		return Combo{}, fmt.Errorf("invalid key %q in key combination: %s", key, name)
+	}
+	combo.KeyCode = keyCode
+
+	return combo, nil
+}
+
+// lookupComboKey finds a combination key by name, ignoring case
+func lookupComboKey(name string) (KeyCode, bool) {
+	for keyName, code := range ComboKeyMap {
+		if strings.EqualFold(keyName, name) {
+			return code, true
+		}
+	}
+	return 0, false
+}
+
+// String formats the combination in canonical form, e.g. "Cmd+Shift+Space"
+func (c Combo) String() string {
+	var parts []string
+	for _, m := range []struct {
+		mask ModifierMask
+		name string
+	}{
+		{ModControl, "Ctrl"},
+		{ModOption, "Opt"},
+		{ModShift, "Shift"},
+		{ModCommand, "Cmd"},
+	} {
+		if c.Modifiers&m.mask != 0 {
+			parts = append(parts, m.name)
+		}
+	}
+
+	keyName := fmt.Sprintf("0x%02X", uint32(c.KeyCode))
+	for name, code := range ComboKeyMap {
+		if code == c.KeyCode {
+			keyName = name
+			break
+		}
+	}
+
+	return strings.Join(append(parts, keyName), "+")
+}
+
+// GetComboKeys returns the key names usable as the final key of a combination
+func GetComboKeys() []string {
+	keys := make([]string, 0, len(ComboKeyMap))
+	for name := range ComboKeyMap {
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+	return keys
+}
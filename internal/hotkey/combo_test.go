@@ -0,0 +1,83 @@
+package hotkey
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseCombo(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      Combo
+		expectErr bool
+	}{
+		{"cmd shift space", "Cmd+Shift+Space", Combo{KeyCode: 0x31, Modifiers: ModCommand | ModShift}, false},
+		{"case insensitive", "ctrl+alt+k", Combo{KeyCode: 0x28, Modifiers: ModControl | ModOption}, false},
+		{"long modifier names", "Command+Option+1", Combo{KeyCode: 0x12, Modifiers: ModCommand | ModOption}, false},
+		{"spaces around parts", "Cmd + Return", Combo{KeyCode: 0x24, Modifiers: ModCommand}, false},
+		{"no modifier", "Space", Combo{}, true},
+		{"unknown modifier", "Hyper+Space", Combo{}, true},
+		{"unknown key", "Cmd+F13", Combo{}, true},
+		{"duplicate modifier", "Cmd+Command+Space", Combo{}, true},
+		{"missing key", "Cmd+", Combo{}, true},
+		{"modifier as key", "Cmd+Shift", Combo{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCombo(tt.input)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("ParseCombo(%q) expected error, got %+v", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCombo(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCombo(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCombo_StringRoundTrip(t *testing.T) {
+	combo, err := ParseCombo("shift+cmd+space")
+	if err != nil {
+		t.Fatalf("ParseCombo() error: %v", err)
+	}
+	if got := combo.String(); got != "Shift+Cmd+Space" {
+		t.Errorf("String() = %q, want %q", got, "Shift+Cmd+Space")
+	}
+
+	parsed, err := ParseCombo(combo.String())
+	if err != nil {
+		t.Fatalf("ParseCombo(%q) error: %v", combo.String(), err)
+	}
+	if parsed != combo {
+		t.Errorf("round trip = %+v, want %+v", parsed, combo)
+	}
+}
+
+func TestComboListener_SinglePress(t *testing.T) {
+	var calls atomic.Int32
+	listener, err := NewListener("Cmd+Shift+Space", func() { calls.Add(1) })
+	if err != nil {
+		t.Fatalf("NewListener() error: %v", err)
+	}
+	defer listener.cancel()
+
+	if listener.modifiers != ModCommand|ModShift || listener.keyCode != 0x31 {
+		t.Errorf("listener = keyCode 0x%X, modifiers 0x%X", listener.keyCode, listener.modifiers)
+	}
+
+	listener.handleKeyPress()
+	time.Sleep(50 * time.Millisecond)
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("callback called %d times after single press, want 1", got)
+	}
+}
@@ -3,6 +3,7 @@
 // This package handles:
 //   - Global hotkey registration using macOS Carbon Event Manager
 //   - Double-press detection with configurable time window
+//   - Push-to-talk (hold) mode with separate press/release callbacks
 //   - Support for modifier keys (Option, Shift, Command, Control)
 //   - Platform-specific implementations (macOS only)
 //
@@ -14,6 +15,9 @@
 //   - Left Shift / Right Shift
 //   - Left Command / Right Command
 //   - Left Control / Right Control
+//   - Caps Lock
+//   - F13 - F19
+//   - Key combinations such as Cmd+Shift+Space (single press)
 //
 // Requirements:
 //   - macOS Accessibility permissions must be granted
@@ -4,10 +4,15 @@ package hotkey
 import (
 	"context"
 	"fmt"
+	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// healthCheckInterval is how often a running listener verifies the event tap is still enabled
+const healthCheckInterval = 2 * time.Second
+
 // KeyCode represents a keyboard key code or synthetic mouse button code
 type KeyCode uint32
 
@@ -21,6 +26,15 @@ const (
 	KeyLeftCmd     KeyCode = 0x37
 	KeyRightCtrl   KeyCode = 0x3E
 	KeyLeftCtrl    KeyCode = 0x3B
+	KeyCapsLock    KeyCode = 0x39
+	// Function keys (non-modifier keys, delivered as key down/up events)
+	KeyF13 KeyCode = 0x69
+	KeyF14 KeyCode = 0x6B
+	KeyF15 KeyCode = 0x71
+	KeyF16 KeyCode = 0x6A
+	KeyF17 KeyCode = 0x40
+	KeyF18 KeyCode = 0x4F
+	KeyF19 KeyCode = 0x50
 	// Mouse buttons (synthetic codes, mapped in platform-specific code)
 	ButtonForward KeyCode = 0x10001 // Mouse Forward button (button 4)
 	ButtonBack    KeyCode = 0x10002 // Mouse Back button (button 3)
@@ -36,6 +50,14 @@ var KeyNameMap = map[string]KeyCode{
 	"Left Command":   KeyLeftCmd,
 	"Right Control":  KeyRightCtrl,
 	"Left Control":   KeyLeftCtrl,
+	"Caps Lock":      KeyCapsLock,
+	"F13":            KeyF13,
+	"F14":            KeyF14,
+	"F15":            KeyF15,
+	"F16":            KeyF16,
+	"F17":            KeyF17,
+	"F18":            KeyF18,
+	"F19":            KeyF19,
 	"Forward Button": ButtonForward,
 	"Back Button":    ButtonBack,
 }
@@ -50,41 +72,175 @@ var KeyCodeToName = map[KeyCode]string{
 	KeyLeftCmd:     "Left Command",
 	KeyRightCtrl:   "Right Control",
 	KeyLeftCtrl:    "Left Control",
+	KeyCapsLock:    "Caps Lock",
+	KeyF13:         "F13",
+	KeyF14:         "F14",
+	KeyF15:         "F15",
+	KeyF16:         "F16",
+	KeyF17:         "F17",
+	KeyF18:         "F18",
+	KeyF19:         "F19",
 	ButtonForward:  "Forward Button",
 	ButtonBack:     "Back Button",
 }
 
+// Mode selects how a listener turns key events into actions
+type Mode int
+
+const (
+	// ModeToggle fires the callback on a double-press (default)
+	ModeToggle Mode = iota
+	// ModeHold fires onPress when the key goes down and onRelease when it comes back up
+	ModeHold
+)
+
+// String returns the config name of the mode
+func (m Mode) String() string {
+	if m == ModeHold {
+		return "hold"
+	}
+	return "toggle"
+}
+
+// ParseMode converts a config value ("toggle", "hold", or empty for toggle) to a Mode
+func ParseMode(name string) (Mode, error) {
+	switch name {
+	case "", "toggle":
+		return ModeToggle, nil
+	case "hold":
+		return ModeHold, nil
+	default:
+		return ModeToggle, fmt.Errorf("invalid hotkey mode: %s (must be toggle or hold)", name)
+	}
+}
+
+// TriggerMode selects how many presses of a key fire the toggle callback
+type TriggerMode int
+
+const (
+	// DoublePress fires the callback on two presses within doublePressDelay (default)
+	DoublePress TriggerMode = iota
+	// SinglePress fires the callback on every press. Faster, but an accidental tap
+	// of the key toggles recording, so it is best used with a dedicated key (e.g. F13).
+	SinglePress
+)
+
+// String returns the config name of the trigger mode
+func (m TriggerMode) String() string {
+	if m == SinglePress {
+		return "single"
+	}
+	return "double"
+}
+
+// ParseTriggerMode converts a config value ("double", "single", or empty for double) to a TriggerMode
+func ParseTriggerMode(name string) (TriggerMode, error) {
+	switch name {
+	case "", "double":
+		return DoublePress, nil
+	case "single":
+		return SinglePress, nil
+	default:
+		return DoublePress, fmt.Errorf("invalid trigger mode: %s (must be double or single)", name)
+	}
+}
+
 // Listener listens for global hotkey events
 type Listener struct {
 	keyCode          KeyCode
+	modifiers        ModifierMask // Non-zero for key combinations (e.g. Cmd+Shift+Space)
+	mode             Mode
+	triggerMode      TriggerMode
 	doublePressDelay time.Duration
 	callback         func()
 
+	// Hold mode callbacks
+	onPress   func()
+	onRelease func()
+	isHeld    bool
+	pressDone chan struct{} // Closed when the last onPress call returns
+
 	mu            sync.Mutex
 	lastPressTime time.Time
 	pressCount    int
 
+	// Event tap health (hooks are swappable for tests)
+	healthy     atomic.Bool
+	tapEnabled  func() bool
+	reenableTap func()
+
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
 }
 
-// NewListener creates a new hotkey listener
+// NewListener creates a new hotkey listener.
+// keyName is either a single key from KeyNameMap (double-press to trigger) or a
+// key combination such as "Cmd+Shift+Space" (a single press triggers, since a
+// combination doesn't collide with normal typing).
 func NewListener(keyName string, callback func()) (*Listener, error) {
-	keyCode, ok := KeyNameMap[keyName]
-	if !ok {
-		return nil, fmt.Errorf("unknown key name: %s", keyName)
+	var keyCode KeyCode
+	var modifiers ModifierMask
+	if IsCombo(keyName) {
+		combo, err := ParseCombo(keyName)
+		if err != nil {
+			return nil, err
+		}
+		keyCode, modifiers = combo.KeyCode, combo.Modifiers
+	} else {
+		code, ok := KeyNameMap[keyName]
+		if !ok {
+			return nil, fmt.Errorf("unknown key name: %s", keyName)
+		}
+		keyCode = code
+	}
+
+	// Key combinations don't collide with normal typing, so they always trigger on a single press
+	triggerMode := DoublePress
+	if modifiers != 0 {
+		triggerMode = SinglePress
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &Listener{
+	l := &Listener{
 		keyCode:          keyCode,
+		modifiers:        modifiers,
+		triggerMode:      triggerMode,
 		doublePressDelay: 500 * time.Millisecond, // 500ms window for double-press
 		callback:         callback,
+		tapEnabled:       eventTapEnabled,
+		reenableTap:      reenableEventTap,
 		ctx:              ctx,
 		cancel:           cancel,
-	}, nil
+	}
+	l.healthy.Store(true)
+
+	return l, nil
+}
+
+// NewHoldListener creates a push-to-talk listener that calls onPress when the key
+// goes down and onRelease when it is released
+func NewHoldListener(keyName string, onPress, onRelease func()) (*Listener, error) {
+	l, err := NewListener(keyName, nil)
+	if err != nil {
+		return nil, err
+	}
+	l.mode = ModeHold
+	l.onPress = onPress
+	l.onRelease = onRelease
+	return l, nil
+}
+
+// SetTriggerMode changes how many presses fire the callback. Key combinations
+// always use SinglePress and ignore this setting.
+func (l *Listener) SetTriggerMode(mode TriggerMode) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.modifiers == 0 {
+		l.triggerMode = mode
+	}
 }
 
 // Start begins listening for hotkey events
@@ -103,28 +259,79 @@ func (l *Listener) Stop() {
 	l.wg.Wait()
 }
 
-// eventLoop processes key events and detects double-presses
+// Healthy reports whether the event tap was enabled at the last health check.
+// It turns false when the system disabled the tap and re-enabling it failed.
+func (l *Listener) Healthy() bool {
+	return l.healthy.Load()
+}
+
+// eventLoop processes key events, detects double-presses and watches the event tap
 func (l *Listener) eventLoop() {
 	defer l.wg.Done()
 
 	ticker := time.NewTicker(50 * time.Millisecond)
 	defer ticker.Stop()
 
+	healthTicker := time.NewTicker(healthCheckInterval)
+	defer healthTicker.Stop()
+
 	for {
 		select {
 		case <-l.ctx.Done():
 			return
 		case <-ticker.C:
 			l.checkPressTimeout()
+		case <-healthTicker.C:
+			l.checkEventTap()
 		}
 	}
 }
 
+// checkEventTap re-enables the event tap if the system disabled it
+// (e.g. after sleep or when the tap callback was too slow)
+func (l *Listener) checkEventTap() {
+	if l.tapEnabled() {
+		l.healthy.Store(true)
+		return
+	}
+
+	log.Printf("[HOTKEY] Warning: event tap was disabled by the system, re-enabling")
+	l.reenableTap()
+
+	if l.tapEnabled() {
+		l.healthy.Store(true)
+		return
+	}
+
+	log.Printf("[HOTKEY] Warning: failed to re-enable event tap, triggers will not respond")
+	l.healthy.Store(false)
+}
+
 // handleKeyPress processes a key press event
 func (l *Listener) handleKeyPress() {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	if l.mode == ModeHold {
+		// Ignore repeated down events while the key is held
+		if !l.isHeld {
+			l.isHeld = true
+			done := make(chan struct{})
+			l.pressDone = done
+			go func() {
+				defer close(done)
+				l.onPress()
+			}()
+		}
+		return
+	}
+
+	// Single-press mode (and key combinations) bypass double-press detection
+	if l.triggerMode == SinglePress {
+		go l.callback()
+		return
+	}
+
 	now := time.Now()
 
 	// Check if this is within the double-press window
@@ -145,6 +352,22 @@ func (l *Listener) handleKeyPress() {
 	}
 }
 
+// handleKeyRelease processes a key release event (only meaningful in hold mode)
+func (l *Listener) handleKeyRelease() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.mode == ModeHold && l.isHeld {
+		l.isHeld = false
+		// On a quick tap the release must not overtake its press
+		pressDone := l.pressDone
+		go func() {
+			<-pressDone
+			l.onRelease()
+		}()
+	}
+}
+
 // checkPressTimeout resets the press count if the timeout has elapsed
 func (l *Listener) checkPressTimeout() {
 	l.mu.Lock()
@@ -165,8 +388,14 @@ func GetAvailableKeys() []string {
 	return keys
 }
 
-// ValidateKeyName checks if a key name is valid
+// ValidateKeyName checks if a key name (or key combination) is valid
 func ValidateKeyName(keyName string) error {
+	if IsCombo(keyName) {
+		if _, err := ParseCombo(keyName); err != nil {
+			return err
+		}
+		return nil
+	}
 	if _, ok := KeyNameMap[keyName]; !ok {
 		return fmt.Errorf("invalid key name: %s", keyName)
 	}
@@ -213,6 +442,50 @@ func NewMultiListener(triggerNames []string, callback func()) (*MultiListener, e
 	return ml, nil
 }
 
+// NewMultiHoldListener creates push-to-talk listeners for multiple triggers
+func NewMultiHoldListener(triggerNames []string, onPress, onRelease func()) (*MultiListener, error) {
+	if len(triggerNames) == 0 {
+		return nil, fmt.Errorf("at least one trigger name is required")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ml := &MultiListener{
+		listeners: make([]*Listener, 0, len(triggerNames)),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+
+	// Create a listener for each trigger
+	for _, triggerName := range triggerNames {
+		listener, err := NewHoldListener(triggerName, onPress, onRelease)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to create listener for trigger %q: %w", triggerName, err)
+		}
+		ml.listeners = append(ml.listeners, listener)
+	}
+
+	return ml, nil
+}
+
+// Healthy reports whether every listener's event tap is enabled
+func (ml *MultiListener) Healthy() bool {
+	for _, listener := range ml.listeners {
+		if !listener.Healthy() {
+			return false
+		}
+	}
+	return true
+}
+
+// SetTriggerMode changes the trigger mode of every listener
+func (ml *MultiListener) SetTriggerMode(mode TriggerMode) {
+	for _, listener := range ml.listeners {
+		listener.SetTriggerMode(mode)
+	}
+}
+
 // Start begins listening for all configured triggers
 func (ml *MultiListener) Start() error {
 	// Start all listeners
@@ -11,8 +11,10 @@ package hotkey
 #import <CoreGraphics/CoreGraphics.h>
 #import <ApplicationServices/ApplicationServices.h>
 
-// Global variable to store the Go callback with keycode parameter
+// Go callbacks for key press and release, with keycode parameter
 extern void goHotkeyCallback(uint32_t keyCode);
+extern void goHotkeyReleaseCallback(uint32_t keyCode);
+extern void goHotkeyComboCallback(uint32_t keyCode, uint32_t modifiers, int pressed);
 
 // Global variables for event handling
 static CFMachPortRef gEventTap = NULL;
@@ -24,6 +26,12 @@ static CFRunLoopRef gRunLoop = NULL;
 static uint32_t gTargetKeyCodes[MAX_TARGET_KEYS];
 static int gTargetKeyCount = 0;
 
+// Key combinations (regular key + required modifier flags)
+#define COMBO_MODIFIER_MASK (kCGEventFlagMaskShift | kCGEventFlagMaskControl | kCGEventFlagMaskAlternate | kCGEventFlagMaskCommand)
+static uint32_t gComboKeyCodes[MAX_TARGET_KEYS];
+static uint32_t gComboModifiers[MAX_TARGET_KEYS];
+static int gComboCount = 0;
+
 // Check if a keycode is in the target list
 static bool isTargetKeyCode(uint32_t keyCode) {
     for (int i = 0; i < gTargetKeyCount; i++) {
@@ -34,6 +42,17 @@ static bool isTargetKeyCode(uint32_t keyCode) {
     return false;
 }
 
+// Find a combo by keycode and exact modifiers (matchModifiers) or by keycode only.
+// Returns the combo index or -1.
+static int findCombo(uint32_t keyCode, uint32_t modifiers, bool matchModifiers) {
+    for (int i = 0; i < gComboCount; i++) {
+        if (gComboKeyCodes[i] == keyCode && (!matchModifiers || gComboModifiers[i] == modifiers)) {
+            return i;
+        }
+    }
+    return -1;
+}
+
 // Event tap callback for monitoring keyboard and mouse events
 static CGEventRef eventTapCallback(CGEventTapProxy proxy, CGEventType type, CGEventRef event, void *refcon) {
     // Handle tap disabled event
@@ -45,7 +64,7 @@ static CGEventRef eventTapCallback(CGEventTapProxy proxy, CGEventType type, CGEv
     }
 
     // Handle mouse button events
-    if (type == kCGEventOtherMouseDown) {
+    if (type == kCGEventOtherMouseDown || type == kCGEventOtherMouseUp) {
         int64_t buttonNumber = CGEventGetIntegerValueField(event, kCGMouseEventButtonNumber);
 
         // Map physical mouse button numbers to synthetic key codes
@@ -58,7 +77,49 @@ static CGEventRef eventTapCallback(CGEventTapProxy proxy, CGEventType type, CGEv
 
         // If this is one of our target buttons, trigger the callback
         if (syntheticKeyCode != 0 && isTargetKeyCode(syntheticKeyCode)) {
-            goHotkeyCallback(syntheticKeyCode);
+            if (type == kCGEventOtherMouseDown) {
+                goHotkeyCallback(syntheticKeyCode);
+            } else {
+                goHotkeyReleaseCallback(syntheticKeyCode);
+            }
+        }
+    }
+
+    // Handle key combinations (e.g. Cmd+Shift+Space)
+    if ((type == kCGEventKeyDown || type == kCGEventKeyUp) && gComboCount > 0) {
+        uint32_t keyCode = (uint32_t)CGEventGetIntegerValueField(event, kCGKeyboardEventKeycode);
+        uint32_t modifiers = (uint32_t)(CGEventGetFlags(event) & COMBO_MODIFIER_MASK);
+
+        if (type == kCGEventKeyDown) {
+            int idx = findCombo(keyCode, modifiers, true);
+            if (idx >= 0) {
+                // Ignore auto-repeat while the combination is held
+                if (CGEventGetIntegerValueField(event, kCGKeyboardEventAutorepeat) == 0) {
+                    goHotkeyComboCallback(keyCode, gComboModifiers[idx], 1);
+                }
+                return NULL; // Swallow the combination so it doesn't reach the focused app
+            }
+        } else {
+            // Modifiers may already be released when the key comes up, so match on keycode only
+            int idx = findCombo(keyCode, 0, false);
+            if (idx >= 0) {
+                goHotkeyComboCallback(keyCode, gComboModifiers[idx], 0);
+            }
+        }
+    }
+
+    // Handle non-modifier trigger keys (F13-F19), which arrive as key down/up events
+    if (type == kCGEventKeyDown || type == kCGEventKeyUp) {
+        uint32_t keyCode = (uint32_t)CGEventGetIntegerValueField(event, kCGKeyboardEventKeycode);
+        if (isTargetKeyCode(keyCode)) {
+            if (type == kCGEventKeyDown) {
+                if (CGEventGetIntegerValueField(event, kCGKeyboardEventAutorepeat) == 0) {
+                    goHotkeyCallback(keyCode);
+                }
+            } else {
+                goHotkeyReleaseCallback(keyCode);
+            }
+            return NULL; // Swallow the key so it doesn't reach the focused app
         }
     }
 
@@ -95,11 +156,18 @@ static CGEventRef eventTapCallback(CGEventTapProxy proxy, CGEventType type, CGEv
                 case 0x3B: // Left Control
                     isPressed = (flags & kCGEventFlagMaskControl) != 0;
                     break;
+                case 0x39: // Caps Lock
+                    // Caps Lock toggles its flag on each press and reports no release,
+                    // so every event counts as a press (config validation rejects it in hold mode)
+                    isPressed = true;
+                    break;
             }
 
-            // Only trigger callback on key press (not release)
+            // Release is detected when the modifier flag clears
             if (isPressed) {
                 goHotkeyCallback((uint32_t)keyCode);
+            } else {
+                goHotkeyReleaseCallback((uint32_t)keyCode);
             }
         }
     }
@@ -140,6 +208,23 @@ static int addKeyCode(uint32_t keyCode) {
     return 0;
 }
 
+// Add a key combination to the list of monitored combos
+static int addCombo(uint32_t keyCode, uint32_t modifiers) {
+    if (findCombo(keyCode, modifiers, true) >= 0) {
+        return 0; // Already added
+    }
+
+    if (gComboCount >= MAX_TARGET_KEYS) {
+        return -4; // Too many combos
+    }
+
+    gComboKeyCodes[gComboCount] = keyCode;
+    gComboModifiers[gComboCount] = modifiers;
+    gComboCount++;
+
+    return 0;
+}
+
 // Initialize the event tap (called once for all keys)
 static int initializeEventTap() {
     // Check accessibility permissions first
@@ -152,10 +237,14 @@ static int initializeEventTap() {
         return 0; // Already initialized
     }
 
-    // Create an event tap to monitor flags changed events (for modifier keys)
-    // and mouse button events (for mouse triggers)
+    // Create an event tap to monitor flags changed events (for modifier keys),
+    // key down/up events (for key combinations) and mouse button down/up events
+    // (for mouse triggers)
     CGEventMask eventMask = CGEventMaskBit(kCGEventFlagsChanged) |
-                            CGEventMaskBit(kCGEventOtherMouseDown);
+                            CGEventMaskBit(kCGEventKeyDown) |
+                            CGEventMaskBit(kCGEventKeyUp) |
+                            CGEventMaskBit(kCGEventOtherMouseDown) |
+                            CGEventMaskBit(kCGEventOtherMouseUp);
 
     gEventTap = CGEventTapCreate(
         kCGSessionEventTap,
@@ -195,10 +284,28 @@ static void unregisterHotkeys() {
         gEventTap = NULL;
     }
 
-    // Clear the keycode list
+    // Clear the keycode and combo lists
     gTargetKeyCount = 0;
+    gComboCount = 0;
     for (int i = 0; i < MAX_TARGET_KEYS; i++) {
         gTargetKeyCodes[i] = 0;
+        gComboKeyCodes[i] = 0;
+        gComboModifiers[i] = 0;
+    }
+}
+
+// Check whether the event tap exists and is currently enabled
+static int isEventTapEnabled() {
+    if (gEventTap == NULL) {
+        return 0;
+    }
+    return CGEventTapIsEnabled(gEventTap) ? 1 : 0;
+}
+
+// Re-enable the event tap after the system disabled it
+static void reenableEventTap() {
+    if (gEventTap != NULL) {
+        CGEventTapEnable(gEventTap, true);
     }
 }
 
@@ -237,9 +344,15 @@ import (
 	"sync"
 )
 
+// listenerKey identifies a listener by keycode and required modifiers (0 for single keys)
+type listenerKey struct {
+	keyCode   KeyCode
+	modifiers ModifierMask
+}
+
 // Global map to store listeners by keycode for the C callback
 var (
-	listenerMap   = make(map[KeyCode]*Listener)
+	listenerMap   = make(map[listenerKey]*Listener)
 	listenerMutex sync.RWMutex
 	eventLoopOnce sync.Once
 )
@@ -247,14 +360,51 @@ var (
 //export goHotkeyCallback
 func goHotkeyCallback(keyCode C.uint32_t) {
 	listenerMutex.RLock()
-	listener := listenerMap[KeyCode(keyCode)]
+	listener := listenerMap[listenerKey{keyCode: KeyCode(keyCode)}]
+	listenerMutex.RUnlock()
+
+	if listener != nil {
+		listener.handleKeyPress()
+	}
+}
+
+//export goHotkeyReleaseCallback
+func goHotkeyReleaseCallback(keyCode C.uint32_t) {
+	listenerMutex.RLock()
+	listener := listenerMap[listenerKey{keyCode: KeyCode(keyCode)}]
 	listenerMutex.RUnlock()
 
 	if listener != nil {
+		listener.handleKeyRelease()
+	}
+}
+
+//export goHotkeyComboCallback
+func goHotkeyComboCallback(keyCode C.uint32_t, modifiers C.uint32_t, pressed C.int) {
+	listenerMutex.RLock()
+	listener := listenerMap[listenerKey{keyCode: KeyCode(keyCode), modifiers: ModifierMask(modifiers)}]
+	listenerMutex.RUnlock()
+
+	if listener == nil {
+		return
+	}
+	if pressed != 0 {
 		listener.handleKeyPress()
+	} else {
+		listener.handleKeyRelease()
 	}
 }
 
+// eventTapEnabled reports whether the shared event tap is alive (macOS-specific)
+func eventTapEnabled() bool {
+	return C.isEventTapEnabled() != 0
+}
+
+// reenableEventTap turns the shared event tap back on after the system disabled it
+func reenableEventTap() {
+	C.reenableEventTap()
+}
+
 // startEventMonitor starts monitoring for hotkey events (macOS-specific)
 func (l *Listener) startEventMonitor() error {
 	// Initialize event tap once for all listeners
@@ -292,8 +442,13 @@ func (l *Listener) startEventMonitor() error {
 		return initErr
 	}
 
-	// Add this keycode to the monitored list
-	result := C.addKeyCode(C.uint32_t(l.keyCode))
+	// Add this keycode (or key combination) to the monitored list
+	var result C.int
+	if l.modifiers != 0 {
+		result = C.addCombo(C.uint32_t(l.keyCode), C.uint32_t(l.modifiers))
+	} else {
+		result = C.addKeyCode(C.uint32_t(l.keyCode))
+	}
 	if result == -4 {
 		return fmt.Errorf("too many triggers configured (maximum %d)", 16)
 	} else if result != 0 {
@@ -302,7 +457,7 @@ func (l *Listener) startEventMonitor() error {
 
 	// Register this listener in the global map
 	listenerMutex.Lock()
-	listenerMap[l.keyCode] = l
+	listenerMap[listenerKey{keyCode: l.keyCode, modifiers: l.modifiers}] = l
 	listenerMutex.Unlock()
 
 	return nil
@@ -310,9 +465,13 @@ func (l *Listener) startEventMonitor() error {
 
 // stopEventMonitor stops monitoring for hotkey events (macOS-specific)
 func (l *Listener) stopEventMonitor() {
-	// Remove this listener from the map
+	// Remove this listener from the map, unless a newer listener took over its key
+	// (start reloads triggers by starting the new listeners before stopping the old ones)
+	key := listenerKey{keyCode: l.keyCode, modifiers: l.modifiers}
 	listenerMutex.Lock()
-	delete(listenerMap, l.keyCode)
+	if listenerMap[key] == l {
+		delete(listenerMap, key)
+	}
 	isEmpty := len(listenerMap) == 0
 	listenerMutex.Unlock()
 
@@ -5,6 +5,16 @@ package hotkey
 
 import "fmt"
 
+// eventTapEnabled is a stub for non-Darwin platforms
+func eventTapEnabled() bool {
+	return false
+}
+
+// reenableEventTap is a stub for non-Darwin platforms
+func reenableEventTap() {
+	// No-op on unsupported platforms
+}
+
 // startEventMonitor is a stub for non-Darwin platforms
 func (l *Listener) startEventMonitor() error {
 	return fmt.Errorf("hotkey monitoring is not supported on this platform")
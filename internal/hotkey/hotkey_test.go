@@ -17,10 +17,13 @@ func TestKeyNameMap(t *testing.T) {
 		{"Left Option exists", "Left Option", KeyLeftOption, true},
 		{"Right Shift exists", "Right Shift", KeyRightShift, true},
 		{"Left Shift exists", "Left Shift", KeyLeftShift, true},
-		{"Right Cmd exists", "Right Cmd", KeyRightCmd, true},
-		{"Left Cmd exists", "Left Cmd", KeyLeftCmd, true},
-		{"Right Ctrl exists", "Right Ctrl", KeyRightCtrl, true},
-		{"Left Ctrl exists", "Left Ctrl", KeyLeftCtrl, true},
+		{"Right Command exists", "Right Command", KeyRightCmd, true},
+		{"Left Command exists", "Left Command", KeyLeftCmd, true},
+		{"Right Control exists", "Right Control", KeyRightCtrl, true},
+		{"Left Control exists", "Left Control", KeyLeftCtrl, true},
+		{"Caps Lock exists", "Caps Lock", KeyCapsLock, true},
+		{"F13 exists", "F13", KeyF13, true},
+		{"F19 exists", "F19", KeyF19, true},
 		{"Invalid key doesn't exist", "Invalid Key", 0, false},
 	}
 
@@ -64,6 +67,18 @@ func TestKeyCodeToName(t *testing.T) {
 	}
 }
 
+func TestKeyMaps_RoundTrip(t *testing.T) {
+	if len(KeyNameMap) != len(KeyCodeToName) {
+		t.Fatalf("len(KeyNameMap) = %d, len(KeyCodeToName) = %d, want equal", len(KeyNameMap), len(KeyCodeToName))
+	}
+
+	for name, code := range KeyNameMap {
+		if got := KeyCodeToName[code]; got != name {
+			t.Errorf("KeyCodeToName[KeyNameMap[%q]] = %q, want %q", name, got, name)
+		}
+	}
+}
+
 func TestNewListener(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -71,7 +86,8 @@ func TestNewListener(t *testing.T) {
 		wantError bool
 	}{
 		{"Valid key name", "Right Option", false},
-		{"Another valid key", "Left Cmd", false},
+		{"Another valid key", "Left Command", false},
+		{"Function key", "F15", false},
 		{"Invalid key name", "Invalid Key", true},
 		{"Empty key name", "", true},
 	}
@@ -353,9 +369,9 @@ func TestGetAvailableKeys(t *testing.T) {
 		t.Error("GetAvailableKeys() returned empty slice")
 	}
 
-	// Should have exactly 8 keys
-	if len(keys) != 8 {
-		t.Errorf("GetAvailableKeys() returned %d keys, want 8", len(keys))
+	// Should list every key in KeyNameMap
+	if len(keys) != len(KeyNameMap) {
+		t.Errorf("GetAvailableKeys() returned %d keys, want %d", len(keys), len(KeyNameMap))
 	}
 
 	// Verify all keys are in KeyNameMap
@@ -373,7 +389,10 @@ func TestValidateKeyName(t *testing.T) {
 		wantError bool
 	}{
 		{"Valid key", "Right Option", false},
-		{"Another valid key", "Left Ctrl", false},
+		{"Another valid key", "Left Control", false},
+		{"Caps Lock", "Caps Lock", false},
+		{"Function key", "F13", false},
+		{"Unsupported function key", "F12", true},
 		{"Invalid key", "Invalid Key", true},
 		{"Empty key", "", true},
 		{"Random string", "foobar", true},
@@ -440,3 +459,234 @@ func TestListenerConcurrency(t *testing.T) {
 
 	t.Logf("Concurrent test triggered %d callbacks", count)
 }
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Mode
+		wantErr bool
+	}{
+		{"Empty defaults to toggle", "", ModeToggle, false},
+		{"Toggle", "toggle", ModeToggle, false},
+		{"Hold", "hold", ModeHold, false},
+		{"Invalid", "push", ModeToggle, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMode(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHoldListener_PressAndRelease(t *testing.T) {
+	var mu sync.Mutex
+	presses, releases := 0, 0
+
+	listener, err := NewHoldListener("Right Option",
+		func() { mu.Lock(); presses++; mu.Unlock() },
+		func() { mu.Lock(); releases++; mu.Unlock() },
+	)
+	if err != nil {
+		t.Fatalf("NewHoldListener() error: %v", err)
+	}
+	defer listener.cancel()
+
+	// Repeated down events while held must only fire once
+	listener.handleKeyPress()
+	listener.handleKeyPress()
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	if presses != 1 || releases != 0 {
+		t.Errorf("after press: presses = %d, releases = %d, want 1, 0", presses, releases)
+	}
+	mu.Unlock()
+
+	listener.handleKeyRelease()
+	listener.handleKeyRelease() // Spurious release is ignored
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	if presses != 1 || releases != 1 {
+		t.Errorf("after release: presses = %d, releases = %d, want 1, 1", presses, releases)
+	}
+	mu.Unlock()
+}
+
+func TestHoldListener_QuickTapStopsRecording(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		var mu sync.Mutex
+		recording := false
+		released := make(chan struct{})
+
+		listener, err := NewHoldListener("Right Option",
+			func() {
+				time.Sleep(time.Millisecond) // A slow start must not let the release overtake it
+				mu.Lock()
+				recording = true
+				mu.Unlock()
+			},
+			func() {
+				mu.Lock()
+				recording = false
+				mu.Unlock()
+				close(released)
+			},
+		)
+		if err != nil {
+			t.Fatalf("NewHoldListener() error: %v", err)
+		}
+
+		listener.handleKeyPress()
+		listener.handleKeyRelease()
+		<-released
+		listener.cancel()
+
+		mu.Lock()
+		if recording {
+			t.Fatalf("tap %d: still recording after press then release", i)
+		}
+		mu.Unlock()
+	}
+}
+
+func TestToggleListener_IgnoresRelease(t *testing.T) {
+	called := false
+	listener, err := NewListener("Right Option", func() { called = true })
+	if err != nil {
+		t.Fatalf("NewListener() error: %v", err)
+	}
+	defer listener.cancel()
+
+	listener.handleKeyRelease()
+	time.Sleep(50 * time.Millisecond)
+
+	if called {
+		t.Error("release triggered the toggle callback")
+	}
+}
+
+func TestParseTriggerMode(t *testing.T) {
+	tests := []struct {
+		input     string
+		want      TriggerMode
+		expectErr bool
+	}{
+		{"", DoublePress, false},
+		{"double", DoublePress, false},
+		{"single", SinglePress, false},
+		{"triple", DoublePress, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTriggerMode(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParseTriggerMode(%q) error = %v, expectErr %v", tt.input, err, tt.expectErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTriggerMode(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSinglePressMode_FiresOnFirstPress(t *testing.T) {
+	var mu sync.Mutex
+	callbackCount := 0
+
+	listener, err := NewListener("F13", func() { mu.Lock(); callbackCount++; mu.Unlock() })
+	if err != nil {
+		t.Fatalf("NewListener() error: %v", err)
+	}
+	defer listener.cancel()
+
+	listener.SetTriggerMode(SinglePress)
+	listener.handleKeyPress()
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	if callbackCount != 1 {
+		t.Errorf("callback count after first press = %d, want 1", callbackCount)
+	}
+	mu.Unlock()
+
+	listener.handleKeyPress()
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	if callbackCount != 2 {
+		t.Errorf("callback count after second press = %d, want 2", callbackCount)
+	}
+	mu.Unlock()
+}
+
+func TestSetTriggerMode_ComboStaysSinglePress(t *testing.T) {
+	listener, err := NewListener("Cmd+Shift+Space", func() {})
+	if err != nil {
+		t.Fatalf("NewListener() error: %v", err)
+	}
+	defer listener.cancel()
+
+	listener.SetTriggerMode(DoublePress)
+	if listener.triggerMode != SinglePress {
+		t.Errorf("combo triggerMode = %v, want %v", listener.triggerMode, SinglePress)
+	}
+}
+
+func TestCheckEventTap_ReenablesDisabledTap(t *testing.T) {
+	listener, err := NewListener("Right Option", func() {})
+	if err != nil {
+		t.Fatalf("NewListener() error: %v", err)
+	}
+	defer listener.cancel()
+
+	enabled := false
+	reenables := 0
+	listener.tapEnabled = func() bool { return enabled }
+	listener.reenableTap = func() { reenables++; enabled = true }
+
+	listener.checkEventTap()
+
+	if reenables != 1 {
+		t.Errorf("reenableTap called %d times, want 1", reenables)
+	}
+	if !listener.Healthy() {
+		t.Error("Healthy() = false after successful re-enable, want true")
+	}
+
+	// A healthy tap is left alone
+	listener.checkEventTap()
+	if reenables != 1 {
+		t.Errorf("reenableTap called %d times for a healthy tap, want 1", reenables)
+	}
+}
+
+func TestCheckEventTap_ReportsUnhealthy(t *testing.T) {
+	listener, err := NewListener("Right Option", func() {})
+	if err != nil {
+		t.Fatalf("NewListener() error: %v", err)
+	}
+	defer listener.cancel()
+
+	listener.tapEnabled = func() bool { return false }
+	listener.reenableTap = func() {}
+
+	if !listener.Healthy() {
+		t.Fatal("Healthy() = false for a new listener, want true")
+	}
+
+	listener.checkEventTap()
+
+	if listener.Healthy() {
+		t.Error("Healthy() = true after failed re-enable, want false")
+	}
+}
@@ -1,21 +1,23 @@
 // Package keyboard provides keyboard simulation for text input on macOS.
 //
 // This package handles:
+//   - Clipboard-based pasting (Cmd+V) with clipboard restore
 //   - Direct text injection using macOS CGEvent APIs
 //   - Unicode character support
 //   - Accessibility permission checking
 //   - Platform-specific implementations (macOS only)
 //
-// The keyboard simulation uses CGEventCreateKeyboardEvent and
-// CGEventKeyboardSetUnicodeString to simulate typing text character-by-character
-// at the current cursor position. This is NOT clipboard-based - it directly
-// simulates keyboard input events.
+// PasteText (the default "clipboard" paste mode) temporarily places the text on
+// the clipboard and sends Cmd+V. TypeText (the "type" paste mode) uses
+// CGEventCreateKeyboardEvent and CGEventKeyboardSetUnicodeString to simulate
+// typing text character-by-character at the current cursor position, which
+// works in apps that intercept Cmd+V such as terminals and vim.
 //
 // Requirements:
 //   - macOS Accessibility permissions must be granted
 //   - Application must be added to System Preferences > Security & Privacy > Accessibility
 //
-// Note: Text is typed with a small delay (2ms) between characters for reliability
+// Note: TypeText types with a small delay (2ms) between characters for reliability
 // across different applications.
 //
 // Example usage:
@@ -28,14 +30,13 @@
 //	defer sim.Close()
 //
 //	// Check permissions
-//	if !sim.HasPermissions() {
-//	    if err := sim.RequestPermissions(); err != nil {
-//	        log.Fatal("Please grant Accessibility permissions")
-//	    }
+//	if err := sim.CheckPermissions(); err != nil {
+//	    keyboard.RequestPermissions()
+//	    log.Fatal("Please grant Accessibility permissions")
 //	}
 //
 //	// Type text at cursor
-//	if err := sim.TypeText("Hello, world!"); err != nil {
+//	if err := keyboard.Insert(sim, keyboard.PasteModeType, "Hello, world!"); err != nil {
 //	    log.Fatal(err)
 //	}
 package keyboard
@@ -1,11 +1,60 @@
 // Package keyboard provides keyboard simulation functionality for pasting text
 package keyboard
 
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+// Default delays around the clipboard paste
+const (
+	DefaultPasteSettleDelay      = 10 * time.Millisecond // Clipboard set → Cmd+V
+	DefaultClipboardRestoreDelay = 50 * time.Millisecond // Cmd+V → clipboard restore
+)
+
+// Options configures clipboard paste timing
+type Options struct {
+	// PasteSettleDelay is how long to wait after setting the clipboard before sending Cmd+V
+	PasteSettleDelay time.Duration
+	// ClipboardRestoreDelay is how long to wait after Cmd+V before restoring the original
+	// clipboard. Slow apps may need more time, or they paste the restored content instead.
+	ClipboardRestoreDelay time.Duration
+}
+
+// OptionsFromConfig builds keyboard options from the user's configuration
+func OptionsFromConfig(cfg *config.Config) Options {
+	return Options{
+		PasteSettleDelay:      time.Duration(cfg.PasteSettleMs) * time.Millisecond,
+		ClipboardRestoreDelay: time.Duration(cfg.ClipboardRestoreMs) * time.Millisecond,
+	}
+}
+
+// withDefaults fills in any unset delays
+func (o Options) withDefaults() Options {
+	if o.PasteSettleDelay <= 0 {
+		o.PasteSettleDelay = DefaultPasteSettleDelay
+	}
+	if o.ClipboardRestoreDelay <= 0 {
+		o.ClipboardRestoreDelay = DefaultClipboardRestoreDelay
+	}
+	return o
+}
+
 // Keyboard provides an interface for simulating keyboard input
 type Keyboard interface {
 	// PasteText pastes the given text at the current cursor position using clipboard
 	PasteText(text string) error
 
+	// CopyText places the given text on the clipboard without pasting it
+	CopyText(text string) error
+
+	// TypeText types the given text at the current cursor position character by character
+	TypeText(text string) error
+
 	// CheckPermissions verifies that the necessary permissions are granted
 	CheckPermissions() error
 
@@ -13,7 +62,138 @@ type Keyboard interface {
 	Close() error
 }
 
-// New creates a new Keyboard instance for the current platform
+// PasteMode selects how transcribed text is inserted at the cursor
+type PasteMode string
+
+const (
+	// PasteModeClipboard sets the clipboard and sends Cmd+V (default, fast)
+	PasteModeClipboard PasteMode = "clipboard"
+	// PasteModeType types each character directly, for apps that intercept Cmd+V (terminals, vim)
+	PasteModeType PasteMode = "type"
+	// PasteModeCopy only leaves the text on the clipboard for the user to paste manually
+	PasteModeCopy PasteMode = "copy"
+)
+
+// ParsePasteMode converts a config value ("clipboard", "type", "copy", or empty for clipboard) to a PasteMode
+func ParsePasteMode(name string) (PasteMode, error) {
+	switch name {
+	case "", string(PasteModeClipboard):
+		return PasteModeClipboard, nil
+	case string(PasteModeType):
+		return PasteModeType, nil
+	case string(PasteModeCopy):
+		return PasteModeCopy, nil
+	default:
+		return PasteModeClipboard, fmt.Errorf("invalid paste mode: %s (must be clipboard, type or copy)", name)
+	}
+}
+
+// Insert inserts text at the cursor using the given paste mode
+func Insert(kb Keyboard, mode PasteMode, text string) error {
+	switch mode {
+	case PasteModeType:
+		return kb.TypeText(text)
+	case PasteModeCopy:
+		return kb.CopyText(text)
+	default:
+		return kb.PasteText(text)
+	}
+}
+
+// NeedsPermissions reports whether the paste mode simulates keyboard input
+// and therefore requires accessibility permissions
+func (m PasteMode) NeedsPermissions() bool {
+	return m != PasteModeCopy
+}
+
+// FallbackPasteMode returns the paste mode to use with kb. Modes that simulate input
+// fall back to PasteModeCopy when accessibility permissions aren't granted, since
+// setting the clipboard needs none. The bool reports whether it fell back.
+func FallbackPasteMode(kb Keyboard, mode PasteMode) (PasteMode, bool) {
+	if !mode.NeedsPermissions() || kb.CheckPermissions() == nil {
+		return mode, false
+	}
+	return PasteModeCopy, true
+}
+
+// permissionPollInterval is how often WaitForPermissions checks for permissions
+var permissionPollInterval = time.Second
+
+// WaitForPermissions calls check (typically Keyboard.CheckPermissions) every second
+// until it succeeds, returning nil once permissions are granted. Bound the wait
+// with a deadline on ctx; when ctx ends first, the error wraps ctx.Err().
+func WaitForPermissions(ctx context.Context, check func() error) error {
+	ticker := time.NewTicker(permissionPollInterval)
+	defer ticker.Stop()
+
+	for {
+		err := check()
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %v", ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// macOS virtual key codes for control characters that can't be typed as Unicode
+const (
+	keyCodeReturn = 36
+	keyCodeTab    = 48
+)
+
+// keystroke is one step of TypeText: either a key press (KeyCode != 0) or Unicode text
+type keystroke struct {
+	KeyCode uint16
+	Text    string
+}
+
+// keystrokesFor splits text into keystrokes, mapping newlines to Return and tabs to Tab.
+// Carriage returns are dropped so "\r\n" produces a single Return.
+func keystrokesFor(text string) []keystroke {
+	var strokes []keystroke
+	for _, r := range text {
+		switch r {
+		case '\n':
+			strokes = append(strokes, keystroke{KeyCode: keyCodeReturn})
+		case '\t':
+			strokes = append(strokes, keystroke{KeyCode: keyCodeTab})
+		case '\r':
+			continue
+		default:
+			strokes = append(strokes, keystroke{Text: string(r)})
+		}
+	}
+	return strokes
+}
+
+// StripNewlines collapses a multi-line transcription into a single line,
+// replacing each line break (and surrounding whitespace) with one space
+func StripNewlines(text string) string {
+	lines := strings.FieldsFunc(text, func(r rune) bool { return r == '\n' || r == '\r' })
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	kept := lines[:0]
+	for _, line := range lines {
+		if line != "" {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// New creates a new Keyboard instance for the current platform with default paste delays
 func New() (Keyboard, error) {
-	return newKeyboard()
+	return NewWithOptions(Options{})
+}
+
+// NewWithOptions creates a new Keyboard instance for the current platform
+func NewWithOptions(opts Options) (Keyboard, error) {
+	return newKeyboard(opts.withDefaults())
 }
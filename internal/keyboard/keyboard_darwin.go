@@ -26,6 +26,8 @@ static void requestAccessibilityPermissions() {
 }
 
 // Get current clipboard contents
+// Returns NULL when the clipboard holds no string (empty or non-text content);
+// a clipboard containing an empty string returns "". Caller must free.
 static char* getClipboardContents() {
     @autoreleasepool {
         NSPasteboard *pasteboard = [NSPasteboard generalPasteboard];
@@ -37,7 +39,7 @@ static char* getClipboardContents() {
 
         // Convert to C string - caller must free
         const char *cStr = [contents UTF8String];
-        return strdup(cStr);
+        return strdup(cStr != NULL ? cStr : "");
     }
 }
 
@@ -75,18 +77,66 @@ static void simulateCommandV() {
     if (keyUpV) CFRelease(keyUpV);
     if (keyUpCmd) CFRelease(keyUpCmd);
 }
+
+// Type a single character (one or two UTF-16 code units) as a key down/up pair
+static void typeUnicodeChar(const UniChar *chars, int length) {
+    CGEventRef keyDown = CGEventCreateKeyboardEvent(NULL, 0, true);
+    CGEventRef keyUp = CGEventCreateKeyboardEvent(NULL, 0, false);
+
+    CGEventKeyboardSetUnicodeString(keyDown, length, chars);
+    CGEventKeyboardSetUnicodeString(keyUp, length, chars);
+
+    CGEventPost(kCGHIDEventTap, keyDown);
+    CGEventPost(kCGHIDEventTap, keyUp);
+
+    if (keyDown) CFRelease(keyDown);
+    if (keyUp) CFRelease(keyUp);
+}
+
+// Get the localized name of the frontmost application
+// Returns NULL when there is none or it has no name. Caller must free.
+static char* frontmostAppName() {
+    @autoreleasepool {
+        NSRunningApplication *app = [[NSWorkspace sharedWorkspace] frontmostApplication];
+        NSString *name = app.localizedName;
+        if (name == nil) {
+            return NULL;
+        }
+
+        const char *cStr = [name UTF8String];
+        return cStr != NULL ? strdup(cStr) : NULL;
+    }
+}
+
+// Press and release a single key by virtual key code (e.g. Return, Tab)
+static void pressKeyCode(CGKeyCode keyCode) {
+    CGEventRef keyDown = CGEventCreateKeyboardEvent(NULL, keyCode, true);
+    CGEventRef keyUp = CGEventCreateKeyboardEvent(NULL, keyCode, false);
+
+    CGEventPost(kCGHIDEventTap, keyDown);
+    CGEventPost(kCGHIDEventTap, keyUp);
+
+    if (keyDown) CFRelease(keyDown);
+    if (keyUp) CFRelease(keyUp);
+}
 */
 import "C"
 import (
 	"fmt"
 	"time"
+	"unicode/utf16"
 	"unsafe"
 )
 
-type macKeyboard struct{}
+// typeCharDelay is the pause between typed characters, for reliability across applications
+const typeCharDelay = 2 * time.Millisecond
+
+type macKeyboard struct {
+	opts Options
+}
 
-func newKeyboard() (Keyboard, error) {
-	return &macKeyboard{}, nil
+func newKeyboard(opts Options) (Keyboard, error) {
+	return &macKeyboard{opts: opts}, nil
 }
 
 // CheckPermissions verifies that accessibility permissions are granted
@@ -110,10 +160,12 @@ func (k *macKeyboard) PasteText(text string) error {
 		return fmt.Errorf("cannot paste text: %w", err)
 	}
 
-	// Save current clipboard contents
+	// Save current clipboard contents. A clipboard without text has nothing
+	// to restore; an empty string is still restored as-is.
 	originalClipboard := C.getClipboardContents()
+	hasSavedClipboard := originalClipboard != nil
 	var savedClipboard string
-	if originalClipboard != nil {
+	if hasSavedClipboard {
 		savedClipboard = C.GoString(originalClipboard)
 		C.free(unsafe.Pointer(originalClipboard))
 	}
@@ -124,16 +176,16 @@ func (k *macKeyboard) PasteText(text string) error {
 	C.free(unsafe.Pointer(cText))
 
 	// Small delay to ensure clipboard is set
-	time.Sleep(10 * time.Millisecond)
+	time.Sleep(k.opts.PasteSettleDelay)
 
 	// Simulate Command+V
 	C.simulateCommandV()
 
 	// Small delay to ensure paste completes
-	time.Sleep(50 * time.Millisecond)
+	time.Sleep(k.opts.ClipboardRestoreDelay)
 
 	// Restore original clipboard contents
-	if savedClipboard != "" {
+	if hasSavedClipboard {
 		cSaved := C.CString(savedClipboard)
 		C.setClipboardContents(cSaved)
 		C.free(unsafe.Pointer(cSaved))
@@ -142,6 +194,55 @@ func (k *macKeyboard) PasteText(text string) error {
 	return nil
 }
 
+// CopyText places the given text on the clipboard and leaves it there, without
+// simulating Cmd+V. It needs no accessibility permissions.
+func (k *macKeyboard) CopyText(text string) error {
+	return CopyToClipboard(text)
+}
+
+// CopyToClipboard sets the clipboard contents. Unlike pasting it doesn't post
+// keyboard events, so it works without accessibility permissions.
+func CopyToClipboard(text string) error {
+	cText := C.CString(text)
+	C.setClipboardContents(cText)
+	C.free(unsafe.Pointer(cText))
+	return nil
+}
+
+// FrontmostAppName returns the name of the application in front, e.g. "Notes",
+// or "" when it can't be determined
+func FrontmostAppName() string {
+	cName := C.frontmostAppName()
+	if cName == nil {
+		return ""
+	}
+	defer C.free(unsafe.Pointer(cName))
+	return C.GoString(cName)
+}
+
+// TypeText types the given text at the current cursor position using CGEventKeyboardSetUnicodeString.
+// Unlike PasteText it leaves the clipboard untouched and works in apps that intercept Cmd+V.
+// Newlines and tabs are sent as Return and Tab key presses.
+func (k *macKeyboard) TypeText(text string) error {
+	// Check permissions first
+	if err := k.CheckPermissions(); err != nil {
+		return fmt.Errorf("cannot type text: %w", err)
+	}
+
+	for _, stroke := range keystrokesFor(text) {
+		if stroke.KeyCode != 0 {
+			C.pressKeyCode(C.CGKeyCode(stroke.KeyCode))
+		} else {
+			// Characters outside the BMP (e.g. emoji) need a surrogate pair in one event
+			units := utf16.Encode([]rune(stroke.Text))
+			C.typeUnicodeChar((*C.UniChar)(unsafe.Pointer(&units[0])), C.int(len(units)))
+		}
+		time.Sleep(typeCharDelay)
+	}
+
+	return nil
+}
+
 // Close cleans up any resources (nothing needed for CGEvent/NSPasteboard)
 func (k *macKeyboard) Close() error {
 	return nil
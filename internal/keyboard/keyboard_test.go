@@ -1,8 +1,13 @@
 package keyboard
 
 import (
+	"context"
+	"errors"
 	"runtime"
 	"testing"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/config"
 )
 
 func TestNew(t *testing.T) {
@@ -110,6 +115,237 @@ func TestPasteText_Unicode(t *testing.T) {
 	}
 }
 
+// recordingKeyboard records which insertion method was used
+type recordingKeyboard struct {
+	pasted  []string
+	copied  []string
+	typed   []string
+	permErr error // Returned by CheckPermissions, PasteText and TypeText, like macOS without Accessibility
+}
+
+func (k *recordingKeyboard) PasteText(text string) error {
+	if k.permErr != nil {
+		return k.permErr
+	}
+	k.pasted = append(k.pasted, text)
+	return nil
+}
+
+func (k *recordingKeyboard) CopyText(text string) error {
+	k.copied = append(k.copied, text)
+	return nil
+}
+
+func (k *recordingKeyboard) TypeText(text string) error {
+	if k.permErr != nil {
+		return k.permErr
+	}
+	k.typed = append(k.typed, text)
+	return nil
+}
+
+func (k *recordingKeyboard) CheckPermissions() error { return k.permErr }
+func (k *recordingKeyboard) Close() error            { return nil }
+
+func TestParsePasteMode(t *testing.T) {
+	tests := []struct {
+		input     string
+		want      PasteMode
+		expectErr bool
+	}{
+		{"", PasteModeClipboard, false},
+		{"clipboard", PasteModeClipboard, false},
+		{"type", PasteModeType, false},
+		{"copy", PasteModeCopy, false},
+		{"keystrokes", PasteModeClipboard, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePasteMode(tt.input)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ParsePasteMode(%q) error = %v, expectErr %v", tt.input, err, tt.expectErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePasteMode(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsert_ModeSelection(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       PasteMode
+		wantPasted int
+		wantCopied int
+		wantTyped  int
+	}{
+		{"clipboard mode pastes", PasteModeClipboard, 1, 0, 0},
+		{"type mode types", PasteModeType, 0, 0, 1},
+		{"copy mode only copies", PasteModeCopy, 0, 1, 0},
+		{"zero value pastes", PasteMode(""), 1, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kb := &recordingKeyboard{}
+			if err := Insert(kb, tt.mode, "hello"); err != nil {
+				t.Fatalf("Insert() error: %v", err)
+			}
+			if len(kb.pasted) != tt.wantPasted || len(kb.copied) != tt.wantCopied || len(kb.typed) != tt.wantTyped {
+				t.Errorf("Insert(%q) pasted %d, copied %d, typed %d; want %d, %d, %d", tt.mode,
+					len(kb.pasted), len(kb.copied), len(kb.typed), tt.wantPasted, tt.wantCopied, tt.wantTyped)
+			}
+		})
+	}
+}
+
+func TestPasteMode_NeedsPermissions(t *testing.T) {
+	if !PasteModeClipboard.NeedsPermissions() || !PasteModeType.NeedsPermissions() {
+		t.Error("clipboard and type modes should need accessibility permissions")
+	}
+	if PasteModeCopy.NeedsPermissions() {
+		t.Error("copy mode should not need accessibility permissions")
+	}
+}
+
+func TestFallbackPasteMode(t *testing.T) {
+	denied := errors.New("accessibility permissions not granted")
+
+	tests := []struct {
+		name         string
+		mode         PasteMode
+		permErr      error
+		want         PasteMode
+		wantFallback bool
+	}{
+		{"clipboard with permissions", PasteModeClipboard, nil, PasteModeClipboard, false},
+		{"type with permissions", PasteModeType, nil, PasteModeType, false},
+		{"clipboard without permissions", PasteModeClipboard, denied, PasteModeCopy, true},
+		{"type without permissions", PasteModeType, denied, PasteModeCopy, true},
+		{"copy without permissions", PasteModeCopy, denied, PasteModeCopy, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kb := &recordingKeyboard{permErr: tt.permErr}
+			got, fellBack := FallbackPasteMode(kb, tt.mode)
+			if got != tt.want || fellBack != tt.wantFallback {
+				t.Fatalf("FallbackPasteMode(%q) = %q, %t; want %q, %t", tt.mode, got, fellBack, tt.want, tt.wantFallback)
+			}
+
+			// The resulting mode must work whatever the permission state
+			if err := Insert(kb, got, "hello"); err != nil {
+				t.Errorf("Insert(%q) error = %v", got, err)
+			}
+		})
+	}
+}
+
+func TestCopyToClipboard(t *testing.T) {
+	err := CopyToClipboard("hello")
+	if runtime.GOOS == "darwin" {
+		// Copying never checks accessibility permissions
+		if err != nil {
+			t.Errorf("CopyToClipboard() error = %v", err)
+		}
+	} else if err == nil {
+		t.Error("CopyToClipboard() should fail on non-darwin platforms")
+	}
+}
+
+func TestInsert_ClipboardPreservesNewlinesAndTabs(t *testing.T) {
+	kb := &recordingKeyboard{}
+	text := "first line\nsecond\tcolumn"
+
+	if err := Insert(kb, PasteModeClipboard, text); err != nil {
+		t.Fatalf("Insert() error: %v", err)
+	}
+	if len(kb.pasted) != 1 || kb.pasted[0] != text {
+		t.Errorf("pasted = %q, want [%q]", kb.pasted, text)
+	}
+}
+
+func TestKeystrokesFor(t *testing.T) {
+	got := keystrokesFor("a\tb\r\né")
+	want := []keystroke{
+		{Text: "a"},
+		{KeyCode: keyCodeTab},
+		{Text: "b"},
+		{KeyCode: keyCodeReturn},
+		{Text: "é"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("keystrokesFor() returned %d keystrokes, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("keystroke[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestStripNewlines(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"single line unchanged", "hello world", "hello world"},
+		{"newline becomes space", "hello\nworld", "hello world"},
+		{"windows line endings", "hello\r\nworld", "hello world"},
+		{"blank lines dropped", "hello\n\n  \nworld\n", "hello world"},
+		{"surrounding whitespace trimmed", "  hello  \n  world  ", "hello world"},
+		{"tabs kept", "a\tb\nc", "a\tb c"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripNewlines(tt.input); got != tt.want {
+				t.Errorf("StripNewlines(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptionsFromConfig(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.PasteSettleMs = 25
+	cfg.ClipboardRestoreMs = 300
+
+	opts := OptionsFromConfig(cfg)
+	if opts.PasteSettleDelay != 25*time.Millisecond {
+		t.Errorf("PasteSettleDelay = %v, want 25ms", opts.PasteSettleDelay)
+	}
+	if opts.ClipboardRestoreDelay != 300*time.Millisecond {
+		t.Errorf("ClipboardRestoreDelay = %v, want 300ms", opts.ClipboardRestoreDelay)
+	}
+}
+
+func TestOptions_WithDefaults(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        Options
+		wantSettle  time.Duration
+		wantRestore time.Duration
+	}{
+		{"unset uses defaults", Options{}, DefaultPasteSettleDelay, DefaultClipboardRestoreDelay},
+		{"custom kept", Options{PasteSettleDelay: 5 * time.Millisecond, ClipboardRestoreDelay: time.Second}, 5 * time.Millisecond, time.Second},
+		{"negative uses defaults", Options{PasteSettleDelay: -1, ClipboardRestoreDelay: -1}, DefaultPasteSettleDelay, DefaultClipboardRestoreDelay},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.opts.withDefaults()
+			if got.PasteSettleDelay != tt.wantSettle || got.ClipboardRestoreDelay != tt.wantRestore {
+				t.Errorf("withDefaults() = %v/%v, want %v/%v", got.PasteSettleDelay, got.ClipboardRestoreDelay, tt.wantSettle, tt.wantRestore)
+			}
+		})
+	}
+}
+
 func TestClose_Multiple(t *testing.T) {
 	if runtime.GOOS != "darwin" {
 		t.Skip("Skipping macOS-specific test on non-darwin platform")
@@ -195,3 +431,53 @@ func BenchmarkCheckPermissions(b *testing.B) {
 		_ = kb.CheckPermissions()
 	}
 }
+
+// withPollInterval shortens the permission poll interval for a test
+func withPollInterval(t *testing.T, d time.Duration) {
+	t.Helper()
+	old := permissionPollInterval
+	permissionPollInterval = d
+	t.Cleanup(func() { permissionPollInterval = old })
+}
+
+func TestWaitForPermissions_GrantedWhileWaiting(t *testing.T) {
+	withPollInterval(t, time.Millisecond)
+
+	calls := 0
+	check := func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("accessibility permissions not granted")
+		}
+		return nil
+	}
+
+	if err := WaitForPermissions(context.Background(), check); err != nil {
+		t.Fatalf("WaitForPermissions() error = %v, want nil once granted", err)
+	}
+	if calls != 3 {
+		t.Errorf("check called %d times, want 3", calls)
+	}
+}
+
+func TestWaitForPermissions_AlreadyGranted(t *testing.T) {
+	// No waiting for the first tick when permissions are already there
+	withPollInterval(t, time.Hour)
+
+	if err := WaitForPermissions(context.Background(), func() error { return nil }); err != nil {
+		t.Errorf("WaitForPermissions() error = %v, want nil", err)
+	}
+}
+
+func TestWaitForPermissions_Timeout(t *testing.T) {
+	withPollInterval(t, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	denied := errors.New("accessibility permissions not granted")
+	err := WaitForPermissions(ctx, func() error { return denied })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForPermissions() error = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -7,7 +7,7 @@ import "fmt"
 
 type unsupportedKeyboard struct{}
 
-func newKeyboard() (Keyboard, error) {
+func newKeyboard(_ Options) (Keyboard, error) {
 	return nil, fmt.Errorf("keyboard simulation is only supported on macOS")
 }
 
@@ -16,6 +16,16 @@ func (k *unsupportedKeyboard) CheckPermissions() error {
 	return fmt.Errorf("keyboard simulation is only supported on macOS")
 }
 
+// PasteText always returns an error on unsupported platforms
+func (k *unsupportedKeyboard) PasteText(text string) error {
+	return fmt.Errorf("keyboard simulation is only supported on macOS")
+}
+
+// CopyText always returns an error on unsupported platforms
+func (k *unsupportedKeyboard) CopyText(text string) error {
+	return fmt.Errorf("keyboard simulation is only supported on macOS")
+}
+
 // TypeText always returns an error on unsupported platforms
 func (k *unsupportedKeyboard) TypeText(text string) error {
 	return fmt.Errorf("keyboard simulation is only supported on macOS")
@@ -26,7 +36,17 @@ func (k *unsupportedKeyboard) Close() error {
 	return nil
 }
 
+// CopyToClipboard always returns an error on unsupported platforms
+func CopyToClipboard(text string) error {
+	return fmt.Errorf("keyboard simulation is only supported on macOS")
+}
+
 // RequestPermissions does nothing on unsupported platforms
 func RequestPermissions() {
 	// No-op
 }
+
+// FrontmostAppName returns "" on unsupported platforms
+func FrontmostAppName() string {
+	return ""
+}
@@ -5,13 +5,17 @@
 //   - JSON-based log entries with timestamps
 //   - Reading and displaying transcription history
 //   - Log file management and clearing
+//   - Size-based rotation (transcriptions.log.1, .2, ...) with a retention count
+//   - Optional encryption at rest (AES-GCM, key derived from a passphrase with scrypt; see SetPassphrase)
 //
 // Each transcription log entry includes:
+//   - Schema version (EntryVersion; entries without one are legacy, version 0)
 //   - Timestamp (ISO 8601 format)
-//   - Transcribed text
+//   - Transcribed text and its word count
 //   - Audio duration
 //   - Model used
 //   - Language detected/specified
+//   - Application the text was dictated into, when known
 //
 // Log file location:
 //
@@ -0,0 +1,166 @@
+package logging
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+var (
+	// ErrPassphraseRequired is returned when reading an encrypted entry without a passphrase
+	ErrPassphraseRequired = errors.New("the transcription log is encrypted: a passphrase is required")
+
+	// ErrWrongPassphrase is returned when an encrypted entry can't be decrypted with the passphrase
+	ErrWrongPassphrase = errors.New("wrong passphrase for the encrypted transcription log")
+)
+
+// scrypt parameters recommended for interactive logins, and the AES-256 key size
+const (
+	scryptN  = 1 << 15
+	scryptR  = 8
+	scryptP  = 1
+	keySize  = 32
+	saltSize = 16
+)
+
+// encryptedLine is how an encrypted entry is stored. Each line is still a JSON object,
+// so plaintext and encrypted entries can share a log (e.g. after turning encryption on).
+type encryptedLine struct {
+	Salt string `json:"salt"`      // scrypt salt the key was derived with (base64)
+	Data string `json:"encrypted"` // AES-GCM nonce followed by the sealed entry (base64)
+}
+
+// cryptoMu guards the encryption state below; it is separate from logMu because
+// entries are decoded both with and without logMu held
+var cryptoMu sync.Mutex
+
+var (
+	passphrase []byte                 // nil = write plaintext, can't read encrypted entries
+	writeSalt  []byte                 // salt of the key new entries are encrypted with
+	keys       map[string]cipher.AEAD // keys derived from passphrase, by salt
+)
+
+// SetPassphrase encrypts new entries with a key derived from passphrase and lets
+// readers decrypt entries written with it. An empty passphrase turns encryption off.
+// Deriving the key is deliberately slow (scrypt), so call it once per process.
+func SetPassphrase(p string) error {
+	cryptoMu.Lock()
+	defer cryptoMu.Unlock()
+
+	passphrase, writeSalt, keys = nil, nil, nil
+	if p == "" {
+		return nil
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+	passphrase = []byte(p)
+	keys = make(map[string]cipher.AEAD)
+	if _, err := keyForSalt(salt); err != nil {
+		passphrase, keys = nil, nil
+		return err
+	}
+	writeSalt = salt
+	return nil
+}
+
+// keyForSalt returns the cipher for the passphrase and salt, deriving it on first use; cryptoMu must be held
+func keyForSalt(salt []byte) (cipher.AEAD, error) {
+	if aead, ok := keys[string(salt)]; ok {
+		return aead, nil
+	}
+	key, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	keys[string(salt)] = aead
+	return aead, nil
+}
+
+// encodeLine returns the log line for the JSON-encoded entry, encrypted if a passphrase is set
+func encodeLine(jsonData []byte) ([]byte, error) {
+	cryptoMu.Lock()
+	defer cryptoMu.Unlock()
+
+	if passphrase == nil {
+		return jsonData, nil
+	}
+	aead, err := keyForSalt(writeSalt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	line, err := json.Marshal(encryptedLine{
+		Salt: base64.StdEncoding.EncodeToString(writeSalt),
+		Data: base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, jsonData, nil)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal encrypted entry: %w", err)
+	}
+	return line, nil
+}
+
+// decodeLine parses one log line, decrypting it if it is encrypted. ok is false for
+// malformed lines, which readers skip; err is set for encrypted entries that can't be
+// decrypted (ErrPassphraseRequired or ErrWrongPassphrase), which readers report.
+func decodeLine(line []byte) (entry TranscriptionEntry, ok bool, err error) {
+	var enc encryptedLine
+	if json.Unmarshal(line, &enc) != nil {
+		return TranscriptionEntry{}, false, nil
+	}
+	if enc.Data == "" {
+		// Plaintext entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return TranscriptionEntry{}, false, nil
+		}
+		return entry, true, nil
+	}
+
+	salt, saltErr := base64.StdEncoding.DecodeString(enc.Salt)
+	sealed, dataErr := base64.StdEncoding.DecodeString(enc.Data)
+	if saltErr != nil || dataErr != nil {
+		return TranscriptionEntry{}, false, nil
+	}
+
+	cryptoMu.Lock()
+	defer cryptoMu.Unlock()
+	if passphrase == nil {
+		return TranscriptionEntry{}, false, ErrPassphraseRequired
+	}
+	aead, err := keyForSalt(salt)
+	if err != nil {
+		return TranscriptionEntry{}, false, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return TranscriptionEntry{}, false, nil
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return TranscriptionEntry{}, false, ErrWrongPassphrase
+	}
+	if err := json.Unmarshal(plain, &entry); err != nil {
+		return TranscriptionEntry{}, false, nil
+	}
+	return entry, true, nil
+}
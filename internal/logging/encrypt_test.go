@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+// withPassphrase encrypts the log with passphrase for the duration of a test
+func withPassphrase(t *testing.T, passphrase string) {
+	t.Helper()
+	if err := SetPassphrase(passphrase); err != nil {
+		t.Fatalf("SetPassphrase() error: %v", err)
+	}
+	t.Cleanup(func() { _ = SetPassphrase("") })
+}
+
+func TestEncryptedLog_RoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// An entry written before encryption was turned on stays readable
+	if err := LogTranscription(1, "small", "en", "plain entry"); err != nil {
+		t.Fatalf("LogTranscription() error: %v", err)
+	}
+	withPassphrase(t, "correct horse battery staple")
+	for _, text := range []string{"first secret", "second secret"} {
+		if err := LogTranscription(2.5, "small", "en", text); err != nil {
+			t.Fatalf("LogTranscription() error: %v", err)
+		}
+	}
+
+	logPath, err := config.GetTranscriptionLogPath()
+	if err != nil {
+		t.Fatalf("GetTranscriptionLogPath() error: %v", err)
+	}
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("log file contains plaintext: %s", data)
+	}
+
+	entries, err := GetTranscriptions(0)
+	if err != nil {
+		t.Fatalf("GetTranscriptions() error: %v", err)
+	}
+	var texts []string
+	for _, entry := range entries {
+		texts = append(texts, entry.Text)
+	}
+	if got := strings.Join(texts, ", "); got != "plain entry, first secret, second secret" {
+		t.Errorf("decrypted entries = %q", got)
+	}
+	if entries[2].Duration != 2.5 || entries[2].Model != "small" {
+		t.Errorf("decrypted entry = %+v", entries[2])
+	}
+
+	// A new process derives its own key from the same passphrase
+	withPassphrase(t, "correct horse battery staple")
+	last, err := RemoveLast()
+	if err != nil {
+		t.Fatalf("RemoveLast() error: %v", err)
+	}
+	if last.Text != "second secret" {
+		t.Errorf("RemoveLast() = %q, want %q", last.Text, "second secret")
+	}
+}
+
+func TestEncryptedLog_WrongPassphrase(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	withPassphrase(t, "right")
+	if err := LogTranscription(1, "small", "en", "secret"); err != nil {
+		t.Fatalf("LogTranscription() error: %v", err)
+	}
+
+	withPassphrase(t, "wrong")
+	if _, err := GetTranscriptions(0); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("GetTranscriptions() with a wrong passphrase error = %v, want ErrWrongPassphrase", err)
+	}
+	if _, err := RemoveLast(); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("RemoveLast() with a wrong passphrase error = %v, want ErrWrongPassphrase", err)
+	}
+
+	withPassphrase(t, "")
+	if _, err := GetTranscriptions(0); !errors.Is(err, ErrPassphraseRequired) {
+		t.Errorf("GetTranscriptions() without a passphrase error = %v, want ErrPassphraseRequired", err)
+	}
+}
@@ -0,0 +1,103 @@
+package logging
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Export formats supported by ExportTranscriptions
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+)
+
+// csvHeader is the header row written by CSV exports
+var csvHeader = []string{"timestamp", "duration_seconds", "model", "language", "text"}
+
+// ExportTranscriptions writes the full transcription history (including rotated logs)
+// to w as CSV with a header row, or as a JSON array
+func ExportTranscriptions(w io.Writer, format string) error {
+	switch format {
+	case ExportFormatCSV:
+		return exportCSV(w)
+	case ExportFormatJSON:
+		return exportJSON(w)
+	default:
+		return fmt.Errorf("unsupported export format: %s (must be %s or %s)", format, ExportFormatCSV, ExportFormatJSON)
+	}
+}
+
+// exportCSV streams entries as CSV rows; the csv package quotes commas, quotes and newlines
+func exportCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	var writeErr error
+	err := scanTranscriptions(true, func(entry TranscriptionEntry) {
+		if writeErr != nil {
+			return
+		}
+		writeErr = cw.Write([]string{
+			entry.Timestamp.Format(time.RFC3339Nano),
+			strconv.FormatFloat(entry.Duration, 'f', -1, 64),
+			entry.Model,
+			entry.Language,
+			entry.Text,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if writeErr != nil {
+		return fmt.Errorf("failed to write CSV row: %w", writeErr)
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// exportJSON streams entries as an indented JSON array
+func exportJSON(w io.Writer) error {
+	count := 0
+	var writeErr error
+	err := scanTranscriptions(true, func(entry TranscriptionEntry) {
+		if writeErr != nil {
+			return
+		}
+		data, err := json.MarshalIndent(entry, "  ", "  ")
+		if err != nil {
+			writeErr = err
+			return
+		}
+		sep := ",\n  "
+		if count == 0 {
+			sep = "[\n  "
+		}
+		count++
+		_, writeErr = fmt.Fprintf(w, "%s%s", sep, data)
+	})
+	if err != nil {
+		return err
+	}
+	if writeErr != nil {
+		return fmt.Errorf("failed to write JSON: %w", writeErr)
+	}
+
+	closing := "\n]\n"
+	if count == 0 {
+		closing = "[]\n"
+	}
+	if _, err := io.WriteString(w, closing); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportTranscriptions_CSVRoundTrip(t *testing.T) {
+	writeLogLines(t,
+		`{"timestamp":"2024-01-01T09:00:00Z","duration_seconds":2.5,"model":"small","language":"en","text":"Hello, \"world\""}`,
+		`{"timestamp":"2024-01-02T10:30:00.5Z","duration_seconds":4,"model":"base","language":"fr","text":"line one\nline two, with comma"}`,
+	)
+
+	var buf bytes.Buffer
+	if err := ExportTranscriptions(&buf, "csv"); err != nil {
+		t.Fatalf("ExportTranscriptions() error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("exported CSV does not parse: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d CSV records, want header + 2", len(records))
+	}
+
+	if strings.Join(records[0], ",") != "timestamp,duration_seconds,model,language,text" {
+		t.Errorf("header = %v", records[0])
+	}
+
+	want := [][]string{
+		{"2024-01-01T09:00:00Z", "2.5", "small", "en", `Hello, "world"`},
+		{"2024-01-02T10:30:00.5Z", "4", "base", "fr", "line one\nline two, with comma"},
+	}
+	for i, row := range want {
+		if strings.Join(records[i+1], "|") != strings.Join(row, "|") {
+			t.Errorf("record %d = %q, want %q", i+1, records[i+1], row)
+		}
+	}
+}
+
+func TestExportTranscriptions_JSON(t *testing.T) {
+	writeLogLines(t,
+		`{"timestamp":"2024-01-01T09:00:00Z","duration_seconds":2.5,"model":"small","language":"en","text":"first"}`,
+		`malformed`,
+		`{"timestamp":"2024-01-02T09:00:00Z","duration_seconds":1,"model":"small","language":"en","text":"second"}`,
+	)
+
+	var buf bytes.Buffer
+	if err := ExportTranscriptions(&buf, "json"); err != nil {
+		t.Fatalf("ExportTranscriptions() error: %v", err)
+	}
+
+	var entries []TranscriptionEntry
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("exported JSON is not an array of entries: %v\n%s", err, buf.String())
+	}
+	if len(entries) != 2 || entries[0].Text != "first" || entries[1].Text != "second" {
+		t.Fatalf("entries = %+v, want first and second", entries)
+	}
+	if !entries[0].Timestamp.Equal(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("entries[0].Timestamp = %v", entries[0].Timestamp)
+	}
+}
+
+func TestExportTranscriptions_Empty(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var buf bytes.Buffer
+	if err := ExportTranscriptions(&buf, "json"); err != nil {
+		t.Fatalf("ExportTranscriptions(json) error: %v", err)
+	}
+	if buf.String() != "[]\n" {
+		t.Errorf("empty JSON export = %q, want \"[]\\n\"", buf.String())
+	}
+
+	buf.Reset()
+	if err := ExportTranscriptions(&buf, "csv"); err != nil {
+		t.Fatalf("ExportTranscriptions(csv) error: %v", err)
+	}
+	if buf.String() != "timestamp,duration_seconds,model,language,text\n" {
+		t.Errorf("empty CSV export = %q, want header only", buf.String())
+	}
+}
+
+func TestExportTranscriptions_InvalidFormat(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var buf bytes.Buffer
+	if err := ExportTranscriptions(&buf, "xml"); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output for an unsupported format, got %q", buf.String())
+	}
+}
@@ -0,0 +1,144 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+// FollowTranscriptions calls fn with the last tail entries of the log (all if tail <= 0),
+// then checks the file each time ticks fires and calls fn for every newly appended entry.
+// Rotation and clearing are detected and the new log is followed from its start.
+// Returns nil when ctx is done.
+func FollowTranscriptions(ctx context.Context, tail int, ticks <-chan time.Time, fn func(TranscriptionEntry)) error {
+	logPath, err := config.GetTranscriptionLogPath()
+	if err != nil {
+		return fmt.Errorf("failed to get log path: %w", err)
+	}
+	return followLog(ctx, logPath, tail, ticks, fn)
+}
+
+// followLog implements FollowTranscriptions for the log at path
+func followLog(ctx context.Context, path string, tail int, ticks <-chan time.Time, fn func(TranscriptionEntry)) error {
+	f := &follower{path: path}
+	defer f.close()
+
+	// Existing entries: only the last tail are shown
+	var existing []TranscriptionEntry
+	if err := f.poll(func(entry TranscriptionEntry) { existing = append(existing, entry) }); err != nil {
+		return err
+	}
+	if tail > 0 && len(existing) > tail {
+		existing = existing[len(existing)-tail:]
+	}
+	for _, entry := range existing {
+		fn(entry)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticks:
+			if err := f.poll(fn); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// follower tracks the read position in the followed log file
+type follower struct {
+	path    string
+	file    *os.File
+	info    os.FileInfo // Identity of the open file, to detect rotation
+	offset  int64       // Bytes read from the open file
+	partial []byte      // Trailing line not yet terminated by a newline
+}
+
+// poll reads any entries appended since the last poll
+func (f *follower) poll(fn func(TranscriptionEntry)) error {
+	pathInfo, err := os.Stat(f.path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	if f.file != nil {
+		switch {
+		case pathInfo == nil || !os.SameFile(pathInfo, f.info):
+			// Rotated or cleared: finish the old file, then switch to the new one (if any)
+			if err := f.readAvailable(fn); err != nil {
+				return err
+			}
+			f.close()
+		case pathInfo.Size() < f.offset:
+			// Truncated in place: start over
+			if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+				return fmt.Errorf("failed to rewind log file: %w", err)
+			}
+			f.offset = 0
+			f.partial = nil
+		}
+	}
+
+	if f.file == nil {
+		if pathInfo == nil {
+			return nil // No log yet
+		}
+		file, err := os.Open(f.path)
+		if os.IsNotExist(err) {
+			return nil // Removed between stat and open, pick it up next poll
+		}
+		if err != nil {
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+		info, err := file.Stat()
+		if err != nil {
+			_ = file.Close()
+			return fmt.Errorf("failed to stat log file: %w", err)
+		}
+		f.file, f.info, f.offset = file, info, 0
+	}
+
+	return f.readAvailable(fn)
+}
+
+// readAvailable reads to the current end of the open file and emits each complete line
+func (f *follower) readAvailable(fn func(TranscriptionEntry)) error {
+	data, err := io.ReadAll(f.file)
+	if err != nil {
+		return fmt.Errorf("error reading log file: %w", err)
+	}
+	f.offset += int64(len(data))
+
+	buf := append(f.partial, data...)
+	for {
+		i := bytes.IndexByte(buf, '\n')
+		if i < 0 {
+			break
+		}
+		entry, ok, err := decodeLine(buf[:i])
+		if err != nil {
+			return err
+		}
+		if ok {
+			fn(entry)
+		}
+		buf = buf[i+1:]
+	}
+	f.partial = append([]byte(nil), buf...)
+	return nil
+}
+
+// close releases the open file, if any
+func (f *follower) close() {
+	if f.file != nil {
+		_ = f.file.Close() // Read-only, error not critical
+	}
+	f.file, f.info, f.offset, f.partial = nil, nil, 0, nil
+}
@@ -0,0 +1,126 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// logLine returns a JSONL log line with the given text
+func logLine(text string) string {
+	return fmt.Sprintf(`{"timestamp":"2024-01-01T09:00:00Z","duration_seconds":1,"model":"small","language":"en","text":%q}`+"\n", text)
+}
+
+// appendToFile appends data to path, creating it if needed
+func appendToFile(t *testing.T, path, data string) {
+	t.Helper()
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer func() { _ = file.Close() }()
+	if _, err := file.WriteString(data); err != nil {
+		t.Fatalf("failed to append to %s: %v", path, err)
+	}
+}
+
+func TestFollowLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transcriptions.log")
+	appendToFile(t, path, logLine("old 1")+logLine("old 2")+logLine("old 3"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ticks := make(chan time.Time)
+	entries := make(chan string, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- followLog(ctx, path, 2, ticks, func(e TranscriptionEntry) { entries <- e.Text })
+	}()
+
+	expect := func(want ...string) {
+		t.Helper()
+		for _, w := range want {
+			select {
+			case got := <-entries:
+				if got != w {
+					t.Fatalf("followed entry = %q, want %q", got, w)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatalf("timed out waiting for entry %q", w)
+			}
+		}
+	}
+	tick := func() { ticks <- time.Now() }
+
+	// Only the last tail existing entries are shown
+	expect("old 2", "old 3")
+
+	// A new line appended, written in two parts, is emitted once complete
+	line := logLine("new")
+	appendToFile(t, path, line[:10])
+	tick()
+	appendToFile(t, path, line[10:])
+	tick()
+	expect("new")
+
+	// Rotation: the rest of the old file is drained, then the new file is followed from its start
+	appendToFile(t, path, logLine("before rotation"))
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+	appendToFile(t, path, logLine("after rotation"))
+	tick()
+	expect("before rotation", "after rotation")
+
+	// Clearing: nothing to read until the log is recreated
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("failed to clear: %v", err)
+	}
+	tick()
+	appendToFile(t, path, logLine("after clear"))
+	tick()
+	expect("after clear")
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("followLog() error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("followLog() did not return after cancel")
+	}
+
+	select {
+	case extra := <-entries:
+		t.Errorf("unexpected extra entry %q", extra)
+	default:
+	}
+}
+
+func TestFollowLog_NoFileYet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transcriptions.log")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ticks := make(chan time.Time)
+	entries := make(chan string, 10)
+	go func() {
+		_ = followLog(ctx, path, 10, ticks, func(e TranscriptionEntry) { entries <- e.Text })
+	}()
+
+	ticks <- time.Now()
+	appendToFile(t, path, logLine("first"))
+	ticks <- time.Now()
+
+	select {
+	case got := <-entries:
+		if got != "first" {
+			t.Errorf("followed entry = %q, want \"first\"", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the first entry")
+	}
+}
@@ -2,106 +2,351 @@
 package logging
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/alexandrelam/openscribe/internal/config"
 )
 
+// EntryVersion is the schema version written to new log entries.
+// Bump it whenever the fields of TranscriptionEntry change.
+//
+//	1: version field added
+//	2: app field added
+//	3: word_count field added
+//	4: audio_path field added
+const EntryVersion = 4
+
 // TranscriptionEntry represents a single transcription log entry
 type TranscriptionEntry struct {
+	// Version is the schema version of the entry; 0 means a legacy entry written before versioning
+	Version   int       `json:"version"`
 	Timestamp time.Time `json:"timestamp"`
 	Duration  float64   `json:"duration_seconds"`
 	Model     string    `json:"model"`
 	Language  string    `json:"language"`
 	Text      string    `json:"text"`
+	App       string    `json:"app,omitempty"`        // Application the text was dictated into, when known
+	WordCount int       `json:"word_count"`           // Words in Text, see CountWords; use Words() to read it
+	AudioPath string    `json:"audio_path,omitempty"` // Recording kept with keep_recordings, if any
 }
 
-// LogTranscription writes a transcription entry to the log file
-func LogTranscription(duration float64, model, language, text string) error {
-	// Ensure log directory exists
-	if err := config.EnsureDirectories(); err != nil {
-		return fmt.Errorf("failed to ensure directories: %w", err)
+// Words returns the entry's word count, computing it for entries logged before word_count existed
+func (e TranscriptionEntry) Words() int {
+	if e.Version < 3 {
+		return CountWords(e.Text)
 	}
+	return e.WordCount
+}
 
-	// Get log file path
-	logPath, err := config.GetTranscriptionLogPath()
+// CountWords counts the whitespace-separated words in text, treating any Unicode
+// space as a separator. Scripts written without spaces between words (Chinese,
+// Japanese, Thai, ...) are undercounted: a whole sentence counts as one word.
+func CountWords(text string) int {
+	return len(strings.Fields(text))
+}
+
+// logMu serializes log writes, rotation, reads and Logger state across goroutines,
+// so every JSONL line is written whole even when several Loggers share the file
+var logMu sync.Mutex
+
+// enabled is whether LogTranscription writes anything (the logging_enabled setting)
+var enabled = true
+
+// SetEnabled turns LogTranscription and LogTranscriptionEntry on or off; while off they
+// write nothing and return nil
+func SetEnabled(on bool) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	enabled = on
+}
+
+// Enabled reports whether LogTranscription writes transcriptions
+func Enabled() bool {
+	logMu.Lock()
+	defer logMu.Unlock()
+	return enabled
+}
+
+// Entry is a transcription to record with a Logger
+type Entry struct {
+	Timestamp time.Time // Defaults to the time Log is called
+	Text      string
+	Duration  time.Duration // Length of the recorded audio
+	Model     string
+	Language  string
+	App       string // Application the text was dictated into, if known
+	AudioPath string // Recording the text was transcribed from, if kept
+}
+
+// toTranscriptionEntry converts an Entry to its on-disk form
+func (e Entry) toTranscriptionEntry() TranscriptionEntry {
+	return TranscriptionEntry{
+		Version:   EntryVersion,
+		Timestamp: e.Timestamp,
+		Duration:  e.Duration.Seconds(),
+		Model:     e.Model,
+		Language:  e.Language,
+		Text:      e.Text,
+		App:       e.App,
+		WordCount: CountWords(e.Text),
+		AudioPath: e.AudioPath,
+	}
+}
+
+// entryFromTranscription converts an on-disk entry to an Entry
+func entryFromTranscription(t TranscriptionEntry) Entry {
+	return Entry{
+		Timestamp: t.Timestamp,
+		Text:      t.Text,
+		Duration:  time.Duration(t.Duration * float64(time.Second)),
+		Model:     t.Model,
+		Language:  t.Language,
+		App:       t.App,
+		AudioPath: t.AudioPath,
+	}
+}
+
+// Logger appends transcriptions to the log file, keeping it open between writes.
+// The file is opened by the first Log call; call Close when done. Safe for concurrent use.
+type Logger struct {
+	rotation RotationOptions
+	path     string
+	file     *os.File
+}
+
+// NewLogger creates a Logger for the transcription log using the current rotation policy
+func NewLogger() *Logger {
+	logMu.Lock()
+	defer logMu.Unlock()
+	return &Logger{rotation: rotation}
+}
+
+// Log appends an entry to the log file
+func (l *Logger) Log(entry Entry) error {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	return l.write(entry.toTranscriptionEntry())
+}
+
+// ReadRecent returns the last n entries of the current log file (all entries if n <= 0)
+func (l *Logger) ReadRecent(n int) ([]Entry, error) {
+	logMu.Lock()
+	defer logMu.Unlock()
+
+	if err := l.resolvePath(); err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	err := scanLogFile(l.path, func(entry TranscriptionEntry) {
+		entries = append(entries, entryFromTranscription(entry))
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get log path: %w", err)
+		return nil, err
 	}
 
-	// Create entry
-	entry := TranscriptionEntry{
-		Timestamp: time.Now(),
-		Duration:  duration,
-		Model:     model,
-		Language:  language,
-		Text:      text,
+	if n > 0 && len(entries) > n {
+		return entries[len(entries)-n:], nil
 	}
+	return entries, nil
+}
 
-	// Open file in append mode (create if doesn't exist)
-	file, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+// Clear removes the log file and all rotated logs
+func (l *Logger) Clear() error {
+	logMu.Lock()
+	defer logMu.Unlock()
+
+	if err := l.resolvePath(); err != nil {
+		return err
+	}
+	if err := l.closeFile(); err != nil {
+		return err
+	}
+
+	rotated, err := rotatedLogPaths(l.path)
 	if err != nil {
-		return fmt.Errorf("failed to open log file: %w", err)
+		return err
 	}
-	defer func() {
-		if closeErr := file.Close(); closeErr != nil && err == nil {
-			err = fmt.Errorf("failed to close log file: %w", closeErr)
+	for _, path := range rotated {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove rotated log file: %w", err)
 		}
-	}()
+	}
+
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove log file: %w", err)
+	}
+	return nil
+}
+
+// Close closes the log file if it is open. The Logger reopens it on the next Log call.
+func (l *Logger) Close() error {
+	logMu.Lock()
+	defer logMu.Unlock()
+	return l.closeFile()
+}
+
+// closeFile closes the log file if it is open; logMu must be held
+func (l *Logger) closeFile() error {
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	if err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	return nil
+}
 
-	// Marshal to JSON
+// write appends one JSON line in a single write call, rotating first if it would push the log past its size limit
+func (l *Logger) write(entry TranscriptionEntry) error {
 	jsonData, err := json.Marshal(entry)
 	if err != nil {
 		return fmt.Errorf("failed to marshal entry: %w", err)
 	}
+	line, err := encodeLine(jsonData)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
 
-	// Write JSON line
-	if _, err := file.Write(append(jsonData, '\n')); err != nil {
+	logMu.Lock()
+	defer logMu.Unlock()
+
+	if err := l.resolvePath(); err != nil {
+		return err
+	}
+	if err := rotateIfNeeded(l.path, int64(len(line)), l.rotation); err != nil {
+		return err
+	}
+	if err := l.open(); err != nil {
+		return err
+	}
+
+	if _, err := l.file.Write(line); err != nil {
 		return fmt.Errorf("failed to write to log file: %w", err)
 	}
+	return nil
+}
+
+// open makes sure l.file is the file currently at l.path, reopening it if the log was
+// rotated, cleared or replaced (possibly by another process) since it was opened; logMu must be held
+func (l *Logger) open() error {
+	if l.file != nil {
+		pathInfo, pathErr := os.Stat(l.path)
+		fileInfo, fileErr := l.file.Stat()
+		if pathErr == nil && fileErr == nil && os.SameFile(pathInfo, fileInfo) {
+			return nil
+		}
+		_ = l.file.Close() // Stale handle, nothing more will be written to it
+		l.file = nil
+	}
+
+	// Ensure log directory exists
+	if err := config.EnsureDirectories(); err != nil {
+		return fmt.Errorf("failed to ensure directories: %w", err)
+	}
 
+	// Open file in append mode (create if doesn't exist)
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	l.file = file
 	return nil
 }
 
-// GetTranscriptions reads transcription entries from the log file
-func GetTranscriptions(tail int) ([]TranscriptionEntry, error) {
+// resolvePath looks up the log file path on first use
+func (l *Logger) resolvePath() error {
+	if l.path != "" {
+		return nil
+	}
 	logPath, err := config.GetTranscriptionLogPath()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get log path: %w", err)
+		return fmt.Errorf("failed to get log path: %w", err)
+	}
+	l.path = logPath
+	return nil
+}
+
+// LogTranscription writes a transcription entry to the log file
+func LogTranscription(duration float64, model, language, text string) error {
+	return LogTranscriptionEntry(TranscriptionEntry{
+		Duration: duration,
+		Model:    model,
+		Language: language,
+		Text:     text,
+	})
+}
+
+// LogTranscriptionEntry writes entry to the log file, stamping it with the
+// current schema version and, if unset, the current time. Does nothing while
+// logging is disabled (see SetEnabled).
+func LogTranscriptionEntry(entry TranscriptionEntry) error {
+	if !Enabled() {
+		return nil
+	}
+	entry.Version = EntryVersion
+	entry.WordCount = CountWords(entry.Text)
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
 	}
 
-	// Check if log file exists
-	if _, statErr := os.Stat(logPath); os.IsNotExist(statErr) {
-		return []TranscriptionEntry{}, nil
+	logger := NewLogger()
+	err := logger.write(entry)
+	if closeErr := logger.Close(); closeErr != nil && err == nil {
+		err = closeErr
 	}
+	return err
+}
 
-	// Open log file
-	file, err := os.Open(logPath)
+// GetTranscriptions reads transcription entries from the current log file
+func GetTranscriptions(tail int) ([]TranscriptionEntry, error) {
+	return readTranscriptions(tail, false)
+}
+
+// GetTranscriptionHistory reads transcription entries from the current log file
+// and all rotated ones, oldest first
+func GetTranscriptionHistory(tail int) ([]TranscriptionEntry, error) {
+	return readTranscriptions(tail, true)
+}
+
+// GetTranscription returns the entry at index in the current log file,
+// counting back from the most recent one (1 = latest transcription)
+func GetTranscription(index int) (TranscriptionEntry, error) {
+	entries, err := GetTranscriptions(0)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open log file: %w", err)
-	}
-	defer func() {
-		_ = file.Close() // Read-only operation, error not critical
-	}()
-
-	// Read all lines
-	var entries []TranscriptionEntry
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		var entry TranscriptionEntry
-		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
-			// Skip malformed lines
-			continue
-		}
-		entries = append(entries, entry)
+		return TranscriptionEntry{}, err
+	}
+	return selectTranscription(entries, index)
+}
+
+// selectTranscription picks entry index (1 = last) from entries, validating it against the count
+func selectTranscription(entries []TranscriptionEntry, index int) (TranscriptionEntry, error) {
+	if len(entries) == 0 {
+		return TranscriptionEntry{}, fmt.Errorf("no transcriptions logged yet")
+	}
+	if index < 1 || index > len(entries) {
+		return TranscriptionEntry{}, fmt.Errorf("invalid index %d: must be between 1 (most recent) and %d", index, len(entries))
 	}
+	return entries[len(entries)-index], nil
+}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading log file: %w", err)
+// readTranscriptions reads log entries, returning only the last tail entries if tail > 0
+func readTranscriptions(tail int, includeRotated bool) ([]TranscriptionEntry, error) {
+	entries := []TranscriptionEntry{}
+	err := scanTranscriptions(includeRotated, func(entry TranscriptionEntry) {
+		entries = append(entries, entry)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Return last N entries if tail is specified
@@ -112,28 +357,12 @@ func GetTranscriptions(tail int) ([]TranscriptionEntry, error) {
 	return entries, nil
 }
 
-// ClearTranscriptions removes all transcription log entries
+// ClearTranscriptions removes all transcription log entries, including rotated logs
 func ClearTranscriptions() error {
-	logPath, err := config.GetTranscriptionLogPath()
-	if err != nil {
-		return fmt.Errorf("failed to get log path: %w", err)
-	}
-
-	// Check if file exists
-	if _, err := os.Stat(logPath); os.IsNotExist(err) {
-		// File doesn't exist, nothing to clear
-		return nil
-	}
-
-	// Remove the file
-	if err := os.Remove(logPath); err != nil {
-		return fmt.Errorf("failed to remove log file: %w", err)
-	}
-
-	return nil
+	return NewLogger().Clear()
 }
 
-// CountTranscriptions returns the total number of transcription entries
+// CountTranscriptions returns the total number of transcription entries in the current log file
 func CountTranscriptions() (int, error) {
 	entries, err := GetTranscriptions(0)
 	if err != nil {
@@ -141,3 +370,12 @@ func CountTranscriptions() (int, error) {
 	}
 	return len(entries), nil
 }
+
+// CountTranscriptionHistory returns the number of entries across the current and rotated log files
+func CountTranscriptionHistory() (int, error) {
+	entries, err := GetTranscriptionHistory(0)
+	if err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
@@ -1,7 +1,11 @@
 package logging
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -101,6 +105,36 @@ func TestGetTranscriptionsNoFile(t *testing.T) {
 	}
 }
 
+func TestLogTranscription_Disabled(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { SetEnabled(true) })
+
+	SetEnabled(false)
+	if err := LogTranscription(1, "small", "en", "secret"); err != nil {
+		t.Fatalf("LogTranscription() while disabled error: %v", err)
+	}
+	logPath, err := config.GetTranscriptionLogPath()
+	if err != nil {
+		t.Fatalf("GetTranscriptionLogPath() error: %v", err)
+	}
+	if _, err := os.Stat(logPath); !os.IsNotExist(err) {
+		t.Fatalf("log file exists while logging is disabled (stat error: %v)", err)
+	}
+
+	// Logging resumes once re-enabled
+	SetEnabled(true)
+	if err := LogTranscription(1, "small", "en", "hello"); err != nil {
+		t.Fatalf("LogTranscription() error: %v", err)
+	}
+	entries, err := GetTranscriptions(0)
+	if err != nil {
+		t.Fatalf("GetTranscriptions() error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Text != "hello" {
+		t.Errorf("entries = %+v, want only the entry logged after re-enabling", entries)
+	}
+}
+
 func TestClearTranscriptions(t *testing.T) {
 	// Log a transcription
 	err := LogTranscription(1.0, "whisper-small", "en", "Test")
@@ -165,3 +199,363 @@ func TestCountTranscriptions(t *testing.T) {
 		t.Errorf("Expected count >= 3, got %d", count)
 	}
 }
+
+func TestLogger_DocExample(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	logger := NewLogger()
+	defer func() {
+		if err := logger.Close(); err != nil {
+			t.Errorf("Close() error: %v", err)
+		}
+	}()
+
+	entry := Entry{
+		Timestamp: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		Text:      "Hello, world!",
+		Duration:  5 * time.Second,
+		Model:     "small",
+		Language:  "en",
+	}
+	if err := logger.Log(entry); err != nil {
+		t.Fatalf("Log() error: %v", err)
+	}
+	if err := logger.Log(Entry{Text: "Second", Duration: 1500 * time.Millisecond, Model: "small"}); err != nil {
+		t.Fatalf("Log() error: %v", err)
+	}
+
+	entries, err := logger.ReadRecent(10)
+	if err != nil {
+		t.Fatalf("ReadRecent() error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("ReadRecent() returned %d entries, want 2", len(entries))
+	}
+	if entries[0] != entry {
+		t.Errorf("entries[0] = %+v, want %+v", entries[0], entry)
+	}
+	if entries[1].Duration != 1500*time.Millisecond || entries[1].Timestamp.IsZero() {
+		t.Errorf("entries[1] = %+v, want 1.5s duration and a default timestamp", entries[1])
+	}
+
+	// The free functions see what the Logger wrote
+	legacy, err := GetTranscriptions(0)
+	if err != nil {
+		t.Fatalf("GetTranscriptions() error: %v", err)
+	}
+	if len(legacy) != 2 || legacy[0].Duration != 5 {
+		t.Errorf("GetTranscriptions() = %+v, want 2 entries with the first lasting 5s", legacy)
+	}
+
+	recent, err := logger.ReadRecent(1)
+	if err != nil || len(recent) != 1 || recent[0].Text != "Second" {
+		t.Errorf("ReadRecent(1) = %+v, %v, want only the newest entry", recent, err)
+	}
+}
+
+func TestLogger_ReopensAfterClear(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	logger := NewLogger()
+	defer func() { _ = logger.Close() }()
+
+	if err := logger.Log(Entry{Text: "before"}); err != nil {
+		t.Fatalf("Log() error: %v", err)
+	}
+
+	// Another process clearing the log must not leave the logger writing to a deleted file
+	if err := ClearTranscriptions(); err != nil {
+		t.Fatalf("ClearTranscriptions() error: %v", err)
+	}
+	if err := logger.Log(Entry{Text: "after"}); err != nil {
+		t.Fatalf("Log() error: %v", err)
+	}
+
+	entries, err := GetTranscriptions(0)
+	if err != nil {
+		t.Fatalf("GetTranscriptions() error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Text != "after" {
+		t.Errorf("GetTranscriptions() = %+v, want only the entry written after clearing", entries)
+	}
+
+	if err := logger.Clear(); err != nil {
+		t.Fatalf("Clear() error: %v", err)
+	}
+	remaining, err := logger.ReadRecent(0)
+	if err != nil || len(remaining) != 0 {
+		t.Errorf("ReadRecent() after Clear() = %+v, %v, want no entries", remaining, err)
+	}
+}
+
+func TestLogTranscription_Concurrent(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	const goroutines = 20
+	const perGoroutine = 10
+
+	shared := NewLogger()
+	defer func() { _ = shared.Close() }()
+
+	// Half the goroutines use the free function, half share one Logger
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines*perGoroutine)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			text := strings.Repeat(fmt.Sprintf("goroutine %d ", g), 50)
+			for i := 0; i < perGoroutine; i++ {
+				if g%2 == 0 {
+					errs <- LogTranscription(1, "small", "en", text)
+				} else {
+					errs <- shared.Log(Entry{Text: text, Duration: time.Second, Model: "small", Language: "en"})
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent log error: %v", err)
+		}
+	}
+
+	logPath, err := config.GetTranscriptionLogPath()
+	if err != nil {
+		t.Fatalf("GetTranscriptionLogPath() error: %v", err)
+	}
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("failed to read log: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != goroutines*perGoroutine {
+		t.Fatalf("log has %d lines, want %d", len(lines), goroutines*perGoroutine)
+	}
+	for i, line := range lines {
+		var entry TranscriptionEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v", i+1, err)
+		}
+	}
+}
+
+func TestGetTranscription(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := GetTranscription(1); err == nil || !strings.Contains(err.Error(), "no transcriptions") {
+		t.Errorf("GetTranscription() on empty log error = %v, want no transcriptions error", err)
+	}
+
+	for _, text := range []string{"first", "second", "third"} {
+		if err := LogTranscription(1.0, "small", "en", text); err != nil {
+			t.Fatalf("LogTranscription failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		index   int
+		want    string
+		wantErr bool
+	}{
+		{1, "third", false},
+		{2, "second", false},
+		{3, "first", false},
+		{0, "", true},
+		{-1, "", true},
+		{4, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.index), func(t *testing.T) {
+			entry, err := GetTranscription(tt.index)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetTranscription(%d) error = %v, wantErr %v", tt.index, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), "between 1 (most recent) and 3") {
+					t.Errorf("GetTranscription(%d) error = %q, want the valid range", tt.index, err)
+				}
+				return
+			}
+			if entry.Text != tt.want {
+				t.Errorf("GetTranscription(%d).Text = %q, want %q", tt.index, entry.Text, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTranscriptions_EntryVersion(t *testing.T) {
+	// A legacy entry written before the version field existed
+	writeLogLines(t, `{"timestamp":"2024-01-01T09:00:00Z","duration_seconds":2.5,"model":"small","language":"en","text":"legacy"}`)
+
+	if err := LogTranscription(1.0, "small", "en", "current"); err != nil {
+		t.Fatalf("LogTranscription failed: %v", err)
+	}
+	logger := NewLogger()
+	if err := logger.Log(Entry{Text: "from logger", Model: "small"}); err != nil {
+		t.Fatalf("Log() error: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+
+	entries, err := GetTranscriptions(0)
+	if err != nil {
+		t.Fatalf("GetTranscriptions failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+
+	want := []struct {
+		text    string
+		version int
+	}{
+		{"legacy", 0},
+		{"current", EntryVersion},
+		{"from logger", EntryVersion},
+	}
+	for i, w := range want {
+		if entries[i].Text != w.text || entries[i].Version != w.version {
+			t.Errorf("entries[%d] = %q version %d, want %q version %d", i, entries[i].Text, entries[i].Version, w.text, w.version)
+		}
+	}
+}
+
+func TestLogTranscriptionEntry_RecordsApp(t *testing.T) {
+	writeLogLines(t, `{"version":1,"timestamp":"2024-01-01T09:00:00Z","duration_seconds":2.5,"model":"small","language":"en","text":"before apps"}`)
+
+	if err := LogTranscriptionEntry(TranscriptionEntry{Duration: 1.5, Model: "small", Language: "en", Text: "dictated", App: "Notes"}); err != nil {
+		t.Fatalf("LogTranscriptionEntry failed: %v", err)
+	}
+	if err := LogTranscription(1.0, "small", "en", "no app"); err != nil {
+		t.Fatalf("LogTranscription failed: %v", err)
+	}
+
+	entries, err := GetTranscriptions(0)
+	if err != nil {
+		t.Fatalf("GetTranscriptions failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+
+	if entries[0].App != "" || entries[0].Version != 1 {
+		t.Errorf("old entry = app %q version %d, want no app and version 1", entries[0].App, entries[0].Version)
+	}
+	if entries[1].App != "Notes" || entries[1].Version != EntryVersion || entries[1].Timestamp.IsZero() {
+		t.Errorf("new entry = %+v, want app Notes, version %d and a timestamp", entries[1], EntryVersion)
+	}
+	if entries[2].App != "" {
+		t.Errorf("entry without app has App = %q, want empty", entries[2].App)
+	}
+
+	// An unknown app is left out of the JSON line entirely
+	logPath, err := config.GetTranscriptionLogPath()
+	if err != nil {
+		t.Fatalf("GetTranscriptionLogPath() error: %v", err)
+	}
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if !strings.Contains(lines[1], `"app":"Notes"`) || strings.Contains(lines[2], `"app"`) {
+		t.Errorf("log lines = %q, want the app only on the second entry", lines[1:])
+	}
+}
+
+func TestLogTranscriptionEntry_RecordsAudioPath(t *testing.T) {
+	writeLogLines(t)
+
+	if err := LogTranscriptionEntry(TranscriptionEntry{Duration: 2, Model: "small", Text: "kept", AudioPath: "/tmp/recording_1.wav"}); err != nil {
+		t.Fatalf("LogTranscriptionEntry failed: %v", err)
+	}
+	if err := LogTranscriptionEntry(TranscriptionEntry{Duration: 1, Model: "small", Text: "not kept"}); err != nil {
+		t.Fatalf("LogTranscriptionEntry failed: %v", err)
+	}
+
+	entries, err := GetTranscriptions(0)
+	if err != nil {
+		t.Fatalf("GetTranscriptions failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if entries[0].AudioPath != "/tmp/recording_1.wav" || entries[1].AudioPath != "" {
+		t.Errorf("audio paths = %q, %q, want the path on the first entry only", entries[0].AudioPath, entries[1].AudioPath)
+	}
+
+	// Entries without a recording leave the field out of the JSON line
+	logPath, _ := config.GetTranscriptionLogPath()
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if !strings.Contains(lines[0], `"audio_path":"/tmp/recording_1.wav"`) || strings.Contains(lines[1], `"audio_path"`) {
+		t.Errorf("log lines = %q, want audio_path only on the first entry", lines)
+	}
+}
+
+func TestCountWords(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{"empty", "", 0},
+		{"only spaces", "   \t\n ", 0},
+		{"single word", "hello", 1},
+		{"multiple spaces", "  hello    big   world  ", 3},
+		{"newlines and tabs", "one\ntwo\tthree", 3},
+		{"punctuation attached", "Hello, world! How's it going?", 5},
+		{"standalone punctuation counts", "wait - what", 3},
+		{"unicode spaces", "un\u00a0deux\u3000trois", 3},
+		{"accented words", "déjà vu café", 3},
+		// Whitespace splitting undercounts scripts without spaces between words
+		{"CJK sentence", "我喜欢学习中文", 1},
+		{"CJK with spaces", "你好 世界", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountWords(tt.text); got != tt.want {
+				t.Errorf("CountWords(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogTranscription_WordCount(t *testing.T) {
+	// Entries from before word_count was added have it computed on read
+	writeLogLines(t,
+		`{"timestamp":"2024-01-01T09:00:00Z","duration_seconds":1,"model":"small","language":"en","text":"three legacy words"}`,
+		`{"version":2,"timestamp":"2024-01-01T10:00:00Z","duration_seconds":1,"model":"small","language":"en","text":"two words"}`,
+	)
+	if err := LogTranscription(1.0, "small", "en", "  four   words  right here "); err != nil {
+		t.Fatalf("LogTranscription failed: %v", err)
+	}
+
+	entries, err := GetTranscriptions(0)
+	if err != nil {
+		t.Fatalf("GetTranscriptions failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+
+	if entries[0].WordCount != 0 || entries[0].Words() != 3 {
+		t.Errorf("legacy entry WordCount = %d, Words() = %d, want 0 and 3", entries[0].WordCount, entries[0].Words())
+	}
+	if entries[1].Words() != 2 {
+		t.Errorf("version 2 entry Words() = %d, want 2", entries[1].Words())
+	}
+	if entries[2].WordCount != 4 || entries[2].Words() != 4 {
+		t.Errorf("new entry WordCount = %d, Words() = %d, want 4", entries[2].WordCount, entries[2].Words())
+	}
+}
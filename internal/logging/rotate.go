@@ -0,0 +1,134 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+// RotationOptions controls when the transcription log is rotated and how much history is kept
+type RotationOptions struct {
+	MaxSizeBytes int64 // Rotate once the log would grow past this size (0 = never rotate)
+	MaxFiles     int   // Rotated files kept as transcriptions.log.1 (newest) to .N (oldest)
+}
+
+// DefaultRotation matches the config defaults (10 MB, 5 files)
+var DefaultRotation = RotationOptions{
+	MaxSizeBytes: 10 * 1024 * 1024,
+	MaxFiles:     5,
+}
+
+// rotation is the policy applied by LogTranscription
+var rotation = DefaultRotation
+
+// SetRotation changes the rotation policy used by LogTranscription
+func SetRotation(opts RotationOptions) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	rotation = opts
+}
+
+// RotationFromConfig builds rotation options from the log_max_size_mb and log_max_files settings
+func RotationFromConfig(cfg *config.Config) RotationOptions {
+	return RotationOptions{
+		MaxSizeBytes: int64(cfg.LogMaxSizeMB) * 1024 * 1024,
+		MaxFiles:     cfg.LogMaxFiles,
+	}
+}
+
+// rotateIfNeeded rotates the log at logPath if writing incoming more bytes would exceed
+// the size limit. An empty log is never rotated, so a single oversized entry still gets written.
+func rotateIfNeeded(logPath string, incoming int64, opts RotationOptions) error {
+	if opts.MaxSizeBytes <= 0 {
+		return nil
+	}
+
+	info, err := os.Stat(logPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	if info.Size() == 0 || info.Size()+incoming <= opts.MaxSizeBytes {
+		return nil
+	}
+
+	return rotateLogFiles(logPath, opts.MaxFiles)
+}
+
+// rotateLogFiles shifts logPath.N to logPath.N+1 (deleting anything past maxFiles)
+// and moves logPath to logPath.1
+func rotateLogFiles(logPath string, maxFiles int) error {
+	if maxFiles <= 0 {
+		if err := os.Remove(logPath); err != nil {
+			return fmt.Errorf("failed to remove log file: %w", err)
+		}
+		return nil
+	}
+
+	// Drop rotated files beyond the retention count, including leftovers from a larger setting
+	rotated, err := rotatedLogPaths(logPath)
+	if err != nil {
+		return err
+	}
+	for _, path := range rotated {
+		if n, _ := rotatedIndex(logPath, path); n >= maxFiles {
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("failed to remove old log file: %w", err)
+			}
+		}
+	}
+
+	for n := maxFiles - 1; n >= 1; n-- {
+		from := fmt.Sprintf("%s.%d", logPath, n)
+		if _, err := os.Stat(from); os.IsNotExist(err) {
+			continue
+		}
+		if err := os.Rename(from, fmt.Sprintf("%s.%d", logPath, n+1)); err != nil {
+			return fmt.Errorf("failed to rotate log file: %w", err)
+		}
+	}
+
+	if err := os.Rename(logPath, logPath+".1"); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	return nil
+}
+
+// rotatedLogPaths returns the existing rotated files for logPath, oldest first
+func rotatedLogPaths(logPath string) ([]string, error) {
+	matches, err := filepath.Glob(logPath + ".*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rotated log files: %w", err)
+	}
+
+	var paths []string
+	for _, path := range matches {
+		if _, ok := rotatedIndex(logPath, path); ok {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Slice(paths, func(i, j int) bool {
+		a, _ := rotatedIndex(logPath, paths[i])
+		b, _ := rotatedIndex(logPath, paths[j])
+		return a > b
+	})
+	return paths, nil
+}
+
+// rotatedIndex extracts N from logPath.N, reporting false for any other file
+func rotatedIndex(logPath, path string) (int, bool) {
+	suffix := strings.TrimPrefix(path, logPath+".")
+	n, err := strconv.Atoi(suffix)
+	if err != nil || n < 1 || suffix != strconv.Itoa(n) {
+		return 0, false
+	}
+	return n, true
+}
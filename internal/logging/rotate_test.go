@@ -0,0 +1,163 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+// withRotation applies opts for the duration of a test
+func withRotation(t *testing.T, opts RotationOptions) {
+	t.Helper()
+	previous := rotation
+	SetRotation(opts)
+	t.Cleanup(func() { SetRotation(previous) })
+}
+
+func TestLogTranscription_RotatesWhenFull(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	// Each entry is ~120 bytes, so every second write triggers a rotation
+	withRotation(t, RotationOptions{MaxSizeBytes: 200, MaxFiles: 2})
+
+	for i := 1; i <= 7; i++ {
+		if err := LogTranscription(1, "small", "en", fmt.Sprintf("entry %d", i)); err != nil {
+			t.Fatalf("LogTranscription(%d) error: %v", i, err)
+		}
+	}
+
+	logPath, err := config.GetTranscriptionLogPath()
+	if err != nil {
+		t.Fatalf("GetTranscriptionLogPath() error: %v", err)
+	}
+	for _, path := range []string{logPath, logPath + ".1", logPath + ".2"} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s to exist: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(logPath + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected %s.3 to be deleted by retention", logPath)
+	}
+
+	// The current file only holds the newest entries
+	current, err := GetTranscriptions(0)
+	if err != nil {
+		t.Fatalf("GetTranscriptions() error: %v", err)
+	}
+	if len(current) == 0 || current[len(current)-1].Text != "entry 7" {
+		t.Fatalf("GetTranscriptions() = %v, want the newest entry last", current)
+	}
+
+	// History spans rotated files in chronological order, minus what retention dropped
+	history, err := GetTranscriptionHistory(0)
+	if err != nil {
+		t.Fatalf("GetTranscriptionHistory() error: %v", err)
+	}
+	if len(history) <= len(current) || len(history) >= 7 {
+		t.Fatalf("GetTranscriptionHistory() returned %d entries, want between %d and 7", len(history), len(current))
+	}
+	for i := 1; i < len(history); i++ {
+		var prev, cur int
+		_, _ = fmt.Sscanf(history[i-1].Text, "entry %d", &prev)
+		_, _ = fmt.Sscanf(history[i].Text, "entry %d", &cur)
+		if cur != prev+1 {
+			t.Errorf("history out of order: %q followed by %q", history[i-1].Text, history[i].Text)
+		}
+	}
+
+	count, err := CountTranscriptionHistory()
+	if err != nil || count != len(history) {
+		t.Errorf("CountTranscriptionHistory() = %d, %v, want %d", count, err, len(history))
+	}
+}
+
+func TestLogTranscription_NoRotationWhenDisabled(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	withRotation(t, RotationOptions{MaxSizeBytes: 0, MaxFiles: 2})
+
+	for i := 0; i < 5; i++ {
+		if err := LogTranscription(1, "small", "en", "no rotation"); err != nil {
+			t.Fatalf("LogTranscription() error: %v", err)
+		}
+	}
+
+	logPath, _ := config.GetTranscriptionLogPath()
+	if _, err := os.Stat(logPath + ".1"); !os.IsNotExist(err) {
+		t.Error("expected no rotated file when rotation is disabled")
+	}
+}
+
+func TestRotateLogFiles(t *testing.T) {
+	dir := t.TempDir()
+	logPath := dir + "/transcriptions.log"
+
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	write(logPath, "current")
+	write(logPath+".1", "one")
+	write(logPath+".2", "two")
+	write(logPath+".3", "three")     // Beyond retention, left over from a larger setting
+	write(logPath+".backup", "keep") // Not a rotated file
+
+	if err := rotateLogFiles(logPath, 3); err != nil {
+		t.Fatalf("rotateLogFiles() error: %v", err)
+	}
+
+	if _, err := os.Stat(logPath); !os.IsNotExist(err) {
+		t.Error("expected the current log to be moved")
+	}
+	for path, want := range map[string]string{
+		logPath + ".1":      "current",
+		logPath + ".2":      "one",
+		logPath + ".3":      "two",
+		logPath + ".backup": "keep",
+	} {
+		if got := read(path); got != want {
+			t.Errorf("%s = %q, want %q", path, got, want)
+		}
+	}
+	if _, err := os.Stat(logPath + ".4"); !os.IsNotExist(err) {
+		t.Error("expected no file beyond the retention count")
+	}
+
+	rotated, err := rotatedLogPaths(logPath)
+	if err != nil {
+		t.Fatalf("rotatedLogPaths() error: %v", err)
+	}
+	want := []string{logPath + ".3", logPath + ".2", logPath + ".1"}
+	if fmt.Sprint(rotated) != fmt.Sprint(want) {
+		t.Errorf("rotatedLogPaths() = %v, want %v", rotated, want)
+	}
+}
+
+func TestRotateLogFiles_ZeroRetention(t *testing.T) {
+	logPath := t.TempDir() + "/transcriptions.log"
+	if err := os.WriteFile(logPath, []byte("current"), 0644); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+
+	if err := rotateLogFiles(logPath, 0); err != nil {
+		t.Fatalf("rotateLogFiles() error: %v", err)
+	}
+
+	if _, err := os.Stat(logPath); !os.IsNotExist(err) {
+		t.Error("expected the log to be discarded")
+	}
+	if _, err := os.Stat(logPath + ".1"); !os.IsNotExist(err) {
+		t.Error("expected no rotated file with zero retention")
+	}
+}
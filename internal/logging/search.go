@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+// SearchOptions filters transcription log entries. Zero-valued fields match everything.
+type SearchOptions struct {
+	Query    string    // Case-insensitive substring of the transcription text
+	Since    time.Time // Only entries at or after this time
+	Until    time.Time // Only entries before this time
+	Language string    // Exact language code (case-insensitive), e.g. "en"
+}
+
+// Matches reports whether an entry satisfies all of the search options
+func (o SearchOptions) Matches(entry TranscriptionEntry) bool {
+	if !o.Since.IsZero() && entry.Timestamp.Before(o.Since) {
+		return false
+	}
+	if !o.Until.IsZero() && !entry.Timestamp.Before(o.Until) {
+		return false
+	}
+	if o.Language != "" && !strings.EqualFold(entry.Language, o.Language) {
+		return false
+	}
+	if o.Query != "" && !strings.Contains(strings.ToLower(entry.Text), strings.ToLower(o.Query)) {
+		return false
+	}
+	return true
+}
+
+// SearchTranscriptions returns the log entries matching opts, oldest first.
+// The log file is streamed line by line, so only matching entries are kept in memory.
+func SearchTranscriptions(opts SearchOptions) ([]TranscriptionEntry, error) {
+	var matches []TranscriptionEntry
+	err := scanTranscriptions(false, func(entry TranscriptionEntry) {
+		if opts.Matches(entry) {
+			matches = append(matches, entry)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// scanTranscriptions calls fn for each well-formed entry in the log file, oldest first.
+// With includeRotated, rotated log files are read before the current one.
+func scanTranscriptions(includeRotated bool, fn func(TranscriptionEntry)) error {
+	logPath, err := config.GetTranscriptionLogPath()
+	if err != nil {
+		return fmt.Errorf("failed to get log path: %w", err)
+	}
+
+	logMu.Lock()
+	defer logMu.Unlock()
+
+	paths := []string{logPath}
+	if includeRotated {
+		rotated, err := rotatedLogPaths(logPath)
+		if err != nil {
+			return err
+		}
+		paths = append(rotated, logPath)
+	}
+
+	for _, path := range paths {
+		if err := scanLogFile(path, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanLogFile calls fn for each well-formed entry in one log file, decrypting encrypted ones.
+// Malformed lines are skipped, and a missing file yields no entries.
+func scanLogFile(path string, fn func(TranscriptionEntry)) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer func() {
+		_ = file.Close() // Read-only operation, error not critical
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry, ok, err := decodeLine(scanner.Bytes())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// Skip malformed lines
+			continue
+		}
+		fn(entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading log file: %w", err)
+	}
+	return nil
+}
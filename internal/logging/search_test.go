@@ -0,0 +1,91 @@
+package logging
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+// writeLogLines writes raw lines to the transcription log in a temporary HOME
+func writeLogLines(t *testing.T, lines ...string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	if err := config.EnsureDirectories(); err != nil {
+		t.Fatalf("EnsureDirectories() error: %v", err)
+	}
+	logPath, err := config.GetTranscriptionLogPath()
+	if err != nil {
+		t.Fatalf("GetTranscriptionLogPath() error: %v", err)
+	}
+
+	var data []byte
+	for _, line := range lines {
+		data = append(data, line+"\n"...)
+	}
+	if err := os.WriteFile(logPath, data, 0644); err != nil {
+		t.Fatalf("failed to write log file: %v", err)
+	}
+}
+
+func TestSearchTranscriptions(t *testing.T) {
+	writeLogLines(t,
+		`{"timestamp":"2024-01-01T09:00:00Z","duration_seconds":1,"model":"small","language":"en","text":"Team meeting notes"}`,
+		`not json`,
+		`{"timestamp":"2024-02-15T10:00:00Z","duration_seconds":2,"model":"small","language":"fr","text":"Réunion MEETING demain"}`,
+		`{"timestamp":"2024-03-20T11:00:00Z","duration_seconds":3,"model":"small","language":"en","text":"Buy groceries"}`,
+	)
+
+	date := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			t.Fatalf("bad date %q: %v", s, err)
+		}
+		return d
+	}
+
+	tests := []struct {
+		name      string
+		opts      SearchOptions
+		wantTexts []string
+	}{
+		{"no filters", SearchOptions{}, []string{"Team meeting notes", "Réunion MEETING demain", "Buy groceries"}},
+		{"query is case-insensitive", SearchOptions{Query: "meeting"}, []string{"Team meeting notes", "Réunion MEETING demain"}},
+		{"language", SearchOptions{Language: "EN"}, []string{"Team meeting notes", "Buy groceries"}},
+		{"since", SearchOptions{Since: date("2024-02-01")}, []string{"Réunion MEETING demain", "Buy groceries"}},
+		{"until is exclusive", SearchOptions{Until: date("2024-03-20").Add(11 * time.Hour)}, []string{"Team meeting notes", "Réunion MEETING demain"}},
+		{"combined", SearchOptions{Query: "meeting", Language: "en", Since: date("2024-01-01")}, []string{"Team meeting notes"}},
+		{"no match", SearchOptions{Query: "nothing"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := SearchTranscriptions(tt.opts)
+			if err != nil {
+				t.Fatalf("SearchTranscriptions() error: %v", err)
+			}
+			if len(entries) != len(tt.wantTexts) {
+				t.Fatalf("SearchTranscriptions() returned %d entries, want %d", len(entries), len(tt.wantTexts))
+			}
+			for i, want := range tt.wantTexts {
+				if entries[i].Text != want {
+					t.Errorf("entries[%d].Text = %q, want %q", i, entries[i].Text, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchTranscriptions_NoFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	entries, err := SearchTranscriptions(SearchOptions{Query: "anything"})
+	if err != nil {
+		t.Fatalf("SearchTranscriptions() error: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("SearchTranscriptions() returned %d entries, want 0", len(entries))
+	}
+}
@@ -0,0 +1,72 @@
+package logging
+
+// Stats summarizes the transcription history
+type Stats struct {
+	TotalEntries    int
+	TotalDuration   float64        // Total recorded audio in seconds
+	TotalWords      int            // Words across all transcriptions (see CountWords)
+	ByModel         map[string]int // Entry count per model
+	ByLanguage      map[string]int // Entry count per language ("" when not recorded)
+	ByApp           map[string]int // Entry count per application ("" when not recorded)
+	BusiestDay      string         // Day with the most entries (YYYY-MM-DD), empty if there are none
+	BusiestDayCount int
+}
+
+// TranscriptionStats computes statistics over the current and rotated log files in a single pass.
+// A missing log yields zeroed stats.
+func TranscriptionStats() (Stats, error) {
+	stats := newStats()
+	perDay := make(map[string]int)
+
+	err := scanTranscriptions(true, func(entry TranscriptionEntry) {
+		stats.add(entry)
+		perDay[entry.Timestamp.Format("2006-01-02")]++
+	})
+	if err != nil {
+		return Stats{}, err
+	}
+
+	for day, count := range perDay {
+		// Ties go to the earliest day so the result is deterministic
+		if count > stats.BusiestDayCount || (count == stats.BusiestDayCount && day < stats.BusiestDay) {
+			stats.BusiestDay = day
+			stats.BusiestDayCount = count
+		}
+	}
+
+	return stats, nil
+}
+
+// newStats returns zeroed stats with initialized maps
+func newStats() Stats {
+	return Stats{
+		ByModel:    make(map[string]int),
+		ByLanguage: make(map[string]int),
+		ByApp:      make(map[string]int),
+	}
+}
+
+// add folds one entry into the totals
+func (s *Stats) add(entry TranscriptionEntry) {
+	s.TotalEntries++
+	s.TotalDuration += entry.Duration
+	s.TotalWords += entry.Words()
+	s.ByModel[entry.Model]++
+	s.ByLanguage[entry.Language]++
+	s.ByApp[entry.App]++
+}
+
+// GroupByApp groups entries by the application they were dictated into, keeping
+// their order within each group. Apps are returned in order of first appearance,
+// with "" for entries that don't record one.
+func GroupByApp(entries []TranscriptionEntry) ([]string, map[string][]TranscriptionEntry) {
+	var apps []string
+	groups := make(map[string][]TranscriptionEntry)
+	for _, entry := range entries {
+		if _, seen := groups[entry.App]; !seen {
+			apps = append(apps, entry.App)
+		}
+		groups[entry.App] = append(groups[entry.App], entry)
+	}
+	return apps, groups
+}
@@ -0,0 +1,133 @@
+package logging
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTranscriptionStats(t *testing.T) {
+	writeLogLines(t,
+		`{"timestamp":"2024-01-01T09:00:00Z","duration_seconds":2.5,"model":"small","language":"en","text":"Hello there world"}`,
+		`{broken`,
+		`{"timestamp":"2024-01-02T09:00:00Z","duration_seconds":4,"model":"small","language":"fr","text":"Bonjour"}`,
+		`{"timestamp":"2024-01-02T18:30:00Z","duration_seconds":1.5,"model":"base","language":"en","text":"  two   words "}`,
+		`{"timestamp":"2024-01-03T09:00:00Z","duration_seconds":2,"model":"base","language":"","text":""}`,
+	)
+
+	stats, err := TranscriptionStats()
+	if err != nil {
+		t.Fatalf("TranscriptionStats() error: %v", err)
+	}
+
+	if stats.TotalEntries != 4 {
+		t.Errorf("TotalEntries = %d, want 4", stats.TotalEntries)
+	}
+	if stats.TotalDuration != 10 {
+		t.Errorf("TotalDuration = %.2f, want 10", stats.TotalDuration)
+	}
+	if stats.TotalWords != 6 {
+		t.Errorf("TotalWords = %d, want 6", stats.TotalWords)
+	}
+	if stats.ByModel["small"] != 2 || stats.ByModel["base"] != 2 || len(stats.ByModel) != 2 {
+		t.Errorf("ByModel = %v, want small:2 base:2", stats.ByModel)
+	}
+	if stats.ByLanguage["en"] != 2 || stats.ByLanguage["fr"] != 1 || stats.ByLanguage[""] != 1 {
+		t.Errorf("ByLanguage = %v, want en:2 fr:1 (unknown):1", stats.ByLanguage)
+	}
+	if stats.BusiestDay != "2024-01-02" || stats.BusiestDayCount != 2 {
+		t.Errorf("busiest day = %s (%d), want 2024-01-02 (2)", stats.BusiestDay, stats.BusiestDayCount)
+	}
+}
+
+func TestTranscriptionStats_BusiestDayTie(t *testing.T) {
+	writeLogLines(t,
+		`{"timestamp":"2024-05-02T09:00:00Z","duration_seconds":1,"model":"small","language":"en","text":"later"}`,
+		`{"timestamp":"2024-05-01T09:00:00Z","duration_seconds":1,"model":"small","language":"en","text":"earlier"}`,
+	)
+
+	stats, err := TranscriptionStats()
+	if err != nil {
+		t.Fatalf("TranscriptionStats() error: %v", err)
+	}
+	if stats.BusiestDay != "2024-05-01" {
+		t.Errorf("BusiestDay = %s, want the earliest tied day 2024-05-01", stats.BusiestDay)
+	}
+}
+
+func TestTranscriptionStats_NoLog(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	stats, err := TranscriptionStats()
+	if err != nil {
+		t.Fatalf("TranscriptionStats() error: %v", err)
+	}
+	if stats.TotalEntries != 0 || stats.TotalWords != 0 || stats.TotalDuration != 0 || stats.BusiestDay != "" {
+		t.Errorf("TranscriptionStats() = %+v, want zeroed stats", stats)
+	}
+	if stats.ByModel == nil || stats.ByLanguage == nil {
+		t.Error("expected initialized maps for empty stats")
+	}
+}
+
+func TestTranscriptionStats_ByApp(t *testing.T) {
+	writeLogLines(t,
+		`{"timestamp":"2024-01-01T09:00:00Z","duration_seconds":1,"model":"small","language":"en","text":"legacy"}`,
+		`{"version":2,"timestamp":"2024-01-01T10:00:00Z","duration_seconds":1,"model":"small","language":"en","text":"one","app":"Slack"}`,
+		`{"version":2,"timestamp":"2024-01-01T11:00:00Z","duration_seconds":1,"model":"small","language":"en","text":"two","app":"Slack"}`,
+		`{"version":2,"timestamp":"2024-01-01T12:00:00Z","duration_seconds":1,"model":"small","language":"en","text":"three","app":"Notes"}`,
+	)
+
+	stats, err := TranscriptionStats()
+	if err != nil {
+		t.Fatalf("TranscriptionStats() error: %v", err)
+	}
+	want := map[string]int{"Slack": 2, "Notes": 1, "": 1}
+	if !reflect.DeepEqual(stats.ByApp, want) {
+		t.Errorf("ByApp = %v, want %v", stats.ByApp, want)
+	}
+}
+
+func TestGroupByApp(t *testing.T) {
+	entries := []TranscriptionEntry{
+		{Text: "a", App: "Slack"},
+		{Text: "b"},
+		{Text: "c", App: "Notes"},
+		{Text: "d", App: "Slack"},
+	}
+
+	apps, groups := GroupByApp(entries)
+	if !reflect.DeepEqual(apps, []string{"Slack", "", "Notes"}) {
+		t.Errorf("apps = %q, want [Slack  Notes] in order of first appearance", apps)
+	}
+
+	var slack []string
+	for _, e := range groups["Slack"] {
+		slack = append(slack, e.Text)
+	}
+	if !reflect.DeepEqual(slack, []string{"a", "d"}) {
+		t.Errorf("Slack group = %v, want [a d]", slack)
+	}
+	if len(groups[""]) != 1 || len(groups["Notes"]) != 1 {
+		t.Errorf("groups = %v", groups)
+	}
+
+	if apps, groups := GroupByApp(nil); apps != nil || len(groups) != 0 {
+		t.Errorf("GroupByApp(nil) = %v, %v, want nothing", apps, groups)
+	}
+}
+
+func TestTranscriptionStats_UsesStoredWordCount(t *testing.T) {
+	// A stored word_count is summed as-is rather than recomputed from the text
+	writeLogLines(t,
+		`{"version":3,"timestamp":"2024-01-01T09:00:00Z","duration_seconds":1,"model":"small","language":"en","text":"hello world","word_count":7}`,
+		`{"timestamp":"2024-01-01T10:00:00Z","duration_seconds":1,"model":"small","language":"en","text":"legacy entry here"}`,
+	)
+
+	stats, err := TranscriptionStats()
+	if err != nil {
+		t.Fatalf("TranscriptionStats() error: %v", err)
+	}
+	if stats.TotalWords != 10 {
+		t.Errorf("TotalWords = %d, want 10 (7 stored + 3 computed)", stats.TotalWords)
+	}
+}
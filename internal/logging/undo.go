@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+// ErrNoTranscriptions is returned by RemoveLast when the log has no entries
+var ErrNoTranscriptions = errors.New("no transcriptions logged yet")
+
+// RemoveLast removes the most recent entry from the current log file and returns it,
+// deleting the recording kept with it (keep_recordings), if any. The file is rewritten
+// without that line; rotated logs are left alone.
+func RemoveLast() (TranscriptionEntry, error) {
+	logPath, err := config.GetTranscriptionLogPath()
+	if err != nil {
+		return TranscriptionEntry{}, fmt.Errorf("failed to get log path: %w", err)
+	}
+
+	logMu.Lock()
+	defer logMu.Unlock()
+
+	data, err := os.ReadFile(logPath)
+	if os.IsNotExist(err) {
+		return TranscriptionEntry{}, ErrNoTranscriptions
+	}
+	if err != nil {
+		return TranscriptionEntry{}, fmt.Errorf("failed to read log file: %w", err)
+	}
+
+	entry, start, end, ok, err := lastEntry(data)
+	if err != nil {
+		return TranscriptionEntry{}, err
+	}
+	if !ok {
+		return TranscriptionEntry{}, ErrNoTranscriptions
+	}
+
+	rest := append(data[:start:start], data[end:]...)
+	if err := replaceLogFile(logPath, rest); err != nil {
+		return TranscriptionEntry{}, err
+	}
+
+	if entry.AudioPath != "" {
+		if err := os.Remove(entry.AudioPath); err != nil && !os.IsNotExist(err) {
+			return entry, fmt.Errorf("transcription removed, but failed to delete its recording %s: %w", entry.AudioPath, err)
+		}
+	}
+	return entry, nil
+}
+
+// lastEntry finds the last well-formed entry in JSON Lines data, returning it with
+// the byte range of its line (including the newline). Malformed lines are skipped;
+// an encrypted entry that can't be decrypted is an error.
+func lastEntry(data []byte) (TranscriptionEntry, int, int, bool, error) {
+	end := len(data)
+	for end > 0 {
+		start := bytes.LastIndexByte(data[:end-1], '\n') + 1
+		entry, ok, err := decodeLine(bytes.TrimRight(data[start:end], "\n"))
+		if err != nil {
+			return TranscriptionEntry{}, 0, 0, false, err
+		}
+		if ok {
+			return entry, start, end, true, nil
+		}
+		end = start
+	}
+	return TranscriptionEntry{}, 0, 0, false, nil
+}
+
+// replaceLogFile writes data to a temporary file and renames it over the log,
+// so an interrupted rewrite never truncates the history. Open Loggers notice
+// the new file and reopen it on their next write.
+func replaceLogFile(logPath string, data []byte) error {
+	tempFile := logPath + ".tmp"
+	if err := os.WriteFile(tempFile, data, 0644); err != nil {
+		_ = os.Remove(tempFile)
+		return fmt.Errorf("failed to rewrite log file: %w", err)
+	}
+	if err := os.Rename(tempFile, logPath); err != nil {
+		_ = os.Remove(tempFile)
+		return fmt.Errorf("failed to replace log file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package logging
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+func TestRemoveLast(t *testing.T) {
+	tests := []struct {
+		name       string
+		lines      []string
+		wantText   string
+		wantRemain []string
+		wantErr    error
+	}{
+		{
+			name: "multiple entries",
+			lines: []string{
+				`{"timestamp":"2024-01-01T09:00:00Z","text":"first"}`,
+				`{"timestamp":"2024-01-01T10:00:00Z","text":"second"}`,
+				`{"timestamp":"2024-01-01T11:00:00Z","text":"third"}`,
+			},
+			wantText:   "third",
+			wantRemain: []string{"first", "second"},
+		},
+		{
+			name:       "single entry",
+			lines:      []string{`{"timestamp":"2024-01-01T09:00:00Z","text":"only"}`},
+			wantText:   "only",
+			wantRemain: nil,
+		},
+		{
+			name: "skips trailing malformed line",
+			lines: []string{
+				`{"timestamp":"2024-01-01T09:00:00Z","text":"first"}`,
+				`{"timestamp":"2024-01-01T10:00:00Z","text":"second"}`,
+				`{broken`,
+			},
+			wantText:   "second",
+			wantRemain: []string{"first"},
+		},
+		{
+			name:    "empty file",
+			lines:   nil,
+			wantErr: ErrNoTranscriptions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeLogLines(t, tt.lines...)
+
+			removed, err := RemoveLast()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RemoveLast() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if removed.Text != tt.wantText {
+				t.Errorf("RemoveLast() removed %q, want %q", removed.Text, tt.wantText)
+			}
+
+			entries, err := GetTranscriptions(0)
+			if err != nil {
+				t.Fatalf("GetTranscriptions failed: %v", err)
+			}
+			var remaining []string
+			for _, e := range entries {
+				remaining = append(remaining, e.Text)
+			}
+			if len(remaining) != len(tt.wantRemain) {
+				t.Fatalf("remaining entries = %q, want %q", remaining, tt.wantRemain)
+			}
+			for i := range remaining {
+				if remaining[i] != tt.wantRemain[i] {
+					t.Errorf("remaining entries = %q, want %q", remaining, tt.wantRemain)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveLast_MissingLog(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := RemoveLast(); !errors.Is(err, ErrNoTranscriptions) {
+		t.Errorf("RemoveLast() on missing log error = %v, want ErrNoTranscriptions", err)
+	}
+}
+
+func TestRemoveLast_LoggerKeepsWriting(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	logger := NewLogger()
+	defer func() {
+		_ = logger.Close()
+	}()
+	for _, text := range []string{"keep", "throwaway"} {
+		if err := logger.Log(Entry{Text: text}); err != nil {
+			t.Fatalf("Log() error: %v", err)
+		}
+	}
+
+	if _, err := RemoveLast(); err != nil {
+		t.Fatalf("RemoveLast() error: %v", err)
+	}
+
+	// The open Logger must write to the rewritten file, not the replaced one
+	if err := logger.Log(Entry{Text: "next"}); err != nil {
+		t.Fatalf("Log() error: %v", err)
+	}
+	entries, err := GetTranscriptions(0)
+	if err != nil {
+		t.Fatalf("GetTranscriptions failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Text != "keep" || entries[1].Text != "next" {
+		t.Errorf("entries = %+v, want keep then next", entries)
+	}
+
+	logPath, _ := config.GetTranscriptionLogPath()
+	if _, err := os.Stat(logPath + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+}
+
+func TestRemoveLast_DeletesKeptRecording(t *testing.T) {
+	writeLogLines(t)
+
+	recording := filepath.Join(t.TempDir(), "recording.wav")
+	if err := os.WriteFile(recording, []byte("RIFF"), 0644); err != nil {
+		t.Fatalf("failed to write recording: %v", err)
+	}
+
+	logger := NewLogger()
+	defer func() {
+		_ = logger.Close()
+	}()
+	if err := logger.Log(Entry{Text: "throwaway", AudioPath: recording}); err != nil {
+		t.Fatalf("Log() error: %v", err)
+	}
+
+	removed, err := RemoveLast()
+	if err != nil {
+		t.Fatalf("RemoveLast() error: %v", err)
+	}
+	if removed.AudioPath != recording {
+		t.Errorf("RemoveLast() AudioPath = %q, want %q", removed.AudioPath, recording)
+	}
+	if _, err := os.Stat(recording); !os.IsNotExist(err) {
+		t.Errorf("kept recording still exists after RemoveLast(): %v", err)
+	}
+}
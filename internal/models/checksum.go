@@ -0,0 +1,73 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// checksumSuffix names the file next to a model that records its expected SHA256
+const checksumSuffix = ".sha256"
+
+// sha256Pattern matches a hex-encoded SHA256
+var sha256Pattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// publishedChecksum asks the download server for the SHA256 it publishes for url.
+// Hugging Face sends the Git LFS object hash (the file's SHA256) in X-Linked-Etag on the
+// redirect to its CDN. Returns "" when the server publishes none (e.g. a plain mirror).
+func publishedChecksum(ctx context.Context, client *http.Client, url string) string {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return ""
+	}
+
+	// The header is on the redirect itself, not on the CDN response
+	noRedirect := *client
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	resp, err := noRedirect.Do(req)
+	if err != nil {
+		return ""
+	}
+	_ = resp.Body.Close()
+
+	etag := strings.ToLower(strings.Trim(strings.TrimPrefix(resp.Header.Get("X-Linked-Etag"), "W/"), `"`))
+	if !sha256Pattern.MatchString(etag) {
+		return ""
+	}
+	return etag
+}
+
+// ExpectedChecksum returns the SHA256 a downloaded model must match: the one pinned in
+// AvailableModels, otherwise the one recorded from the server when it was downloaded.
+// Returns "" when neither is known, so only the file size can be checked.
+func ExpectedChecksum(modelName ModelSize) string {
+	if sum := AvailableModels[modelName].SHA256; sum != "" {
+		return sum
+	}
+	modelPath, err := GetModelPath(modelName)
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(modelPath + checksumSuffix)
+	if err != nil {
+		return ""
+	}
+	sum := strings.TrimSpace(string(data))
+	if !sha256Pattern.MatchString(sum) {
+		return ""
+	}
+	return sum
+}
+
+// saveChecksum records a model's expected SHA256 next to it for later verification
+func saveChecksum(modelPath, sum string) error {
+	if err := os.WriteFile(modelPath+checksumSuffix, []byte(sum+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to save checksum: %w", err)
+	}
+	return nil
+}
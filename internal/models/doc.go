@@ -35,11 +35,11 @@
 //	    fmt.Printf("%s: %s, Size: %s\n", m.Name, m.Description, m.Size)
 //	}
 //
-//	// Download a model
-//	downloader := models.NewDownloader()
-//	if err := downloader.Download("small", func(progress float64) {
-//	    fmt.Printf("\rProgress: %.1f%%", progress*100)
-//	}); err != nil {
+//	// Download a model (cancel ctx to abort it)
+//	err := models.DownloadModel(ctx, models.Small, func(downloaded, total int64, percent, bytesPerSecond float64) {
+//	    fmt.Printf("\r%s", models.RenderProgressBar(downloaded, total, percent, bytesPerSecond))
+//	})
+//	if err != nil {
 //	    log.Fatal(err)
 //	}
 //
@@ -2,29 +2,50 @@
 package models
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/alexandrelam/openscribe/internal/config"
 )
 
-// ProgressCallback is called periodically during download
-type ProgressCallback func(downloaded, total int64, percent float64)
+// ProgressCallback is called periodically during download.
+// bytesPerSecond is the current throughput, averaged over the last few seconds.
+type ProgressCallback func(downloaded, total int64, percent, bytesPerSecond float64)
 
-// checkDiskSpace verifies there's enough disk space for the download
-func checkDiskSpace(directory string, requiredBytes int64) error {
+// AvailableDiskSpace returns the bytes available to the current user on the volume holding directory
+func AvailableDiskSpace(directory string) (int64, error) {
 	var stat syscall.Statfs_t
 	if err := syscall.Statfs(directory, &stat); err != nil {
-		return fmt.Errorf("failed to check disk space: %w", err)
+		return 0, fmt.Errorf("failed to check disk space: %w", err)
 	}
 
 	// Available blocks * block size = available bytes
-	availableBytes := int64(stat.Bavail) * int64(stat.Bsize)
+	return int64(stat.Bavail) * int64(stat.Bsize), nil
+}
+
+// freeDiskSpace reports the space available for a download; replaced in tests
+var freeDiskSpace = AvailableDiskSpace
+
+// ErrDiskFull is returned when the disk runs out of space during a download
+var ErrDiskFull = errors.New("ran out of disk space")
+
+// diskCheckInterval is how many bytes are written between free-space checks during a download
+const diskCheckInterval = 64 * 1024 * 1024
+
+// checkDiskSpace verifies there's enough disk space for the download
+func checkDiskSpace(directory string, requiredBytes int64) error {
+	availableBytes, err := AvailableDiskSpace(directory)
+	if err != nil {
+		return err
+	}
 
 	// Add 10% buffer for safety
 	requiredWithBuffer := requiredBytes + (requiredBytes / 10)
@@ -38,8 +59,24 @@ func checkDiskSpace(directory string, requiredBytes int64) error {
 	return nil
 }
 
-// DownloadModel downloads a Whisper model with progress reporting
-func DownloadModel(modelName ModelSize, progress ProgressCallback) error {
+// DownloadOptions tunes how a model is downloaded
+type DownloadOptions struct {
+	// Connections is the number of concurrent range requests (1 or less downloads in a single stream)
+	Connections int
+}
+
+// DownloadModel downloads a Whisper model with progress reporting.
+// Cancelling ctx stops the download, removes the partial file and returns ctx.Err().
+func DownloadModel(ctx context.Context, modelName ModelSize, progress ProgressCallback) error {
+	return DownloadModelWithOptions(ctx, modelName, DownloadOptions{}, progress)
+}
+
+// DownloadModelWithOptions downloads a Whisper model like DownloadModel, using opts
+func DownloadModelWithOptions(ctx context.Context, modelName ModelSize, opts DownloadOptions, progress ProgressCallback) error {
+	if opts.Connections > MaxConnections {
+		return fmt.Errorf("too many connections: %d (maximum %d)", opts.Connections, MaxConnections)
+	}
+
 	modelInfo, ok := AvailableModels[modelName]
 	if !ok {
 		return fmt.Errorf("unknown model: %s", modelName)
@@ -55,12 +92,6 @@ func DownloadModel(modelName ModelSize, progress ProgressCallback) error {
 		return fmt.Errorf("failed to create models directory: %w", mkdirErr)
 	}
 
-	// Check disk space before attempting download
-	requiredBytes := int64(modelInfo.SizeMB) * 1024 * 1024
-	if err := checkDiskSpace(modelsDir, requiredBytes); err != nil {
-		return fmt.Errorf("cannot download model: %w", err)
-	}
-
 	// Download to a temporary file first
 	tempFile := filepath.Join(modelsDir, modelInfo.FileName+".tmp")
 	finalPath := filepath.Join(modelsDir, modelInfo.FileName)
@@ -70,112 +101,333 @@ func DownloadModel(modelName ModelSize, progress ProgressCallback) error {
 		return fmt.Errorf("model already exists: %s", modelName)
 	}
 
-	// Create the HTTP request with timeout and retry logic
-	client := &http.Client{
-		Timeout: 5 * time.Minute, // 5 minute timeout for each request
+	// Check disk space before attempting download (a partial download only needs the remainder)
+	requiredBytes := int64(modelInfo.SizeMB) * 1024 * 1024
+	if info, statErr := os.Stat(tempFile); statErr == nil && info.Size() < requiredBytes {
+		requiredBytes -= info.Size()
+	}
+	if err := checkDiskSpace(modelsDir, requiredBytes); err != nil {
+		return fmt.Errorf("cannot download model: %w", err)
 	}
 
-	var resp *http.Response
-	var httpErr error
-	maxRetries := 3
+	// Use the configured mirror, if any
+	var mirror string
+	if cfg, cfgErr := config.Load(); cfgErr == nil {
+		mirror = cfg.ModelMirrorURL
+	}
+	downloadURL := modelURL(modelInfo, mirror)
 
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		resp, httpErr = client.Get(modelInfo.URL)
-		if httpErr == nil && resp.StatusCode == http.StatusOK {
-			break
+	// The checksum the download must match, when the pinned or published one is known
+	checksum := modelInfo.SHA256
+	if checksum == "" {
+		checksum = publishedChecksum(ctx, newDownloadClient(), downloadURL)
+	}
+
+	// Download, resuming from any partial file left by an interrupted attempt
+	if err := downloadParallel(ctx, downloadURL, tempFile, opts.Connections, progress); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
 		}
+		if errors.Is(err, ErrDiskFull) {
+			return fmt.Errorf("failed to download model: %w", err)
+		}
+		return fmt.Errorf("failed to download model: %w\nPlease check your internet connection and run the command again to resume", err)
+	}
+
+	// Move temp file to final location
+	if err := os.Rename(tempFile, finalPath); err != nil {
+		_ = os.Remove(tempFile) // Clean up on error
+		return fmt.Errorf("failed to finalize model file: %w", err)
+	}
 
-		// Close response body if we got one
-		if resp != nil {
-			_ = resp.Body.Close()
+	// Validate the downloaded model, including its checksum
+	info := modelInfo
+	info.SHA256 = checksum
+	if err := validateModelFile(finalPath, info, true); err != nil {
+		_ = os.Remove(finalPath) // Remove invalid file
+		return fmt.Errorf("model validation failed: %w", err)
+	}
+
+	// Remember a published checksum so 'models verify' can check it again later
+	if checksum != "" && checksum != modelInfo.SHA256 {
+		if err := saveChecksum(finalPath, checksum); err != nil {
+			return err
 		}
+	}
+
+	return nil
+}
+
+// modelURL returns the download URL for a model, rewritten to the mirror when one is configured.
+// The mirror is a base URL the file name is appended to, or a template containing "{filename}".
+func modelURL(info ModelInfo, mirror string) string {
+	if mirror == "" {
+		return info.URL
+	}
+	if strings.Contains(mirror, "{filename}") {
+		return strings.ReplaceAll(mirror, "{filename}", info.FileName)
+	}
+	return strings.TrimRight(mirror, "/") + "/" + info.FileName
+}
+
+// newDownloadClient returns the HTTP client used for downloads.
+// Proxies are taken from the standard HTTPS_PROXY/HTTP_PROXY/NO_PROXY environment variables.
+func newDownloadClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   5 * time.Minute, // 5 minute timeout for each request
+	}
+}
 
-		if attempt < maxRetries {
-			// Wait before retrying (exponential backoff)
-			waitTime := time.Duration(attempt) * 2 * time.Second
-			time.Sleep(waitTime)
-			continue
+// maxDownloadRetries is the number of attempts made before a download fails
+const maxDownloadRetries = 3
+
+// retryBaseDelay is multiplied by the attempt number to back off between retries
+const retryBaseDelay = 2 * time.Second
+
+// downloadWithRetry downloads url into tempFile, resuming from the partial file after each failed attempt.
+// The partial file is kept on failure so a later call can resume it, unless ctx was cancelled.
+func downloadWithRetry(ctx context.Context, url, tempFile string, progress ProgressCallback) error {
+	client := newDownloadClient()
+
+	var lastErr error
+	for attempt := 1; attempt <= maxDownloadRetries; attempt++ {
+		lastErr = fetchResumable(ctx, client, url, tempFile, progress)
+		if lastErr == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			// Cancelled on purpose: don't leave a partial file behind
+			_ = os.Remove(tempFile)
+			return ctx.Err()
+		}
+		if errors.Is(lastErr, ErrDiskFull) {
+			// Retrying can't help until space is freed
+			return lastErr
 		}
 
-		// All retries exhausted
-		if httpErr != nil {
-			return fmt.Errorf("failed to download model after %d attempts: %w\nPlease check your internet connection", maxRetries, httpErr)
+		if attempt < maxDownloadRetries {
+			// Wait before retrying (linear backoff)
+			select {
+			case <-time.After(time.Duration(attempt) * retryBaseDelay):
+			case <-ctx.Done():
+				_ = os.Remove(tempFile)
+				return ctx.Err()
+			}
 		}
-		return fmt.Errorf("failed to download model after %d attempts: HTTP %d\nServer returned error: %s", maxRetries, resp.StatusCode, resp.Status)
 	}
 
-	defer func() {
-		_ = resp.Body.Close() // Best effort close
-	}()
+	return fmt.Errorf("failed after %d attempts: %w", maxDownloadRetries, lastErr)
+}
 
-	// Create the temporary file
-	out, err := os.Create(tempFile)
+// fetchResumable downloads url into tempFile. If tempFile already holds a partial
+// download, it requests only the remaining bytes with a Range header and appends them.
+// Servers that ignore the range (HTTP 200) get a full download instead.
+func fetchResumable(ctx context.Context, client *http.Client, url, tempFile string, progress ProgressCallback) error {
+	var offset int64
+	if info, err := os.Stat(tempFile); err == nil {
+		offset = info.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to create temporary file: %w", err)
+		return err
 	}
 	defer func() {
-		_ = out.Close() // Will be closed explicitly before rename
+		_ = resp.Body.Close() // Best effort close
 	}()
 
-	// Get the total size
-	totalSize := resp.ContentLength
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		// Fresh download, or the server doesn't support ranges: start over
+		flags |= os.O_TRUNC
+		offset = 0
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file doesn't match the remote file; discard it so the next attempt starts over
+		_ = os.Remove(tempFile)
+		return fmt.Errorf("cannot resume download: %s", resp.Status)
+	default:
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+	}
 
-	// Create a progress reader
+	out, err := os.OpenFile(tempFile, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open temporary file: %w", err)
+	}
+
+	// Report progress against the full file size
+	total := resp.ContentLength
+	if total > 0 {
+		total += offset
+	}
 	reader := &progressReader{
-		reader:   resp.Body,
-		total:    totalSize,
-		callback: progress,
+		ctx:        ctx,
+		reader:     resp.Body,
+		total:      total,
+		downloaded: offset,
+		callback:   progress,
 	}
 
-	// Copy with progress
-	_, err = io.Copy(out, reader)
-	if err != nil {
-		_ = os.Remove(tempFile) // Clean up on error
-		return fmt.Errorf("failed to write model file: %w", err)
+	if err := copyDownload(out, reader, filepath.Dir(tempFile)); err != nil {
+		_ = out.Close()
+		if errors.Is(err, ErrDiskFull) {
+			// Free the space taken by the partial file rather than keeping it to resume
+			_ = os.Remove(tempFile)
+		}
+		return err
 	}
 
-	// Close the file before renaming
 	if err := out.Close(); err != nil {
-		_ = os.Remove(tempFile) // Clean up on error
 		return fmt.Errorf("failed to close temporary file: %w", err)
 	}
 
-	// Move temp file to final location
-	if err := os.Rename(tempFile, finalPath); err != nil {
-		_ = os.Remove(tempFile) // Clean up on error
-		return fmt.Errorf("failed to finalize model file: %w", err)
+	return nil
+}
+
+// copyDownload copies the download from reader to out. It fails with ErrDiskFull when
+// a write runs out of space, or when a periodic check of the free space in dir finds
+// too little left for the rest of the download.
+func copyDownload(out io.Writer, reader *progressReader, dir string) error {
+	buf := make([]byte, 32*1024)
+	written := reader.downloaded
+	var sinceCheck int64
+
+	for {
+		n, readErr := reader.Read(buf)
+		if n > 0 {
+			wn, err := out.Write(buf[:n])
+			written += int64(wn)
+			if err != nil {
+				if errors.Is(err, syscall.ENOSPC) {
+					return diskFullError(written, reader.total)
+				}
+				return fmt.Errorf("failed to write file: %w", err)
+			}
+
+			sinceCheck += int64(wn)
+			if sinceCheck >= diskCheckInterval && reader.total > 0 {
+				sinceCheck = 0
+				remaining := reader.total - written
+				if available, err := freeDiskSpace(dir); err == nil && available < remaining {
+					return fmt.Errorf("%w: stopped after downloading %s of %s, with %s still to download but only %s free",
+						ErrDiskFull, FormatBytes(written), FormatBytes(reader.total), FormatBytes(remaining), FormatBytes(available))
+				}
+			}
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			return readErr
+		}
 	}
+}
 
-	// Validate the downloaded model
-	if err := ValidateModel(modelName); err != nil {
-		_ = os.Remove(finalPath) // Remove invalid file
-		return fmt.Errorf("model validation failed: %w", err)
+// diskFullError reports how far a download got before the disk filled up
+func diskFullError(written, total int64) error {
+	if total > 0 {
+		return fmt.Errorf("%w after downloading %s of %s; free up space and try again", ErrDiskFull, FormatBytes(written), FormatBytes(total))
 	}
+	return fmt.Errorf("%w after downloading %s; free up space and try again", ErrDiskFull, FormatBytes(written))
+}
 
-	return nil
+// speedWindow is how far back the download speed estimate looks
+const speedWindow = 2 * time.Second
+
+// speedSample is the cumulative byte count observed at a point in time
+type speedSample struct {
+	at    time.Time
+	bytes int64
+}
+
+// speedEstimator computes throughput over a sliding time window, so the reported
+// speed follows the current rate instead of the average since the download started
+type speedEstimator struct {
+	window  time.Duration
+	samples []speedSample
+}
+
+func newSpeedEstimator(window time.Duration) *speedEstimator {
+	return &speedEstimator{window: window}
+}
+
+// add records the cumulative byte count at time now
+func (e *speedEstimator) add(now time.Time, bytes int64) {
+	e.samples = append(e.samples, speedSample{at: now, bytes: bytes})
+
+	// Drop samples older than the window, keeping one at or before its start as the baseline
+	cutoff := now.Add(-e.window)
+	drop := 0
+	for drop+1 < len(e.samples) && !e.samples[drop+1].at.After(cutoff) {
+		drop++
+	}
+	e.samples = e.samples[drop:]
+}
+
+// rate returns bytes per second across the window, or 0 until two samples span some time
+func (e *speedEstimator) rate() float64 {
+	if len(e.samples) < 2 {
+		return 0
+	}
+	first, last := e.samples[0], e.samples[len(e.samples)-1]
+	elapsed := last.at.Sub(first.at).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(last.bytes-first.bytes) / elapsed
 }
 
 // progressReader wraps an io.Reader to report download progress
 type progressReader struct {
+	ctx        context.Context // Stops the download when cancelled; may be nil
 	reader     io.Reader
 	total      int64
 	downloaded int64
 	callback   ProgressCallback
 	lastUpdate time.Time
+	speed      *speedEstimator
 }
 
 func (pr *progressReader) Read(p []byte) (int, error) {
+	if pr.speed == nil {
+		// Start from the bytes already on disk so resumed downloads don't inflate the speed
+		pr.speed = newSpeedEstimator(speedWindow)
+		pr.speed.add(time.Now(), pr.downloaded)
+	}
+
+	if pr.ctx != nil && pr.ctx.Err() != nil {
+		return 0, pr.ctx.Err()
+	}
+
 	n, err := pr.reader.Read(p)
 	pr.downloaded += int64(n)
 
 	// Update progress every 100ms to avoid too many callbacks
 	if pr.callback != nil && time.Since(pr.lastUpdate) > 100*time.Millisecond {
+		now := time.Now()
+		pr.speed.add(now, pr.downloaded)
+
 		percent := 0.0
 		if pr.total > 0 {
 			percent = float64(pr.downloaded) / float64(pr.total) * 100.0
 		}
-		pr.callback(pr.downloaded, pr.total, percent)
-		pr.lastUpdate = time.Now()
+		pr.callback(pr.downloaded, pr.total, percent, pr.speed.rate())
+		pr.lastUpdate = now
 	}
 
 	return n, err
@@ -201,60 +453,14 @@ func FormatSpeed(bytesPerSecond float64) string {
 }
 
 // downloadFile downloads a URL to a local file path with progress reporting
-func downloadFile(url, destPath string, progress ProgressCallback) error {
+func downloadFile(ctx context.Context, url, destPath string, progress ProgressCallback) error {
 	tempFile := destPath + ".tmp"
 
-	client := &http.Client{
-		Timeout: 5 * time.Minute,
-	}
-
-	var resp *http.Response
-	var httpErr error
-	maxRetries := 3
-
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		resp, httpErr = client.Get(url)
-		if httpErr == nil && resp.StatusCode == http.StatusOK {
-			break
-		}
-		if resp != nil {
-			_ = resp.Body.Close()
+	if err := downloadWithRetry(ctx, url, tempFile, progress); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
 		}
-		if attempt < maxRetries {
-			time.Sleep(time.Duration(attempt) * 2 * time.Second)
-			continue
-		}
-		if httpErr != nil {
-			return fmt.Errorf("failed to download after %d attempts: %w", maxRetries, httpErr)
-		}
-		return fmt.Errorf("failed to download after %d attempts: HTTP %d", maxRetries, resp.StatusCode)
-	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	out, err := os.Create(tempFile)
-	if err != nil {
-		return fmt.Errorf("failed to create temporary file: %w", err)
-	}
-	defer func() {
-		_ = out.Close()
-	}()
-
-	reader := &progressReader{
-		reader:   resp.Body,
-		total:    resp.ContentLength,
-		callback: progress,
-	}
-
-	if _, err = io.Copy(out, reader); err != nil {
-		_ = os.Remove(tempFile)
-		return fmt.Errorf("failed to write file: %w", err)
-	}
-
-	if err := out.Close(); err != nil {
-		_ = os.Remove(tempFile)
-		return fmt.Errorf("failed to close temporary file: %w", err)
+		return fmt.Errorf("failed to download: %w", err)
 	}
 
 	if err := os.Rename(tempFile, destPath); err != nil {
@@ -0,0 +1,390 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+// testPayload returns deterministic content large enough to split
+func testPayload() []byte {
+	return bytes.Repeat([]byte("openscribe-model-data-"), 4096)
+}
+
+func TestFetchResumable_ResumesPartialDownload(t *testing.T) {
+	payload := testPayload()
+	var gotRange string
+
+	// http.ServeContent answers Range requests with 206 Partial Content
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRange = r.Header.Get("Range")
+		http.ServeContent(w, r, "model.bin", time.Time{}, bytes.NewReader(payload))
+	}))
+	defer server.Close()
+
+	tempFile := filepath.Join(t.TempDir(), "model.bin.tmp")
+	half := len(payload) / 2
+	if err := os.WriteFile(tempFile, payload[:half], 0644); err != nil {
+		t.Fatalf("failed to write partial file: %v", err)
+	}
+
+	var lastDownloaded, lastTotal int64
+	progress := func(downloaded, total int64, _, _ float64) {
+		lastDownloaded, lastTotal = downloaded, total
+	}
+
+	if err := fetchResumable(context.Background(), server.Client(), server.URL, tempFile, progress); err != nil {
+		t.Fatalf("fetchResumable() error: %v", err)
+	}
+
+	if want := fmt.Sprintf("bytes=%d-", half); gotRange != want {
+		t.Errorf("Range header = %q, want %q", gotRange, want)
+	}
+
+	got, err := os.ReadFile(tempFile)
+	if err != nil {
+		t.Fatalf("failed to read assembled file: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Errorf("assembled file has %d bytes, want %d matching bytes", len(got), len(payload))
+	}
+
+	if lastTotal != int64(len(payload)) || lastDownloaded > lastTotal {
+		t.Errorf("progress = %d/%d, want total %d", lastDownloaded, lastTotal, len(payload))
+	}
+}
+
+func TestFetchResumable_FallsBackToFullDownload(t *testing.T) {
+	payload := testPayload()
+
+	// Server ignores Range and always returns the whole file with 200
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(payload)
+	}))
+	defer server.Close()
+
+	tempFile := filepath.Join(t.TempDir(), "model.bin.tmp")
+	if err := os.WriteFile(tempFile, []byte("stale partial data"), 0644); err != nil {
+		t.Fatalf("failed to write partial file: %v", err)
+	}
+
+	if err := fetchResumable(context.Background(), server.Client(), server.URL, tempFile, nil); err != nil {
+		t.Fatalf("fetchResumable() error: %v", err)
+	}
+
+	got, err := os.ReadFile(tempFile)
+	if err != nil {
+		t.Fatalf("failed to read downloaded file: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Errorf("downloaded file has %d bytes, want %d matching bytes (partial data must be truncated)", len(got), len(payload))
+	}
+}
+
+func TestFetchResumable_DiscardsUnsatisfiableRange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+	}))
+	defer server.Close()
+
+	tempFile := filepath.Join(t.TempDir(), "model.bin.tmp")
+	if err := os.WriteFile(tempFile, []byte("too long"), 0644); err != nil {
+		t.Fatalf("failed to write partial file: %v", err)
+	}
+
+	if err := fetchResumable(context.Background(), server.Client(), server.URL, tempFile, nil); err == nil {
+		t.Fatal("fetchResumable() expected error for HTTP 416, got nil")
+	}
+	if _, err := os.Stat(tempFile); !os.IsNotExist(err) {
+		t.Errorf("partial file should be removed after HTTP 416, stat error = %v", err)
+	}
+}
+
+func TestModelURL(t *testing.T) {
+	info := ModelInfo{
+		URL:      "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-base.bin",
+		FileName: "ggml-base.bin",
+	}
+
+	tests := []struct {
+		name   string
+		mirror string
+		want   string
+	}{
+		{"no mirror", "", info.URL},
+		{"base URL", "https://models.example.com/whisper", "https://models.example.com/whisper/ggml-base.bin"},
+		{"trailing slash", "https://models.example.com/whisper/", "https://models.example.com/whisper/ggml-base.bin"},
+		{"template", "https://models.example.com/{filename}?raw=1", "https://models.example.com/ggml-base.bin?raw=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := modelURL(info, tt.mirror); got != tt.want {
+				t.Errorf("modelURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDownloadModel_FromMirror(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	payload := testPayload()
+	var requestedPath string
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		http.ServeContent(w, r, "model.bin", time.Time{}, bytes.NewReader(payload))
+	}))
+	defer mirror.Close()
+
+	// Register a small test model whose canonical URL is unreachable
+	const testModel = ModelSize("mirror-test")
+	AvailableModels[testModel] = ModelInfo{
+		Name:     testModel,
+		URL:      "http://127.0.0.1:1/unreachable/ggml-mirror-test.bin",
+		FileName: "ggml-mirror-test.bin",
+	}
+	defer delete(AvailableModels, testModel)
+
+	cfg := config.DefaultConfig()
+	cfg.ModelMirrorURL = mirror.URL + "/whisper"
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	if err := DownloadModel(context.Background(), testModel, nil); err != nil {
+		t.Fatalf("DownloadModel() error: %v", err)
+	}
+
+	if requestedPath != "/whisper/ggml-mirror-test.bin" {
+		t.Errorf("mirror request path = %q, want %q", requestedPath, "/whisper/ggml-mirror-test.bin")
+	}
+
+	modelPath, err := GetModelPath(testModel)
+	if err != nil {
+		t.Fatalf("GetModelPath() error: %v", err)
+	}
+	got, err := os.ReadFile(modelPath)
+	if err != nil {
+		t.Fatalf("failed to read downloaded model: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Errorf("downloaded model has %d bytes, want %d matching bytes", len(got), len(payload))
+	}
+}
+
+func TestSpeedEstimator_SlidingWindow(t *testing.T) {
+	start := time.Unix(0, 0)
+	est := newSpeedEstimator(2 * time.Second)
+
+	if rate := est.rate(); rate != 0 {
+		t.Errorf("rate() with no samples = %v, want 0", rate)
+	}
+
+	// Slow start: 100 B/s for 10 seconds
+	var total int64
+	for s := 0; s <= 10; s++ {
+		total = int64(s) * 100
+		est.add(start.Add(time.Duration(s)*time.Second), total)
+	}
+	if rate := est.rate(); rate != 100 {
+		t.Errorf("rate() during slow phase = %v, want 100", rate)
+	}
+
+	// Speed up to 10 KB/s; after a full window only the fast phase counts
+	for s := 11; s <= 13; s++ {
+		total += 10000
+		est.add(start.Add(time.Duration(s)*time.Second), total)
+	}
+	if rate := est.rate(); rate != 10000 {
+		t.Errorf("rate() after speed-up = %v, want 10000 (cumulative average would be ~%v)", rate, float64(total)/13)
+	}
+}
+
+func TestSpeedEstimator_SingleSample(t *testing.T) {
+	est := newSpeedEstimator(2 * time.Second)
+	est.add(time.Unix(0, 0), 5000)
+
+	if rate := est.rate(); rate != 0 {
+		t.Errorf("rate() with one sample = %v, want 0", rate)
+	}
+}
+
+// fullDiskWriter accepts limit bytes, then fails like a write to a full disk
+type fullDiskWriter struct {
+	limit   int
+	written int
+}
+
+func (w *fullDiskWriter) Write(p []byte) (int, error) {
+	if w.written+len(p) <= w.limit {
+		w.written += len(p)
+		return len(p), nil
+	}
+	n := w.limit - w.written
+	w.written = w.limit
+	return n, &os.PathError{Op: "write", Path: "model.bin.tmp", Err: syscall.ENOSPC}
+}
+
+func TestCopyDownload_DiskFull(t *testing.T) {
+	payload := testPayload()
+	reader := &progressReader{reader: bytes.NewReader(payload), total: int64(len(payload))}
+
+	err := copyDownload(&fullDiskWriter{limit: 40000}, reader, t.TempDir())
+
+	if !errors.Is(err, ErrDiskFull) {
+		t.Fatalf("copyDownload() error = %v, want ErrDiskFull", err)
+	}
+	want := fmt.Sprintf("ran out of disk space after downloading %s of %s", FormatBytes(40000), FormatBytes(int64(len(payload))))
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("copyDownload() error = %q, want it to contain %q", err, want)
+	}
+}
+
+func TestCopyDownload_OtherWriteError(t *testing.T) {
+	reader := &progressReader{reader: bytes.NewReader(testPayload())}
+	err := copyDownload(errWriter{}, reader, t.TempDir())
+
+	if err == nil || errors.Is(err, ErrDiskFull) {
+		t.Errorf("copyDownload() error = %v, want a plain write error", err)
+	}
+}
+
+// errWriter fails every write with a non-space error
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, syscall.EIO }
+
+func TestFetchResumable_StopsWhenDiskSpaceRunsLow(t *testing.T) {
+	payload := bytes.Repeat([]byte{0x42}, diskCheckInterval+1024*1024)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "model.bin", time.Time{}, bytes.NewReader(payload))
+	}))
+	defer server.Close()
+
+	// Another process fills the disk: only 100 bytes are left at the first check
+	originalFree := freeDiskSpace
+	freeDiskSpace = func(string) (int64, error) { return 100, nil }
+	defer func() { freeDiskSpace = originalFree }()
+
+	tempFile := filepath.Join(t.TempDir(), "model.bin.tmp")
+	err := downloadWithRetry(context.Background(), server.URL, tempFile, nil)
+
+	if !errors.Is(err, ErrDiskFull) {
+		t.Fatalf("downloadWithRetry() error = %v, want ErrDiskFull without retrying", err)
+	}
+	if _, statErr := os.Stat(tempFile); !os.IsNotExist(statErr) {
+		t.Errorf("partial file should be removed when the disk is full, stat error = %v", statErr)
+	}
+}
+
+func TestDownloadWithRetry_Cancelled(t *testing.T) {
+	payload := testPayload()
+
+	// Stream the file slowly so the download is still running when it is cancelled
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", fmt.Sprint(len(payload)))
+		for offset := 0; offset < len(payload); offset += 1024 {
+			if _, err := w.Write(payload[offset:min(offset+1024, len(payload))]); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+			select {
+			case <-time.After(20 * time.Millisecond):
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	progress := func(_, _ int64, _, _ float64) { cancel() }
+
+	tempFile := filepath.Join(t.TempDir(), "model.bin.tmp")
+	done := make(chan error, 1)
+	go func() { done <- downloadWithRetry(ctx, server.URL, tempFile, progress) }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("downloadWithRetry() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("download was not cancelled")
+	}
+
+	if _, err := os.Stat(tempFile); !os.IsNotExist(err) {
+		t.Errorf("partial file should be removed after cancelling, stat error = %v", err)
+	}
+}
+
+func TestDownloadModel_PublishedChecksum(t *testing.T) {
+	payload := testPayload()
+	sum := sha256.Sum256(payload)
+	payloadSHA256 := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name      string
+		published string
+		wantErr   bool
+	}{
+		{"matching checksum", payloadSHA256, false},
+		{"mismatched checksum", strings.Repeat("0", 64), true},
+		{"no published checksum", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+
+			// Like Hugging Face: the checksum is on the redirect to the CDN
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasPrefix(r.URL.Path, "/cdn/") {
+					http.ServeContent(w, r, "model.bin", time.Time{}, bytes.NewReader(payload))
+					return
+				}
+				if tt.published != "" {
+					w.Header().Set("X-Linked-Etag", `"`+tt.published+`"`)
+				}
+				http.Redirect(w, r, "/cdn"+r.URL.Path, http.StatusFound)
+			}))
+			defer server.Close()
+
+			const testModel = ModelSize("checksum-test")
+			AvailableModels[testModel] = ModelInfo{
+				Name:     testModel,
+				URL:      server.URL + "/ggml-checksum-test.bin",
+				FileName: "ggml-checksum-test.bin",
+			}
+			defer delete(AvailableModels, testModel)
+
+			err := DownloadModel(context.Background(), testModel, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DownloadModel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			downloaded, _ := IsModelDownloaded(testModel)
+			if downloaded == tt.wantErr {
+				t.Errorf("IsModelDownloaded() = %t after DownloadModel() error %v", downloaded, err)
+			}
+			if got := ExpectedChecksum(testModel); !tt.wantErr && got != tt.published {
+				t.Errorf("ExpectedChecksum() = %q, want %q", got, tt.published)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+//go:build darwin
+// +build darwin
+
+package models
+
+import (
+	"encoding/binary"
+	"syscall"
+)
+
+// totalMemory returns the physical memory in bytes from the hw.memsize sysctl (0 if unavailable)
+func totalMemory() uint64 {
+	value, err := syscall.Sysctl("hw.memsize")
+	if err != nil {
+		return 0
+	}
+	// syscall.Sysctl returns the raw little-endian uint64 as a string, minus a trailing NUL byte
+	buf := make([]byte, 8)
+	copy(buf, value)
+	return binary.LittleEndian.Uint64(buf)
+}
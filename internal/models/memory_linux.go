@@ -0,0 +1,15 @@
+//go:build linux
+// +build linux
+
+package models
+
+import "syscall"
+
+// totalMemory returns the physical memory in bytes from sysinfo (0 if unavailable)
+func totalMemory() uint64 {
+	var info syscall.Sysinfo_t
+	if err := syscall.Sysinfo(&info); err != nil {
+		return 0
+	}
+	return uint64(info.Totalram) * uint64(info.Unit)
+}
@@ -0,0 +1,9 @@
+//go:build !darwin && !linux
+// +build !darwin,!linux
+
+package models
+
+// totalMemory is unknown on other platforms
+func totalMemory() uint64 {
+	return 0
+}
@@ -21,8 +21,51 @@ const (
 	Small  ModelSize = "small"
 	Medium ModelSize = "medium"
 	Large  ModelSize = "large"
+
+	// LargeTurbo is large-v3 with a pruned decoder: nearly as accurate, much faster
+	LargeTurbo ModelSize = "large-turbo"
 )
 
+// Quantized Whisper model variants (smaller files and lower memory use, minimal accuracy loss).
+// whisper.cpp publishes q5_1 for tiny/base/small and q5_0 for medium/large.
+const (
+	TinyQ5_1   ModelSize = "tiny-q5_1"
+	TinyQ8_0   ModelSize = "tiny-q8_0"
+	BaseQ5_1   ModelSize = "base-q5_1"
+	BaseQ8_0   ModelSize = "base-q8_0"
+	SmallQ5_1  ModelSize = "small-q5_1"
+	SmallQ8_0  ModelSize = "small-q8_0"
+	MediumQ5_0 ModelSize = "medium-q5_0"
+	MediumQ8_0 ModelSize = "medium-q8_0"
+	LargeQ5_0  ModelSize = "large-q5_0"
+)
+
+// English-only Whisper models (faster and more accurate for English dictation)
+const (
+	TinyEn   ModelSize = "tiny.en"
+	BaseEn   ModelSize = "base.en"
+	SmallEn  ModelSize = "small.en"
+	MediumEn ModelSize = "medium.en"
+)
+
+// DefaultModel is the model setup downloads unless another is chosen
+const DefaultModel = Small
+
+// ModelOrder lists the full-precision models from smallest to largest
+var ModelOrder = []ModelSize{Tiny, Base, Small, Medium, LargeTurbo, Large}
+
+// EnglishModelOrder lists the English-only models from smallest to largest
+var EnglishModelOrder = []ModelSize{TinyEn, BaseEn, SmallEn, MediumEn}
+
+// QuantizedModelOrder lists the quantized models from smallest base model to largest
+var QuantizedModelOrder = []ModelSize{
+	TinyQ5_1, TinyQ8_0,
+	BaseQ5_1, BaseQ8_0,
+	SmallQ5_1, SmallQ8_0,
+	MediumQ5_0, MediumQ8_0,
+	LargeQ5_0,
+}
+
 // ModelInfo contains metadata about a Whisper model
 type ModelInfo struct {
 	Name        ModelSize
@@ -30,10 +73,15 @@ type ModelInfo struct {
 	SizeMB      int
 	URL         string
 	FileName    string
-	SHA256      string // Optional checksum for validation
+	SHA256      string // Pinned SHA256 of the published file; overrides the one the server publishes
+	Quantized   bool   // Quantized variant of a full-precision model
+	EnglishOnly bool   // Only transcribes English (".en" models)
 }
 
-// AvailableModels defines all available Whisper models
+// AvailableModels defines all available Whisper models.
+// Downloads are checked against the SHA256 Hugging Face publishes for each file (see
+// publishedChecksum), which is recorded next to the model for 'models verify'. A pinned
+// SHA256 must be the file's Git LFS object hash and is used instead.
 var AvailableModels = map[ModelSize]ModelInfo{
 	Tiny: {
 		Name:        Tiny,
@@ -70,6 +118,117 @@ var AvailableModels = map[ModelSize]ModelInfo{
 		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3.bin",
 		FileName:    "ggml-large-v3.bin",
 	},
+	LargeTurbo: {
+		Name:        LargeTurbo,
+		Description: "Large v3 turbo, near-large accuracy, much faster (1.6 GB)",
+		SizeMB:      1620,
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3-turbo.bin",
+		FileName:    "ggml-large-v3-turbo.bin",
+	},
+	TinyEn: {
+		Name:        TinyEn,
+		Description: "Tiny, English-only (75 MB)",
+		SizeMB:      75,
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-tiny.en.bin",
+		FileName:    "ggml-tiny.en.bin",
+		EnglishOnly: true,
+	},
+	BaseEn: {
+		Name:        BaseEn,
+		Description: "Base, English-only (142 MB)",
+		SizeMB:      142,
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-base.en.bin",
+		FileName:    "ggml-base.en.bin",
+		EnglishOnly: true,
+	},
+	SmallEn: {
+		Name:        SmallEn,
+		Description: "Small, English-only (466 MB)",
+		SizeMB:      466,
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-small.en.bin",
+		FileName:    "ggml-small.en.bin",
+		EnglishOnly: true,
+	},
+	MediumEn: {
+		Name:        MediumEn,
+		Description: "Medium, English-only (1.5 GB)",
+		SizeMB:      1500,
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium.en.bin",
+		FileName:    "ggml-medium.en.bin",
+		EnglishOnly: true,
+	},
+	TinyQ5_1: {
+		Name:        TinyQ5_1,
+		Description: "Tiny, 5-bit quantized (31 MB)",
+		SizeMB:      31,
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-tiny-q5_1.bin",
+		FileName:    "ggml-tiny-q5_1.bin",
+		Quantized:   true,
+	},
+	TinyQ8_0: {
+		Name:        TinyQ8_0,
+		Description: "Tiny, 8-bit quantized (42 MB)",
+		SizeMB:      42,
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-tiny-q8_0.bin",
+		FileName:    "ggml-tiny-q8_0.bin",
+		Quantized:   true,
+	},
+	BaseQ5_1: {
+		Name:        BaseQ5_1,
+		Description: "Base, 5-bit quantized (57 MB)",
+		SizeMB:      57,
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-base-q5_1.bin",
+		FileName:    "ggml-base-q5_1.bin",
+		Quantized:   true,
+	},
+	BaseQ8_0: {
+		Name:        BaseQ8_0,
+		Description: "Base, 8-bit quantized (78 MB)",
+		SizeMB:      78,
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-base-q8_0.bin",
+		FileName:    "ggml-base-q8_0.bin",
+		Quantized:   true,
+	},
+	SmallQ5_1: {
+		Name:        SmallQ5_1,
+		Description: "Small, 5-bit quantized (181 MB)",
+		SizeMB:      181,
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-small-q5_1.bin",
+		FileName:    "ggml-small-q5_1.bin",
+		Quantized:   true,
+	},
+	SmallQ8_0: {
+		Name:        SmallQ8_0,
+		Description: "Small, 8-bit quantized (252 MB)",
+		SizeMB:      252,
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-small-q8_0.bin",
+		FileName:    "ggml-small-q8_0.bin",
+		Quantized:   true,
+	},
+	MediumQ5_0: {
+		Name:        MediumQ5_0,
+		Description: "Medium, 5-bit quantized (514 MB)",
+		SizeMB:      514,
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium-q5_0.bin",
+		FileName:    "ggml-medium-q5_0.bin",
+		Quantized:   true,
+	},
+	MediumQ8_0: {
+		Name:        MediumQ8_0,
+		Description: "Medium, 8-bit quantized (785 MB)",
+		SizeMB:      785,
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium-q8_0.bin",
+		FileName:    "ggml-medium-q8_0.bin",
+		Quantized:   true,
+	},
+	LargeQ5_0: {
+		Name:        LargeQ5_0,
+		Description: "Large v3, 5-bit quantized (1.1 GB)",
+		SizeMB:      1080,
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3-q5_0.bin",
+		FileName:    "ggml-large-v3-q5_0.bin",
+		Quantized:   true,
+	},
 }
 
 // GetModelPath returns the full path to a model file
@@ -105,11 +264,20 @@ func IsModelDownloaded(modelName ModelSize) (bool, error) {
 	return true, nil
 }
 
-// ListDownloadedModels returns a list of models that are downloaded
+// OrderedModels returns every Whisper model in display order:
+// full-precision, then English-only, then quantized
+func OrderedModels() []ModelSize {
+	ordered := make([]ModelSize, 0, len(ModelOrder)+len(EnglishModelOrder)+len(QuantizedModelOrder))
+	ordered = append(ordered, ModelOrder...)
+	ordered = append(ordered, EnglishModelOrder...)
+	return append(ordered, QuantizedModelOrder...)
+}
+
+// ListDownloadedModels returns a list of models that are downloaded, in display order
 func ListDownloadedModels() ([]ModelSize, error) {
 	var downloaded []ModelSize
 
-	for modelName := range AvailableModels {
+	for _, modelName := range OrderedModels() {
 		isDownloaded, err := IsModelDownloaded(modelName)
 		if err != nil {
 			return nil, err
@@ -122,13 +290,118 @@ func ListDownloadedModels() ([]ModelSize, error) {
 	return downloaded, nil
 }
 
-// ValidateModel checks if a downloaded model file is valid
-func ValidateModel(modelName ModelSize) error {
+// DeleteModel removes a downloaded model file
+func DeleteModel(modelName ModelSize) error {
 	modelPath, err := GetModelPath(modelName)
 	if err != nil {
 		return err
 	}
 
+	if err := os.Remove(modelPath); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("model is not downloaded: %s", modelName)
+		}
+		return fmt.Errorf("failed to delete model: %w", err)
+	}
+	_ = os.Remove(modelPath + checksumSuffix)
+
+	return nil
+}
+
+// ModelStatus describes a model and whether it is downloaded, for listings and JSON output
+type ModelStatus struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	SizeMB      int    `json:"size_mb"`
+	Downloaded  bool   `json:"downloaded"`
+	EnglishOnly bool   `json:"english_only"`
+	Quantized   bool   `json:"quantized"`
+}
+
+// ListModelStatus returns every Whisper model with its download status, in display order
+func ListModelStatus() ([]ModelStatus, error) {
+	ordered := OrderedModels()
+	statuses := make([]ModelStatus, 0, len(ordered))
+	for _, name := range ordered {
+		downloaded, err := IsModelDownloaded(name)
+		if err != nil {
+			return nil, err
+		}
+		info := AvailableModels[name]
+		statuses = append(statuses, ModelStatus{
+			Name:        string(name),
+			Description: info.Description,
+			SizeMB:      info.SizeMB,
+			Downloaded:  downloaded,
+			EnglishOnly: info.EnglishOnly,
+			Quantized:   info.Quantized,
+		})
+	}
+	return statuses, nil
+}
+
+// VerifyResult is the outcome of verifying one downloaded model
+type VerifyResult struct {
+	Model    ModelSize
+	Size     int64 // File size in bytes (0 if the file is missing)
+	Err      error // nil if the model passed validation
+	SizeOnly bool  // No checksum is known, so only the file size was checked
+}
+
+// VerifyModels fully validates each model, including its checksum when known.
+// Models without a known checksum are only checked by size and marked SizeOnly.
+func VerifyModels(modelNames []ModelSize) []VerifyResult {
+	return verifyModels(modelNames, func(m ModelSize) error {
+		return ValidateModel(m, true)
+	})
+}
+
+// verifyModels runs validate on each model and collects the results with file sizes
+func verifyModels(modelNames []ModelSize, validate func(ModelSize) error) []VerifyResult {
+	results := make([]VerifyResult, 0, len(modelNames))
+	for _, modelName := range modelNames {
+		result := VerifyResult{
+			Model:    modelName,
+			Err:      validate(modelName),
+			SizeOnly: ExpectedChecksum(modelName) == "",
+		}
+		if modelPath, err := GetModelPath(modelName); err == nil {
+			if info, statErr := os.Stat(modelPath); statErr == nil {
+				result.Size = info.Size()
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// CountFailed returns how many verification results failed
+func CountFailed(results []VerifyResult) int {
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// ValidateModel checks if a downloaded model file is valid.
+// With verifyHash set, it also compares the file's SHA256 against the known checksum;
+// hashing a multi-GB model is slow, so callers on the hot path pass false for a size-only check.
+func ValidateModel(modelName ModelSize, verifyHash bool) error {
+	modelPath, err := GetModelPath(modelName)
+	if err != nil {
+		return err
+	}
+
+	info := AvailableModels[modelName]
+	info.SHA256 = ExpectedChecksum(modelName)
+	return validateModelFile(modelPath, info, verifyHash)
+}
+
+// validateModelFile checks a model file's size and, optionally, its checksum
+func validateModelFile(modelPath string, modelInfo ModelInfo, verifyHash bool) error {
 	// Check if file exists
 	info, err := os.Stat(modelPath)
 	if err != nil {
@@ -140,8 +413,7 @@ func ValidateModel(modelName ModelSize) error {
 		return fmt.Errorf("model file is empty")
 	}
 
-	// Optional: Check file size is reasonable (within 10% of expected)
-	modelInfo := AvailableModels[modelName]
+	// Check file size is reasonable (within 10% of expected)
 	expectedSize := int64(modelInfo.SizeMB) * 1024 * 1024
 	tolerance := expectedSize / 10 // 10% tolerance
 
@@ -150,8 +422,8 @@ func ValidateModel(modelName ModelSize) error {
 			info.Size(), expectedSize)
 	}
 
-	// Optional: Verify checksum if provided
-	if modelInfo.SHA256 != "" {
+	// Verify checksum if requested and known
+	if verifyHash && modelInfo.SHA256 != "" {
 		if err := verifyChecksum(modelPath, modelInfo.SHA256); err != nil {
 			return fmt.Errorf("checksum verification failed: %w", err)
 		}
@@ -184,11 +456,24 @@ func verifyChecksum(filePath, expectedChecksum string) error {
 	return nil
 }
 
+// IsEnglishOnly reports whether the model only transcribes English
+func (m ModelSize) IsEnglishOnly() bool {
+	return AvailableModels[m].EnglishOnly
+}
+
 // ParseModelSize converts a string to a ModelSize
 func ParseModelSize(s string) (ModelSize, error) {
 	model := ModelSize(s)
 	if _, ok := AvailableModels[model]; !ok {
-		return "", fmt.Errorf("invalid model size: %s (must be one of: tiny, base, small, medium, large)", s)
+		return "", fmt.Errorf("invalid model size: %s (must be one of: tiny, base, small, medium, large-turbo, large, an English-only variant such as base.en, or a quantized variant such as small-q5_1; see 'openscribe models list')", s)
 	}
 	return model, nil
 }
+
+// ParseModelSizeOrDefault is ParseModelSize, with an empty string meaning DefaultModel
+func ParseModelSizeOrDefault(s string) (ModelSize, error) {
+	if s == "" {
+		return DefaultModel, nil
+	}
+	return ParseModelSize(s)
+}
@@ -1,6 +1,10 @@
 package models
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -16,6 +20,12 @@ func TestParseModelSize(t *testing.T) {
 		{"Valid small", "small", Small, false},
 		{"Valid medium", "medium", Medium, false},
 		{"Valid large", "large", Large, false},
+		{"Valid large turbo", "large-turbo", LargeTurbo, false},
+		{"Valid English-only base", "base.en", BaseEn, false},
+		{"No English-only large", "large.en", "", true},
+		{"Valid quantized small", "small-q5_1", SmallQ5_1, false},
+		{"Valid quantized large", "large-q5_0", LargeQ5_0, false},
+		{"Unpublished quantization", "small-q5_0", "", true},
 		{"Invalid model", "invalid", "", true},
 		{"Empty string", "", "", true},
 		{"Case sensitive", "SMALL", "", true},
@@ -35,9 +45,34 @@ func TestParseModelSize(t *testing.T) {
 	}
 }
 
+func TestParseModelSizeOrDefault(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ModelSize
+		wantErr bool
+	}{
+		{"", Small, false},
+		{"base", Base, false},
+		{"medium", Medium, false},
+		{"small-q5_1", SmallQ5_1, false},
+		{"huge", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseModelSizeOrDefault(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseModelSizeOrDefault(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseModelSizeOrDefault(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
 func TestAvailableModels(t *testing.T) {
 	// Test that all expected models are available
-	expectedModels := []ModelSize{Tiny, Base, Small, Medium, Large}
+	expectedModels := append(append(append([]ModelSize{}, ModelOrder...), EnglishModelOrder...), QuantizedModelOrder...)
 
 	for _, model := range expectedModels {
 		t.Run(string(model), func(t *testing.T) {
@@ -113,3 +148,207 @@ func TestEstimateTimeRemaining(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateModelFile_Checksum(t *testing.T) {
+	// testdata/checksum_fixture.bin contains "openscribe checksum fixture\n"
+	const fixture = "testdata/checksum_fixture.bin"
+	const fixtureSHA256 = "e6cde6200b9ceb8467b2e838e90453c7cb15f01fdf3c393c241cf121202606cb"
+	const wrongSHA256 = "0000000000000000000000000000000000000000000000000000000000000000"
+
+	tests := []struct {
+		name       string
+		checksum   string
+		verifyHash bool
+		wantErr    bool
+	}{
+		{"matching checksum", fixtureSHA256, true, false},
+		{"mismatched checksum", wrongSHA256, true, true},
+		{"mismatch ignored by size-only check", wrongSHA256, false, false},
+		{"no known checksum", "", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := ModelInfo{Name: "fixture", SHA256: tt.checksum}
+			err := validateModelFile(fixture, info, tt.verifyHash)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateModelFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeleteModel(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	modelPath, err := GetModelPath(Tiny)
+	if err != nil {
+		t.Fatalf("GetModelPath() error: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(modelPath), 0755); err != nil {
+		t.Fatalf("failed to create models dir: %v", err)
+	}
+	if err := os.WriteFile(modelPath, []byte("fake model"), 0644); err != nil {
+		t.Fatalf("failed to write fake model: %v", err)
+	}
+
+	if err := DeleteModel(Tiny); err != nil {
+		t.Fatalf("DeleteModel() error: %v", err)
+	}
+	if downloaded, _ := IsModelDownloaded(Tiny); downloaded {
+		t.Error("model still downloaded after DeleteModel()")
+	}
+
+	// Deleting again reports that the model isn't downloaded
+	if err := DeleteModel(Tiny); err == nil {
+		t.Error("DeleteModel() on a missing model expected error, got nil")
+	}
+	if err := DeleteModel(ModelSize("huge")); err == nil {
+		t.Error("DeleteModel() with unknown model expected error, got nil")
+	}
+}
+
+func TestModelOrder_CoversAvailableModels(t *testing.T) {
+	listed := make(map[ModelSize]bool)
+	for _, model := range ModelOrder {
+		listed[model] = true
+		if AvailableModels[model].Quantized {
+			t.Errorf("ModelOrder contains quantized model %s", model)
+		}
+	}
+	for _, model := range EnglishModelOrder {
+		listed[model] = true
+		if !model.IsEnglishOnly() {
+			t.Errorf("EnglishModelOrder contains multilingual model %s", model)
+		}
+	}
+	for _, model := range QuantizedModelOrder {
+		listed[model] = true
+		if !AvailableModels[model].Quantized {
+			t.Errorf("QuantizedModelOrder contains full-precision model %s", model)
+		}
+	}
+
+	for model := range AvailableModels {
+		if !listed[model] {
+			t.Errorf("model %s is missing from ModelOrder/EnglishModelOrder/QuantizedModelOrder", model)
+		}
+	}
+}
+
+func TestGetModelPath_Quantized(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	path, err := GetModelPath(SmallQ5_1)
+	if err != nil {
+		t.Fatalf("GetModelPath() error: %v", err)
+	}
+	if filepath.Base(path) != "ggml-small-q5_1.bin" {
+		t.Errorf("GetModelPath(%s) = %s, want file ggml-small-q5_1.bin", SmallQ5_1, path)
+	}
+}
+
+func TestVerifyModels_Aggregation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Only the tiny model file exists on disk, so only it reports a size
+	modelPath, err := GetModelPath(Tiny)
+	if err != nil {
+		t.Fatalf("GetModelPath() error: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(modelPath), 0755); err != nil {
+		t.Fatalf("failed to create models dir: %v", err)
+	}
+	if err := os.WriteFile(modelPath, []byte("fake model"), 0644); err != nil {
+		t.Fatalf("failed to write fake model: %v", err)
+	}
+
+	// Only the tiny model has a recorded checksum
+	if err := saveChecksum(modelPath, strings.Repeat("a", 64)); err != nil {
+		t.Fatalf("saveChecksum() error: %v", err)
+	}
+
+	validate := func(m ModelSize) error {
+		if m == Base {
+			return os.ErrNotExist
+		}
+		return nil
+	}
+
+	results := verifyModels([]ModelSize{Tiny, Base, Small}, validate)
+	if len(results) != 3 {
+		t.Fatalf("verifyModels() returned %d results, want 3", len(results))
+	}
+
+	for i, want := range []ModelSize{Tiny, Base, Small} {
+		if results[i].Model != want {
+			t.Errorf("results[%d].Model = %s, want %s", i, results[i].Model, want)
+		}
+	}
+	if results[0].Size != int64(len("fake model")) {
+		t.Errorf("results[0].Size = %d, want %d", results[0].Size, len("fake model"))
+	}
+	if results[1].Err == nil || results[0].Err != nil || results[2].Err != nil {
+		t.Errorf("errors = [%v %v %v], want only base to fail", results[0].Err, results[1].Err, results[2].Err)
+	}
+
+	if results[0].SizeOnly || !results[2].SizeOnly {
+		t.Errorf("SizeOnly = [%t %t %t], want only models without a checksum size-only", results[0].SizeOnly, results[1].SizeOnly, results[2].SizeOnly)
+	}
+
+	if failed := CountFailed(results); failed != 1 {
+		t.Errorf("CountFailed() = %d, want 1", failed)
+	}
+	if failed := CountFailed(results[:1]); failed != 0 {
+		t.Errorf("CountFailed() for passing results = %d, want 0", failed)
+	}
+}
+
+func TestListModelStatus_JSON(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	modelPath, err := GetModelPath(Base)
+	if err != nil {
+		t.Fatalf("GetModelPath() error: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(modelPath), 0755); err != nil {
+		t.Fatalf("failed to create models dir: %v", err)
+	}
+	if err := os.WriteFile(modelPath, []byte("fake model"), 0644); err != nil {
+		t.Fatalf("failed to write fake model: %v", err)
+	}
+
+	statuses, err := ListModelStatus()
+	if err != nil {
+		t.Fatalf("ListModelStatus() error: %v", err)
+	}
+	if len(statuses) != len(AvailableModels) {
+		t.Errorf("ListModelStatus() returned %d models, want %d", len(statuses), len(AvailableModels))
+	}
+
+	data, err := json.Marshal(statuses)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("models list is not a JSON array of objects: %v", err)
+	}
+
+	for _, key := range []string{"name", "description", "size_mb", "downloaded"} {
+		if _, ok := decoded[0][key]; !ok {
+			t.Errorf("JSON object missing %q: %v", key, decoded[0])
+		}
+	}
+
+	if decoded[0]["name"] != string(Tiny) {
+		t.Errorf("first model = %v, want %s", decoded[0]["name"], Tiny)
+	}
+	for _, m := range decoded {
+		wantDownloaded := m["name"] == string(Base)
+		if m["downloaded"] != wantDownloaded {
+			t.Errorf("%v downloaded = %v, want %t", m["name"], m["downloaded"], wantDownloaded)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -20,6 +21,9 @@ const (
 	MoonshineMediumStreaming MoonshineModelSize = "medium-streaming"
 )
 
+// MoonshineModelOrder lists the Moonshine models from smallest to largest
+var MoonshineModelOrder = []MoonshineModelSize{MoonshineTiny, MoonshineBase, MoonshineSmallStreaming, MoonshineMediumStreaming}
+
 // MoonshineModelInfo contains metadata about a Moonshine model
 type MoonshineModelInfo struct {
 	Name        MoonshineModelSize
@@ -105,6 +109,24 @@ func ListDownloadedMoonshineModels() ([]MoonshineModelSize, error) {
 	return downloaded, nil
 }
 
+// ListMoonshineModelStatus returns every Moonshine model with its download status, in display order
+func ListMoonshineModelStatus() ([]ModelStatus, error) {
+	statuses := make([]ModelStatus, 0, len(MoonshineModelOrder))
+	for _, name := range MoonshineModelOrder {
+		downloaded, err := IsMoonshineModelDownloaded(name)
+		if err != nil {
+			return nil, err
+		}
+		info := AvailableMoonshineModels[name]
+		statuses = append(statuses, ModelStatus{
+			Name:        string(name),
+			Description: info.Description,
+			Downloaded:  downloaded,
+		})
+	}
+	return statuses, nil
+}
+
 // ParseMoonshineModelSize converts a string to a MoonshineModelSize
 func ParseMoonshineModelSize(s string) (MoonshineModelSize, error) {
 	model := MoonshineModelSize(s)
@@ -118,8 +140,9 @@ func ParseMoonshineModelSize(s string) (MoonshineModelSize, error) {
 	return model, nil
 }
 
-// DownloadMoonshineModel downloads model files directly from download.moonshine.ai
-func DownloadMoonshineModel(modelName MoonshineModelSize, progress ProgressCallback) error {
+// DownloadMoonshineModel downloads model files directly from download.moonshine.ai.
+// Cancelling ctx stops the download and returns ctx.Err().
+func DownloadMoonshineModel(ctx context.Context, modelName MoonshineModelSize, progress ProgressCallback) error {
 	info, ok := AvailableMoonshineModels[modelName]
 	if !ok {
 		return fmt.Errorf("unknown moonshine model: %s", modelName)
@@ -137,7 +160,10 @@ func DownloadMoonshineModel(modelName MoonshineModelSize, progress ProgressCallb
 	for _, fileName := range info.RequiredFiles {
 		url := info.BaseURL + fileName
 		destPath := filepath.Join(modelDir, fileName)
-		if err := downloadFile(url, destPath, progress); err != nil {
+		if err := downloadFile(ctx, url, destPath, progress); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
 			return fmt.Errorf("failed to download %s: %w", fileName, err)
 		}
 	}
@@ -0,0 +1,238 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// MaxConnections is the most parallel connections a download may use
+const MaxConnections = 16
+
+// minRangeSize keeps small files from being split into tiny ranges
+const minRangeSize = 1024 * 1024
+
+// errRangesUnsupported means the server can't serve byte ranges, so the file must be fetched in one stream
+var errRangesUnsupported = errors.New("server does not support range requests")
+
+// byteRange is an inclusive range of byte offsets, as in a Range header
+type byteRange struct {
+	start, end int64
+}
+
+// splitRanges divides size bytes into at most parts contiguous ranges of near-equal
+// length (the first ranges take the remainder), each at least minRange bytes long
+// unless the whole file is smaller
+func splitRanges(size int64, parts int, minRange int64) []byteRange {
+	if size <= 0 {
+		return nil
+	}
+	if minRange > 0 {
+		parts = int(min(int64(parts), max(size/minRange, 1)))
+	}
+	parts = int(max(min(int64(parts), size), 1))
+
+	ranges := make([]byteRange, 0, parts)
+	chunk, remainder := size/int64(parts), size%int64(parts)
+	var start int64
+	for i := 0; i < parts; i++ {
+		length := chunk
+		if int64(i) < remainder {
+			length++
+		}
+		ranges = append(ranges, byteRange{start: start, end: start + length - 1})
+		start += length
+	}
+	return ranges
+}
+
+// probeRanges asks the server for the file size and whether it serves byte ranges
+func probeRanges(ctx context.Context, client *http.Client, url string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+	}
+	if !strings.EqualFold(resp.Header.Get("Accept-Ranges"), "bytes") || resp.ContentLength <= 0 {
+		return 0, errRangesUnsupported
+	}
+	return resp.ContentLength, nil
+}
+
+// downloadParallel downloads url into tempFile over up to connections concurrent range
+// requests, falling back to a single resumable stream when the server doesn't support
+// ranges or a partial single-stream download is waiting to be resumed. A failed parallel
+// download leaves gaps in the file, so it is removed rather than kept for resuming.
+func downloadParallel(ctx context.Context, url, tempFile string, connections int, progress ProgressCallback) error {
+	if _, err := os.Stat(tempFile); err == nil || connections <= 1 {
+		return downloadWithRetry(ctx, url, tempFile, progress)
+	}
+
+	client := newDownloadClient()
+	size, err := probeRanges(ctx, client, url)
+	if errors.Is(err, errRangesUnsupported) {
+		return downloadWithRetry(ctx, url, tempFile, progress)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := fetchRanges(ctx, client, url, tempFile, size, splitRanges(size, connections, minRangeSize), progress); err != nil {
+		_ = os.Remove(tempFile)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
+
+// fetchRanges downloads each range concurrently into its place in tempFile,
+// reporting the combined progress. The first failure cancels the other ranges.
+func fetchRanges(ctx context.Context, client *http.Client, url, tempFile string, size int64, ranges []byteRange, progress ProgressCallback) error {
+	out, err := os.OpenFile(tempFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open temporary file: %w", err)
+	}
+	defer func() {
+		_ = out.Close() // Errors surface through the writes
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var downloaded atomic.Int64
+	reportDone := make(chan struct{})
+	if progress != nil {
+		go reportProgress(ctx, &downloaded, size, progress, reportDone)
+	} else {
+		close(reportDone)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(ranges))
+	for _, r := range ranges {
+		wg.Add(1)
+		go func(r byteRange) {
+			defer wg.Done()
+			if err := fetchRange(ctx, client, url, io.NewOffsetWriter(out, r.start), r, &downloaded); err != nil {
+				errs <- err
+				cancel()
+			}
+		}(r)
+	}
+	wg.Wait()
+	cancel()
+	<-reportDone
+	close(errs)
+
+	// Report the first real failure, not the cancellations it caused
+	var firstErr error
+	for err := range errs {
+		if firstErr == nil || (errors.Is(firstErr, context.Canceled) && !errors.Is(err, context.Canceled)) {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		if errors.Is(firstErr, ErrDiskFull) {
+			return diskFullError(downloaded.Load(), size)
+		}
+		return firstErr
+	}
+
+	if progress != nil {
+		progress(size, size, 100, 0)
+	}
+	return out.Close()
+}
+
+// fetchRange downloads one byte range into out, adding the bytes written to downloaded
+func fetchRange(ctx context.Context, client *http.Client, url string, out io.Writer, r byteRange, downloaded *atomic.Int64) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", r.start, r.end))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close() // Best effort close
+	}()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("range %d-%d: HTTP %d: %s", r.start, r.end, resp.StatusCode, resp.Status)
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Range"), "bytes "+strconv.FormatInt(r.start, 10)+"-") {
+		return fmt.Errorf("range %d-%d: server returned %q", r.start, r.end, resp.Header.Get("Content-Range"))
+	}
+
+	want := r.end - r.start + 1
+	buf := make([]byte, 32*1024)
+	var written int64
+	for written < want {
+		n, readErr := resp.Body.Read(buf[:min(int64(len(buf)), want-written)])
+		if n > 0 {
+			wn, err := out.Write(buf[:n])
+			written += int64(wn)
+			downloaded.Add(int64(wn))
+			if err != nil {
+				if errors.Is(err, syscall.ENOSPC) {
+					return ErrDiskFull
+				}
+				return fmt.Errorf("failed to write file: %w", err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+
+	if written != want {
+		return fmt.Errorf("range %d-%d: got %d of %d bytes", r.start, r.end, written, want)
+	}
+	return nil
+}
+
+// reportProgress calls progress with the combined byte count every 100ms until ctx is done
+func reportProgress(ctx context.Context, downloaded *atomic.Int64, total int64, progress ProgressCallback, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	speed := newSpeedEstimator(speedWindow)
+	speed.add(time.Now(), 0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n := downloaded.Load()
+			speed.add(now, n)
+			progress(n, total, float64(n)/float64(total)*100.0, speed.rate())
+		}
+	}
+}
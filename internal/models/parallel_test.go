@@ -0,0 +1,166 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSplitRanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int64
+		parts    int
+		minRange int64
+		want     []byteRange
+	}{
+		{"even split", 100, 4, 0, []byteRange{{0, 24}, {25, 49}, {50, 74}, {75, 99}}},
+		{"remainder goes to first ranges", 10, 3, 0, []byteRange{{0, 3}, {4, 6}, {7, 9}}},
+		{"single part", 10, 1, 0, []byteRange{{0, 9}}},
+		{"zero parts", 10, 0, 0, []byteRange{{0, 9}}},
+		{"more parts than bytes", 3, 8, 0, []byteRange{{0, 0}, {1, 1}, {2, 2}}},
+		{"limited by minimum range", 100, 8, 30, []byteRange{{0, 33}, {34, 66}, {67, 99}}},
+		{"smaller than minimum range", 10, 4, 30, []byteRange{{0, 9}}},
+		{"empty", 0, 4, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitRanges(tt.size, tt.parts, tt.minRange)
+			if len(got) != len(tt.want) {
+				t.Fatalf("splitRanges(%d, %d, %d) = %v, want %v", tt.size, tt.parts, tt.minRange, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("splitRanges(%d, %d, %d) = %v, want %v", tt.size, tt.parts, tt.minRange, got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+// rangeServer serves payload with http.ServeContent (which honors Range and sets
+// Accept-Ranges) and records the Range header of each GET
+func rangeServer(t *testing.T, payload []byte) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var ranges []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			mu.Lock()
+			ranges = append(ranges, r.Header.Get("Range"))
+			mu.Unlock()
+		}
+		http.ServeContent(w, r, "model.bin", time.Time{}, bytes.NewReader(payload))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), ranges...)
+	}
+}
+
+func TestDownloadParallel_ReassemblesRanges(t *testing.T) {
+	// Large enough for four ranges of at least minRangeSize
+	payload := bytes.Repeat([]byte("0123456789abcdef"), 4*minRangeSize/16+123)
+	server, requested := rangeServer(t, payload)
+	tempFile := filepath.Join(t.TempDir(), "model.bin.tmp")
+
+	var lastDownloaded, lastTotal atomic.Int64
+	progress := func(downloaded, total int64, _, _ float64) {
+		lastDownloaded.Store(downloaded)
+		lastTotal.Store(total)
+	}
+
+	if err := downloadParallel(context.Background(), server.URL, tempFile, 4, progress); err != nil {
+		t.Fatalf("downloadParallel() error: %v", err)
+	}
+
+	got, err := os.ReadFile(tempFile)
+	if err != nil {
+		t.Fatalf("failed to read download: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Errorf("reassembled file has %d bytes, want %d matching bytes", len(got), len(payload))
+	}
+
+	ranges := requested()
+	if len(ranges) != 4 {
+		t.Errorf("server received %d GET requests (%v), want 4", len(ranges), ranges)
+	}
+	for _, r := range ranges {
+		if !strings.HasPrefix(r, "bytes=") {
+			t.Errorf("request Range header = %q, want a byte range", r)
+		}
+	}
+
+	if lastDownloaded.Load() != int64(len(payload)) || lastTotal.Load() != int64(len(payload)) {
+		t.Errorf("final progress = %d/%d, want %d/%d", lastDownloaded.Load(), lastTotal.Load(), len(payload), len(payload))
+	}
+}
+
+func TestDownloadParallel_FallsBackWithoutRangeSupport(t *testing.T) {
+	payload := testPayload()
+	var requests atomic.Int32
+
+	// No Accept-Ranges header, and Range requests are ignored
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(payload)
+		}
+	}))
+	defer server.Close()
+
+	tempFile := filepath.Join(t.TempDir(), "model.bin.tmp")
+	if err := downloadParallel(context.Background(), server.URL, tempFile, 4, nil); err != nil {
+		t.Fatalf("downloadParallel() error: %v", err)
+	}
+
+	got, err := os.ReadFile(tempFile)
+	if err != nil {
+		t.Fatalf("failed to read download: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Errorf("downloaded file has %d bytes, want %d matching bytes", len(got), len(payload))
+	}
+	// One HEAD probe, then a single full GET
+	if n := requests.Load(); n != 2 {
+		t.Errorf("server received %d requests, want 2", n)
+	}
+}
+
+func TestDownloadParallel_RemovesFileOnRangeFailure(t *testing.T) {
+	payload := bytes.Repeat([]byte("x"), 2*minRangeSize)
+
+	// Advertise ranges but fail the second half
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && !strings.HasPrefix(r.Header.Get("Range"), "bytes=0-") {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		http.ServeContent(w, r, "model.bin", time.Time{}, bytes.NewReader(payload))
+	}))
+	defer server.Close()
+
+	tempFile := filepath.Join(t.TempDir(), "model.bin.tmp")
+	err := downloadParallel(context.Background(), server.URL, tempFile, 2, nil)
+	if err == nil || !strings.Contains(err.Error(), "HTTP 500") {
+		t.Fatalf("downloadParallel() error = %v, want an HTTP 500 error", err)
+	}
+	if _, statErr := os.Stat(tempFile); !os.IsNotExist(statErr) {
+		t.Errorf("temporary file should be removed after a failed parallel download, stat error: %v", statErr)
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// progressBarWidth is the number of characters between the brackets of a progress bar
+const progressBarWidth = 40
+
+// RenderProgressBar renders one line of download progress from a ProgressCallback's
+// arguments, e.g. "[=====>    ] 45.2% - 210.3 MB / 465.0 MB - 12.1 MB/s - ETA: 21s".
+// When the total size is unknown (0) it shows the amount downloaded so far instead.
+func RenderProgressBar(downloaded, total int64, percent, bytesPerSecond float64) string {
+	speed := FormatSpeed(bytesPerSecond)
+	if total <= 0 {
+		return fmt.Sprintf("[%s] %s - %s", progressBar(0), FormatBytes(downloaded), speed)
+	}
+
+	return fmt.Sprintf("[%s] %.1f%% - %s / %s - %s - ETA: %s",
+		progressBar(percent), percent, FormatBytes(downloaded), FormatBytes(total), speed,
+		EstimateTimeRemaining(downloaded, total, bytesPerSecond))
+}
+
+// progressBar draws the inside of a progress bar filled to percent, with a ">" at the
+// leading edge until it is full
+func progressBar(percent float64) string {
+	filled := 0
+	if percent > 0 { // Also false for NaN
+		filled = min(int(percent/100.0*progressBarWidth), progressBarWidth)
+	}
+
+	if filled == progressBarWidth {
+		return strings.Repeat("=", progressBarWidth)
+	}
+	return strings.Repeat("=", filled) + ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		percent float64
+		filled  int
+		arrow   bool
+	}{
+		{0, 0, true},
+		{2.4, 0, true},
+		{2.5, 1, true},
+		{50, 20, true},
+		{99.9, 39, true},
+		{100, 40, false},
+		{150, 40, false},
+		{-10, 0, true},
+		{math.NaN(), 0, true},
+	}
+
+	for _, tt := range tests {
+		bar := progressBar(tt.percent)
+		if len(bar) != progressBarWidth {
+			t.Errorf("progressBar(%v) width = %d, want %d", tt.percent, len(bar), progressBarWidth)
+		}
+		if got := strings.Count(bar, "="); got != tt.filled {
+			t.Errorf("progressBar(%v) = %q, %d filled, want %d", tt.percent, bar, got, tt.filled)
+		}
+		if got := strings.Contains(bar, ">"); got != tt.arrow {
+			t.Errorf("progressBar(%v) = %q, arrow = %v, want %v", tt.percent, bar, got, tt.arrow)
+		}
+	}
+}
+
+func TestRenderProgressBar(t *testing.T) {
+	got := RenderProgressBar(250*1024*1024, 500*1024*1024, 50, 10*1024*1024)
+	want := "[" + strings.Repeat("=", 20) + ">" + strings.Repeat(" ", 19) + "] 50.0% - 250.0 MB / 500.0 MB - 10.0 MB/s - ETA: 25s"
+	if got != want {
+		t.Errorf("RenderProgressBar() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderProgressBar_PercentFormatting(t *testing.T) {
+	tests := []struct {
+		percent float64
+		want    string
+	}{
+		{0, "] 0.0% -"},
+		{33.333, "] 33.3% -"},
+		{99.96, "] 100.0% -"},
+		{100, "] 100.0% -"},
+	}
+
+	for _, tt := range tests {
+		if got := RenderProgressBar(1, 100, tt.percent, 0); !strings.Contains(got, tt.want) {
+			t.Errorf("RenderProgressBar(percent %v) = %q, want it to contain %q", tt.percent, got, tt.want)
+		}
+	}
+}
+
+func TestRenderProgressBar_UnknownTotal(t *testing.T) {
+	got := RenderProgressBar(3*1024*1024, 0, 0, 1024)
+
+	want := "[>" + strings.Repeat(" ", progressBarWidth-1) + "] 3.0 MB - 1.0 KB/s"
+	if got != want {
+		t.Errorf("RenderProgressBar() with unknown total = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"fmt"
+	"runtime"
+)
+
+const gib = 1 << 30
+
+// RecommendModel suggests a Whisper model for this machine from its CPU cores and
+// total memory, with a short rationale such as
+// "8 GB RAM and 8 CPU cores detected, recommending small"
+func RecommendModel() (ModelSize, string) {
+	return recommendModel(runtime.NumCPU(), totalMemory())
+}
+
+// recommendModel picks the largest model the machine runs comfortably. memory is the
+// total RAM in bytes (0 = unknown, decided by cores alone). Large is never recommended:
+// large-turbo is nearly as accurate and much faster.
+func recommendModel(cores int, memory uint64) (ModelSize, string) {
+	var model ModelSize
+	switch {
+	case memory == 0 && cores <= 4:
+		model = Base
+	case memory == 0:
+		model = Small
+	case memory < 4*gib:
+		model = Tiny
+	case memory < 8*gib:
+		model = Base
+	case memory < 16*gib:
+		model = Small
+	case cores < 8:
+		model = Medium
+	default:
+		model = LargeTurbo
+	}
+
+	// Larger models are slow on very few cores, whatever the memory
+	if cores <= 2 && (model == Small || model == Medium || model == LargeTurbo) {
+		model = Base
+	}
+
+	detected := fmt.Sprintf("%d CPU cores", cores)
+	if cores == 1 {
+		detected = "1 CPU core"
+	}
+	if memory > 0 {
+		detected = fmt.Sprintf("%d GB RAM and %s", (memory+gib/2)/gib, detected)
+	}
+	return model, fmt.Sprintf("%s detected, recommending %s", detected, model)
+}
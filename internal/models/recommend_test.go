@@ -0,0 +1,49 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecommendModel(t *testing.T) {
+	tests := []struct {
+		name       string
+		cores      int
+		memory     uint64
+		want       ModelSize
+		wantReason string
+	}{
+		{"2 GB", 4, 2 * gib, Tiny, "2 GB RAM and 4 CPU cores detected, recommending tiny"},
+		{"4 GB", 4, 4 * gib, Base, "4 GB RAM"},
+		{"8 GB MacBook Air", 8, 8 * gib, Small, "8 GB RAM and 8 CPU cores detected, recommending small"},
+		{"16 GB, few cores", 4, 16 * gib, Medium, "recommending medium"},
+		{"16 GB, 8 cores", 8, 16 * gib, LargeTurbo, "recommending large-turbo"},
+		{"64 GB workstation", 24, 64 * gib, LargeTurbo, "64 GB RAM and 24 CPU cores"},
+		{"lots of RAM, 2 cores", 2, 32 * gib, Base, "recommending base"},
+		{"single core", 1, 8 * gib, Base, "8 GB RAM and 1 CPU core detected"},
+		{"unknown memory, 4 cores", 4, 0, Base, "4 CPU cores detected, recommending base"},
+		{"unknown memory, 10 cores", 10, 0, Small, "10 CPU cores detected, recommending small"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := recommendModel(tt.cores, tt.memory)
+			if got != tt.want {
+				t.Errorf("recommendModel(%d, %d) = %s, want %s", tt.cores, tt.memory, got, tt.want)
+			}
+			if !strings.Contains(reason, tt.wantReason) {
+				t.Errorf("reason = %q, want it to contain %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestRecommendModel_IsAvailable(t *testing.T) {
+	model, reason := RecommendModel()
+	if _, ok := AvailableModels[model]; !ok {
+		t.Errorf("RecommendModel() = %s, not an available model", model)
+	}
+	if reason == "" {
+		t.Error("RecommendModel() returned an empty rationale")
+	}
+}
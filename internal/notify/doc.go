@@ -0,0 +1,13 @@
+// Package notify shows desktop notifications.
+//
+// On macOS notifications are delivered through Notification Center; on other
+// platforms Send returns ErrUnsupported. Callers treat notifications as
+// best-effort and ignore failures.
+//
+// Example usage:
+//
+//	var n notify.Notifier = notify.System{}
+//	if err := n.Send("OpenScribe", "Transcription complete"); err != nil {
+//	    log.Printf("notification not shown: %v", err)
+//	}
+package notify
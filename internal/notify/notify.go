@@ -0,0 +1,24 @@
+package notify
+
+import "errors"
+
+// ErrUnsupported is returned by Send on platforms without desktop notifications
+var ErrUnsupported = errors.New("desktop notifications are not supported on this platform")
+
+// Notifier shows desktop notifications
+type Notifier interface {
+	Send(title, body string) error
+}
+
+// System is the Notifier backed by the operating system's notification center
+type System struct{}
+
+// Send implements Notifier
+func (System) Send(title, body string) error {
+	return Send(title, body)
+}
+
+// Send shows a notification with the given title and body
+func Send(title, body string) error {
+	return send(title, body)
+}
@@ -0,0 +1,50 @@
+//go:build darwin
+// +build darwin
+
+package notify
+
+/*
+#cgo CFLAGS: -x objective-c -Wno-deprecated-declarations
+#cgo LDFLAGS: -framework Foundation
+
+#include <stdlib.h>
+#import <Foundation/Foundation.h>
+
+// Deliver a notification through Notification Center. NSUserNotification is used
+// rather than UNUserNotificationCenter because the latter requires an app bundle,
+// and openscribe runs as a plain command-line binary.
+// Returns -1 when no notification center is available.
+static int sendNotification(const char* title, const char* body) {
+    @autoreleasepool {
+        NSUserNotificationCenter *center = [NSUserNotificationCenter defaultUserNotificationCenter];
+        if (center == nil) {
+            return -1;
+        }
+
+        NSUserNotification *notification = [[NSUserNotification alloc] init];
+        notification.title = [NSString stringWithUTF8String:title];
+        notification.informativeText = [NSString stringWithUTF8String:body];
+        [center deliverNotification:notification];
+        [notification release];
+        return 0;
+    }
+}
+*/
+import "C"
+import (
+	"fmt"
+	"unsafe"
+)
+
+// send delivers the notification through Notification Center
+func send(title, body string) error {
+	cTitle := C.CString(title)
+	defer C.free(unsafe.Pointer(cTitle))
+	cBody := C.CString(body)
+	defer C.free(unsafe.Pointer(cBody))
+
+	if C.sendNotification(cTitle, cBody) != 0 {
+		return fmt.Errorf("notification center is not available")
+	}
+	return nil
+}
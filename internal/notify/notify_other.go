@@ -0,0 +1,9 @@
+//go:build !darwin
+// +build !darwin
+
+package notify
+
+// send is unsupported outside macOS
+func send(_, _ string) error {
+	return ErrUnsupported
+}
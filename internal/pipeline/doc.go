@@ -0,0 +1,45 @@
+// Package pipeline turns a finished recording into text.
+//
+// This package handles:
+//   - Downmixing, silence trimming and gain control of the captured audio
+//   - Saving the audio to a temporary WAV file and transcribing it
+//   - Detecting recordings without speech
+//   - Formatting the text (see textproc) and pasting it at the cursor (or
+//     copying it) when a keyboard is set
+//   - Logging the transcription to the history
+//   - The hotkey-driven recording state machine (Session): start, stop on a
+//     second press, release, silence, timeout or shutdown, then transcribe
+//   - A record → transcribe round trip (RoundTrip) for "openscribe test"
+//
+// Both "openscribe start" (after a hotkey stops the recording) and
+// "openscribe record" (after a fixed duration) run the same Pipeline.
+// The recorder factory, transcriber, keyboard, feedback and logger are
+// interfaces or funcs, so the pipeline and session can be tested with fakes.
+//
+// Example usage:
+//
+//	p := &pipeline.Pipeline{
+//	    Config:      cfg,
+//	    Transcriber: transcriber,
+//	    Model:       modelSize,
+//	}
+//	data, _ := recorder.RecordDuration(10 * time.Second)
+//	result, err := p.Process(pipeline.Recording{
+//	    Audio:      data,
+//	    SampleRate: recorder.GetSampleRate(),
+//	    Channels:   recorder.GetChannels(),
+//	    Duration:   recorder.Duration().Seconds(),
+//	})
+//	if err == nil {
+//	    fmt.Println(result.Text)
+//	}
+//
+//	// Driven by a hotkey listener
+//	session := &pipeline.Session{
+//	    Pipeline:    p,
+//	    NewRecorder: func() pipeline.Recorder { return audio.NewRecorder(device.Name, cfg.AudioBackend) },
+//	    MaxDuration: 5 * time.Minute,
+//	    OnResult:    func(r *pipeline.Result, err error) { ... },
+//	}
+//	listener, _ := hotkey.NewMultiListener(cfg.Triggers, session.HandleToggle)
+package pipeline
@@ -0,0 +1,80 @@
+package pipeline
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/keyboard"
+)
+
+// OutputMode selects where transcribed text goes
+type OutputMode string
+
+const (
+	// OutputPaste inserts the text at the cursor with the Keyboard and PasteMode (default)
+	OutputPaste OutputMode = "paste"
+	// OutputClipboard only copies the text to the clipboard (needs no accessibility permissions)
+	OutputClipboard OutputMode = "clipboard"
+	// OutputStdout prints the text on its own line, for piping
+	OutputStdout OutputMode = "stdout"
+	// OutputNone leaves the text in the history only
+	OutputNone OutputMode = "none"
+)
+
+// ParseOutputMode converts a config value ("paste", "clipboard", "stdout", "none",
+// or empty for paste) to an OutputMode
+func ParseOutputMode(name string) (OutputMode, error) {
+	switch mode := OutputMode(name); mode {
+	case "":
+		return OutputPaste, nil
+	case OutputPaste, OutputClipboard, OutputStdout, OutputNone:
+		return mode, nil
+	default:
+		return OutputPaste, fmt.Errorf("invalid output mode: %s (must be paste, clipboard, stdout or none)", name)
+	}
+}
+
+// output sends text to the destination selected by mode and reports whether it got there
+func (p *Pipeline) output(mode OutputMode, text string) (bool, error) {
+	switch mode {
+	case OutputPaste:
+		if p.Keyboard == nil {
+			return false, nil
+		}
+		return true, keyboard.Insert(p.Keyboard, p.PasteMode, text)
+	case OutputClipboard:
+		copyText := p.Clipboard
+		if copyText == nil {
+			copyText = keyboard.CopyToClipboard
+		}
+		return true, copyText(text)
+	case OutputStdout:
+		var w io.Writer = os.Stdout
+		if p.Stdout != nil {
+			w = p.Stdout
+		}
+		_, err := fmt.Fprintln(w, text)
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+// waitToPaste counts down delay in steps of at most a second, printing the time left
+func (p *Pipeline) waitToPaste(out io.Writer, delay time.Duration) {
+	sleep := p.Sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	for remaining := delay; remaining > 0; {
+		step := remaining
+		if step > time.Second {
+			step = time.Second
+		}
+		fmt.Fprintf(out, "Pasting in %s...\n", remaining)
+		sleep(step)
+		remaining -= step
+	}
+}
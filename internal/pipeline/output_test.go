@@ -0,0 +1,194 @@
+package pipeline
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/transcription"
+)
+
+func TestParseOutputMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    OutputMode
+		wantErr bool
+	}{
+		{"", OutputPaste, false},
+		{"paste", OutputPaste, false},
+		{"clipboard", OutputClipboard, false},
+		{"stdout", OutputStdout, false},
+		{"none", OutputNone, false},
+		{"type", OutputPaste, true},
+		{"STDOUT", OutputPaste, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseOutputMode(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseOutputMode(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseOutputMode(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOutput(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          OutputMode
+		noKeyboard    bool
+		wantOK        bool
+		wantPasted    bool
+		wantCopied    bool
+		wantStdout    string
+		clipboardFail bool
+		wantErr       bool
+	}{
+		{name: "paste", mode: OutputPaste, wantOK: true, wantPasted: true},
+		{name: "paste without keyboard", mode: OutputPaste, noKeyboard: true},
+		{name: "clipboard", mode: OutputClipboard, wantOK: true, wantCopied: true},
+		{name: "clipboard failure", mode: OutputClipboard, wantOK: true, clipboardFail: true, wantErr: true},
+		{name: "stdout", mode: OutputStdout, wantOK: true, wantStdout: "hello world\n"},
+		{name: "none", mode: OutputNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kb := &fakeKeyboard{}
+			var copied []string
+			var stdout bytes.Buffer
+			p := &Pipeline{
+				Keyboard: kb,
+				Clipboard: func(text string) error {
+					if tt.clipboardFail {
+						return errors.New("pasteboard unavailable")
+					}
+					copied = append(copied, text)
+					return nil
+				},
+				Stdout: &stdout,
+			}
+			if tt.noKeyboard {
+				p.Keyboard = nil
+			}
+
+			ok, err := p.output(tt.mode, "hello world")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("output() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ok != tt.wantOK {
+				t.Errorf("output() = %t, want %t", ok, tt.wantOK)
+			}
+			if pasted := len(kb.pasted) > 0; pasted != tt.wantPasted {
+				t.Errorf("pasted %q, want pasted = %t", kb.pasted, tt.wantPasted)
+			}
+			if c := len(copied) > 0; c != tt.wantCopied {
+				t.Errorf("copied %q, want copied = %t", copied, tt.wantCopied)
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+		})
+	}
+}
+
+func TestProcess_OutputStdoutIsPipeable(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "line one\nline two", Language: "en"}}
+	p, kb, logged := newTestPipeline(t, transcriber)
+	var stdout bytes.Buffer
+	p.Output = OutputStdout
+	p.Stdout = &stdout
+
+	result, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1, Duration: 1})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	// Only the text, without status messages; newlines are kept unless strip_newlines is set
+	if stdout.String() != "line one\nline two\n" {
+		t.Errorf("stdout = %q, want just the text", stdout.String())
+	}
+	if !result.Pasted || len(kb.pasted) != 0 {
+		t.Errorf("Pasted = %t, keyboard got %q; want printed, not pasted", result.Pasted, kb.pasted)
+	}
+	if len(*logged) != 1 {
+		t.Errorf("logged %d entries, want 1", len(*logged))
+	}
+}
+
+func TestProcess_AutoPasteOnlyAffectsPasteMode(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "hello", Language: "en"}}
+	p, kb, _ := newTestPipeline(t, transcriber)
+	p.Config.AutoPaste = false
+	var copied []string
+	p.Clipboard = func(text string) error { copied = append(copied, text); return nil }
+
+	// Pasting at the cursor is off
+	result, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1, Duration: 1})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if result.Pasted || len(kb.pasted) != 0 {
+		t.Errorf("Pasted = %t, keyboard got %q; want nothing with auto_paste off", result.Pasted, kb.pasted)
+	}
+
+	// An explicit clipboard output still copies
+	p.Output = OutputClipboard
+	result, err = p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1, Duration: 1})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if !result.Pasted || len(copied) != 1 || copied[0] != "hello" {
+		t.Errorf("Pasted = %t, copied %q; want the text copied", result.Pasted, copied)
+	}
+}
+
+func TestProcess_PasteDelay(t *testing.T) {
+	tests := []struct {
+		name      string
+		delayMs   int
+		output    OutputMode
+		wantSleep []time.Duration
+	}{
+		{"no delay", 0, OutputPaste, nil},
+		{"sub-second delay", 400, OutputPaste, []time.Duration{400 * time.Millisecond}},
+		{"countdown", 2500, OutputPaste, []time.Duration{time.Second, time.Second, 500 * time.Millisecond}},
+		{"clipboard output doesn't wait", 2000, OutputClipboard, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "hello", Language: "en"}}
+			p, kb, _ := newTestPipeline(t, transcriber)
+			p.Config.PasteDelayMs = tt.delayMs
+			p.Output = tt.output
+			p.Clipboard = func(string) error { return nil }
+
+			var slept []time.Duration
+			p.Sleep = func(d time.Duration) {
+				if len(kb.pasted) != 0 {
+					t.Error("slept after pasting")
+				}
+				slept = append(slept, d)
+			}
+
+			if _, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1, Duration: 1}); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if !reflect.DeepEqual(slept, tt.wantSleep) {
+				t.Errorf("slept %v, want %v", slept, tt.wantSleep)
+			}
+			if tt.output == OutputPaste && len(kb.pasted) != 1 {
+				t.Errorf("keyboard got %q, want the text pasted once", kb.pasted)
+			}
+			if out := p.Out.(*bytes.Buffer).String(); (len(tt.wantSleep) > 0) != strings.Contains(out, "Pasting in") {
+				t.Errorf("output = %q, want a countdown only when waiting", out)
+			}
+		})
+	}
+}
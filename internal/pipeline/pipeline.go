@@ -0,0 +1,435 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/audio"
+	"github.com/alexandrelam/openscribe/internal/config"
+	"github.com/alexandrelam/openscribe/internal/keyboard"
+	"github.com/alexandrelam/openscribe/internal/logging"
+	"github.com/alexandrelam/openscribe/internal/models"
+	"github.com/alexandrelam/openscribe/internal/notify"
+	"github.com/alexandrelam/openscribe/internal/progress"
+	"github.com/alexandrelam/openscribe/internal/textproc"
+	"github.com/alexandrelam/openscribe/internal/transcription"
+)
+
+var (
+	// ErrNoAudio is returned when the recording captured no audio data
+	ErrNoAudio = errors.New("no audio data captured")
+
+	// ErrNoSpeech is returned when the recording contains no speech
+	ErrNoSpeech = errors.New("no speech detected in recording")
+
+	// ErrTooShort is reported by Session for recordings shorter than its MinDuration
+	ErrTooShort = errors.New("recording too short")
+)
+
+// Recording is the audio captured by a recorder
+type Recording struct {
+	Audio      []byte  // 16-bit PCM samples
+	SampleRate uint32  // Sample rate of Audio in Hz
+	Channels   uint32  // Number of interleaved channels in Audio
+	Duration   float64 // How long the recording ran, in seconds
+}
+
+// Result is the outcome of processing a recording
+type Result struct {
+	Text     string `json:"text"`
+	Language string `json:"language"`
+	Pasted   bool   `json:"pasted"`        // Text reached its output: inserted, copied or printed
+	Logged   bool   `json:"logged"`        // Text was added to the transcription history
+	App      string `json:"app,omitempty"` // Application in front when the text was pasted
+}
+
+// Pipeline transcribes recordings, then pastes and logs the text
+type Pipeline struct {
+	Config      *config.Config
+	Transcriber transcription.Transcriber
+	Model       models.ModelSize
+
+	// Timeout limits how long a transcription may run (0 = transcription.DefaultTimeout)
+	Timeout time.Duration
+
+	// Output is where the text goes; defaults to OutputPaste
+	Output OutputMode
+
+	// Keyboard pastes the text using PasteMode in OutputPaste; nil disables pasting
+	Keyboard  keyboard.Keyboard
+	PasteMode keyboard.PasteMode
+
+	// Sleep waits out the paste_delay_ms countdown; defaults to time.Sleep
+	Sleep func(time.Duration)
+
+	// Clipboard copies the text in OutputClipboard; defaults to keyboard.CopyToClipboard
+	Clipboard func(text string) error
+
+	// Stdout receives the text in OutputStdout; defaults to os.Stdout
+	Stdout io.Writer
+
+	// Feedback plays the completion sound; nil disables it
+	Feedback audio.Feedback
+
+	// Notifier shows the text in a desktop notification when desktop_notifications
+	// is on; nil disables notifications
+	Notifier notify.Notifier
+
+	// Progress is shown while the transcriber runs; nil shows nothing
+	Progress *progress.Spinner
+
+	// Out receives status messages; defaults to os.Stdout
+	Out io.Writer
+
+	// ErrOut receives warnings and diagnostics (timings, confidence); defaults to os.Stderr
+	ErrOut io.Writer
+
+	// FrontmostApp names the application the text goes into; nil leaves it unrecorded
+	FrontmostApp func() string
+
+	// Log records the transcription in the history when logging_enabled is on;
+	// defaults to logging.LogTranscriptionEntry
+	Log func(entry logging.TranscriptionEntry) error
+
+	// DryRun prints what would be pasted and logged instead of doing it
+	DryRun bool
+}
+
+// Process cleans up, transcribes, pastes and logs a recording.
+// Returns ErrNoAudio or ErrNoSpeech when there is nothing to transcribe.
+func (p *Pipeline) Process(rec Recording) (*Result, error) {
+	cfg := p.Config
+	out := p.Out
+	if out == nil {
+		out = os.Stdout
+	}
+
+	audioData := rec.Audio
+	if len(audioData) == 0 {
+		return nil, ErrNoAudio
+	}
+
+	// Whisper expects mono audio; downmix multi-channel captures
+	channels := rec.Channels
+	if channels > 1 {
+		audioData = audio.DownmixToMono(audioData, channels)
+		channels = 1
+	}
+
+	// Remove dead air around the recording
+	if cfg.TrimSilence {
+		originalLen := len(audioData)
+		audioData = audio.TrimSilence(audioData, rec.SampleRate, channels, cfg.SilenceThresholdDB)
+		if len(audioData) == 0 {
+			return nil, ErrNoSpeech
+		}
+		if cfg.Verbose {
+			fmt.Fprintf(out, "Trimmed silence: %d → %d bytes\n", originalLen, len(audioData))
+		}
+	}
+
+	// Silence background noise between words before any gain raises it
+	if cfg.NoiseGateDB < 0 {
+		audioData = audio.NoiseGate(audioData, rec.SampleRate, cfg.NoiseGateDB)
+		if cfg.Verbose {
+			fmt.Fprintf(out, "Noise gate applied below %.1f dBFS\n", cfg.NoiseGateDB)
+		}
+	}
+
+	audioData = p.applyGain(out, audioData, rec.SampleRate)
+
+	// Save audio to temporary WAV file
+	cacheDir, err := config.GetCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache directory: %w", err)
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// A unique name, so recordings made in the same second never overwrite each other
+	wavFile, err := os.CreateTemp(cacheDir, fmt.Sprintf("recording_%s_*.wav", time.Now().Format("20060102_150405")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audio file: %w", err)
+	}
+	wavPath := wavFile.Name()
+	_ = wavFile.Close()
+	if err := audio.SaveWAV(wavPath, audioData, rec.SampleRate, channels); err != nil {
+		_ = os.Remove(wavPath)
+		return nil, fmt.Errorf("failed to save audio file: %w", err)
+	}
+	// Every backend gets a well-formed file, not just those that validate their input
+	if err := audio.ValidateWAV(wavPath); err != nil {
+		_ = os.Remove(wavPath)
+		return nil, fmt.Errorf("recorded audio is unusable: %w", err)
+	}
+	if cfg.Verbose {
+		fmt.Fprintf(out, "Audio saved to: %s\n", wavPath)
+	}
+
+	opts := transcription.Options{
+		Model:           p.Model,
+		Language:        cfg.Language,
+		DetectLanguages: cfg.DetectLanguages,
+		Threads:         cfg.Threads,
+		Prompt:          cfg.Prompt,
+		Verbose:         cfg.Verbose,
+		Timeout:         p.Timeout,
+	}
+	if p.Progress != nil {
+		p.Progress.Start()
+	}
+	transcribed, err := p.Transcriber.TranscribeFile(wavPath, opts)
+	if p.Progress != nil {
+		p.Progress.Stop()
+	}
+	// Keep the WAV file for debugging in verbose mode, unless transcription failed.
+	// keep_recordings moves it out of the cache before this runs.
+	if err != nil || !cfg.Verbose {
+		defer func() { _ = os.Remove(wavPath) }()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to transcribe audio: %w", err)
+	}
+
+	if p.Feedback != nil {
+		if err := p.Feedback.PlayCompleteSound(); err != nil && cfg.Verbose {
+			fmt.Fprintf(p.errOut(), "Warning: Failed to play complete sound: %v\n", err)
+		}
+	}
+
+	if cfg.Verbose && transcribed.Timings.TotalMS > 0 {
+		fmt.Fprintf(p.errOut(), "Timings: %s\n", transcribed.Timings)
+	}
+	if cfg.Verbose && (transcribed.AvgLogProb != 0 || transcribed.NoSpeechProb != 0) {
+		fmt.Fprintf(p.errOut(), "Confidence: avg log prob %.3f, no-speech prob %.3f\n", transcribed.AvgLogProb, transcribed.NoSpeechProb)
+	}
+
+	if transcribed.Text == "" || IsNoSpeech(transcribed, cfg.NoSpeechThreshold) {
+		return nil, ErrNoSpeech
+	}
+
+	// Fix known mis-transcriptions and mask redacted words before the text is
+	// pasted and logged, so the log never keeps them
+	text := textproc.ApplyReplacements(transcribed.Text, textproc.RulesFromConfig(cfg))
+	text = textproc.RedactFromConfig(text, cfg)
+	result := &Result{Text: text, Language: transcribed.Language}
+
+	// auto_paste only switches pasting at the cursor on and off
+	mode := p.Output
+	if mode == "" {
+		mode = OutputPaste
+	}
+	if mode == OutputPaste && (!cfg.AutoPaste || p.Keyboard == nil) {
+		mode = OutputNone
+	}
+
+	// Give the user time to switch to the target window
+	if delay := cfg.PasteDelay(); delay > 0 && mode == OutputPaste {
+		if p.DryRun {
+			fmt.Fprintf(out, "[dry-run] would wait %s before pasting\n", delay)
+		} else {
+			p.waitToPaste(out, delay)
+		}
+	}
+
+	// Note where the text is going before pasting changes anything
+	if p.FrontmostApp != nil {
+		result.App = p.FrontmostApp()
+	}
+	if mode != OutputNone {
+		pasteText := result.Text
+		if cfg.StripNewlines {
+			pasteText = keyboard.StripNewlines(pasteText)
+		}
+		pasteText = textproc.Apply(pasteText, textproc.OptionsFromConfig(cfg))
+		if p.DryRun && mode == OutputPaste {
+			fmt.Fprintf(out, "[dry-run] would paste: %s\n", pasteText)
+		} else if p.DryRun {
+			fmt.Fprintf(out, "[dry-run] would output to %s: %s\n", mode, pasteText)
+		} else if ok, err := p.output(mode, pasteText); err != nil {
+			fmt.Fprintf(p.errOut(), "Warning: Failed to output text to %s: %v\n", mode, err)
+		} else {
+			result.Pasted = ok
+		}
+	}
+
+	p.notify(out, result.Text)
+
+	logTranscription := p.Log
+	if logTranscription == nil {
+		logTranscription = logging.LogTranscriptionEntry
+	}
+	entry := logging.TranscriptionEntry{
+		Duration: rec.Duration,
+		Model:    cfg.Model,
+		Language: result.Language,
+		Text:     result.Text,
+		App:      result.App,
+	}
+	// Kept recordings are only reachable through their log entry
+	if cfg.KeepRecordings && cfg.LoggingEnabled && !p.DryRun {
+		if kept, err := keepRecording(wavPath); err != nil {
+			fmt.Fprintf(p.errOut(), "Warning: Failed to keep recording: %v\n", err)
+		} else {
+			entry.AudioPath = kept
+		}
+	}
+	switch {
+	case !cfg.LoggingEnabled:
+		// logging_enabled is off: the text never reaches the disk
+	case p.DryRun:
+		fmt.Fprintf(out, "[dry-run] would log: %s\n", entry.Text)
+	default:
+		if err := logTranscription(entry); err != nil {
+			if cfg.Verbose {
+				fmt.Fprintf(p.errOut(), "Warning: Failed to log transcription: %v\n", err)
+			}
+		} else {
+			result.Logged = true
+		}
+	}
+
+	return result, nil
+}
+
+// keepRecording moves a transcribed recording from the cache to the recordings
+// directory and returns its new path
+func keepRecording(wavPath string) (string, error) {
+	dir, err := config.GetRecordingsDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get recordings directory: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create recordings directory: %w", err)
+	}
+
+	// Claim the name first so an earlier recording is never overwritten
+	dest := filepath.Join(dir, filepath.Base(wavPath))
+	f, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return "", fmt.Errorf("failed to create recording: %w", err)
+	}
+	if err := os.Rename(wavPath, dest); err == nil {
+		_ = f.Close()
+		return dest, nil
+	}
+
+	// The cache and the recordings directory may be on different volumes
+	data, err := os.ReadFile(wavPath)
+	if err == nil {
+		_, err = f.Write(data)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(dest)
+		return "", fmt.Errorf("failed to copy recording: %w", err)
+	}
+	_ = os.Remove(wavPath)
+	return dest, nil
+}
+
+// notify shows the transcription in a desktop notification when enabled.
+// Notifications are best-effort: failures are only reported in verbose mode.
+func (p *Pipeline) notify(out io.Writer, text string) {
+	cfg := p.Config
+	if !cfg.DesktopNotifications || p.Notifier == nil {
+		return
+	}
+	if p.DryRun {
+		fmt.Fprintf(out, "[dry-run] would notify: %s\n", text)
+		return
+	}
+	if err := p.Notifier.Send("OpenScribe", text); err != nil && cfg.Verbose {
+		fmt.Fprintf(p.errOut(), "Warning: Failed to show notification: %v\n", err)
+	}
+}
+
+// applyGain reports the audio level and applies the configured gain: a fixed gain_db,
+// peak normalization, or boosting quiet recordings when auto-gain is enabled
+func (p *Pipeline) applyGain(out io.Writer, audioData []byte, sampleRate uint32) []byte {
+	cfg := p.Config
+
+	levelMetrics, err := audio.AnalyzeLevel(audioData, sampleRate)
+	if err != nil {
+		fmt.Fprintf(p.errOut(), "Warning: Failed to analyze audio level: %v\n", err)
+		return audioData
+	}
+
+	// Display audio levels if verbose mode or ShowAudioLevels is enabled
+	if cfg.Verbose || cfg.ShowAudioLevels {
+		fmt.Fprintf(out, "🔊 Audio level: %.1f dBFS (peak: %d)\n",
+			levelMetrics.DecibelsFS, levelMetrics.PeakAmplitude)
+	}
+
+	// A fixed gain or peak normalization replaces the level-triggered auto gain
+	if cfg.GainDB != 0 {
+		processedAudio, err := audio.ApplyGain(audioData, cfg.GainDB, true)
+		if err != nil {
+			fmt.Fprintf(p.errOut(), "Warning: Failed to apply gain: %v\n", err)
+			return audioData
+		}
+		if cfg.Verbose {
+			fmt.Fprintf(out, "✓ Fixed gain applied: %+.1f dB\n", cfg.GainDB)
+		}
+		return processedAudio
+	}
+	if cfg.Normalize {
+		if cfg.Verbose {
+			fmt.Fprintf(out, "✓ Normalized to %.0f%% peak\n", config.NormalizePeak*100)
+		}
+		return audio.NormalizeAudio(audioData, config.NormalizePeak)
+	}
+
+	if levelMetrics.DecibelsFS >= cfg.MinThresholdDB {
+		return audioData
+	}
+
+	if !cfg.AutoGain {
+		// Warn if audio is low but auto-gain is disabled
+		fmt.Fprintf(out, "⚠️  Low audio level detected (%.1f dBFS). Consider increasing microphone volume or enabling auto_gain in config.\n",
+			levelMetrics.DecibelsFS)
+		return audioData
+	}
+
+	fmt.Fprintf(out, "⚠️  Low audio level detected (%.1f dBFS), applying gain...\n",
+		levelMetrics.DecibelsFS)
+
+	gainConfig := audio.GainControlConfig{
+		Enabled:         true,
+		TargetLevelDB:   cfg.TargetLevelDB,
+		MinThresholdDB:  cfg.MinThresholdDB,
+		MaxGainDB:       cfg.MaxGainDB,
+		PreventClipping: true,
+	}
+	processedAudio, gainResult, err := audio.ProcessAudioGain(audioData, levelMetrics, gainConfig)
+	if err != nil {
+		fmt.Fprintf(p.errOut(), "Warning: Failed to apply gain control: %v\n", err)
+		return audioData
+	}
+
+	fmt.Fprintf(out, "✓ Gain applied: +%.1f dB (level now: %.1f dBFS)\n",
+		gainResult.GainAppliedDB, gainResult.ResultingLevelDB)
+	return processedAudio
+}
+
+// IsNoSpeech reports whether the backend's no-speech probability exceeds the
+// configured threshold. A threshold of 0 disables the check, and backends that
+// don't report the probability leave it at 0 so they never trip it.
+func IsNoSpeech(result *transcription.Result, threshold float64) bool {
+	return threshold > 0 && result.NoSpeechProb > threshold
+}
+
+// errOut returns the writer for warnings and diagnostics
+func (p *Pipeline) errOut() io.Writer {
+	if p.ErrOut != nil {
+		return p.ErrOut
+	}
+	return os.Stderr
+}
@@ -0,0 +1,567 @@
+package pipeline
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexandrelam/openscribe/internal/audio"
+	"github.com/alexandrelam/openscribe/internal/config"
+	"github.com/alexandrelam/openscribe/internal/logging"
+	"github.com/alexandrelam/openscribe/internal/progress"
+	"github.com/alexandrelam/openscribe/internal/transcription"
+)
+
+// fakeKeyboard records pasted text
+type fakeKeyboard struct {
+	pasted []string
+}
+
+func (k *fakeKeyboard) PasteText(text string) error { k.pasted = append(k.pasted, text); return nil }
+func (k *fakeKeyboard) CopyText(text string) error  { k.pasted = append(k.pasted, text); return nil }
+func (k *fakeKeyboard) TypeText(text string) error  { k.pasted = append(k.pasted, text); return nil }
+func (k *fakeKeyboard) CheckPermissions() error     { return nil }
+func (k *fakeKeyboard) Close() error                { return nil }
+
+type logCall struct {
+	duration  float64
+	model     string
+	language  string
+	text      string
+	app       string
+	audioPath string
+}
+
+// sineWave returns one second of a loud 16-bit mono 440 Hz tone at 16 kHz
+func sineWave() []byte {
+	buf := make([]byte, 16000*2)
+	for i := 0; i < 16000; i++ {
+		sample := int16(16000 * math.Sin(2*math.Pi*440*float64(i)/16000))
+		binary.LittleEndian.PutUint16(buf[i*2:], uint16(sample))
+	}
+	return buf
+}
+
+func newTestPipeline(t *testing.T, transcriber *transcription.FakeTranscriber) (*Pipeline, *fakeKeyboard, *[]logCall) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := config.DefaultConfig()
+	cfg.Model = "small"
+	cfg.Language = "fr"
+	cfg.AutoPaste = true
+	cfg.TrimSilence = false
+
+	kb := &fakeKeyboard{}
+	var logged []logCall
+	p := &Pipeline{
+		Config:      cfg,
+		Transcriber: transcriber,
+		Keyboard:    kb,
+		Out:         &bytes.Buffer{},
+		ErrOut:      &bytes.Buffer{},
+		Log: func(entry logging.TranscriptionEntry) error {
+			logged = append(logged, logCall{entry.Duration, entry.Model, entry.Language, entry.Text, entry.App, entry.AudioPath})
+			return nil
+		},
+	}
+	return p, kb, &logged
+}
+
+func TestProcess_TranscribesPastesAndLogs(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "Bonjour tout le monde", Language: "fr"}}
+	p, kb, logged := newTestPipeline(t, transcriber)
+
+	result, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1, Duration: 2.5})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if result.Text != "Bonjour tout le monde" || result.Language != "fr" {
+		t.Errorf("result = %+v", result)
+	}
+	if !result.Pasted || !result.Logged {
+		t.Errorf("Pasted = %t, Logged = %t, want both true", result.Pasted, result.Logged)
+	}
+
+	calls := transcriber.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("transcriber called %d times, want 1", len(calls))
+	}
+	if !calls[0].FileExists {
+		t.Error("WAV file did not exist while transcribing")
+	}
+	if calls[0].Opts.Language != "fr" {
+		t.Errorf("transcription language = %q, want %q", calls[0].Opts.Language, "fr")
+	}
+	if _, err := os.Stat(calls[0].AudioPath); !os.IsNotExist(err) {
+		t.Errorf("WAV file should be removed after transcription, stat error = %v", err)
+	}
+
+	if len(kb.pasted) != 1 || kb.pasted[0] != "Bonjour tout le monde" {
+		t.Errorf("pasted = %v", kb.pasted)
+	}
+	want := logCall{duration: 2.5, model: "small", language: "fr", text: "Bonjour tout le monde"}
+	if len(*logged) != 1 || (*logged)[0] != want {
+		t.Errorf("logged = %+v, want [%+v]", *logged, want)
+	}
+}
+
+func TestProcess_LoggingDisabled(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "private", Language: "en"}}
+	p, kb, logged := newTestPipeline(t, transcriber)
+	p.Config.LoggingEnabled = false
+
+	result, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1, Duration: 1})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if !result.Pasted || len(kb.pasted) != 1 {
+		t.Errorf("Pasted = %t, keyboard got %q; want the text still pasted", result.Pasted, kb.pasted)
+	}
+	if result.Logged || len(*logged) != 0 {
+		t.Errorf("Logged = %t, logged %+v; want nothing logged", result.Logged, *logged)
+	}
+
+	// Logging resumes when the setting is turned back on
+	p.Config.LoggingEnabled = true
+	if result, err = p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1, Duration: 1}); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if !result.Logged || len(*logged) != 1 {
+		t.Errorf("Logged = %t, logged %d entries; want 1", result.Logged, len(*logged))
+	}
+}
+
+func TestProcess_ShowsProgressWhileTranscribing(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "hello", Language: "en"}}
+	p, _, _ := newTestPipeline(t, transcriber)
+	var status bytes.Buffer
+	p.Progress = progress.New(&status, "Transcribing...")
+	p.Progress.Enabled = true
+
+	if _, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1, Duration: 1}); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	got := status.String()
+	if !strings.HasPrefix(got, "\r⠋ Transcribing... 0s") || !strings.HasSuffix(got, " \r") {
+		t.Errorf("progress output = %q, want the spinner drawn and then cleared", got)
+	}
+}
+
+func TestProcess_NoPasteWithoutAutoPaste(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "hello"}}
+	p, kb, logged := newTestPipeline(t, transcriber)
+	p.Config.AutoPaste = false
+
+	result, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if result.Pasted || len(kb.pasted) != 0 {
+		t.Errorf("text was pasted with auto-paste disabled: %v", kb.pasted)
+	}
+	if len(*logged) != 1 {
+		t.Errorf("logged %d entries, want 1", len(*logged))
+	}
+}
+
+func TestProcess_FormatsPastedText(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "bonjour\ntout le monde"}}
+	p, kb, logged := newTestPipeline(t, transcriber)
+	p.Config.StripNewlines = true
+	p.Config.CapitalizeFirst = true
+	p.Config.EnsurePunctuation = true
+	p.Config.TrailingSpace = true
+
+	if _, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1}); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if len(kb.pasted) != 1 || kb.pasted[0] != "Bonjour tout le monde. " {
+		t.Errorf("pasted = %q, want [%q]", kb.pasted, "Bonjour tout le monde. ")
+	}
+	// The log keeps the text as transcribed
+	if len(*logged) != 1 || (*logged)[0].text != "bonjour\ntout le monde" {
+		t.Errorf("logged = %+v", *logged)
+	}
+}
+
+func TestProcess_AppliesReplacements(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "deploy to cube and eddies"}}
+	p, kb, logged := newTestPipeline(t, transcriber)
+	p.Config.Replacements = []config.Replacement{{From: "cube and eddies", To: "Kubernetes"}}
+
+	result, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	want := "deploy to Kubernetes"
+	if result.Text != want {
+		t.Errorf("Text = %q, want %q", result.Text, want)
+	}
+	if len(kb.pasted) != 1 || kb.pasted[0] != want {
+		t.Errorf("pasted = %q, want [%q]", kb.pasted, want)
+	}
+	if len(*logged) != 1 || (*logged)[0].text != want {
+		t.Errorf("logged = %+v", *logged)
+	}
+}
+
+func TestProcess_RedactsPastedAndLoggedText(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "the darn build failed"}}
+	p, kb, logged := newTestPipeline(t, transcriber)
+	p.Config.Redact = []string{"darn"}
+
+	result, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	want := "the **** build failed"
+	if result.Text != want {
+		t.Errorf("Text = %q, want %q", result.Text, want)
+	}
+	if len(kb.pasted) != 1 || kb.pasted[0] != want {
+		t.Errorf("pasted = %q, want [%q]", kb.pasted, want)
+	}
+	if len(*logged) != 1 || (*logged)[0].text != want {
+		t.Errorf("logged = %+v", *logged)
+	}
+}
+
+func TestProcess_ManualGain(t *testing.T) {
+	tests := []struct {
+		name      string
+		normalize bool
+		gainDB    float64
+		wantPeak  float64
+	}{
+		{"normalize", true, 0, config.NormalizePeak * 32767},
+		{"fixed gain", false, 6, 16000 * audio.DBToLinear(6)},
+		{"fixed attenuation", false, -6, 16000 * audio.DBToLinear(-6)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var peak float64
+			transcriber := &transcription.FakeTranscriber{
+				TranscribeFunc: func(audioPath string, _ transcription.Options) (*transcription.Result, error) {
+					data, _, _, err := audio.LoadWAV(audioPath)
+					if err != nil {
+						return nil, err
+					}
+					for i := 0; i+1 < len(data); i += 2 {
+						peak = math.Max(peak, math.Abs(float64(int16(binary.LittleEndian.Uint16(data[i:])))))
+					}
+					return &transcription.Result{Text: "hello"}, nil
+				},
+			}
+			p, _, _ := newTestPipeline(t, transcriber)
+			p.Config.Normalize = tt.normalize
+			p.Config.GainDB = tt.gainDB
+
+			if _, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1}); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if math.Abs(peak-tt.wantPeak) > 2 {
+				t.Errorf("transcribed audio peak = %.0f, want %.0f", peak, tt.wantPeak)
+			}
+		})
+	}
+}
+
+func TestProcess_NoiseGate(t *testing.T) {
+	// Low hiss followed by a loud tone
+	hiss := make([]byte, 8000)
+	for i := 0; i < len(hiss); i += 2 {
+		binary.LittleEndian.PutUint16(hiss[i:], uint16(int16(40)))
+	}
+
+	var transcribed []byte
+	transcriber := &transcription.FakeTranscriber{
+		TranscribeFunc: func(audioPath string, _ transcription.Options) (*transcription.Result, error) {
+			data, _, _, err := audio.LoadWAV(audioPath)
+			transcribed = data
+			return &transcription.Result{Text: "hello"}, err
+		},
+	}
+	p, _, _ := newTestPipeline(t, transcriber)
+	p.Config.NoiseGateDB = -40
+	p.Config.AutoGain = false
+
+	if _, err := p.Process(Recording{Audio: append(hiss, sineWave()...), SampleRate: 16000, Channels: 1}); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if len(transcribed) < len(hiss) || !bytes.Equal(transcribed[:len(hiss)], make([]byte, len(hiss))) {
+		t.Error("hiss before the tone was not silenced by the noise gate")
+	}
+}
+
+func TestProcess_Errors(t *testing.T) {
+	transcribeErr := errors.New("whisper-cli crashed")
+
+	tests := []struct {
+		name        string
+		audio       []byte
+		transcriber *transcription.FakeTranscriber
+		threshold   float64
+		wantErr     error
+	}{
+		{
+			name:        "no audio",
+			audio:       nil,
+			transcriber: &transcription.FakeTranscriber{},
+			wantErr:     ErrNoAudio,
+		},
+		{
+			name:        "empty transcription",
+			audio:       sineWave(),
+			transcriber: &transcription.FakeTranscriber{Result: &transcription.Result{Text: ""}},
+			wantErr:     ErrNoSpeech,
+		},
+		{
+			name:        "no-speech probability above threshold",
+			audio:       sineWave(),
+			transcriber: &transcription.FakeTranscriber{Result: &transcription.Result{Text: "Thank you.", NoSpeechProb: 0.9}},
+			threshold:   0.6,
+			wantErr:     ErrNoSpeech,
+		},
+		{
+			name:        "transcription failure",
+			audio:       sineWave(),
+			transcriber: &transcription.FakeTranscriber{Err: transcribeErr},
+			wantErr:     transcribeErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, kb, logged := newTestPipeline(t, tt.transcriber)
+			p.Config.NoSpeechThreshold = tt.threshold
+
+			result, err := p.Process(Recording{Audio: tt.audio, SampleRate: 16000, Channels: 1})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Process() error = %v, want %v", err, tt.wantErr)
+			}
+			if result != nil {
+				t.Errorf("result = %+v, want nil", result)
+			}
+			if len(kb.pasted) != 0 || len(*logged) != 0 {
+				t.Errorf("pasted %v and logged %v on error", kb.pasted, *logged)
+			}
+		})
+	}
+}
+
+func TestIsNoSpeech(t *testing.T) {
+	tests := []struct {
+		name      string
+		prob      float64
+		threshold float64
+		want      bool
+	}{
+		{"disabled", 0.9, 0, false},
+		{"below threshold", 0.3, 0.6, false},
+		{"above threshold", 0.9, 0.6, true},
+		{"not reported", 0, 0.6, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &transcription.Result{NoSpeechProb: tt.prob}
+			if got := IsNoSpeech(result, tt.threshold); got != tt.want {
+				t.Errorf("IsNoSpeech(%v, %v) = %t, want %t", tt.prob, tt.threshold, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcess_RecordsFrontmostApp(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "hello", Language: "en"}}
+	p, kb, logged := newTestPipeline(t, transcriber)
+
+	// The app must be looked up before the text is pasted into it
+	p.FrontmostApp = func() string {
+		if len(kb.pasted) != 0 {
+			t.Error("FrontmostApp called after pasting")
+		}
+		return "Notes"
+	}
+
+	result, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1, Duration: 1})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if result.App != "Notes" {
+		t.Errorf("result.App = %q, want Notes", result.App)
+	}
+	if len(*logged) != 1 || (*logged)[0].app != "Notes" {
+		t.Errorf("logged = %+v, want one entry for Notes", *logged)
+	}
+}
+
+func TestProcess_DryRun(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "just testing", Language: "en"}}
+	p, kb, logged := newTestPipeline(t, transcriber)
+	var out bytes.Buffer
+	p.Out = &out
+	p.DryRun = true
+
+	result, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1, Duration: 1})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if len(kb.pasted) != 0 || len(*logged) != 0 {
+		t.Errorf("dry run pasted %v and logged %+v, want neither", kb.pasted, *logged)
+	}
+	if result.Text != "just testing" || result.Pasted || result.Logged {
+		t.Errorf("result = %+v, want the text with nothing pasted or logged", result)
+	}
+	for _, want := range []string{"[dry-run] would paste: just testing\n", "[dry-run] would log: just testing\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output %q does not contain %q", out.String(), want)
+		}
+	}
+}
+
+// fakeNotifier records the notifications sent
+type fakeNotifier struct {
+	sent []string
+	err  error
+}
+
+func (n *fakeNotifier) Send(title, body string) error {
+	n.sent = append(n.sent, title+": "+body)
+	return n.err
+}
+
+func TestProcess_DesktopNotifications(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		dryRun  bool
+		sendErr error
+		want    []string
+		output  string
+	}{
+		{name: "enabled", enabled: true, want: []string{"OpenScribe: hello there"}},
+		{name: "disabled by default", enabled: false},
+		{name: "dry run", enabled: true, dryRun: true, output: "[dry-run] would notify: hello there\n"},
+		{name: "failure is not fatal", enabled: true, sendErr: errors.New("unavailable"), want: []string{"OpenScribe: hello there"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "hello there", Language: "en"}}
+			p, _, logged := newTestPipeline(t, transcriber)
+			var out bytes.Buffer
+			p.Out = &out
+			p.DryRun = tt.dryRun
+			p.Config.DesktopNotifications = tt.enabled
+			notifier := &fakeNotifier{err: tt.sendErr}
+			p.Notifier = notifier
+
+			if _, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1, Duration: 1}); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+
+			if strings.Join(notifier.sent, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("notifications = %q, want %q", notifier.sent, tt.want)
+			}
+			if tt.output != "" && !strings.Contains(out.String(), tt.output) {
+				t.Errorf("output %q does not contain %q", out.String(), tt.output)
+			}
+			if !tt.dryRun && len(*logged) != 1 {
+				t.Errorf("logged = %+v, want the transcription logged", *logged)
+			}
+		})
+	}
+}
+
+func TestProcess_KeepRecordings(t *testing.T) {
+	for _, keep := range []bool{false, true} {
+		t.Run(fmt.Sprintf("keep_recordings=%t", keep), func(t *testing.T) {
+			transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "for the record"}}
+			p, _, logged := newTestPipeline(t, transcriber)
+			p.Config.KeepRecordings = keep
+
+			if _, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1, Duration: 1}); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if len(*logged) != 1 {
+				t.Fatalf("logged = %+v, want one entry", *logged)
+			}
+			audioPath := (*logged)[0].audioPath
+
+			recordingsDir, _ := config.GetRecordingsDir()
+			cacheDir, _ := config.GetCacheDir()
+			cached, _ := filepath.Glob(filepath.Join(cacheDir, "*.wav"))
+			if len(cached) != 0 {
+				t.Errorf("recordings left in the cache: %v", cached)
+			}
+
+			if !keep {
+				if audioPath != "" {
+					t.Errorf("audio path = %q, want none without keep_recordings", audioPath)
+				}
+				return
+			}
+			if filepath.Dir(audioPath) != recordingsDir {
+				t.Errorf("audio path = %q, want a file in %s", audioPath, recordingsDir)
+			}
+			if err := audio.ValidateWAV(audioPath); err != nil {
+				t.Errorf("kept recording is not a valid WAV: %v", err)
+			}
+		})
+	}
+}
+
+func TestProcess_KeepRecordingsSameSecond(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "again"}}
+	p, _, logged := newTestPipeline(t, transcriber)
+	p.Config.KeepRecordings = true
+
+	// Back-to-back dictations land in the same second
+	for i := 0; i < 3; i++ {
+		if _, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1, Duration: 1}); err != nil {
+			t.Fatalf("Process() error = %v", err)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, entry := range *logged {
+		if entry.audioPath == "" || seen[entry.audioPath] {
+			t.Fatalf("audio paths = %+v, want a distinct file per entry", *logged)
+		}
+		seen[entry.audioPath] = true
+	}
+	recordingsDir, _ := config.GetRecordingsDir()
+	if kept, _ := filepath.Glob(filepath.Join(recordingsDir, "*.wav")); len(kept) != 3 {
+		t.Errorf("kept recordings = %v, want 3", kept)
+	}
+}
+
+func TestProcess_KeepRecordingsLoggingDisabled(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "off the record"}}
+	p, _, _ := newTestPipeline(t, transcriber)
+	p.Config.KeepRecordings = true
+	p.Config.LoggingEnabled = false
+
+	if _, err := p.Process(Recording{Audio: sineWave(), SampleRate: 16000, Channels: 1, Duration: 1}); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	recordingsDir, _ := config.GetRecordingsDir()
+	cacheDir, _ := config.GetCacheDir()
+	for _, dir := range []string{recordingsDir, cacheDir} {
+		if files, _ := filepath.Glob(filepath.Join(dir, "*.wav")); len(files) != 0 {
+			t.Errorf("audio written to disk with logging disabled: %v", files)
+		}
+	}
+}
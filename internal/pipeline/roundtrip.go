@@ -0,0 +1,54 @@
+package pipeline
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/logging"
+)
+
+// RoundTripResult is the outcome of a record → transcribe smoke test
+type RoundTripResult struct {
+	Text       string        `json:"text"`
+	Language   string        `json:"language"`
+	Recorded   time.Duration `json:"recorded"`   // Length of the captured audio
+	Transcribe time.Duration `json:"transcribe"` // Time spent processing and transcribing it
+}
+
+// RoundTrip records from rec for the given duration, then runs the pipeline on the
+// audio without pasting or logging the text. It checks the microphone, model and
+// backend end to end.
+func (p *Pipeline) RoundTrip(rec Recorder, duration time.Duration) (*RoundTripResult, error) {
+	if err := rec.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start recording: %w", err)
+	}
+	time.Sleep(duration)
+	audioData, err := rec.Stop()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop recording: %w", err)
+	}
+
+	// A smoke test leaves the cursor, the history and the notifications alone
+	check := *p
+	check.Keyboard = nil
+	check.Notifier = nil
+	check.Log = func(logging.TranscriptionEntry) error { return nil }
+
+	started := time.Now()
+	result, err := check.Process(Recording{
+		Audio:      audioData,
+		SampleRate: rec.GetSampleRate(),
+		Channels:   rec.GetChannels(),
+		Duration:   rec.Duration().Seconds(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RoundTripResult{
+		Text:       result.Text,
+		Language:   result.Language,
+		Recorded:   rec.Duration(),
+		Transcribe: time.Since(started),
+	}, nil
+}
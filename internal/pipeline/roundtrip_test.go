@@ -0,0 +1,89 @@
+package pipeline
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/transcription"
+)
+
+func TestRoundTrip_TranscribesWithoutPastingOrLogging(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "testing one two", Language: "en"}}
+	p, kb, logged := newTestPipeline(t, transcriber)
+	rec := &fakeRecorder{audio: sineWave()}
+
+	result, err := p.RoundTrip(rec, time.Millisecond)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	if !rec.started || !rec.stopped {
+		t.Errorf("recorder started = %t, stopped = %t; want both", rec.started, rec.stopped)
+	}
+	if result.Text != "testing one two" || result.Language != "en" {
+		t.Errorf("result = %+v, want the transcribed text and language", result)
+	}
+	if result.Recorded != time.Second {
+		t.Errorf("Recorded = %v, want 1s of captured audio", result.Recorded)
+	}
+	if len(transcriber.Calls()) != 1 {
+		t.Errorf("transcriber called %d times, want 1", len(transcriber.Calls()))
+	}
+	if len(kb.pasted) != 0 {
+		t.Errorf("pasted %q, want nothing", kb.pasted)
+	}
+	if len(*logged) != 0 {
+		t.Errorf("logged %v, want nothing", *logged)
+	}
+
+	// The pipeline itself still pastes and logs afterwards
+	if p.Keyboard == nil || p.Log == nil {
+		t.Error("RoundTrip() changed the pipeline")
+	}
+}
+
+func TestRoundTrip_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		rec         *fakeRecorder
+		transcriber *transcription.FakeTranscriber
+		wantErr     error
+	}{
+		{
+			name:        "recorder fails to start",
+			rec:         &fakeRecorder{startErr: errors.New("no device")},
+			transcriber: &transcription.FakeTranscriber{Result: &transcription.Result{Text: "unused"}},
+		},
+		{
+			name:        "no audio",
+			rec:         &fakeRecorder{},
+			transcriber: &transcription.FakeTranscriber{Result: &transcription.Result{Text: "unused"}},
+			wantErr:     ErrNoAudio,
+		},
+		{
+			name:        "no speech",
+			rec:         &fakeRecorder{audio: sineWave()},
+			transcriber: &transcription.FakeTranscriber{Result: &transcription.Result{}},
+			wantErr:     ErrNoSpeech,
+		},
+		{
+			name:        "transcription fails",
+			rec:         &fakeRecorder{audio: sineWave()},
+			transcriber: &transcription.FakeTranscriber{Err: errors.New("model missing")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _, _ := newTestPipeline(t, tt.transcriber)
+
+			_, err := p.RoundTrip(tt.rec, time.Millisecond)
+			if err == nil {
+				t.Fatal("RoundTrip() error = nil, want an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("RoundTrip() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
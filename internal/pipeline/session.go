@@ -0,0 +1,455 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/audio"
+	"github.com/alexandrelam/openscribe/internal/transcription"
+)
+
+// ErrShutdownTimeout is returned by Shutdown when the final recording is still being
+// transcribed when the timeout expires
+var ErrShutdownTimeout = errors.New("transcription did not finish before shutdown")
+
+// Recorder captures audio from a microphone; *audio.Recorder implements it
+type Recorder interface {
+	Start() error
+	Stop() ([]byte, error)
+	GetSampleRate() uint32
+	GetCaptureSampleRate() uint32
+	GetChannels() uint32
+	SetSilenceDetection(timeout time.Duration, thresholdDB float64)
+	SilenceDetected() <-chan struct{}
+	Snapshot(offset int) []byte
+	Duration() time.Duration
+}
+
+// Session is the recording state machine driven by the hotkey: a trigger starts a
+// recording, the next one (or a release in hold mode, a timeout or silence) stops it
+// and runs the Pipeline on the captured audio.
+type Session struct {
+	Pipeline *Pipeline
+
+	// NewRecorder creates the recorder for each new recording
+	NewRecorder func() Recorder
+
+	// MaxDuration stops a recording automatically; WarningAfter announces the upcoming stop
+	MaxDuration  time.Duration
+	WarningAfter time.Duration
+
+	// MinDuration ignores shorter recordings (accidental taps) without transcribing them
+	MinDuration time.Duration
+
+	// StopHint tells the user how to stop a recording, e.g. "release hotkey to stop"
+	StopHint string
+
+	// ErrOut receives errors; defaults to os.Stderr
+	ErrOut io.Writer
+
+	// OnResult receives the outcome of each processed recording
+	OnResult func(result *Result, err error)
+
+	mu            sync.Mutex
+	recording     bool
+	closed        bool // Set by Shutdown; no new recordings start
+	recorder      Recorder
+	timeoutTimer  *time.Timer
+	warningTimer  *time.Timer
+	streamCancel  context.CancelFunc // Stops partial transcription (streaming mode)
+	recordingDone chan struct{}      // Closed when the current recording stops
+
+	transcribingMu sync.Mutex // Separate lock for transcription state
+	transcribing   bool
+	processing     sync.WaitGroup // Recordings stopped but not yet processed
+}
+
+// HandleToggle starts a recording, or stops the current one and processes it
+// (toggle mode: a double or single press starts and stops)
+func (s *Session) HandleToggle() {
+	if s.isBusyTranscribing() {
+		return
+	}
+
+	s.mu.Lock()
+	if !s.recording {
+		s.startRecordingLocked()
+		s.mu.Unlock()
+		return
+	}
+
+	rec := s.stopRecordingLocked()
+	s.mu.Unlock()
+
+	fmt.Fprintln(s.out(), "⏹  Recording stopped. Transcribing...")
+	s.processRecording(rec)
+}
+
+// HandlePress starts a recording when a trigger is pressed (hold mode)
+func (s *Session) HandlePress() {
+	if s.isBusyTranscribing() {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.recording {
+		s.startRecordingLocked()
+	}
+}
+
+// HandleRelease stops and processes the recording when the trigger is released (hold mode)
+func (s *Session) HandleRelease() {
+	s.mu.Lock()
+	if !s.recording {
+		s.mu.Unlock()
+		return
+	}
+
+	rec := s.stopRecordingLocked()
+	s.mu.Unlock()
+
+	fmt.Fprintln(s.out(), "⏹  Recording stopped. Transcribing...")
+	s.processRecording(rec)
+}
+
+// WhenIdle waits until nothing is being recorded or transcribed, then runs fn.
+// New recordings wait until fn returns, so fn may change the Pipeline and Session settings.
+func (s *Session) WhenIdle(fn func()) {
+	for {
+		s.mu.Lock()
+		s.transcribingMu.Lock()
+		busy := s.recording || s.transcribing
+		s.transcribingMu.Unlock()
+		if !busy {
+			break
+		}
+		s.mu.Unlock()
+		time.Sleep(500 * time.Millisecond)
+	}
+	defer s.mu.Unlock()
+
+	fn()
+}
+
+// Shutdown stops accepting new recordings and, if one is in progress, stops it and
+// processes it like a final stop (transcribe, paste, log). Waits at most timeout for
+// that and any transcription already running to finish, returning ErrShutdownTimeout
+// if they don't.
+func (s *Session) Shutdown(timeout time.Duration) error {
+	s.mu.Lock()
+	s.closed = true
+	if s.recording {
+		rec := s.stopRecordingLocked()
+		fmt.Fprintln(s.out(), "⏹  Recording stopped. Transcribing before exit...")
+		go s.processRecording(rec)
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.processing.Wait()
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("%w (waited %s)", ErrShutdownTimeout, timeout)
+	}
+}
+
+// isBusyTranscribing reports (and announces) whether a transcription is still running
+func (s *Session) isBusyTranscribing() bool {
+	s.transcribingMu.Lock()
+	defer s.transcribingMu.Unlock()
+	if s.transcribing {
+		fmt.Fprintln(s.out(), "⚠️  Transcription in progress, please wait...")
+	}
+	return s.transcribing
+}
+
+// startRecordingLocked begins a new recording session. Caller must hold mu.
+func (s *Session) startRecordingLocked() {
+	if s.closed {
+		return
+	}
+
+	cfg := s.Pipeline.Config
+	out := s.out()
+
+	s.recording = true
+	fmt.Fprintf(out, "🔴 Recording started... (%s)\n", s.StopHint)
+	fmt.Fprintf(out, "   Maximum recording time: %s\n", formatLimit(s.MaxDuration))
+
+	// Play start sound
+	if s.Pipeline.Feedback != nil {
+		if err := s.Pipeline.Feedback.PlayStartSound(); err != nil && cfg.Verbose {
+			fmt.Fprintf(s.errOut(), "Warning: Failed to play start sound: %v\n", err)
+		}
+	}
+
+	// Create and start recorder
+	rec := s.NewRecorder()
+	if cfg.SilenceTimeoutSeconds > 0 {
+		rec.SetSilenceDetection(time.Duration(cfg.SilenceTimeoutSeconds*float64(time.Second)), cfg.SilenceThresholdDB)
+	}
+	if err := rec.Start(); err != nil {
+		fmt.Fprintf(s.errOut(), "Error starting recording: %v\n", err)
+		s.recording = false
+		return
+	}
+	if cfg.Verbose && rec.GetCaptureSampleRate() != rec.GetSampleRate() {
+		fmt.Fprintf(out, "   Capturing at %d Hz (resampled to %d Hz)\n", rec.GetCaptureSampleRate(), rec.GetSampleRate())
+	}
+	s.recorder = rec
+	s.recordingDone = make(chan struct{})
+
+	// Show partial transcriptions while recording
+	if cfg.Streaming {
+		s.streamCancel = s.startStreaming(rec)
+	}
+
+	// Auto-stop after a period of silence
+	if silenceCh := rec.SilenceDetected(); silenceCh != nil {
+		done := s.recordingDone
+		timeout := cfg.SilenceTimeoutSeconds
+		go func() {
+			select {
+			case <-silenceCh:
+				s.autoStop(rec, fmt.Sprintf("🤫 Recording automatically stopped after %.1fs of silence", timeout))
+			case <-done:
+			}
+		}()
+	}
+
+	// Warn before the automatic timeout
+	maxDuration, warningAfter := s.MaxDuration, s.WarningAfter
+	if warningAfter > 0 && warningAfter < maxDuration {
+		s.warningTimer = time.AfterFunc(warningAfter, func() {
+			fmt.Fprintf(out, "\n⚠️  Warning: Recording has been running for %s\n", formatLimit(warningAfter))
+			fmt.Fprintf(out, "   Will auto-stop in %s\n", formatLimit(maxDuration-warningAfter))
+		})
+	}
+
+	// Stop automatically at the maximum duration
+	s.timeoutTimer = time.AfterFunc(maxDuration, func() {
+		s.autoStop(rec, fmt.Sprintf("⏱️  Recording automatically stopped after %s (max duration)", formatLimit(maxDuration)))
+	})
+}
+
+// stopRecordingLocked ends the current recording session and returns the
+// recorder for processing. Caller must hold mu.
+func (s *Session) stopRecordingLocked() Recorder {
+	s.recording = false
+
+	// Cancel timers
+	if s.timeoutTimer != nil {
+		s.timeoutTimer.Stop()
+	}
+	if s.warningTimer != nil {
+		s.warningTimer.Stop()
+	}
+	if s.streamCancel != nil {
+		s.streamCancel()
+		s.streamCancel = nil
+	}
+	if s.recordingDone != nil {
+		close(s.recordingDone)
+		s.recordingDone = nil
+	}
+
+	// Every caller goes on to processRecording; mark it now so WhenIdle
+	// never sees an idle gap once mu is released
+	s.transcribingMu.Lock()
+	s.transcribing = true
+	s.transcribingMu.Unlock()
+	s.processing.Add(1)
+
+	return s.recorder
+}
+
+// autoStop stops the recording from a timer or watcher goroutine, unless it
+// has already been stopped (or a newer recording has started)
+func (s *Session) autoStop(rec Recorder, reason string) {
+	s.mu.Lock()
+	if !s.recording || s.recorder != rec {
+		s.mu.Unlock()
+		return
+	}
+
+	fmt.Fprintf(s.out(), "\n%s\n", reason)
+	fmt.Fprintln(s.out(), "⏹  Recording stopped. Transcribing...")
+
+	s.stopRecordingLocked()
+	s.mu.Unlock()
+
+	s.processRecording(rec)
+}
+
+// processRecording stops the recorder and runs the pipeline on the captured audio.
+// Shared by the manual stop and the automatic stops.
+func (s *Session) processRecording(rec Recorder) {
+	p := s.Pipeline
+
+	// Clear the transcribing flag (set by stopRecordingLocked) however we return
+	defer func() {
+		s.transcribingMu.Lock()
+		s.transcribing = false
+		s.transcribingMu.Unlock()
+		s.processing.Done()
+	}()
+
+	// Play stop sound
+	if p.Feedback != nil {
+		if err := p.Feedback.PlayStopSound(); err != nil && p.Config.Verbose {
+			fmt.Fprintf(s.errOut(), "Warning: Failed to play stop sound: %v\n", err)
+		}
+	}
+
+	audioData, err := rec.Stop()
+	if err != nil {
+		fmt.Fprintf(s.errOut(), "Error stopping recording: %v\n", err)
+		return
+	}
+
+	// Skip accidental taps before they are transcribed and logged
+	if captured := rec.Duration(); captured < s.MinDuration {
+		if s.OnResult != nil {
+			s.OnResult(nil, fmt.Errorf("%w (%s, minimum %s)", ErrTooShort, captured.Round(time.Millisecond), s.MinDuration))
+		}
+		return
+	}
+
+	result, err := p.Process(Recording{
+		Audio:      audioData,
+		SampleRate: rec.GetSampleRate(),
+		Channels:   rec.GetChannels(),
+		Duration:   rec.Duration().Seconds(), // Captured audio, not wall-clock time
+	})
+	if s.OnResult != nil {
+		s.OnResult(result, err)
+	}
+}
+
+// startStreaming feeds newly captured audio to a chunked streamer and prints
+// partial transcriptions until the returned cancel func is called
+func (s *Session) startStreaming(rec Recorder) context.CancelFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+	cfg := s.Pipeline.Config
+	out := s.out()
+
+	// Snapshots are raw captures; the streamer gets mono audio at the output rate
+	// (16kHz), the same format the pipeline transcribes
+	captureRate, sampleRate, channels := rec.GetCaptureSampleRate(), rec.GetSampleRate(), rec.GetChannels()
+	streamer := transcription.NewChunkedStreamer(s.Pipeline.Transcriber, sampleRate, 1)
+	if cfg.Verbose {
+		streamer.OnError = func(err error) {
+			fmt.Fprintf(s.errOut(), "Warning: Partial transcription failed: %v\n", err)
+		}
+	}
+	audioChan := make(chan []byte)
+	opts := transcription.Options{
+		Model:    s.Pipeline.Model,
+		Language: cfg.Language,
+		Threads:  cfg.Threads,
+		Prompt:   cfg.Prompt,
+	}
+	results, err := streamer.TranscribeStreaming(ctx, audioChan, opts)
+	if err != nil {
+		fmt.Fprintf(s.errOut(), "Warning: Failed to start streaming transcription: %v\n", err)
+		return cancel
+	}
+
+	// Poll the recorder buffer and forward new audio
+	go func() {
+		defer close(audioChan)
+		ticker := time.NewTicker(500 * time.Millisecond)
+		defer ticker.Stop()
+
+		offset := 0
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				chunk := rec.Snapshot(offset)
+				// Only consume whole frames; a partial one is picked up next tick
+				chunk = chunk[:len(chunk)-len(chunk)%(int(channels)*2)]
+				if len(chunk) == 0 {
+					continue
+				}
+				offset += len(chunk)
+				chunk = streamChunk(chunk, captureRate, sampleRate, channels)
+				select {
+				case audioChan <- chunk:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	// Print partial results as they arrive
+	go func() {
+		for result := range results {
+			if result.Text != "" {
+				fmt.Fprintf(out, "   … %s\n", result.Text)
+			}
+		}
+	}()
+
+	return cancel
+}
+
+// streamChunk converts a raw capture chunk to the mono output-rate audio the streamer expects
+func streamChunk(chunk []byte, captureRate, sampleRate, channels uint32) []byte {
+	chunk = audio.DownmixToMono(chunk, channels)
+	return audio.Resample(chunk, captureRate, sampleRate, 1)
+}
+
+// WarningTime returns when to warn that a recording will soon hit maxDuration:
+// a minute before for long limits, otherwise at 80% of the limit
+func WarningTime(maxDuration time.Duration) time.Duration {
+	if maxDuration >= 2*time.Minute {
+		return maxDuration - time.Minute
+	}
+	return maxDuration * 4 / 5
+}
+
+// formatLimit formats a recording limit, e.g. "5 minutes", "1 minute" or "45s"
+func formatLimit(d time.Duration) string {
+	switch {
+	case d == time.Minute:
+		return "1 minute"
+	case d > 0 && d%time.Minute == 0:
+		return fmt.Sprintf("%d minutes", d/time.Minute)
+	case d >= time.Second:
+		return d.Round(time.Second).String()
+	default:
+		return d.String()
+	}
+}
+
+// out returns the writer for status messages
+func (s *Session) out() io.Writer {
+	if s.Pipeline.Out != nil {
+		return s.Pipeline.Out
+	}
+	return os.Stdout
+}
+
+// errOut returns the writer for errors
+func (s *Session) errOut() io.Writer {
+	if s.ErrOut != nil {
+		return s.ErrOut
+	}
+	return os.Stderr
+}
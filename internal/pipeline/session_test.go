@@ -0,0 +1,481 @@
+package pipeline
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/transcription"
+)
+
+// fakeRecorder returns canned audio when stopped
+type fakeRecorder struct {
+	audio    []byte
+	startErr error
+	started  bool
+	stopped  bool
+}
+
+func (r *fakeRecorder) Start() error {
+	r.started = true
+	return r.startErr
+}
+
+func (r *fakeRecorder) Stop() ([]byte, error) {
+	r.stopped = true
+	return r.audio, nil
+}
+
+func (r *fakeRecorder) GetSampleRate() uint32                      { return 16000 }
+func (r *fakeRecorder) GetCaptureSampleRate() uint32               { return 16000 }
+func (r *fakeRecorder) GetChannels() uint32                        { return 1 }
+func (r *fakeRecorder) SetSilenceDetection(time.Duration, float64) {}
+func (r *fakeRecorder) SilenceDetected() <-chan struct{}           { return nil }
+func (r *fakeRecorder) Snapshot(int) []byte                        { return nil }
+func (r *fakeRecorder) Duration() time.Duration {
+	return time.Duration(len(r.audio)/2) * time.Second / 16000
+}
+
+// fakeFeedback counts the sounds played
+type fakeFeedback struct {
+	start, stop, complete int
+}
+
+func (f *fakeFeedback) PlayStartSound() error    { f.start++; return nil }
+func (f *fakeFeedback) PlayStopSound() error     { f.stop++; return nil }
+func (f *fakeFeedback) PlayCompleteSound() error { f.complete++; return nil }
+func (f *fakeFeedback) Close() error             { return nil }
+
+type sessionOutcome struct {
+	result *Result
+	err    error
+}
+
+func newTestSession(t *testing.T, transcriber *transcription.FakeTranscriber, rec *fakeRecorder) (*Session, *fakeKeyboard, *[]logCall, *[]sessionOutcome) {
+	t.Helper()
+	p, kb, logged := newTestPipeline(t, transcriber)
+
+	var mu sync.Mutex
+	var outcomes []sessionOutcome
+	s := &Session{
+		Pipeline:     p,
+		NewRecorder:  func() Recorder { return rec },
+		MaxDuration:  time.Hour,
+		WarningAfter: time.Hour,
+		StopHint:     "press hotkey again to stop",
+		ErrOut:       &bytes.Buffer{},
+		OnResult: func(result *Result, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			outcomes = append(outcomes, sessionOutcome{result, err})
+		},
+	}
+	return s, kb, logged, &outcomes
+}
+
+func TestSession_ToggleRecordsTranscribesPastesAndLogs(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "hello world", Language: "en"}}
+	rec := &fakeRecorder{audio: sineWave()}
+	s, kb, logged, outcomes := newTestSession(t, transcriber, rec)
+	feedback := &fakeFeedback{}
+	s.Pipeline.Feedback = feedback
+
+	s.HandleToggle()
+	if !rec.started || rec.stopped {
+		t.Fatalf("after first toggle: started = %t, stopped = %t, want recording", rec.started, rec.stopped)
+	}
+
+	s.HandleToggle()
+	if !rec.stopped {
+		t.Fatal("second toggle did not stop the recorder")
+	}
+
+	if len(*outcomes) != 1 || (*outcomes)[0].err != nil || (*outcomes)[0].result.Text != "hello world" {
+		t.Fatalf("outcomes = %+v, want one successful result", *outcomes)
+	}
+	if len(kb.pasted) != 1 || kb.pasted[0] != "hello world" {
+		t.Errorf("pasted = %v, want [hello world]", kb.pasted)
+	}
+	if len(*logged) != 1 || (*logged)[0].text != "hello world" {
+		t.Errorf("logged = %+v", *logged)
+	}
+	// The logged duration is the captured audio (one second), not the time between toggles
+	if len(*logged) == 1 && (*logged)[0].duration != 1.0 {
+		t.Errorf("logged duration = %v, want 1", (*logged)[0].duration)
+	}
+	if feedback.start != 1 || feedback.stop != 1 || feedback.complete != 1 {
+		t.Errorf("sounds played = %+v, want one of each", *feedback)
+	}
+
+	// The session is idle again: the next toggle starts a new recording
+	rec.started = false
+	s.HandleToggle()
+	if !rec.started {
+		t.Error("third toggle did not start a new recording")
+	}
+}
+
+func TestSession_ToggleErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		audio   []byte
+		text    string
+		wantErr error
+	}{
+		{"no audio captured", nil, "unused", ErrNoAudio},
+		{"empty transcription", sineWave(), "", ErrNoSpeech},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: tt.text}}
+			s, kb, logged, outcomes := newTestSession(t, transcriber, &fakeRecorder{audio: tt.audio})
+
+			s.HandleToggle()
+			s.HandleToggle()
+
+			if len(*outcomes) != 1 || !errors.Is((*outcomes)[0].err, tt.wantErr) {
+				t.Fatalf("outcomes = %+v, want one with %v", *outcomes, tt.wantErr)
+			}
+			if len(kb.pasted) != 0 || len(*logged) != 0 {
+				t.Errorf("pasted %v and logged %v on error", kb.pasted, *logged)
+			}
+		})
+	}
+}
+
+func TestSession_RecorderStartFailure(t *testing.T) {
+	rec := &fakeRecorder{startErr: errors.New("device busy")}
+	s, _, _, outcomes := newTestSession(t, &transcription.FakeTranscriber{}, rec)
+
+	s.HandleToggle()
+
+	// A failed start leaves the session idle, so the next toggle tries again
+	rec.started = false
+	s.HandleToggle()
+	if !rec.started || rec.stopped {
+		t.Errorf("started = %t, stopped = %t, want a new recording attempt", rec.started, rec.stopped)
+	}
+	if len(*outcomes) != 0 {
+		t.Errorf("outcomes = %+v, want none", *outcomes)
+	}
+}
+
+func TestSession_HoldPressAndRelease(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "held"}}
+	rec := &fakeRecorder{audio: sineWave()}
+	s, _, _, outcomes := newTestSession(t, transcriber, rec)
+
+	// A release without a recording is ignored
+	s.HandleRelease()
+	if rec.started || len(*outcomes) != 0 {
+		t.Fatal("release without a recording should do nothing")
+	}
+
+	s.HandlePress()
+	s.HandlePress() // Key repeat while held doesn't restart the recording
+	s.HandleRelease()
+
+	if len(*outcomes) != 1 || (*outcomes)[0].result == nil || (*outcomes)[0].result.Text != "held" {
+		t.Errorf("outcomes = %+v, want one result", *outcomes)
+	}
+}
+
+func TestSession_AutoStopAtMaxDuration(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "timed out"}}
+	rec := &fakeRecorder{audio: sineWave()}
+	s, _, _, _ := newTestSession(t, transcriber, rec)
+
+	done := make(chan sessionOutcome, 1)
+	s.OnResult = func(result *Result, err error) { done <- sessionOutcome{result, err} }
+	s.MaxDuration = 10 * time.Millisecond
+
+	s.HandleToggle()
+
+	select {
+	case outcome := <-done:
+		if outcome.err != nil || outcome.result.Text != "timed out" {
+			t.Errorf("outcome = %+v, want the transcription", outcome)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("recording was not stopped at the maximum duration")
+	}
+
+	// Once processing finishes, the next toggle starts a new recording instead of stopping
+	s.WhenIdle(func() { rec.started = false })
+	s.HandleToggle()
+	if !rec.started {
+		t.Error("toggle after auto-stop did not start a new recording")
+	}
+}
+
+func TestSession_AutoStopNotice(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "capped"}}
+	s, _, _, _ := newTestSession(t, transcriber, &fakeRecorder{audio: sineWave()})
+	out := &lockedBuffer{}
+	s.Pipeline.Out = out
+
+	done := make(chan struct{})
+	s.OnResult = func(*Result, error) { close(done) }
+	s.MaxDuration = 500 * time.Millisecond
+	s.WarningAfter = 100 * time.Millisecond
+
+	s.HandleToggle()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("recording was not stopped at the maximum duration")
+	}
+
+	s.WhenIdle(func() {
+		got := out.String()
+		for _, want := range []string{"Maximum recording time: 500ms", "Recording has been running for 100ms", "Will auto-stop in 400ms", "automatically stopped after 500ms (max duration)"} {
+			if !strings.Contains(got, want) {
+				t.Errorf("output missing %q:\n%s", want, got)
+			}
+		}
+	})
+}
+
+// lockedBuffer is a bytes.Buffer safe for the session's timer goroutines
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWarningTime(t *testing.T) {
+	tests := []struct {
+		max  time.Duration
+		want time.Duration
+	}{
+		{5 * time.Minute, 4 * time.Minute},
+		{2 * time.Minute, time.Minute},
+		{time.Minute, 48 * time.Second},
+		{10 * time.Second, 8 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := WarningTime(tt.max); got != tt.want {
+			t.Errorf("WarningTime(%v) = %v, want %v", tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestFormatLimit(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{5 * time.Minute, "5 minutes"},
+		{time.Minute, "1 minute"},
+		{90 * time.Second, "1m30s"},
+		{45 * time.Second, "45s"},
+		{400 * time.Millisecond, "400ms"},
+	}
+
+	for _, tt := range tests {
+		if got := formatLimit(tt.d); got != tt.want {
+			t.Errorf("formatLimit(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestSession_WhenIdle(t *testing.T) {
+	s, _, _, _ := newTestSession(t, &transcription.FakeTranscriber{Result: &transcription.Result{Text: "x"}}, &fakeRecorder{audio: sineWave()})
+
+	ran := false
+	s.WhenIdle(func() { ran = true })
+	if !ran {
+		t.Error("WhenIdle did not run fn on an idle session")
+	}
+}
+
+func TestSession_ShutdownFinalizesRecording(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "last words"}}
+	rec := &fakeRecorder{audio: sineWave()}
+	s, kb, logged, outcomes := newTestSession(t, transcriber, rec)
+
+	s.HandleToggle()
+	if err := s.Shutdown(5 * time.Second); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	if !rec.stopped {
+		t.Fatal("Shutdown() did not stop the recorder")
+	}
+	if len(*outcomes) != 1 || (*outcomes)[0].err != nil || (*outcomes)[0].result.Text != "last words" {
+		t.Fatalf("outcomes = %+v, want one successful result", *outcomes)
+	}
+	if len(kb.pasted) != 1 || kb.pasted[0] != "last words" {
+		t.Errorf("pasted = %v, want [last words]", kb.pasted)
+	}
+	if len(*logged) != 1 || (*logged)[0].text != "last words" {
+		t.Errorf("logged = %+v", *logged)
+	}
+
+	// No recording starts once the session is shut down
+	rec.started = false
+	s.HandleToggle()
+	if rec.started {
+		t.Error("toggle after Shutdown() started a new recording")
+	}
+}
+
+func TestSession_ShutdownWhileIdle(t *testing.T) {
+	transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "unused"}}
+	s, _, _, outcomes := newTestSession(t, transcriber, &fakeRecorder{audio: sineWave()})
+
+	if err := s.Shutdown(time.Second); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if len(*outcomes) != 0 || len(transcriber.Calls()) != 0 {
+		t.Errorf("Shutdown() while idle processed something: outcomes = %+v", *outcomes)
+	}
+}
+
+func TestSession_ShutdownTimeout(t *testing.T) {
+	release := make(chan struct{})
+	transcriber := &transcription.FakeTranscriber{
+		TranscribeFunc: func(string, transcription.Options) (*transcription.Result, error) {
+			<-release
+			return &transcription.Result{Text: "too late"}, nil
+		},
+	}
+	s, _, _, _ := newTestSession(t, transcriber, &fakeRecorder{audio: sineWave()})
+	done := make(chan struct{})
+	s.OnResult = func(*Result, error) { close(done) }
+
+	s.HandleToggle()
+	start := time.Now()
+	err := s.Shutdown(50 * time.Millisecond)
+	if !errors.Is(err, ErrShutdownTimeout) {
+		t.Errorf("Shutdown() error = %v, want ErrShutdownTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Shutdown() took %v, want it bounded by the timeout", elapsed)
+	}
+
+	// Let the abandoned transcription finish before the test's HOME is removed
+	close(release)
+	<-done
+}
+
+func TestSession_ShutdownWaitsForRunningTranscription(t *testing.T) {
+	tests := []struct {
+		name string
+		stop func(s *Session)
+	}{
+		{"toggle stop", func(s *Session) { s.HandleToggle(); s.HandleToggle() }},
+		{"hold release", func(s *Session) { s.HandlePress(); s.HandleRelease() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entered := make(chan struct{})
+			release := make(chan struct{})
+			transcriber := &transcription.FakeTranscriber{
+				TranscribeFunc: func(string, transcription.Options) (*transcription.Result, error) {
+					close(entered)
+					<-release
+					return &transcription.Result{Text: "in flight"}, nil
+				},
+			}
+			s, _, _, _ := newTestSession(t, transcriber, &fakeRecorder{audio: sineWave()})
+			done := make(chan struct{})
+			s.OnResult = func(*Result, error) { close(done) }
+
+			// The recording is already stopped and blocked inside TranscribeFile
+			go tt.stop(s)
+			<-entered
+
+			start := time.Now()
+			err := s.Shutdown(50 * time.Millisecond)
+			if !errors.Is(err, ErrShutdownTimeout) {
+				t.Errorf("Shutdown() error = %v, want ErrShutdownTimeout", err)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("Shutdown() took %v, want it bounded by the timeout", elapsed)
+			}
+
+			close(release)
+			<-done
+		})
+	}
+}
+
+func TestSession_MinDuration(t *testing.T) {
+	tests := []struct {
+		name       string
+		durationMS int
+		wantSkip   bool
+	}{
+		{"just under the minimum", 299, true},
+		{"at the minimum", 300, false},
+		{"just over the minimum", 301, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transcriber := &transcription.FakeTranscriber{Result: &transcription.Result{Text: "short"}}
+			// 16 kHz mono 16-bit audio is 32 bytes per millisecond
+			rec := &fakeRecorder{audio: sineWave()[:tt.durationMS*32]}
+			s, kb, logged, outcomes := newTestSession(t, transcriber, rec)
+			s.MinDuration = 300 * time.Millisecond
+
+			s.HandleToggle()
+			s.HandleToggle()
+
+			if len(*outcomes) != 1 {
+				t.Fatalf("outcomes = %+v, want one", *outcomes)
+			}
+			err := (*outcomes)[0].err
+			if tt.wantSkip {
+				if !errors.Is(err, ErrTooShort) {
+					t.Errorf("error = %v, want ErrTooShort", err)
+				}
+				if len(transcriber.Calls()) != 0 || len(kb.pasted) != 0 || len(*logged) != 0 {
+					t.Errorf("short recording was transcribed (%d calls), pasted %v or logged %+v", len(transcriber.Calls()), kb.pasted, *logged)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("error = %v, want the recording transcribed", err)
+			}
+			if len(*logged) != 1 {
+				t.Errorf("logged = %+v, want the transcription logged", *logged)
+			}
+		})
+	}
+}
+
+func TestStreamChunk_ConvertsToMonoOutputRate(t *testing.T) {
+	// 48kHz stereo, 100ms: 4800 frames of 4 bytes
+	chunk := make([]byte, 4800*4)
+
+	got := streamChunk(chunk, 48000, 16000, 2)
+	if want := 1600 * 2; len(got) != want {
+		t.Errorf("streamChunk() returned %d bytes, want %d (100ms of 16kHz mono)", len(got), want)
+	}
+
+	// Audio already in the output format passes through
+	mono := sineWave()
+	if got := streamChunk(mono, 16000, 16000, 1); len(got) != len(mono) {
+		t.Errorf("streamChunk() on 16kHz mono returned %d bytes, want %d", len(got), len(mono))
+	}
+}
@@ -0,0 +1,14 @@
+// Package progress shows that a long-running step is still working.
+//
+// A Spinner draws a single status line with an animation frame and the elapsed
+// time, e.g. "⠹ Transcribing... 12s", redrawing it in place until stopped and
+// then erasing it. It only draws when its output is a terminal, so piped or
+// redirected output stays clean.
+//
+// Example usage:
+//
+//	spinner := progress.New(os.Stderr, "Transcribing...")
+//	spinner.Start()
+//	result, err := transcriber.TranscribeFile(path, opts)
+//	spinner.Stop()
+package progress
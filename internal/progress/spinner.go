@@ -0,0 +1,116 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// DefaultInterval is how often a Spinner redraws its line
+const DefaultInterval = 100 * time.Millisecond
+
+// frames are drawn in turn, one per tick
+var frames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Spinner animates a status line with the elapsed time while an operation runs.
+// Start and Stop may be called repeatedly (one operation after another); a Spinner
+// with Enabled false does nothing. Safe for concurrent use.
+type Spinner struct {
+	// Enabled draws the spinner; New sets it when the output is a terminal
+	Enabled bool
+
+	out     io.Writer
+	message string
+
+	// newTicker and now are replaced in tests
+	newTicker func() (<-chan time.Time, func())
+	now       func() time.Time
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New creates a Spinner that draws message on out, enabled only if out is a terminal
+func New(out io.Writer, message string) *Spinner {
+	return &Spinner{
+		Enabled: IsTerminal(out),
+		out:     out,
+		message: message,
+		newTicker: func() (<-chan time.Time, func()) {
+			ticker := time.NewTicker(DefaultInterval)
+			return ticker.C, ticker.Stop
+		},
+		now: time.Now,
+	}
+}
+
+// IsTerminal reports whether w is a terminal (and not a pipe or file)
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
+// Start draws the spinner and keeps it updating until Stop. It does nothing if the
+// spinner is disabled or already running.
+func (s *Spinner) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.Enabled || s.stop != nil {
+		return
+	}
+
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	ticks, stopTicker := s.newTicker()
+	go s.run(s.now(), ticks, stopTicker, s.stop, s.done)
+}
+
+// Stop halts the spinner and erases its line. It does nothing if the spinner isn't running.
+func (s *Spinner) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop == nil {
+		return
+	}
+
+	close(s.stop)
+	<-s.done
+	s.stop, s.done = nil, nil
+}
+
+// run redraws the line on every tick (elapsed time is measured to the tick's time)
+// until stop is closed, then clears it
+func (s *Spinner) run(started time.Time, ticks <-chan time.Time, stopTicker func(), stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	defer stopTicker()
+
+	width := 0
+	draw := func(frame int, elapsed time.Duration) {
+		line := s.render(frame, elapsed)
+		// Pad over anything left from a longer previous line
+		fmt.Fprintf(s.out, "\r%-*s", width, line)
+		width = max(width, len([]rune(line)))
+	}
+
+	draw(0, 0)
+	for frame := 1; ; frame++ {
+		select {
+		case <-stop:
+			fmt.Fprintf(s.out, "\r%s\r", strings.Repeat(" ", width))
+			return
+		case tick := <-ticks:
+			draw(frame, tick.Sub(started))
+		}
+	}
+}
+
+// render returns the spinner line for an animation frame and elapsed time, e.g. "⠹ Transcribing... 12s"
+func (s *Spinner) render(frame int, elapsed time.Duration) string {
+	return fmt.Sprintf("%s %s %s", frames[frame%len(frames)], s.message, elapsed.Truncate(time.Second))
+}
@@ -0,0 +1,81 @@
+package progress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startTime is when test spinners start
+var startTime = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// newTestSpinner returns an enabled spinner started at startTime and driven by the returned tick channel
+func newTestSpinner(out *bytes.Buffer) (*Spinner, chan time.Time) {
+	ticks := make(chan time.Time)
+	s := New(out, "Transcribing...")
+	s.Enabled = true
+	s.newTicker = func() (<-chan time.Time, func()) { return ticks, func() {} }
+	s.now = func() time.Time { return startTime }
+	return s, ticks
+}
+
+func TestSpinner_DrawsElapsedTimeAndClears(t *testing.T) {
+	var out bytes.Buffer
+	s, ticks := newTestSpinner(&out)
+
+	s.Start()
+	ticks <- startTime.Add(1500 * time.Millisecond)
+	ticks <- startTime.Add(2500 * time.Millisecond)
+	s.Stop()
+
+	got := out.String()
+	for _, want := range []string{"\r⠋ Transcribing... 0s", "\r⠙ Transcribing... 1s", "\r⠹ Transcribing... 2s"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output %q does not contain %q", got, want)
+		}
+	}
+	// The line is erased when stopped
+	width := len([]rune("⠹ Transcribing... 2s"))
+	if clear := "\r" + strings.Repeat(" ", width) + "\r"; !strings.HasSuffix(got, clear) {
+		t.Errorf("output %q does not end by clearing the line", got)
+	}
+}
+
+func TestSpinner_StartStopAreIdempotent(t *testing.T) {
+	var out bytes.Buffer
+	s, ticks := newTestSpinner(&out)
+
+	s.Stop() // Not running: nothing to do
+	s.Start()
+	s.Start() // Already running
+	s.Stop()
+	s.Stop()
+	if got := strings.Count(out.String(), "⠋"); got != 1 {
+		t.Errorf("drew the first frame %d times, want 1", got)
+	}
+
+	// A stopped spinner can run again
+	out.Reset()
+	s.Start()
+	ticks <- startTime
+	s.Stop()
+	if !strings.Contains(out.String(), "⠙ Transcribing...") {
+		t.Errorf("restarted spinner output = %q", out.String())
+	}
+}
+
+func TestSpinner_Disabled(t *testing.T) {
+	var out bytes.Buffer
+
+	// A buffer isn't a terminal, so New leaves the spinner off
+	s := New(&out, "Transcribing...")
+	if s.Enabled {
+		t.Fatal("New() enabled the spinner for a non-terminal writer")
+	}
+	s.Start()
+	s.Stop()
+	if out.Len() != 0 {
+		t.Errorf("disabled spinner wrote %q", out.String())
+	}
+}
@@ -0,0 +1,22 @@
+// Package textproc formats transcriptions before they are pasted.
+//
+// This package handles:
+//   - Trimming surrounding whitespace
+//   - Capitalizing the first letter
+//   - Ending the text with punctuation
+//   - Adding a trailing space so consecutive dictations don't run together
+//   - Replacing words the model consistently gets wrong (replacements)
+//   - Masking words that must not be pasted or logged (redact)
+//
+// Each transform is a pure string function; Apply runs the ones enabled in
+// the configuration (capitalize_first, ensure_punctuation, trailing_space).
+//
+// Example usage:
+//
+//	text := textproc.Apply(result.Text, textproc.OptionsFromConfig(cfg))
+//	// "hello world" → "Hello world. "
+//
+//	rules := []textproc.Rule{{From: "cube and eddies", To: "Kubernetes"}}
+//	text = textproc.ApplyReplacements("deploy to cube and eddies", rules)
+//	// "deploy to Kubernetes"
+package textproc
@@ -0,0 +1,35 @@
+package textproc
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+// Redact masks each listed word or phrase with asterisks of the same length.
+// Matching ignores case and only covers whole words.
+func Redact(text string, words []string) string {
+	return RedactWithMask(text, words, "")
+}
+
+// RedactWithMask replaces each listed word or phrase with mask, or with asterisks
+// of the same length when mask is empty. Matching ignores case and only covers whole words.
+func RedactWithMask(text string, words []string, mask string) string {
+	rules := make([]Rule, 0, len(words))
+	for _, word := range words {
+		rules = append(rules, Rule{From: strings.TrimSpace(word)})
+	}
+
+	return applyRules(text, compileRules(rules), func(out []byte, _ compiledRule, src string, match []int) []byte {
+		if mask != "" {
+			return append(out, mask...)
+		}
+		return append(out, strings.Repeat("*", utf8.RuneCountInString(src[match[0]:match[1]]))...)
+	})
+}
+
+// RedactFromConfig masks the words listed in the user's configuration
+func RedactFromConfig(text string, cfg *config.Config) string {
+	return RedactWithMask(text, cfg.Redact, cfg.RedactMask)
+}
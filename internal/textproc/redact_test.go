@@ -0,0 +1,61 @@
+package textproc
+
+import (
+	"testing"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		words []string
+		want  string
+	}{
+		{"no words", "darn it", nil, "darn it"},
+		{"empty text", "", []string{"darn"}, ""},
+		{"preserves length", "darn it", []string{"darn"}, "**** it"},
+		{"case-insensitive", "DARN it, Darn", []string{"darn"}, "**** it, ****"},
+		{"multiple occurrences", "darn, darn and darn", []string{"darn"}, "****, **** and ****"},
+		{"partial words untouched", "darned darn undarn", []string{"darn"}, "darned **** undarn"},
+		{"several words", "heck and darn", []string{"darn", "heck"}, "**** and ****"},
+		{"phrase", "project falcon launches", []string{"project falcon"}, "************** launches"},
+		{"unicode length in runes", "merde alors", []string{"merde"}, "***** alors"},
+		{"accented word", "zut ÇA suffit", []string{"ça"}, "zut ** suffit"},
+		{"blank words ignored", "darn it", []string{"", "  "}, "darn it"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Redact(tt.text, tt.words); got != tt.want {
+				t.Errorf("Redact(%q, %q) = %q, want %q", tt.text, tt.words, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactWithMask(t *testing.T) {
+	got := RedactWithMask("darn it, darn it all", []string{"darn"}, "[redacted]")
+	want := "[redacted] it, [redacted] it all"
+	if got != want {
+		t.Errorf("RedactWithMask() = %q, want %q", got, want)
+	}
+}
+
+func TestRedactFromConfig(t *testing.T) {
+	cfg := config.DefaultConfig()
+	if got := RedactFromConfig("darn it", cfg); got != "darn it" {
+		t.Errorf("RedactFromConfig() with no words = %q, want unchanged", got)
+	}
+
+	cfg.Redact = []string{"darn"}
+	if got := RedactFromConfig("darn it", cfg); got != "**** it" {
+		t.Errorf("RedactFromConfig() = %q, want %q", got, "**** it")
+	}
+
+	cfg.RedactMask = "***"
+	if got := RedactFromConfig("darned darn", cfg); got != "darned ***" {
+		t.Errorf("RedactFromConfig() with mask = %q, want %q", got, "darned ***")
+	}
+}
@@ -0,0 +1,165 @@
+package textproc
+
+import (
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+// Rule replaces text matching From with To. Literal rules match whole words and
+// ignore case; Regex rules use From as a regular expression and may reference
+// capture groups ($1, ${name}) in To.
+type Rule struct {
+	From  string
+	To    string
+	Regex bool
+}
+
+// RulesFromConfig returns the replacement rules from the user's configuration
+func RulesFromConfig(cfg *config.Config) []Rule {
+	rules := make([]Rule, 0, len(cfg.Replacements))
+	for _, r := range cfg.Replacements {
+		rules = append(rules, Rule(r))
+	}
+	return rules
+}
+
+// compiledRule is a Rule with its compiled pattern. Literal patterns are anchored
+// to the current position; regex patterns run on the whole text so that anchors
+// and word boundaries (^, \A, \b) see the surrounding context.
+type compiledRule struct {
+	Rule
+	re *regexp.Regexp
+}
+
+// ApplyReplacements rewrites text in a single left-to-right pass, so a replacement
+// is never rewritten by another rule. Where several rules match at the same position
+// the longest match wins, then the first rule listed. Invalid regex rules are skipped.
+func ApplyReplacements(text string, rules []Rule) string {
+	return applyRules(text, compileRules(rules), func(out []byte, rule compiledRule, src string, match []int) []byte {
+		if rule.Regex {
+			return rule.re.ExpandString(out, rule.To, src, match)
+		}
+		return append(out, rule.To...)
+	})
+}
+
+// applyRules scans text once, replacing the best rule match at each position
+// with the output of replace. src is the whole text and match the submatch
+// indexes of the replaced match within it.
+func applyRules(text string, rules []compiledRule, replace func(out []byte, rule compiledRule, src string, match []int) []byte) string {
+	if len(rules) == 0 || text == "" {
+		return text
+	}
+
+	// Regex matches are found up front on the whole text; next tracks each
+	// rule's first match that doesn't start before the current position
+	regexMatches := make([][][]int, len(rules))
+	next := make([]int, len(rules))
+	for n, rule := range rules {
+		if rule.Regex {
+			regexMatches[n] = rule.re.FindAllStringSubmatchIndex(text, -1)
+		}
+	}
+
+	var out []byte
+	i := 0
+	for i < len(text) {
+		best, bestEnd := -1, i
+		var bestMatch []int
+		for n, rule := range rules {
+			var m []int
+			if rule.Regex {
+				matches := regexMatches[n]
+				for next[n] < len(matches) && matches[next[n]][0] < i {
+					next[n]++
+				}
+				if next[n] == len(matches) || matches[next[n]][0] != i {
+					continue
+				}
+				m = matches[next[n]]
+			} else {
+				m = rule.re.FindStringSubmatchIndex(text[i:])
+				if m == nil {
+					continue
+				}
+				m = offsetMatch(m, i)
+				if !atWordBoundaries(text, i, m[1]) {
+					continue
+				}
+			}
+			if m[1] > bestEnd {
+				best, bestEnd, bestMatch = n, m[1], m
+			}
+		}
+
+		if best == -1 {
+			_, size := utf8.DecodeRuneInString(text[i:])
+			out = append(out, text[i:i+size]...)
+			i += size
+			continue
+		}
+
+		out = replace(out, rules[best], text, bestMatch)
+		i = bestEnd
+	}
+
+	return string(out)
+}
+
+// offsetMatch shifts submatch indexes found in text[offset:] so they index into text
+func offsetMatch(match []int, offset int) []int {
+	shifted := make([]int, len(match))
+	for k, idx := range match {
+		if idx >= 0 {
+			idx += offset
+		}
+		shifted[k] = idx
+	}
+	return shifted
+}
+
+// compileRules compiles each rule's pattern. Literal rules are anchored so they
+// only match at the start of the input they are given.
+func compileRules(rules []Rule) []compiledRule {
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.From == "" {
+			continue
+		}
+		pattern := `^(?i:` + regexp.QuoteMeta(rule.From) + `)`
+		if rule.Regex {
+			pattern = rule.From
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			continue
+		}
+		compiled = append(compiled, compiledRule{Rule: rule, re: re})
+	}
+	return compiled
+}
+
+// atWordBoundaries reports whether text[start:end] is not part of a longer word.
+// Only word characters at the edges of the match need a boundary, so rules such
+// as "c++" still match before punctuation.
+func atWordBoundaries(text string, start, end int) bool {
+	if first, _ := utf8.DecodeRuneInString(text[start:]); isWordChar(first) && start > 0 {
+		if prev, _ := utf8.DecodeLastRuneInString(text[:start]); isWordChar(prev) {
+			return false
+		}
+	}
+	if last, _ := utf8.DecodeLastRuneInString(text[:end]); isWordChar(last) && end < len(text) {
+		if next, _ := utf8.DecodeRuneInString(text[end:]); isWordChar(next) {
+			return false
+		}
+	}
+	return true
+}
+
+// isWordChar reports whether r is part of a word: a letter, digit, combining mark or underscore
+func isWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r) || r == '_'
+}
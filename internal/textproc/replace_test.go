@@ -0,0 +1,176 @@
+package textproc
+
+import (
+	"testing"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+func TestApplyReplacements(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		rules []Rule
+		want  string
+	}{
+		{
+			name:  "no rules",
+			text:  "cube and eddies",
+			rules: nil,
+			want:  "cube and eddies",
+		},
+		{
+			name:  "literal phrase",
+			text:  "deploy it on cube and eddies today",
+			rules: []Rule{{From: "cube and eddies", To: "Kubernetes"}},
+			want:  "deploy it on Kubernetes today",
+		},
+		{
+			name:  "case-insensitive",
+			text:  "Cube And Eddies is great",
+			rules: []Rule{{From: "cube and eddies", To: "Kubernetes"}},
+			want:  "Kubernetes is great",
+		},
+		{
+			name:  "every occurrence",
+			text:  "git hub and git hub",
+			rules: []Rule{{From: "git hub", To: "GitHub"}},
+			want:  "GitHub and GitHub",
+		},
+		{
+			name:  "whole words only",
+			text:  "the cat scattered the catalog",
+			rules: []Rule{{From: "cat", To: "dog"}},
+			want:  "the dog scattered the catalog",
+		},
+		{
+			name:  "boundary at punctuation",
+			text:  "cat, cat. (cat)",
+			rules: []Rule{{From: "cat", To: "dog"}},
+			want:  "dog, dog. (dog)",
+		},
+		{
+			name:  "rule ending in punctuation",
+			text:  "I write c++ code",
+			rules: []Rule{{From: "c++", To: "C++"}},
+			want:  "I write C++ code",
+		},
+		{
+			name:  "unicode word boundaries",
+			text:  "café cafés écafé",
+			rules: []Rule{{From: "café", To: "coffee"}},
+			want:  "coffee cafés écafé",
+		},
+		{
+			name:  "unicode case folding",
+			text:  "ÉCOLE et école",
+			rules: []Rule{{From: "école", To: "school"}},
+			want:  "school et school",
+		},
+		{
+			name: "overlapping rules: longest match wins",
+			text: "new york city and york",
+			rules: []Rule{
+				{From: "york", To: "York"},
+				{From: "new york city", To: "NYC"},
+			},
+			want: "NYC and York",
+		},
+		{
+			name: "same length: first rule wins",
+			text: "jason",
+			rules: []Rule{
+				{From: "jason", To: "JSON"},
+				{From: "Jason", To: "Jason"},
+			},
+			want: "JSON",
+		},
+		{
+			name: "replacements are not rewritten",
+			text: "a b",
+			rules: []Rule{
+				{From: "a", To: "b"},
+				{From: "b", To: "c"},
+			},
+			want: "b c",
+		},
+		{
+			name:  "regex with capture group",
+			text:  "version 1 point 2",
+			rules: []Rule{{From: `(\d+) point (\d+)`, To: "$1.$2", Regex: true}},
+			want:  "version 1.2",
+		},
+		{
+			name:  "regex is case-sensitive unless flagged",
+			text:  "Foo foo",
+			rules: []Rule{{From: `foo`, To: "bar", Regex: true}},
+			want:  "Foo bar",
+		},
+		{
+			name:  "regex has no implicit word boundaries",
+			text:  "football",
+			rules: []Rule{{From: `foot`, To: "hand", Regex: true}},
+			want:  "handball",
+		},
+		{
+			name:  "regex word boundary sees the surrounding text",
+			text:  "concat the cat",
+			rules: []Rule{{From: `\bcat\b`, To: "dog", Regex: true}},
+			want:  "concat the dog",
+		},
+		{
+			name:  "regex start anchor only matches at the start",
+			text:  "um so um the plan",
+			rules: []Rule{{From: `^um `, To: "", Regex: true}},
+			want:  "so um the plan",
+		},
+		{
+			name:  "regex and literal rules share one pass",
+			text:  "scatter cat",
+			rules: []Rule{{From: `\bcat`, To: "dog", Regex: true}, {From: "scatter", To: "spread"}},
+			want:  "spread dog",
+		},
+		{
+			name:  "empty regex match is ignored",
+			text:  "abc",
+			rules: []Rule{{From: `x*`, To: "-", Regex: true}},
+			want:  "abc",
+		},
+		{
+			name:  "invalid regex is skipped",
+			text:  "abc",
+			rules: []Rule{{From: `(`, To: "-", Regex: true}, {From: "abc", To: "xyz"}},
+			want:  "xyz",
+		},
+		{
+			name:  "empty from is skipped",
+			text:  "abc",
+			rules: []Rule{{From: "", To: "-"}},
+			want:  "abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ApplyReplacements(tt.text, tt.rules); got != tt.want {
+				t.Errorf("ApplyReplacements(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRulesFromConfig(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Replacements = []config.Replacement{
+		{From: "cube and eddies", To: "Kubernetes"},
+		{From: `(\d+) percent`, To: "$1%", Regex: true},
+	}
+
+	rules := RulesFromConfig(cfg)
+	if len(rules) != 2 {
+		t.Fatalf("RulesFromConfig() returned %d rules, want 2", len(rules))
+	}
+	if rules[1] != (Rule{From: `(\d+) percent`, To: "$1%", Regex: true}) {
+		t.Errorf("rules[1] = %+v", rules[1])
+	}
+}
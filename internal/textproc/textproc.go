@@ -0,0 +1,96 @@
+package textproc
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+// Options selects the transforms Apply runs
+type Options struct {
+	CapitalizeFirst   bool
+	EnsurePunctuation bool
+	TrailingSpace     bool
+}
+
+// OptionsFromConfig builds transform options from the user's configuration
+func OptionsFromConfig(cfg *config.Config) Options {
+	return Options{
+		CapitalizeFirst:   cfg.CapitalizeFirst,
+		EnsurePunctuation: cfg.EnsurePunctuation,
+		TrailingSpace:     cfg.TrailingSpace,
+	}
+}
+
+// Apply trims text and runs the enabled transforms. The trailing space is added
+// last so it isn't removed or punctuated.
+func Apply(text string, opts Options) string {
+	text = Trim(text)
+	if opts.CapitalizeFirst {
+		text = CapitalizeFirst(text)
+	}
+	if opts.EnsurePunctuation {
+		text = EnsurePunctuation(text)
+	}
+	if opts.TrailingSpace {
+		text = AddTrailingSpace(text)
+	}
+	return text
+}
+
+// Trim removes leading and trailing whitespace
+func Trim(text string) string {
+	return strings.TrimSpace(text)
+}
+
+// CapitalizeFirst upper-cases the first letter, skipping leading quotes and
+// punctuation. Text starting with a digit is left unchanged.
+func CapitalizeFirst(text string) string {
+	for i, r := range text {
+		if unicode.IsDigit(r) {
+			return text
+		}
+		if unicode.IsLetter(r) {
+			upper := unicode.ToUpper(r)
+			if upper == r {
+				return text
+			}
+			return text[:i] + string(upper) + text[i+utf8.RuneLen(r):]
+		}
+	}
+	return text
+}
+
+// terminalPunctuation ends a sentence
+const terminalPunctuation = ".!?…。！？"
+
+// closingMarks may follow the terminal punctuation, e.g. `He said "hi."`
+const closingMarks = `"')]»”’`
+
+// EnsurePunctuation appends a period unless the text already ends with
+// terminal punctuation (possibly followed by closing quotes or brackets)
+func EnsurePunctuation(text string) string {
+	trimmed := strings.TrimRight(text, " \t\n")
+	if trimmed == "" {
+		return text
+	}
+
+	body := strings.TrimRight(trimmed, closingMarks)
+	if body != "" {
+		last, _ := utf8.DecodeLastRuneInString(body)
+		if strings.ContainsRune(terminalPunctuation, last) {
+			return text
+		}
+	}
+	return trimmed + "." + text[len(trimmed):]
+}
+
+// AddTrailingSpace ends non-empty text with a space
+func AddTrailingSpace(text string) string {
+	if text == "" || strings.HasSuffix(text, " ") {
+		return text
+	}
+	return text + " "
+}
@@ -0,0 +1,123 @@
+package textproc
+
+import (
+	"testing"
+
+	"github.com/alexandrelam/openscribe/internal/config"
+)
+
+func TestCapitalizeFirst(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"hello world", "Hello world"},
+		{"Hello world", "Hello world"},
+		{"élan vital", "Élan vital"},
+		{"über", "Über"},
+		{`"quoted" text`, `"Quoted" text`},
+		{"...and then", "...And then"},
+		{"3 apples", "3 apples"},
+		{"你好", "你好"},
+		{"!!!", "!!!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := CapitalizeFirst(tt.input); got != tt.want {
+				t.Errorf("CapitalizeFirst(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnsurePunctuation(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"   ", "   "},
+		{"hello", "hello."},
+		{"hello.", "hello."},
+		{"really?", "really?"},
+		{"wow!", "wow!"},
+		{"and so…", "and so…"},
+		{"你好。", "你好。"},
+		{`he said "hi."`, `he said "hi."`},
+		{`he said "hi"`, `he said "hi".`},
+		{"(aside)", "(aside)."},
+		{"hello ", "hello. "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := EnsurePunctuation(tt.input); got != tt.want {
+				t.Errorf("EnsurePunctuation(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddTrailingSpace(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"hello", "hello "},
+		{"hello ", "hello "},
+		{"café", "café "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := AddTrailingSpace(tt.input); got != tt.want {
+				t.Errorf("AddTrailingSpace(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	all := Options{CapitalizeFirst: true, EnsurePunctuation: true, TrailingSpace: true}
+
+	tests := []struct {
+		name  string
+		input string
+		opts  Options
+		want  string
+	}{
+		{"no transforms only trims", "  hello world \n", Options{}, "hello world"},
+		{"all transforms", " hello world ", all, "Hello world. "},
+		{"already formatted", "Hello world. ", all, "Hello world. "},
+		{"empty stays empty", "   ", all, ""},
+		{"unicode", "ça va", all, "Ça va. "},
+		{"capitalize only", "hello", Options{CapitalizeFirst: true}, "Hello"},
+		{"punctuation only", "hello", Options{EnsurePunctuation: true}, "hello."},
+		{"trailing space only", "hello", Options{TrailingSpace: true}, "hello "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Apply(tt.input, tt.opts); got != tt.want {
+				t.Errorf("Apply(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptionsFromConfig(t *testing.T) {
+	cfg := config.DefaultConfig()
+	if got := OptionsFromConfig(cfg); got != (Options{}) {
+		t.Errorf("OptionsFromConfig(default) = %+v, want all transforms off", got)
+	}
+
+	cfg.CapitalizeFirst = true
+	cfg.TrailingSpace = true
+	want := Options{CapitalizeFirst: true, TrailingSpace: true}
+	if got := OptionsFromConfig(cfg); got != want {
+		t.Errorf("OptionsFromConfig() = %+v, want %+v", got, want)
+	}
+}
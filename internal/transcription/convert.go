@@ -0,0 +1,82 @@
+package transcription
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"github.com/alexandrelam/openscribe/internal/audio"
+)
+
+// whisperSampleRate is the sample rate whisper-cli and Moonshine expect
+const whisperSampleRate = 16000
+
+// ErrFFmpegNotFound is returned when an input file needs converting but ffmpeg isn't installed
+var ErrFFmpegNotFound = errors.New("ffmpeg not found in PATH. Install it to transcribe non-WAV audio: brew install ffmpeg")
+
+// CheckFFmpeg verifies that ffmpeg is installed
+func CheckFFmpeg() error {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		return ErrFFmpegNotFound
+	}
+	return nil
+}
+
+// NeedsConversion reports whether an audio file must be converted before
+// transcription, i.e. it isn't a 16-bit PCM, 16kHz mono WAV file
+func NeedsConversion(audioPath string) bool {
+	header, err := audio.ReadWAVHeader(audioPath)
+	if err != nil {
+		return true
+	}
+	return header.AudioFormat != 1 ||
+		header.BitsPerSample != 16 ||
+		header.SampleRate != whisperSampleRate ||
+		header.NumChannels != 1
+}
+
+// prepareAudio returns a path to a validated 16kHz mono WAV version of audioPath,
+// converting it with ffmpeg when needed. The returned cleanup func removes any temporary file.
+func prepareAudio(audioPath string) (string, func(), error) {
+	noop := func() {}
+	if !NeedsConversion(audioPath) {
+		// Catch empty or truncated files here rather than with a cryptic backend error
+		if err := audio.ValidateWAV(audioPath); err != nil {
+			return "", noop, fmt.Errorf("cannot transcribe %s: %w", audioPath, err)
+		}
+		return audioPath, noop, nil
+	}
+
+	ffmpegPath, err := exec.LookPath("ffmpeg")
+	if err != nil {
+		return "", noop, fmt.Errorf("%s is not a 16kHz mono WAV file: %w", audioPath, ErrFFmpegNotFound)
+	}
+
+	tmpFile, err := os.CreateTemp("", "openscribe-*.wav")
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to create temporary WAV file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	_ = tmpFile.Close()
+	cleanup := func() { _ = os.Remove(tmpPath) }
+
+	cmd := exec.Command(ffmpegPath,
+		"-nostdin", "-y", "-loglevel", "error",
+		"-i", audioPath,
+		"-ar", strconv.Itoa(whisperSampleRate),
+		"-ac", "1",
+		"-c:a", "pcm_s16le",
+		tmpPath,
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("ffmpeg failed to convert %s: %w\nStderr: %s", audioPath, err, stderr.String())
+	}
+
+	return tmpPath, cleanup, nil
+}
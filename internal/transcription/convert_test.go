@@ -0,0 +1,94 @@
+package transcription
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexandrelam/openscribe/internal/audio"
+)
+
+// writeTestWAV writes a short silent WAV file with the given format and returns its path
+func writeTestWAV(t *testing.T, sampleRate, channels uint32) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audio.wav")
+	if err := audio.SaveWAV(path, make([]byte, 3200), sampleRate, channels); err != nil {
+		t.Fatalf("SaveWAV() error = %v", err)
+	}
+	return path
+}
+
+func TestNeedsConversion(t *testing.T) {
+	notWAV := filepath.Join(t.TempDir(), "audio.m4a")
+	if err := os.WriteFile(notWAV, []byte("not a wav file, just some bytes to fill the header"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{"16kHz mono WAV", writeTestWAV(t, 16000, 1), false},
+		{"44.1kHz WAV", writeTestWAV(t, 44100, 1), true},
+		{"stereo WAV", writeTestWAV(t, 16000, 2), true},
+		{"non-WAV file", notWAV, true},
+		{"missing file", filepath.Join(t.TempDir(), "missing.mp3"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NeedsConversion(tt.path); got != tt.want {
+				t.Errorf("NeedsConversion(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrepareAudio_NoConversionNeeded(t *testing.T) {
+	path := writeTestWAV(t, 16000, 1)
+
+	got, cleanup, err := prepareAudio(path)
+	if err != nil {
+		t.Fatalf("prepareAudio() error = %v", err)
+	}
+	cleanup()
+
+	if got != path {
+		t.Errorf("prepareAudio() = %q, want the original path %q", got, path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("cleanup removed the original file: %v", err)
+	}
+}
+
+func TestPrepareAudio_MissingFFmpeg(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	path := writeTestWAV(t, 44100, 2)
+
+	_, cleanup, err := prepareAudio(path)
+	defer cleanup()
+
+	if !errors.Is(err, ErrFFmpegNotFound) {
+		t.Errorf("prepareAudio() error = %v, want ErrFFmpegNotFound", err)
+	}
+	if !errors.Is(CheckFFmpeg(), ErrFFmpegNotFound) {
+		t.Errorf("CheckFFmpeg() = %v, want ErrFFmpegNotFound", CheckFFmpeg())
+	}
+}
+
+func TestPrepareAudio_RejectsEmptyRecording(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.wav")
+	if err := audio.SaveWAV(path, nil, 16000, 1); err != nil {
+		t.Fatalf("SaveWAV() error = %v", err)
+	}
+
+	_, cleanup, err := prepareAudio(path)
+	defer cleanup()
+
+	if err == nil || !strings.Contains(err.Error(), "contains no audio") {
+		t.Errorf("prepareAudio() error = %v, want error containing 'contains no audio'", err)
+	}
+}
@@ -0,0 +1,62 @@
+package transcription
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// whisperLanguageProbRegex matches whisper-cli's language detection line, e.g.
+// "whisper_full_with_state: auto-detected language: fr (p = 0.973)"
+var whisperLanguageProbRegex = regexp.MustCompile(`detected language:\s*([a-z]{2,3})\s*\(p\s*=\s*([\d.]+)\)`)
+
+// detectLanguage runs a whisper-cli detection pass on audioPath and returns the most likely
+// of opts.DetectLanguages. Returns "" (plain auto-detection) if the pass fails.
+func (t *WhisperTranscriber) detectLanguage(modelPath, audioPath string, opts Options, timeout time.Duration) string {
+	args := []string{
+		"-m", modelPath,
+		"-f", audioPath,
+		"-l", "auto",
+		"--detect-language",
+		"-t", strconv.Itoa(resolveThreads(opts.Threads)),
+	}
+	stdout, stderr, err := t.run(args, timeout)
+	if err != nil {
+		return ""
+	}
+	return chooseLanguage(parseLanguageProbabilities(stderr+"\n"+stdout), opts.DetectLanguages)
+}
+
+// parseLanguageProbabilities extracts the detected languages and their probabilities
+// from whisper-cli output. A language reported more than once keeps its highest probability.
+func parseLanguageProbabilities(output string) map[string]float64 {
+	probs := make(map[string]float64)
+	for _, match := range whisperLanguageProbRegex.FindAllStringSubmatch(output, -1) {
+		p, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			continue
+		}
+		if current, ok := probs[match[1]]; !ok || p > current {
+			probs[match[1]] = p
+		}
+	}
+	return probs
+}
+
+// chooseLanguage returns the candidate with the highest detected probability. When none
+// of the candidates was detected (whisper guessed an unrelated language), the first
+// candidate is used, so transcription stays within the user's languages.
+func chooseLanguage(detected map[string]float64, candidates []string) string {
+	best, bestProb := "", -1.0
+	for _, code := range candidates {
+		code = strings.ToLower(strings.TrimSpace(code))
+		if p, ok := detected[code]; ok && p > bestProb {
+			best, bestProb = code, p
+		}
+	}
+	if best == "" && len(candidates) > 0 {
+		return strings.ToLower(strings.TrimSpace(candidates[0]))
+	}
+	return best
+}
@@ -0,0 +1,103 @@
+package transcription
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/models"
+)
+
+func TestParseLanguageProbabilities(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   map[string]float64
+	}{
+		{
+			name: "whisper-cli --detect-language",
+			output: `whisper_init_from_file_with_params_no_state: loading model from 'ggml-small.bin'
+main: processing 'recording.wav' (88000 samples, 5.5 sec), 8 threads, 1 processors, lang = auto, task = transcribe ...
+whisper_full_with_state: auto-detected language: fr (p = 0.873215)
+`,
+			want: map[string]float64{"fr": 0.873215},
+		},
+		{
+			name:   "several reports keep the highest probability",
+			output: "auto-detected language: en (p = 0.41)\nauto-detected language: de (p = 0.32)\nauto-detected language: en (p = 0.55)\n",
+			want:   map[string]float64{"en": 0.55, "de": 0.32},
+		},
+		{
+			name:   "three-letter code",
+			output: "whisper_full_with_state: auto-detected language: haw (p = 0.61)",
+			want:   map[string]float64{"haw": 0.61},
+		},
+		{
+			name:   "no detection",
+			output: "whisper_model_load: loading model\n",
+			want:   map[string]float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseLanguageProbabilities(tt.output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLanguageProbabilities() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChooseLanguage(t *testing.T) {
+	tests := []struct {
+		name       string
+		detected   map[string]float64
+		candidates []string
+		want       string
+	}{
+		{"detected candidate", map[string]float64{"fr": 0.87}, []string{"en", "fr"}, "fr"},
+		{"highest candidate wins", map[string]float64{"en": 0.3, "fr": 0.6, "de": 0.9}, []string{"en", "fr"}, "fr"},
+		{"unrelated language falls back to the first candidate", map[string]float64{"nl": 0.7}, []string{"en", "fr"}, "en"},
+		{"nothing detected", map[string]float64{}, []string{"fr", "en"}, "fr"},
+		{"no candidates", map[string]float64{"en": 0.9}, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chooseLanguage(tt.detected, tt.candidates); got != tt.want {
+				t.Errorf("chooseLanguage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWhisperTranscriber_DetectLanguages(t *testing.T) {
+	// The detection pass reports Dutch; the transcription echoes the language it was given
+	transcriber := fakeWhisper(t, `lang=""
+for arg in "$@"; do
+  if [ "$arg" = "--detect-language" ]; then
+    echo "whisper_full_with_state: auto-detected language: nl (p = 0.52)" >&2
+    exit 0
+  fi
+done
+while [ $# -gt 0 ]; do
+  if [ "$1" = "-l" ]; then lang="$2"; fi
+  shift
+done
+echo "language=$lang"
+`)
+
+	result, err := transcriber.TranscribeFile(writeTestWAV(t, 16000, 1), Options{
+		Model:           models.Tiny,
+		DetectLanguages: []string{"fr", "en"},
+		Timeout:         time.Second,
+	})
+	if err != nil {
+		t.Fatalf("TranscribeFile() error = %v", err)
+	}
+	// Dutch isn't a candidate, so the first candidate is used
+	if !strings.Contains(result.Text, "language=fr") || result.Language != "fr" {
+		t.Errorf("result = %+v, want a transcription with -l fr", result)
+	}
+}
@@ -23,18 +23,24 @@
 //
 // Example usage:
 //
-//	// Create transcriber
-//	transcriber := transcription.NewTranscriber()
+//	// Create the transcriber for the configured backend
+//	transcriber, err := transcription.New(cfg)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
 //
 //	// Transcribe audio file
 //	opts := transcription.Options{
-//	    Model:    "small",
+//	    Model:    models.Small,
 //	    Language: "en",
 //	    Verbose:  false,
 //	}
-//	text, err := transcriber.Transcribe("audio.wav", opts)
+//	result, err := transcriber.TranscribeFile("audio.wav", opts)
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-//	fmt.Println(text)
+//	fmt.Println(result.Text)
+//
+// Code that transcribes should depend on the Transcriber interface, so tests
+// can substitute a FakeTranscriber and run without whisper-cli installed.
 package transcription
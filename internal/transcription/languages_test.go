@@ -0,0 +1,24 @@
+package transcription
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestLanguageCodes(t *testing.T) {
+	codes := LanguageCodes()
+	if len(codes) != len(Languages) {
+		t.Fatalf("LanguageCodes() returned %d codes, want %d", len(codes), len(Languages))
+	}
+	if !sort.StringsAreSorted(codes) {
+		t.Errorf("LanguageCodes() = %v, want sorted", codes)
+	}
+	for _, code := range []string{"en", "fr", "es", "zh", "yue"} {
+		if Languages[code] == "" {
+			t.Errorf("Languages is missing %q", code)
+		}
+	}
+	if _, ok := Languages["auto"]; ok {
+		t.Error(`Languages should not list "auto", which isn't a language`)
+	}
+}
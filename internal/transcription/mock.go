@@ -0,0 +1,48 @@
+package transcription
+
+import (
+	"os"
+	"sync"
+)
+
+// FakeTranscriber is a Transcriber for testing that returns a canned result
+// instead of running a backend
+type FakeTranscriber struct {
+	// Result and Err are returned by every call unless TranscribeFunc is set
+	Result *Result
+	Err    error
+
+	// TranscribeFunc, when set, computes the result of each call
+	TranscribeFunc func(audioPath string, opts Options) (*Result, error)
+
+	mu    sync.Mutex
+	calls []FakeCall
+}
+
+// FakeCall records one TranscribeFile call
+type FakeCall struct {
+	AudioPath  string
+	Opts       Options
+	FileExists bool // Whether the audio file existed during the call
+}
+
+// TranscribeFile implements Transcriber
+func (f *FakeTranscriber) TranscribeFile(audioPath string, opts Options) (*Result, error) {
+	_, statErr := os.Stat(audioPath)
+
+	f.mu.Lock()
+	f.calls = append(f.calls, FakeCall{AudioPath: audioPath, Opts: opts, FileExists: statErr == nil})
+	f.mu.Unlock()
+
+	if f.TranscribeFunc != nil {
+		return f.TranscribeFunc(audioPath, opts)
+	}
+	return f.Result, f.Err
+}
+
+// Calls returns the calls made so far
+func (f *FakeTranscriber) Calls() []FakeCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]FakeCall(nil), f.calls...)
+}
@@ -17,6 +17,8 @@ type MoonshineTranscriber struct {
 	modelDir string
 }
 
+var _ Transcriber = (*MoonshineTranscriber)(nil)
+
 func newMoonshineTranscriber(cfg *config.Config) (Transcriber, error) {
 	modelSize := models.MoonshineModelSize(cfg.MoonshineModel)
 	if modelSize == "" {
@@ -47,6 +49,13 @@ func newMoonshineTranscriber(cfg *config.Config) (Transcriber, error) {
 
 // TranscribeFile reads a WAV file and transcribes it using Moonshine.
 func (t *MoonshineTranscriber) TranscribeFile(audioPath string, opts Options) (*Result, error) {
+	// Convert non-WAV input with ffmpeg
+	audioPath, cleanup, err := prepareAudio(audioPath)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
 	// Read WAV file and convert to float32 samples
 	samples, sampleRate, err := readWAVAsFloat32(audioPath)
 	if err != nil {
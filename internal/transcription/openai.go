@@ -17,6 +17,8 @@ type OpenAITranscriber struct {
 	model  string
 }
 
+var _ Transcriber = (*OpenAITranscriber)(nil)
+
 // openAIResponse represents the JSON response from the OpenAI transcription API.
 type openAIResponse struct {
 	Text string `json:"text"`
@@ -70,6 +72,13 @@ func (t *OpenAITranscriber) TranscribeFile(audioPath string, opts Options) (*Res
 		}
 	}
 
+	// Add prompt if specified
+	if opts.Prompt != "" {
+		if err := writer.WriteField("prompt", opts.Prompt); err != nil {
+			return nil, fmt.Errorf("failed to write prompt field: %w", err)
+		}
+	}
+
 	// Add response format
 	if err := writer.WriteField("response_format", "json"); err != nil {
 		return nil, fmt.Errorf("failed to write response_format field: %w", err)
@@ -0,0 +1,71 @@
+package transcription
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Output formats for a transcription result
+const (
+	FormatText = "text"
+	FormatSRT  = "srt"
+	FormatVTT  = "vtt"
+)
+
+// formatExtensions maps each output format to its file extension
+var formatExtensions = map[string]string{
+	FormatText: ".txt",
+	FormatSRT:  ".srt",
+	FormatVTT:  ".vtt",
+}
+
+// ValidateFormat checks that format is a supported output format
+func ValidateFormat(format string) error {
+	if _, ok := formatExtensions[format]; !ok {
+		return fmt.Errorf("invalid format: %s (must be one of: text, srt, vtt)", format)
+	}
+	return nil
+}
+
+// Render formats the result as plain text, SRT or WebVTT
+func (r *Result) Render(format string) string {
+	switch format {
+	case FormatSRT:
+		return r.ToSRT()
+	case FormatVTT:
+		return r.ToVTT()
+	default:
+		return r.Text + "\n"
+	}
+}
+
+// OutputPath derives the output file for an audio file by replacing its extension
+// with the format's (audio.wav → audio.txt). The file goes in outputDir, or next
+// to the audio file when outputDir is empty.
+func OutputPath(audioPath, outputDir, format string) string {
+	ext, ok := formatExtensions[format]
+	if !ok {
+		ext = formatExtensions[FormatText]
+	}
+
+	base := filepath.Base(audioPath)
+	name := strings.TrimSuffix(base, filepath.Ext(base)) + ext
+
+	if outputDir == "" {
+		outputDir = filepath.Dir(audioPath)
+	}
+	return filepath.Join(outputDir, name)
+}
+
+// WriteOutput writes the rendered result to path, creating parent directories as needed
+func (r *Result) WriteOutput(path, format string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(r.Render(format)), 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	return nil
+}
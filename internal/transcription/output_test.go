@@ -0,0 +1,74 @@
+package transcription
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOutputPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		audioPath string
+		outputDir string
+		format    string
+		want      string
+	}{
+		{"text next to audio", "recordings/audio.wav", "", FormatText, "recordings/audio.txt"},
+		{"srt next to audio", "recordings/audio.wav", "", FormatSRT, "recordings/audio.srt"},
+		{"vtt in output dir", "recordings/audio.wav", "subs", FormatVTT, "subs/audio.vtt"},
+		{"bare file name", "audio.wav", "", FormatText, "audio.txt"},
+		{"dots in name", "/tmp/meeting.2024-01-02.wav", "/out", FormatText, "/out/meeting.2024-01-02.txt"},
+		{"no extension", "/tmp/audio", "", FormatText, "/tmp/audio.txt"},
+		{"unknown format falls back to text", "audio.wav", "", "pdf", "audio.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OutputPath(tt.audioPath, tt.outputDir, tt.format); got != filepath.FromSlash(tt.want) {
+				t.Errorf("OutputPath(%q, %q, %q) = %q, want %q", tt.audioPath, tt.outputDir, tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	for _, format := range []string{FormatText, FormatSRT, FormatVTT} {
+		if err := ValidateFormat(format); err != nil {
+			t.Errorf("ValidateFormat(%q) error = %v", format, err)
+		}
+	}
+	if err := ValidateFormat("pdf"); err == nil {
+		t.Error("ValidateFormat(\"pdf\") error = nil, want error")
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	result := &Result{
+		Text:     "Hello world",
+		Segments: []Segment{{Start: 0, End: time.Second, Text: "Hello world"}},
+	}
+	dir := filepath.Join(t.TempDir(), "nested", "out")
+
+	textPath := filepath.Join(dir, "audio.txt")
+	if err := result.WriteOutput(textPath, FormatText); err != nil {
+		t.Fatalf("WriteOutput() error = %v", err)
+	}
+	data, err := os.ReadFile(textPath)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if string(data) != "Hello world\n" {
+		t.Errorf("text output = %q, want %q", data, "Hello world\n")
+	}
+
+	srtPath := filepath.Join(dir, "audio.srt")
+	if err := result.WriteOutput(srtPath, FormatSRT); err != nil {
+		t.Fatalf("WriteOutput() error = %v", err)
+	}
+	data, _ = os.ReadFile(srtPath)
+	if string(data) != result.ToSRT() {
+		t.Errorf("srt output = %q, want %q", data, result.ToSRT())
+	}
+}
@@ -0,0 +1,149 @@
+package transcription
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/audio"
+)
+
+// Default streaming parameters
+const (
+	// DefaultStreamInterval is how much new audio triggers a partial transcription
+	DefaultStreamInterval = 3 * time.Second
+
+	// DefaultStreamWindow is the amount of trailing audio transcribed for each partial result.
+	// Matches Whisper's native 30 second context window.
+	DefaultStreamWindow = 30 * time.Second
+)
+
+// StreamingTranscriber is implemented by backends that emit partial results during recording
+type StreamingTranscriber interface {
+	TranscribeStreaming(ctx context.Context, audioChan <-chan []byte, opts Options) (<-chan Result, error)
+}
+
+// ChunkedStreamer produces partial results while audio is still being captured by
+// repeatedly transcribing a trailing window of the buffered audio with a regular
+// Transcriber. Consecutive windows overlap, so each partial result supersedes the previous one.
+type ChunkedStreamer struct {
+	transcriber Transcriber
+	sampleRate  uint32
+	channels    uint32
+
+	// Interval is how much new audio is buffered before the next partial transcription
+	Interval time.Duration
+
+	// Window is the maximum amount of trailing audio transcribed for each partial result
+	Window time.Duration
+
+	// OnError, if set, is called when a partial transcription fails. Partial results
+	// are best-effort, so the stream carries on with the next window.
+	OnError func(err error)
+}
+
+// NewChunkedStreamer creates a streamer for 16-bit PCM audio in the given format
+func NewChunkedStreamer(t Transcriber, sampleRate, channels uint32) *ChunkedStreamer {
+	return &ChunkedStreamer{
+		transcriber: t,
+		sampleRate:  sampleRate,
+		channels:    channels,
+		Interval:    DefaultStreamInterval,
+		Window:      DefaultStreamWindow,
+	}
+}
+
+// TranscribeStreaming consumes raw 16-bit PCM chunks from audioChan and emits a
+// partial Result every Interval of new audio. When audioChan is closed, any
+// remaining audio is transcribed one last time and the result channel is closed.
+// Cancelling ctx stops the stream without a final transcription.
+func (s *ChunkedStreamer) TranscribeStreaming(ctx context.Context, audioChan <-chan []byte, opts Options) (<-chan Result, error) {
+	if s.transcriber == nil {
+		return nil, fmt.Errorf("streaming requires a transcriber")
+	}
+	if s.Interval <= 0 || s.Window < s.Interval {
+		return nil, fmt.Errorf("invalid streaming parameters: interval %v, window %v", s.Interval, s.Window)
+	}
+
+	intervalBytes := durationToBytes(s.Interval, s.sampleRate, s.channels)
+	windowBytes := durationToBytes(s.Window, s.sampleRate, s.channels)
+
+	results := make(chan Result)
+
+	go func() {
+		defer close(results)
+
+		var buffer []byte
+		pending := 0 // bytes received since the last transcription
+
+		emit := func() bool {
+			pending = 0
+
+			result, err := s.transcribeChunk(buffer, opts)
+			if err != nil {
+				// The next window gets another chance
+				if s.OnError != nil {
+					s.OnError(err)
+				}
+				return true
+			}
+
+			select {
+			case results <- *result:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case chunk, ok := <-audioChan:
+				if !ok {
+					if pending > 0 && ctx.Err() == nil {
+						emit()
+					}
+					return
+				}
+				buffer = append(buffer, chunk...)
+				// Only the trailing window is ever transcribed, so don't keep more
+				if len(buffer) > windowBytes {
+					buffer = buffer[len(buffer)-windowBytes:]
+				}
+				pending += len(chunk)
+				if pending >= intervalBytes && !emit() {
+					return
+				}
+			}
+		}
+	}()
+
+	return results, nil
+}
+
+// transcribeChunk writes a window of audio to a temporary WAV file and transcribes it
+func (s *ChunkedStreamer) transcribeChunk(data []byte, opts Options) (*Result, error) {
+	tmpFile, err := os.CreateTemp("", "openscribe_stream_*.wav")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	_ = tmpFile.Close()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if err := audio.SaveWAV(tmpPath, data, s.sampleRate, s.channels); err != nil {
+		return nil, fmt.Errorf("failed to write audio chunk: %w", err)
+	}
+
+	return s.transcriber.TranscribeFile(tmpPath, opts)
+}
+
+// durationToBytes converts a duration to a byte count of 16-bit PCM, aligned to whole frames
+func durationToBytes(d time.Duration, sampleRate, channels uint32) int {
+	frameSize := int(channels) * 2
+	n := int(d.Seconds() * float64(int(sampleRate)*frameSize))
+	return n - n%frameSize
+}
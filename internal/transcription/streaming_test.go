@@ -0,0 +1,169 @@
+package transcription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingTranscriber numbers each call in the returned text
+type countingTranscriber struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *countingTranscriber) TranscribeFile(audioPath string, opts Options) (*Result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	return &Result{Text: fmt.Sprintf("partial %d", c.calls)}, nil
+}
+
+func TestChunkedStreamer_EmitsPartialResults(t *testing.T) {
+	fake := &countingTranscriber{}
+	streamer := NewChunkedStreamer(fake, 16000, 1)
+	streamer.Interval = time.Second
+	streamer.Window = 2 * time.Second
+
+	audioChan := make(chan []byte)
+	results, err := streamer.TranscribeStreaming(context.Background(), audioChan, DefaultOptions())
+	if err != nil {
+		t.Fatalf("TranscribeStreaming() unexpected error: %v", err)
+	}
+
+	var got []Result
+	done := make(chan struct{})
+	go func() {
+		for r := range results {
+			got = append(got, r)
+		}
+		close(done)
+	}()
+
+	// 2.5 seconds of audio in half-second chunks: two full intervals plus a remainder
+	chunk := make([]byte, 16000) // 0.5s of 16kHz mono 16-bit audio
+	for i := 0; i < 5; i++ {
+		audioChan <- chunk
+	}
+	close(audioChan)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("result channel was not closed after audio channel closed")
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("got %d partial results, want 3", len(got))
+	}
+	if got[2].Text != "partial 3" {
+		t.Errorf("last result = %q, want %q", got[2].Text, "partial 3")
+	}
+}
+
+func TestChunkedStreamer_CancelStopsStream(t *testing.T) {
+	streamer := NewChunkedStreamer(&countingTranscriber{}, 16000, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	audioChan := make(chan []byte)
+	results, err := streamer.TranscribeStreaming(ctx, audioChan, DefaultOptions())
+	if err != nil {
+		t.Fatalf("TranscribeStreaming() unexpected error: %v", err)
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-results:
+		if ok {
+			t.Error("expected no results after cancellation")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("result channel was not closed after cancellation")
+	}
+}
+
+func TestChunkedStreamer_InvalidParameters(t *testing.T) {
+	streamer := NewChunkedStreamer(&countingTranscriber{}, 16000, 1)
+	streamer.Window = time.Second
+	streamer.Interval = 2 * time.Second
+
+	if _, err := streamer.TranscribeStreaming(context.Background(), make(chan []byte), DefaultOptions()); err == nil {
+		t.Error("expected error when window is shorter than interval")
+	}
+}
+
+// windowTranscriber records the WAV size of each window and fails the first call
+type windowTranscriber struct {
+	sizes []int64
+}
+
+func (w *windowTranscriber) TranscribeFile(audioPath string, opts Options) (*Result, error) {
+	info, err := os.Stat(audioPath)
+	if err != nil {
+		return nil, err
+	}
+	w.sizes = append(w.sizes, info.Size())
+	if len(w.sizes) == 1 {
+		return nil, errors.New("whisper crashed")
+	}
+	return &Result{Text: "partial"}, nil
+}
+
+func TestChunkedStreamer_TrimsWindowAndReportsErrors(t *testing.T) {
+	fake := &windowTranscriber{}
+	streamer := NewChunkedStreamer(fake, 16000, 1)
+	streamer.Interval = time.Second
+	streamer.Window = 2 * time.Second
+
+	var reported []error
+	streamer.OnError = func(err error) { reported = append(reported, err) }
+
+	audioChan := make(chan []byte)
+	results, err := streamer.TranscribeStreaming(context.Background(), audioChan, DefaultOptions())
+	if err != nil {
+		t.Fatalf("TranscribeStreaming() unexpected error: %v", err)
+	}
+
+	done := make(chan int)
+	go func() {
+		n := 0
+		for range results {
+			n++
+		}
+		done <- n
+	}()
+
+	// 5 seconds of audio in one-second chunks
+	chunk := make([]byte, 32000)
+	for i := 0; i < 5; i++ {
+		audioChan <- chunk
+	}
+	close(audioChan)
+
+	var got int
+	select {
+	case got = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("result channel was not closed after audio channel closed")
+	}
+
+	if len(reported) != 1 {
+		t.Errorf("OnError called %d times, want 1", len(reported))
+	}
+	if got != 4 {
+		t.Errorf("got %d partial results, want 4 (the failed window is skipped)", got)
+	}
+
+	// Every window is at most 2 seconds of audio plus the WAV header
+	const maxSize = 2*32000 + 44
+	for i, size := range fake.sizes {
+		if size > maxSize {
+			t.Errorf("window %d is %d bytes, want at most %d", i, size, maxSize)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package transcription
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ToSRT renders the result's segments as a SubRip (.srt) subtitle file.
+// Returns an empty string when there are no segments.
+func (r *Result) ToSRT() string {
+	if r == nil || len(r.Segments) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	cue := 0
+	for _, seg := range r.Segments {
+		text := sanitizeCueText(seg.Text)
+		if text == "" {
+			continue
+		}
+		cue++
+		if cue > 1 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%d\n", cue)
+		fmt.Fprintf(&sb, "%s --> %s\n", formatTimestamp(seg.Start, ","), formatTimestamp(seg.End, ","))
+		sb.WriteString(text)
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// ToVTT renders the result's segments as a WebVTT (.vtt) subtitle file.
+// Returns an empty string when there are no segments.
+func (r *Result) ToVTT() string {
+	if r == nil || len(r.Segments) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("WEBVTT\n")
+	for _, seg := range r.Segments {
+		text := sanitizeCueText(seg.Text)
+		if text == "" {
+			continue
+		}
+		sb.WriteString("\n")
+		fmt.Fprintf(&sb, "%s --> %s\n", formatTimestamp(seg.Start, "."), formatTimestamp(seg.End, "."))
+		sb.WriteString(escapeVTT(text))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// formatTimestamp formats a duration as HH:MM:SS<sep>mmm
+// SRT uses "," as the millisecond separator, WebVTT uses "."
+func formatTimestamp(d time.Duration, sep string) string {
+	if d < 0 {
+		d = 0
+	}
+	ms := d.Milliseconds()
+	hours := ms / 3600000
+	minutes := (ms % 3600000) / 60000
+	seconds := (ms % 60000) / 1000
+	millis := ms % 1000
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", hours, minutes, seconds, sep, millis)
+}
+
+// sanitizeCueText removes sequences that would break cue parsing in both formats:
+// blank lines end a cue, and "-->" is reserved for the timing line
+func sanitizeCueText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "-->", "->")
+
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// escapeVTT escapes characters that have special meaning in WebVTT cue text
+func escapeVTT(text string) string {
+	replacer := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	return replacer.Replace(text)
+}
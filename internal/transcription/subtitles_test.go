@@ -0,0 +1,100 @@
+package transcription
+
+import (
+	"testing"
+	"time"
+)
+
+func TestToSRT(t *testing.T) {
+	result := &Result{
+		Segments: []Segment{
+			{Start: 0, End: 2 * time.Second, Text: "Hello, this is a test."},
+			{Start: 2 * time.Second, End: 3*time.Minute + 4*time.Second + 500*time.Millisecond, Text: "Second line."},
+		},
+	}
+
+	expected := "1\n" +
+		"00:00:00,000 --> 00:00:02,000\n" +
+		"Hello, this is a test.\n" +
+		"\n" +
+		"2\n" +
+		"00:00:02,000 --> 00:03:04,500\n" +
+		"Second line.\n"
+
+	if got := result.ToSRT(); got != expected {
+		t.Errorf("ToSRT() = %q, want %q", got, expected)
+	}
+}
+
+func TestToVTT(t *testing.T) {
+	result := &Result{
+		Segments: []Segment{
+			{Start: time.Hour + 500*time.Millisecond, End: time.Hour + 2*time.Second, Text: "Tom & Jerry <3"},
+		},
+	}
+
+	expected := "WEBVTT\n" +
+		"\n" +
+		"01:00:00.500 --> 01:00:02.000\n" +
+		"Tom &amp; Jerry &lt;3\n"
+
+	if got := result.ToVTT(); got != expected {
+		t.Errorf("ToVTT() = %q, want %q", got, expected)
+	}
+}
+
+func TestSubtitles_EmptySegments(t *testing.T) {
+	result := &Result{Text: "No timing information"}
+
+	if got := result.ToSRT(); got != "" {
+		t.Errorf("ToSRT() with no segments = %q, want empty string", got)
+	}
+	if got := result.ToVTT(); got != "" {
+		t.Errorf("ToVTT() with no segments = %q, want empty string", got)
+	}
+}
+
+func TestSubtitles_SanitizesCueText(t *testing.T) {
+	result := &Result{
+		Segments: []Segment{
+			{Start: 0, End: time.Second, Text: "first\n\nsecond --> third"},
+			{Start: time.Second, End: 2 * time.Second, Text: "   "},
+			{Start: 2 * time.Second, End: 3 * time.Second, Text: "last"},
+		},
+	}
+
+	expected := "1\n" +
+		"00:00:00,000 --> 00:00:01,000\n" +
+		"first\nsecond -> third\n" +
+		"\n" +
+		"2\n" +
+		"00:00:02,000 --> 00:00:03,000\n" +
+		"last\n"
+
+	if got := result.ToSRT(); got != expected {
+		t.Errorf("ToSRT() = %q, want %q", got, expected)
+	}
+}
+
+func TestParseWhisperSegments(t *testing.T) {
+	output := `whisper_init_from_file: loading model
+[00:00:00.000 --> 00:00:02.000]  Hello, this is a test.
+[00:00:02.000 --> 00:00:04.120]  This is another line.
+[00:00:04.120 --> 00:00:05.000]
+`
+
+	segments := parseWhisperSegments(output)
+	if len(segments) != 2 {
+		t.Fatalf("parseWhisperSegments() returned %d segments, want 2", len(segments))
+	}
+
+	if segments[0].Start != 0 || segments[0].End != 2*time.Second {
+		t.Errorf("segments[0] timing = %v-%v, want 0s-2s", segments[0].Start, segments[0].End)
+	}
+	if segments[0].Text != "Hello, this is a test." {
+		t.Errorf("segments[0].Text = %q", segments[0].Text)
+	}
+	if segments[1].End != 4*time.Second+120*time.Millisecond {
+		t.Errorf("segments[1].End = %v, want 4.12s", segments[1].End)
+	}
+}
@@ -3,6 +3,7 @@ package transcription
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/alexandrelam/openscribe/internal/config"
 	"github.com/alexandrelam/openscribe/internal/models"
@@ -22,8 +23,25 @@ type Options struct {
 	// Empty string means auto-detect
 	Language string
 
+	// DetectLanguages restricts auto-detection to these codes: when Language is empty the
+	// most likely candidate is detected first and used as the language (whisper only)
+	DetectLanguages []string
+
 	// Verbose enables detailed output
 	Verbose bool
+
+	// Threads is the number of CPU threads to use (0 = auto-detect via runtime.NumCPU())
+	Threads int
+
+	// Timestamps requests per-segment timing from the backend (populates Result.Segments)
+	Timestamps bool
+
+	// Prompt is an initial prompt used to bias vocabulary (names, jargon, acronyms)
+	// Empty string means no prompt
+	Prompt string
+
+	// Timeout limits how long whisper-cli may run before it is killed (0 = DefaultTimeout)
+	Timeout time.Duration
 }
 
 // Result contains the transcription result and metadata
@@ -36,6 +54,49 @@ type Result struct {
 
 	// Duration is the audio duration in seconds (if available)
 	Duration float64
+
+	// Segments contains timed chunks of the transcription (only when Options.Timestamps is set)
+	Segments []Segment
+
+	// AvgLogProb is the mean log probability of the transcribed tokens (closer to 0 = more confident).
+	// Zero when the backend does not report token probabilities.
+	AvgLogProb float64
+
+	// NoSpeechProb is the probability that the audio contains no speech.
+	// Zero when the backend does not report it (whisper-cli builds without it, moonshine, openai).
+	NoSpeechProb float64
+
+	// Timings is the per-stage breakdown reported by whisper-cli in verbose mode.
+	// Zero when the backend does not report it.
+	Timings Timings
+}
+
+// Timings breaks a transcription down by stage, in milliseconds
+type Timings struct {
+	LoadMS   float64 // Loading the model
+	MelMS    float64 // Computing the mel spectrogram
+	SampleMS float64 // Sampling tokens
+	EncodeMS float64 // Running the encoder
+	DecodeMS float64 // Running the decoder (single, batched and prompt passes)
+	TotalMS  float64 // Whole run, as measured by the backend
+}
+
+// String formats the timings on one line, e.g. "load 120ms, encode 300ms, ..., total 650ms"
+func (t Timings) String() string {
+	return fmt.Sprintf("load %.0fms, mel %.0fms, sample %.0fms, encode %.0fms, decode %.0fms, total %.0fms",
+		t.LoadMS, t.MelMS, t.SampleMS, t.EncodeMS, t.DecodeMS, t.TotalMS)
+}
+
+// Segment is a timed portion of a transcription
+type Segment struct {
+	// Start is the offset from the beginning of the audio where the segment starts
+	Start time.Duration
+
+	// End is the offset from the beginning of the audio where the segment ends
+	End time.Duration
+
+	// Text is the transcribed text for this segment
+	Text string
 }
 
 // DefaultOptions returns default transcription options
@@ -44,6 +105,7 @@ func DefaultOptions() Options {
 		Model:    models.Small,
 		Language: "", // auto-detect
 		Verbose:  false,
+		Timeout:  DefaultTimeout,
 	}
 }
 
@@ -1,6 +1,8 @@
 package transcription
 
 import (
+	"math"
+	"runtime"
 	"testing"
 
 	"github.com/alexandrelam/openscribe/internal/models"
@@ -91,6 +93,11 @@ func TestExtractLanguage(t *testing.T) {
 			input:    "DETECTED LANGUAGE: de",
 			expected: "de",
 		},
+		{
+			name:     "whisper-cli auto-detect log line",
+			input:    "whisper_full_with_state: auto-detected language: fr (p = 0.973146)",
+			expected: "fr",
+		},
 	}
 
 	for _, tt := range tests {
@@ -117,6 +124,20 @@ func TestDefaultOptions(t *testing.T) {
 	if opts.Verbose {
 		t.Errorf("DefaultOptions().Verbose = true, want false")
 	}
+
+	if opts.Timeout != DefaultTimeout {
+		t.Errorf("DefaultOptions().Timeout = %v, want %v", opts.Timeout, DefaultTimeout)
+	}
+}
+
+func TestResolveThreads(t *testing.T) {
+	if got := resolveThreads(6); got != 6 {
+		t.Errorf("resolveThreads(6) = %d, want 6", got)
+	}
+
+	if got := resolveThreads(0); got != runtime.NumCPU() {
+		t.Errorf("resolveThreads(0) = %d, want runtime.NumCPU() = %d", got, runtime.NumCPU())
+	}
 }
 
 func TestNewWhisperTranscriber(t *testing.T) {
@@ -138,3 +159,139 @@ func TestNewWhisperTranscriber(t *testing.T) {
 		t.Error("NewWhisperTranscriber() created transcriber with empty whisperPath")
 	}
 }
+
+func TestBuildWhisperArgs_Prompt(t *testing.T) {
+	prompt := `Kubernetes, gRPC & "OpenScribe"; don't split me`
+	opts := DefaultOptions()
+	opts.Prompt = prompt
+
+	args := buildWhisperArgs("/models/ggml-small.bin", "/tmp/audio.wav", opts)
+
+	found := false
+	for i, arg := range args {
+		if arg == "--prompt" {
+			if i+1 >= len(args) {
+				t.Fatal("--prompt has no value")
+			}
+			if args[i+1] != prompt {
+				t.Errorf("prompt argument = %q, want %q", args[i+1], prompt)
+			}
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected --prompt in args, got %v", args)
+	}
+
+	opts.Prompt = ""
+	for _, arg := range buildWhisperArgs("/models/ggml-small.bin", "/tmp/audio.wav", opts) {
+		if arg == "--prompt" {
+			t.Error("--prompt should be omitted when the prompt is empty")
+		}
+	}
+}
+
+func TestParseWhisperLanguage(t *testing.T) {
+	// Trimmed from a real `whisper-cli --output-json-full --no-prints` run with auto-detection
+	sample := `{
+	"systeminfo": "AVX = 0 | AVX2 = 0 | NEON = 1 | ARM_FMA = 1 | METAL = 1",
+	"model": {"type": "small", "multilingual": true, "vocab": 51865},
+	"params": {"model": "ggml-small.bin", "language": "auto", "translate": false},
+	"result": {"language": "fr"},
+	"transcription": [
+		{
+			"timestamps": {"from": "00:00:00,000", "to": "00:00:02,000"},
+			"offsets": {"from": 0, "to": 2000},
+			"text": " Bonjour tout le monde.",
+			"tokens": [{"text": " Bonjour", "p": 0.98}]
+		}
+	]
+}`
+
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"auto-detected", sample, "fr"},
+		{"no result", `{"transcription": []}`, ""},
+		{"unresolved auto", `{"result": {"language": "auto"}}`, ""},
+		{"malformed json", `not json`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseWhisperLanguage([]byte(tt.data)); got != tt.want {
+				t.Errorf("parseWhisperLanguage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWhisperConfidence(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		wantLogProb  float64
+		wantNoSpeech float64
+	}{
+		{
+			name: "tokens without no-speech probability",
+			data: `{"transcription":[{"tokens":[
+				{"text":"[_BEG_]","p":0.1},
+				{"text":" Hello","p":1.0},
+				{"text":" world","p":0.36787944117144233},
+				{"text":"[_TT_150]","p":0.2}
+			]}]}`,
+			wantLogProb:  -0.5,
+			wantNoSpeech: 0,
+		},
+		{
+			name:         "lowest no-speech probability across segments",
+			data:         `{"transcription":[{"no_speech_prob":0.9,"tokens":[]},{"no_speech_prob":0.2,"tokens":[]}]}`,
+			wantLogProb:  0,
+			wantNoSpeech: 0.2,
+		},
+		{
+			name:         "malformed json",
+			data:         `not json`,
+			wantLogProb:  0,
+			wantNoSpeech: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logProb, noSpeech := parseWhisperConfidence([]byte(tt.data))
+			if math.Abs(logProb-tt.wantLogProb) > 1e-9 {
+				t.Errorf("avgLogProb = %v, want %v", logProb, tt.wantLogProb)
+			}
+			if math.Abs(noSpeech-tt.wantNoSpeech) > 1e-9 {
+				t.Errorf("noSpeechProb = %v, want %v", noSpeech, tt.wantNoSpeech)
+			}
+		})
+	}
+}
+
+func TestCheckModelLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    models.ModelSize
+		language string
+		wantErr  bool
+	}{
+		{"english model, auto-detect", models.BaseEn, "", false},
+		{"english model, english", models.BaseEn, "en", false},
+		{"english model, french", models.BaseEn, "fr", true},
+		{"multilingual model, french", models.Base, "fr", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkModelLanguage(tt.model, tt.language)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkModelLanguage(%s, %q) error = %v, wantErr %v", tt.model, tt.language, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,19 +2,38 @@ package transcription
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"os"
 	"os/exec"
 	"regexp"
+	"runtime"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alexandrelam/openscribe/internal/models"
 )
 
+// DefaultTimeout is how long whisper-cli may run when Options.Timeout is not set
+const DefaultTimeout = 120 * time.Second
+
+// timeoutRetries is how many times whisper-cli is re-run after it times out
+const timeoutRetries = 1
+
+// ErrTimeout is returned when whisper-cli does not finish within the timeout
+var ErrTimeout = errors.New("transcription timed out")
+
 // WhisperTranscriber handles speech-to-text transcription using whisper.cpp
 type WhisperTranscriber struct {
 	whisperPath string
 }
 
+var _ Transcriber = (*WhisperTranscriber)(nil)
+
 // NewWhisperTranscriber creates a new whisper-based transcriber
 func NewWhisperTranscriber() (*WhisperTranscriber, error) {
 	whisperPath, err := exec.LookPath("whisper-cli")
@@ -29,6 +48,10 @@ func NewWhisperTranscriber() (*WhisperTranscriber, error) {
 
 // TranscribeFile transcribes an audio file and returns the text
 func (t *WhisperTranscriber) TranscribeFile(audioPath string, opts Options) (*Result, error) {
+	if err := checkModelLanguage(opts.Model, opts.Language); err != nil {
+		return nil, err
+	}
+
 	// Validate that the model is downloaded
 	isDownloaded, err := models.IsModelDownloaded(opts.Model)
 	if err != nil {
@@ -44,40 +67,42 @@ func (t *WhisperTranscriber) TranscribeFile(audioPath string, opts Options) (*Re
 		return nil, fmt.Errorf("failed to get model path: %w", err)
 	}
 
-	// Build whisper-cli command
-	args := []string{
-		"-m", modelPath,
-		"-f", audioPath,
-		"--no-timestamps",
-		"--output-txt",
+	// whisper-cli only reads 16kHz WAV; convert other formats with ffmpeg
+	audioPath, cleanup, err := prepareAudio(audioPath)
+	if err != nil {
+		return nil, err
 	}
+	defer cleanup()
 
-	// Add language if specified
-	if opts.Language != "" {
-		args = append(args, "-l", opts.Language)
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
 	}
 
-	// Add threads for faster processing
-	args = append(args, "-t", "4")
-
-	// Verbose mode
-	if !opts.Verbose {
-		args = append(args, "--no-prints")
+	// Pick the language among the candidates before transcribing with it
+	if opts.Language == "" && len(opts.DetectLanguages) > 0 {
+		opts.Language = t.detectLanguage(modelPath, audioPath, opts, timeout)
 	}
 
-	// Execute whisper-cli
-	cmd := exec.Command(t.whisperPath, args...)
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	args := buildWhisperArgs(modelPath, audioPath, opts)
+
+	// whisper-cli writes the full JSON output (with token probabilities) next to the input file
+	jsonPath := audioPath + ".json"
+	defer func() { _ = os.Remove(jsonPath) }()
 
-	err = cmd.Run()
+	// Execute whisper-cli, retrying when it hangs
+	var output, logOutput string
+	for attempt := 0; ; attempt++ {
+		output, logOutput, err = t.run(args, timeout)
+		if !errors.Is(err, ErrTimeout) || attempt == timeoutRetries {
+			break
+		}
+	}
 	if err != nil {
-		return nil, fmt.Errorf("whisper-cli failed: %w\nStderr: %s", err, stderr.String())
+		return nil, err
 	}
 
 	// Parse the output
-	output := stdout.String()
 	text := parseWhisperOutput(output)
 
 	if text == "" {
@@ -89,9 +114,31 @@ func (t *WhisperTranscriber) TranscribeFile(audioPath string, opts Options) (*Re
 		Language: opts.Language,
 	}
 
-	// If language was auto-detected, try to extract it from output
+	if opts.Timestamps {
+		result.Segments = parseWhisperSegments(output)
+	}
+
+	// whisper-cli only prints its timings without --no-prints
+	if opts.Verbose {
+		result.Timings = parseWhisperTimings(logOutput)
+	}
+
+	// Confidence metrics are best-effort: leave them at zero if the JSON is missing or malformed
+	jsonData, jsonErr := os.ReadFile(jsonPath)
+	if jsonErr == nil {
+		result.AvgLogProb, result.NoSpeechProb = parseWhisperConfidence(jsonData)
+	}
+
+	// If language was auto-detected, read it from the JSON output (written even with
+	// --no-prints), falling back to the "auto-detected language" log line
 	if opts.Language == "" {
-		detectedLang := extractWhisperLanguage(output)
+		detectedLang := ""
+		if jsonErr == nil {
+			detectedLang = parseWhisperLanguage(jsonData)
+		}
+		if detectedLang == "" {
+			detectedLang = extractWhisperLanguage(logOutput + "\n" + output)
+		}
 		if detectedLang != "" {
 			result.Language = detectedLang
 		}
@@ -100,6 +147,137 @@ func (t *WhisperTranscriber) TranscribeFile(audioPath string, opts Options) (*Re
 	return result, nil
 }
 
+// run executes whisper-cli and returns its stdout and stderr.
+// The process is killed if it runs longer than timeout.
+func (t *WhisperTranscriber) run(args []string, timeout time.Duration) (string, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, t.whisperPath, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Don't wait for leftover child processes still holding the output pipes after a kill
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", "", fmt.Errorf("%w: whisper-cli did not finish within %s", ErrTimeout, timeout)
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("whisper-cli failed: %w\nStderr: %s", err, stderr.String())
+	}
+	return stdout.String(), stderr.String(), nil
+}
+
+// buildWhisperArgs builds the whisper-cli argument list for a transcription.
+// Arguments are passed to exec directly (no shell), so the prompt is kept as a
+// single argument regardless of spaces or punctuation.
+func buildWhisperArgs(modelPath, audioPath string, opts Options) []string {
+	args := []string{
+		"-m", modelPath,
+		"-f", audioPath,
+	}
+
+	// Timestamps are only needed when segments were requested
+	if !opts.Timestamps {
+		args = append(args, "--no-timestamps")
+	}
+	args = append(args, "--output-txt", "--output-json-full")
+
+	// Add language if specified
+	if opts.Language != "" {
+		args = append(args, "-l", opts.Language)
+	}
+
+	// Add initial prompt to bias vocabulary
+	if opts.Prompt != "" {
+		args = append(args, "--prompt", opts.Prompt)
+	}
+
+	// Add threads for faster processing
+	args = append(args, "-t", strconv.Itoa(resolveThreads(opts.Threads)))
+
+	// Verbose mode
+	if !opts.Verbose {
+		args = append(args, "--no-prints")
+	}
+
+	return args
+}
+
+// whisperJSONOutput is the subset of whisper-cli's --output-json-full format we read
+type whisperJSONOutput struct {
+	Result struct {
+		// Language is the language used for the transcription (detected when auto)
+		Language string `json:"language"`
+	} `json:"result"`
+	Transcription []struct {
+		// NoSpeechProb is only emitted by whisper-cli builds that expose it
+		NoSpeechProb *float64 `json:"no_speech_prob"`
+		Tokens       []struct {
+			Text string  `json:"text"`
+			P    float64 `json:"p"`
+		} `json:"tokens"`
+	} `json:"transcription"`
+}
+
+// parseWhisperConfidence extracts the average token log probability and the
+// no-speech probability from whisper-cli's full JSON output. Special tokens
+// (e.g. "[_BEG_]", "[_TT_150]") are excluded. The no-speech probability is the
+// lowest reported across segments, so one silent window doesn't discard real
+// speech elsewhere; it stays 0 when whisper-cli doesn't report it.
+func parseWhisperConfidence(data []byte) (avgLogProb, noSpeechProb float64) {
+	var out whisperJSONOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return 0, 0
+	}
+
+	var sum float64
+	var count int
+	noSpeechSet := false
+	for _, seg := range out.Transcription {
+		if seg.NoSpeechProb != nil && (!noSpeechSet || *seg.NoSpeechProb < noSpeechProb) {
+			noSpeechProb = *seg.NoSpeechProb
+			noSpeechSet = true
+		}
+		for _, tok := range seg.Tokens {
+			if strings.HasPrefix(tok.Text, "[_") || tok.P <= 0 {
+				continue
+			}
+			sum += math.Log(tok.P)
+			count++
+		}
+	}
+
+	if count > 0 {
+		avgLogProb = sum / float64(count)
+	}
+	return avgLogProb, noSpeechProb
+}
+
+// parseWhisperLanguage extracts the transcription language from whisper-cli's JSON output.
+// Returns an empty string when it is missing or the JSON is malformed.
+func parseWhisperLanguage(data []byte) string {
+	var out whisperJSONOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return ""
+	}
+	lang := strings.TrimSpace(out.Result.Language)
+	if lang == "auto" {
+		return ""
+	}
+	return lang
+}
+
+// resolveThreads returns the thread count to pass to whisper-cli, auto-detecting when unset
+func resolveThreads(threads int) int {
+	if threads > 0 {
+		return threads
+	}
+	return runtime.NumCPU()
+}
+
 // parseWhisperOutput extracts the transcribed text from whisper-cli output
 func parseWhisperOutput(output string) string {
 	lines := strings.Split(output, "\n")
@@ -141,25 +319,108 @@ func parseWhisperOutput(output string) string {
 	return text
 }
 
+// whisperTimingRegex matches a whisper-cli timing line like
+// "whisper_print_timings:   encode time =   301.22 ms /     1 runs (  301.22 ms per run)"
+var whisperTimingRegex = regexp.MustCompile(`whisper_print_timings:\s+(\w+) time =\s+([\d.]+) ms`)
+
+// parseWhisperTimings extracts the per-stage timings whisper-cli prints to stderr when it
+// exits. Stages it doesn't report are left at zero.
+func parseWhisperTimings(logOutput string) Timings {
+	var t Timings
+	for _, match := range whisperTimingRegex.FindAllStringSubmatch(logOutput, -1) {
+		ms, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			continue
+		}
+		switch match[1] {
+		case "load":
+			t.LoadMS = ms
+		case "mel":
+			t.MelMS = ms
+		case "sample":
+			t.SampleMS = ms
+		case "encode":
+			t.EncodeMS = ms
+		case "decode", "batchd", "prompt":
+			t.DecodeMS += ms
+		case "total":
+			t.TotalMS = ms
+		}
+	}
+	return t
+}
+
+// whisperTimestampRegex matches a whisper-cli segment line like
+// "[00:00:00.000 --> 00:00:02.000]  Hello"
+var whisperTimestampRegex = regexp.MustCompile(`^\[(\d+):(\d{2}):(\d{2})[.,](\d{3}) --> (\d+):(\d{2}):(\d{2})[.,](\d{3})\]\s*(.*)$`)
+
+// parseWhisperSegments extracts timed segments from whisper-cli output
+func parseWhisperSegments(output string) []Segment {
+	var segments []Segment
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		match := whisperTimestampRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		text := strings.TrimSpace(stripAnsiCodes(match[9]))
+		if text == "" {
+			continue
+		}
+
+		segments = append(segments, Segment{
+			Start: parseTimestampParts(match[1], match[2], match[3], match[4]),
+			End:   parseTimestampParts(match[5], match[6], match[7], match[8]),
+			Text:  text,
+		})
+	}
+
+	return segments
+}
+
+// parseTimestampParts converts hour/minute/second/millisecond strings into a duration
+func parseTimestampParts(h, m, s, ms string) time.Duration {
+	hours, _ := strconv.Atoi(h)
+	minutes, _ := strconv.Atoi(m)
+	seconds, _ := strconv.Atoi(s)
+	millis, _ := strconv.Atoi(ms)
+	return time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second +
+		time.Duration(millis)*time.Millisecond
+}
+
 // stripAnsiCodes removes ANSI escape codes from a string
 func stripAnsiCodes(s string) string {
 	ansiRegex := regexp.MustCompile(`(\x1b)?\[[0-9;]*[a-zA-Z]`)
 	return ansiRegex.ReplaceAllString(s, "")
 }
 
-// extractWhisperLanguage tries to extract the detected language from whisper output
+// checkModelLanguage rejects a non-English language for English-only (.en) models
+func checkModelLanguage(model models.ModelSize, language string) error {
+	if !model.IsEnglishOnly() || language == "" || language == "en" {
+		return nil
+	}
+
+	multilingual := strings.TrimSuffix(string(model), ".en")
+	return fmt.Errorf("model %s only supports English, but language is set to %q. Use the multilingual model instead (e.g. --model %s) or set the language to en", model, language, multilingual)
+}
+
+// extractWhisperLanguage tries to extract the detected language from whisper log output,
+// e.g. "whisper_full_with_state: auto-detected language: fr (p = 0.97)"
 func extractWhisperLanguage(output string) string {
 	lines := strings.Split(output, "\n")
 	for _, line := range lines {
-		if strings.Contains(strings.ToLower(line), "detected language") {
-			parts := strings.Split(line, ":")
-			if len(parts) >= 2 {
-				lang := strings.TrimSpace(parts[1])
-				fields := strings.Fields(lang)
-				if len(fields) > 0 {
-					return fields[0]
-				}
-			}
+		idx := strings.Index(strings.ToLower(line), "detected language")
+		if idx == -1 {
+			continue
+		}
+		rest := strings.TrimLeft(line[idx+len("detected language"):], ": ")
+		fields := strings.Fields(rest)
+		if len(fields) > 0 {
+			return fields[0]
 		}
 	}
 	return ""
@@ -0,0 +1,161 @@
+package transcription
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alexandrelam/openscribe/internal/models"
+)
+
+// fakeWhisper installs a dummy tiny model in a temporary HOME and returns a
+// transcriber that runs the given shell script instead of whisper-cli
+func fakeWhisper(t *testing.T, script string) *WhisperTranscriber {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	modelPath, err := models.GetModelPath(models.Tiny)
+	if err != nil {
+		t.Fatalf("GetModelPath() error = %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(modelPath), 0755); err != nil {
+		t.Fatalf("Failed to create models dir: %v", err)
+	}
+	if err := os.WriteFile(modelPath, []byte("model"), 0644); err != nil {
+		t.Fatalf("Failed to write model: %v", err)
+	}
+
+	whisperPath := filepath.Join(t.TempDir(), "whisper-cli")
+	if err := os.WriteFile(whisperPath, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatalf("Failed to write fake whisper-cli: %v", err)
+	}
+	return &WhisperTranscriber{whisperPath: whisperPath}
+}
+
+func TestWhisperTranscriber_Timeout(t *testing.T) {
+	transcriber := fakeWhisper(t, "exec sleep 10\n")
+
+	start := time.Now()
+	_, err := transcriber.TranscribeFile(writeTestWAV(t, 16000, 1), Options{Model: models.Tiny, Timeout: 100 * time.Millisecond})
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("TranscribeFile() error = %v, want ErrTimeout", err)
+	}
+
+	// One attempt plus one retry, each killed at the timeout
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("TranscribeFile() took %v, the hung process was not killed", elapsed)
+	}
+}
+
+func TestWhisperTranscriber_RetriesAfterTimeout(t *testing.T) {
+	// The first run hangs, the retry succeeds
+	marker := filepath.Join(t.TempDir(), "attempted")
+	transcriber := fakeWhisper(t, `if [ ! -e "`+marker+`" ]; then
+  touch "`+marker+`"
+  exec sleep 10
+fi
+echo "Hello after retry"
+`)
+
+	result, err := transcriber.TranscribeFile(writeTestWAV(t, 16000, 1), Options{Model: models.Tiny, Timeout: 500 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("TranscribeFile() error = %v", err)
+	}
+	if result.Text != "Hello after retry" {
+		t.Errorf("Text = %q, want %q", result.Text, "Hello after retry")
+	}
+}
+
+func TestWhisperTranscriber_DetectedLanguage(t *testing.T) {
+	// Like whisper-cli with --no-prints: the language is only in the JSON file
+	transcriber := fakeWhisper(t, `while [ $# -gt 0 ]; do
+  if [ "$1" = "-f" ]; then audio="$2"; fi
+  shift
+done
+echo '{"result": {"language": "de"}, "transcription": []}' > "$audio.json"
+echo "Guten Tag"
+`)
+	audioPath := writeTestWAV(t, 16000, 1)
+
+	result, err := transcriber.TranscribeFile(audioPath, Options{Model: models.Tiny, Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("TranscribeFile() error = %v", err)
+	}
+	if result.Language != "de" {
+		t.Errorf("Language = %q, want %q", result.Language, "de")
+	}
+	if _, err := os.Stat(audioPath + ".json"); !os.IsNotExist(err) {
+		t.Errorf("JSON output was not cleaned up, stat error = %v", err)
+	}
+}
+
+func TestWhisperTranscriber_Failure(t *testing.T) {
+	transcriber := fakeWhisper(t, "echo 'failed to read audio' >&2\nexit 1\n")
+
+	_, err := transcriber.TranscribeFile(writeTestWAV(t, 16000, 1), Options{Model: models.Tiny, Timeout: time.Second})
+	if err == nil {
+		t.Fatal("TranscribeFile() error = nil, want error")
+	}
+	if errors.Is(err, ErrTimeout) {
+		t.Errorf("TranscribeFile() error = %v, want a non-timeout failure", err)
+	}
+}
+
+func TestParseWhisperTimings(t *testing.T) {
+	sample, err := os.ReadFile("testdata/whisper-timings.txt")
+	if err != nil {
+		t.Fatalf("Failed to read sample: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		output string
+		want   Timings
+	}{
+		{
+			name:   "whisper-cli timing section",
+			output: string(sample),
+			want:   Timings{LoadMS: 86.53, MelMS: 4.91, SampleMS: 19.07, EncodeMS: 301.22, DecodeMS: 55.5, TotalMS: 475.83},
+		},
+		{
+			name:   "no timings (--no-prints)",
+			output: "whisper_model_load: loading model\n",
+			want:   Timings{},
+		},
+		{
+			name:   "partial timings",
+			output: "whisper_print_timings:     load time =    12.00 ms\nwhisper_print_timings:    total time =   40.50 ms\n",
+			want:   Timings{LoadMS: 12, TotalMS: 40.5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseWhisperTimings(tt.output)
+			if math.Abs(got.DecodeMS-tt.want.DecodeMS) < 1e-9 {
+				got.DecodeMS = tt.want.DecodeMS
+			}
+			if got != tt.want {
+				t.Errorf("parseWhisperTimings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWhisperTranscriber_VerboseTimings(t *testing.T) {
+	transcriber := fakeWhisper(t, `echo "whisper_print_timings:   encode time =   10.00 ms /     1 runs (   10.00 ms per run)" >&2
+echo "whisper_print_timings:    total time =   25.00 ms" >&2
+echo "Hello"
+`)
+
+	result, err := transcriber.TranscribeFile(writeTestWAV(t, 16000, 1), Options{Model: models.Tiny, Verbose: true, Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("TranscribeFile() error = %v", err)
+	}
+	if want := (Timings{EncodeMS: 10, TotalMS: 25}); result.Timings != want {
+		t.Errorf("Timings = %+v, want %+v", result.Timings, want)
+	}
+}